@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/health"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	healthCmd := &cobra.Command{
+		Use:   "health",
+		Short: "Library health tools",
+	}
+
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Export a library-wide health report",
+		Long: `Export the health of the whole library as CSV or JSON, grouped by category,
+arr instance, release group, indexer and provider: counts by status, bytes at
+risk, oldest unchecked file, segment losses and repair success rates. Groups
+are listed fastest-rotting first. Reads the database directly, so the server
+does not need to be running.`,
+		Args: cobra.NoArgs,
+		RunE: runHealthReport,
+	}
+
+	reportCmd.Flags().String("format", "csv", "Output format (csv or json)")
+	reportCmd.Flags().String("group-by", "", "Comma-separated dimensions (category, arr, release_group, indexer, provider); all by default")
+	reportCmd.Flags().StringP("output", "o", "", "Write the report to this file instead of stdout")
+
+	providersCmd := &cobra.Command{
+		Use:   "providers",
+		Short: "Export the provider coverage report",
+		Long: `Export, per configured provider, how many of the audited articles it held
+and how many no other provider did over the last days, with its quota and
+account expiration. Providers marked "redundant" never served anything the
+others did not in the window. Needs health.provider_coverage_audit. Reads the
+database directly, so the server does not need to be running.`,
+		Args: cobra.NoArgs,
+		RunE: runHealthProviders,
+	}
+
+	providersCmd.Flags().String("format", "csv", "Output format (csv or json)")
+	providersCmd.Flags().Int("days", 0, "Window in days; health.provider_coverage_days by default")
+	providersCmd.Flags().StringP("output", "o", "", "Write the report to this file instead of stdout")
+
+	healthCmd.AddCommand(reportCmd, providersCmd)
+	rootCmd.AddCommand(healthCmd)
+}
+
+func runHealthReport(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("invalid format %q: use csv or json", format)
+	}
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	dims, err := health.ParseReportDimensions(groupBy)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config from %s: %w", configFile, err)
+	}
+
+	ctx := context.Background()
+	db, err := initializeDatabase(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repos := setupRepositories(ctx, db)
+	metadataService, _ := initializeMetadata(cfg)
+
+	report, err := health.BuildLibraryReport(ctx, repos.HealthRepo, metadataService, cfg, dims)
+	if err != nil {
+		return fmt.Errorf("failed to build health report: %w", err)
+	}
+
+	return writeHealthReport(cmd, format, report)
+}
+
+func runHealthProviders(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("invalid format %q: use csv or json", format)
+	}
+	days, _ := cmd.Flags().GetInt("days")
+	if days < 0 {
+		return fmt.Errorf("invalid days %d: must be non-negative", days)
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config from %s: %w", configFile, err)
+	}
+
+	ctx := context.Background()
+	db, err := initializeDatabase(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repos := setupRepositories(ctx, db)
+	report, err := health.BuildProviderCoverageReport(ctx, repos.HealthRepo, cfg, days)
+	if err != nil {
+		return fmt.Errorf("failed to build provider coverage report: %w", err)
+	}
+
+	return writeHealthReport(cmd, format, report)
+}
+
+// writeHealthReport writes a report as JSON or CSV to --output or stdout.
+func writeHealthReport(cmd *cobra.Command, format string, report interface{ WriteCSV(io.Writer) error }) error {
+	var out io.Writer = os.Stdout
+	if path, _ := cmd.Flags().GetString("output"); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return report.WriteCSV(out)
+}
@@ -137,6 +137,9 @@ func runServe(cmd *cobra.Command, args []string) error {
 	}
 
 	fs := initializeFilesystem(ctx, metadataService, repos.HealthRepo, arrsService, rcloneRCClient, poolManager, configManager.GetConfigGetter(), streamTracker, cacheSource)
+	// Post-processing reads subtitle sidecars through the virtual filesystem
+	// when materializing them into the library.
+	importerService.GetPostProcessor().SetVirtualFileOpener(fs)
 
 	// 6. Setup web services
 	app, debugMode := createFiberApp(ctx, cfg)
@@ -196,7 +199,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 		}
 	})
 
-	healthWorker, librarySyncWorker, err := startHealthWorker(ctx, cfg, repos.HealthRepo, poolManager, configManager, rcloneRCClient, arrsService, importerService, progressBroadcaster)
+	healthWorker, librarySyncWorker, err := startHealthWorker(ctx, cfg, repos.HealthRepo, db.FileIndexRepo, poolManager, configManager, rcloneRCClient, arrsService, importerService, progressBroadcaster)
 	if err != nil {
 		logger.Warn("Health worker initialization failed", "err", err)
 	}
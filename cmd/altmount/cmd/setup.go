@@ -374,6 +374,7 @@ func startHealthWorker(
 	ctx context.Context,
 	cfg *config.Config,
 	healthRepo *database.HealthRepository,
+	fileIndex *database.FileIndexRepository,
 	poolManager pool.Manager,
 	configManager *config.Manager,
 	rcloneClient rclonecli.RcloneRcClient,
@@ -383,6 +384,9 @@ func startHealthWorker(
 ) (*health.HealthWorker, *health.LibrarySyncWorker, error) {
 	// Create metadata service for health worker
 	metadataService := metadata.NewMetadataService(cfg.Metadata.RootPath)
+	if fileIndex != nil {
+		metadataService.SetFileIndex(fileIndex)
+	}
 
 	// Create health checker
 	healthChecker := health.NewHealthChecker(
@@ -411,6 +415,9 @@ func startHealthWorker(
 		configManager,
 		rcloneClient,
 	)
+	if fileIndex != nil {
+		librarySyncWorker.SetFileIndex(fileIndex)
+	}
 
 	// Only start health system if enabled
 	if cfg.Health.Enabled != nil && *cfg.Health.Enabled {
@@ -181,8 +181,9 @@ function EnhancedUploadSection() {
 
 	const validateFile = useCallback((file: File): string | null => {
 		const name = file.name.toLowerCase();
-		if (!name.endsWith(".nzb") && !name.endsWith(".nzb.gz"))
-			return "Only .nzb or .nzb.gz files are allowed";
+		const allowed = [".nzb", ".nzb.gz", ".nzb.bz2", ".nzb.xz", ".zip", ".tar", ".tar.gz", ".tgz"];
+		if (!allowed.some((ext) => name.endsWith(ext)))
+			return "Only .nzb (optionally .gz/.bz2/.xz compressed), .zip or .tar files are allowed";
 		if (file.size > 100 * 1024 * 1024) return "File size must be less than 100MB";
 		return null;
 	}, []);
@@ -255,7 +256,7 @@ function EnhancedUploadSection() {
 							? {
 									...f,
 									status: "success" as const,
-									queueId: response.data?.id.toString(),
+									queueId: response.data?.id?.toString(),
 								}
 							: f,
 					),
@@ -486,7 +487,7 @@ function EnhancedUploadSection() {
 						<input
 							type="file"
 							multiple
-							accept=".nzb,.nzb.gz"
+							accept=".nzb,.gz,.bz2,.xz,.zip,.tar,.tgz"
 							onChange={handleFileInput}
 							className="hidden"
 						/>
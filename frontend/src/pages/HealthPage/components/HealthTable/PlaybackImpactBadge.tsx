@@ -7,7 +7,8 @@ interface PlaybackImpactBadgeProps {
 
 // PlaybackImpactBadge renders the hole-model playback verdict stored in
 // error_details: a "degraded" file still plays (streaming zero-fills the
-// missing segments), a "failed" file does not. Clean/unknown render nothing.
+// missing segments), a "failed" file does not — though its release's PAR2
+// data may still cover the damage. Clean/unknown render nothing.
 export function PlaybackImpactBadge({ impact }: PlaybackImpactBadgeProps) {
 	if (impact.verdict === "degraded") {
 		const gaps = impact.total_missing ?? 0;
@@ -24,7 +25,10 @@ export function PlaybackImpactBadge({ impact }: PlaybackImpactBadgeProps) {
 	}
 	if (impact.verdict === "failed") {
 		const gaps = impact.total_missing ?? 0;
-		const label = gaps > 0 ? `Unplayable — ${gaps} segments missing` : "Unplayable";
+		let label = gaps > 0 ? `Unplayable — ${gaps} segments missing` : "Unplayable";
+		if ((impact.recovery_score ?? 0) >= 1) {
+			label += " (recoverable with PAR2)";
+		}
 		return (
 			<span className="badge badge-error badge-xs gap-1">
 				<CircleX className="h-3 w-3" aria-hidden="true" />
@@ -233,6 +233,9 @@ export interface PlaybackImpact {
 	sampled?: number;
 	total_segments?: number;
 	padded_ratio?: number;
+	// Release's PAR2 repairability score; 1 or more means the NZB's recovery
+	// data covers the damage. Absent when unknown.
+	recovery_score?: number;
 }
 
 // Structured envelope stored in FileHealth.error_details. Legacy records may
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/javi11/altmount/internal/config"
 	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/health"
 	"github.com/javi11/altmount/internal/utils"
 )
 
@@ -24,9 +26,10 @@ import (
 //	@Produce		json
 //	@Param			status		query		string	false	"Filter by status"	Enums(pending,checking,corrupted,repair_triggered,healthy)
 //	@Param			search		query		string	false	"Search by file path"
-//	@Param			sort_by		query		string	false	"Sort field"		Enums(file_path,created_at,status,priority,last_checked,scheduled_check_at)
+//	@Param			sort_by		query		string	false	"Sort field"		Enums(file_path,created_at,status,priority,last_checked,scheduled_check_at,last_streamed_at,stream_count)
 //	@Param			sort_order	query		string	false	"Sort direction"	Enums(asc,desc)
 //	@Param			since		query		string	false	"ISO8601 timestamp filter"
+//	@Param			streamed_since	query	string	false	"Only files played at or after this ISO8601 timestamp"
 //	@Param			limit		query		int		false	"Page size (default 50)"
 //	@Param			offset		query		int		false	"Page offset"
 //	@Success		200			{object}	APIResponse{data=[]HealthItemResponse,meta=APIMeta}
@@ -52,6 +55,8 @@ func (s *Server) handleListHealth(c *fiber.Ctx) error {
 		"priority":           true,
 		"last_checked":       true,
 		"scheduled_check_at": true,
+		"last_streamed_at":   true,
+		"stream_count":       true,
 	}
 	if !validSortFields[sortBy] {
 		sortBy = "created_at"
@@ -83,14 +88,20 @@ func (s *Server) handleListHealth(c *fiber.Ctx) error {
 		sinceFilter = since
 	}
 
+	// Parse streamed_since filter
+	streamedSinceFilter, err := ParseTimeParamFiber(c, "streamed_since")
+	if err != nil {
+		return RespondValidationError(c, "Invalid streamed_since parameter", err.Error())
+	}
+
 	// Get health items with search and sort support
-	items, err := s.listHealthItems(c.Context(), statusFilter, pagination, sinceFilter, search, sortBy, sortOrder)
+	items, err := s.listHealthItems(c.Context(), statusFilter, pagination, sinceFilter, streamedSinceFilter, search, sortBy, sortOrder)
 	if err != nil {
 		return RespondInternalError(c, "Failed to retrieve health records", err.Error())
 	}
 
 	// Get total count for pagination
-	totalCount, err := s.countHealthItems(c.Context(), statusFilter, sinceFilter, search)
+	totalCount, err := s.countHealthItems(c.Context(), statusFilter, sinceFilter, streamedSinceFilter, search)
 	if err != nil {
 		return RespondInternalError(c, "Failed to count health records", err.Error())
 	}
@@ -113,13 +124,13 @@ func (s *Server) handleListHealth(c *fiber.Ctx) error {
 }
 
 // listHealthItems is a helper method to list health items with filters
-func (s *Server) listHealthItems(ctx context.Context, statusFilter *database.HealthStatus, pagination Pagination, sinceFilter *time.Time, search string, sortBy string, sortOrder string) ([]*database.FileHealth, error) {
-	return s.healthRepo.ListHealthItems(ctx, statusFilter, pagination.Limit, pagination.Offset, sinceFilter, search, sortBy, sortOrder)
+func (s *Server) listHealthItems(ctx context.Context, statusFilter *database.HealthStatus, pagination Pagination, sinceFilter, streamedSinceFilter *time.Time, search string, sortBy string, sortOrder string) ([]*database.FileHealth, error) {
+	return s.healthRepo.ListHealthItems(ctx, statusFilter, pagination.Limit, pagination.Offset, sinceFilter, streamedSinceFilter, search, sortBy, sortOrder)
 }
 
 // countHealthItems is a helper method to count health items with filters
-func (s *Server) countHealthItems(ctx context.Context, statusFilter *database.HealthStatus, sinceFilter *time.Time, search string) (int, error) {
-	return s.healthRepo.CountHealthItems(ctx, statusFilter, sinceFilter, search)
+func (s *Server) countHealthItems(ctx context.Context, statusFilter *database.HealthStatus, sinceFilter, streamedSinceFilter *time.Time, search string) (int, error) {
+	return s.healthRepo.CountHealthItems(ctx, statusFilter, sinceFilter, streamedSinceFilter, search)
 }
 
 // handleGetHealth handles GET /api/health/{id}
@@ -157,7 +168,13 @@ func (s *Server) handleGetHealth(c *fiber.Ctx) error {
 		return RespondNotFound(c, "Health record", "")
 	}
 
+	events, err := s.healthRepo.GetRepairEvents(c.Context(), item.FilePath)
+	if err != nil {
+		return RespondInternalError(c, "Failed to retrieve repair history", err.Error())
+	}
+
 	response := ToHealthItemResponse(item)
+	response.RepairEvents = ToRepairEventResponses(events)
 	return RespondSuccess(c, response)
 }
 
@@ -430,8 +447,9 @@ func (s *Server) handleRepairHealth(c *fiber.Ctx) error {
 	}
 
 	// Trigger rescan with the resolved path
-	err = s.arrsService.TriggerFileRescan(ctx, pathForRescan, item.FilePath, item.Metadata)
+	rescan, err := s.arrsService.TriggerFileRescan(ctx, pathForRescan, item.FilePath, item.Metadata)
 	if err != nil {
+		health.RecordRepair(ctx, s.healthRepo, item, database.RepairStateFailed, health.RepairActionManual, rescan, err)
 		// Check if this is a "no ARR instance found" error
 		if strings.Contains(err.Error(), "no ARR instance found") {
 			return RespondNotFound(c, "File not managed by any ARR instance", "This file is not found in any of the configured Radarr or Sonarr instances. Please ensure the file is in your media library and the ARR instances are properly configured.")
@@ -439,6 +457,7 @@ func (s *Server) handleRepairHealth(c *fiber.Ctx) error {
 		// Handle other errors as internal server errors
 		return RespondInternalError(c, "Failed to trigger repair in ARR instance, you might need to trigger a manual library sync", err.Error())
 	}
+	health.RecordRepair(ctx, s.healthRepo, item, database.RepairStateTriggered, health.RepairActionManual, rescan, nil)
 
 	// Update status to repair_triggered instead of deleting
 	if err := s.healthRepo.SetRepairTriggered(ctx, item.FilePath, item.LastError, item.ErrorDetails); err != nil {
@@ -533,12 +552,14 @@ func (s *Server) handleRepairHealthBulk(c *fiber.Ctx) error {
 		}
 
 		// Trigger rescan
-		err = s.arrsService.TriggerFileRescan(ctx, pathForRescan, item.FilePath, item.Metadata)
+		rescan, err := s.arrsService.TriggerFileRescan(ctx, pathForRescan, item.FilePath, item.Metadata)
 		if err != nil {
+			health.RecordRepair(ctx, s.healthRepo, item, database.RepairStateFailed, health.RepairActionManual, rescan, err)
 			failedCount++
 			errors[filePath] = fmt.Sprintf("Failed to trigger repair: %v", err)
 			continue
 		}
+		health.RecordRepair(ctx, s.healthRepo, item, database.RepairStateTriggered, health.RepairActionManual, rescan, nil)
 
 		// Update status to repair_triggered instead of deleting
 		if err := s.healthRepo.SetRepairTriggered(ctx, item.FilePath, item.LastError, item.ErrorDetails); err != nil {
@@ -639,6 +660,84 @@ func (s *Server) handleGetHealthStats(c *fiber.Ctx) error {
 	return RespondSuccess(c, response)
 }
 
+// handleGetHealthReport handles GET /api/health/report
+//
+//	@Summary		Get library health report
+//	@Description	Returns the health of the whole library grouped by category, arr instance, release group, indexer and provider: counts by status, bytes at risk, oldest unchecked file, segment losses and repair success rates. format=csv returns the report as a CSV download.
+//	@Tags			Health
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			format		query		string	false	"Output format"	Enums(json, csv)
+//	@Param			group_by	query		string	false	"Comma-separated dimensions (category, arr, release_group, indexer, provider); all by default"
+//	@Success		200			{object}	APIResponse{data=health.LibraryReport}
+//	@Failure		400			{object}	APIResponse
+//	@Failure		500			{object}	APIResponse
+//	@Security		BearerAuth
+//	@Router			/health/report [get]
+func (s *Server) handleGetHealthReport(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", "json"))
+	if format != "json" && format != "csv" {
+		return RespondValidationError(c, fmt.Sprintf("Invalid format: '%s'", format), "Valid values: json, csv")
+	}
+	dims, err := health.ParseReportDimensions(c.Query("group_by"))
+	if err != nil {
+		return RespondValidationError(c, "Invalid group_by parameter", err.Error())
+	}
+
+	var sizer health.FileSizer
+	if s.metadataService != nil {
+		sizer = s.metadataService
+	}
+	report, err := health.BuildLibraryReport(c.Context(), s.healthRepo, sizer, s.configManager.GetConfig(), dims)
+	if err != nil {
+		return RespondInternalError(c, "Failed to build health report", err.Error())
+	}
+
+	if format == "csv" {
+		c.Set("Content-Type", "text/csv; charset=utf-8")
+		c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "altmount-health-report.csv"))
+		return report.WriteCSV(c.Response().BodyWriter())
+	}
+	return RespondSuccess(c, report)
+}
+
+// handleGetProviderCoverage handles GET /api/health/provider-coverage
+//
+//	@Summary		Get provider coverage report
+//	@Description	Returns, per configured provider, how many of the audited articles it held and how many no other provider did over the last N days, with its quota and account expiration. Requires health.provider_coverage_audit. format=csv returns the report as a CSV download.
+//	@Tags			Health
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			format	query		string	false	"Output format"	Enums(json, csv)
+//	@Param			days	query		int		false	"Window in days (default: health.provider_coverage_days)"
+//	@Success		200		{object}	APIResponse{data=health.ProviderCoverageReport}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		500		{object}	APIResponse
+//	@Security		BearerAuth
+//	@Router			/health/provider-coverage [get]
+func (s *Server) handleGetProviderCoverage(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", "json"))
+	if format != "json" && format != "csv" {
+		return RespondValidationError(c, fmt.Sprintf("Invalid format: '%s'", format), "Valid values: json, csv")
+	}
+	days := c.QueryInt("days", 0)
+	if days < 0 {
+		return RespondValidationError(c, "Invalid days parameter", "days must be non-negative")
+	}
+
+	report, err := health.BuildProviderCoverageReport(c.Context(), s.healthRepo, s.configManager.GetConfig(), days)
+	if err != nil {
+		return RespondInternalError(c, "Failed to build provider coverage report", err.Error())
+	}
+
+	if format == "csv" {
+		c.Set("Content-Type", "text/csv; charset=utf-8")
+		c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "altmount-provider-coverage.csv"))
+		return report.WriteCSV(c.Response().BodyWriter())
+	}
+	return RespondSuccess(c, report)
+}
+
 // handleCleanupHealth handles DELETE /api/health/cleanup
 //
 //	@Summary		Cleanup health records
@@ -735,7 +834,7 @@ func (s *Server) cleanupHealthRecords(ctx context.Context, olderThan time.Time,
 	// Process records in batches until no more records found
 	for {
 		// Fetch next batch of records
-		items, queryErr := s.healthRepo.ListHealthItems(ctx, statusFilter, batchSize, offset, nil, "", "created_at", "asc")
+		items, queryErr := s.healthRepo.ListHealthItems(ctx, statusFilter, batchSize, offset, nil, nil, "", "created_at", "asc")
 		if queryErr != nil {
 			return 0, 0, nil, fmt.Errorf("failed to query health records: %w", queryErr)
 		}
@@ -992,6 +1091,86 @@ func (s *Server) handleDirectHealthCheck(c *fiber.Ctx) error {
 	})
 }
 
+// handleFillHealthFromNzb handles POST /api/health/{id}/fill
+//
+//	@Summary		Fill missing segments from an alternate NZB
+//	@Description	Replaces the missing articles of a corrupted or degraded file with the matching articles of an alternate NZB for the same upload (upload or URL). Files are matched by name or size, articles by part number and size, and every replacement must decode with a valid CRC before it is used. A health check is started when any article was replaced.
+//	@Tags			Health
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		int		true	"Health record ID"
+//	@Param			file	formData	file	false	"Alternate NZB file (mutually exclusive with nzb_url)"
+//	@Param			nzb_url	formData	string	false	"URL to download the alternate NZB from (mutually exclusive with file)"
+//	@Success		200	{object}	APIResponse
+//	@Failure		400	{object}	APIResponse
+//	@Failure		404	{object}	APIResponse
+//	@Failure		409	{object}	APIResponse
+//	@Security		BearerAuth
+//	@Router			/health/{id}/fill [post]
+func (s *Server) handleFillHealthFromNzb(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return RespondBadRequest(c, "Invalid health record ID", "ID must be a valid integer")
+	}
+	if s.metadataService == nil || s.poolManager == nil || !s.poolManager.HasPool() {
+		return RespondServiceUnavailable(c, "Usenet connection pool not available", "Configure at least one provider")
+	}
+
+	item, err := s.healthRepo.GetFileHealthByID(c.Context(), id)
+	if err != nil {
+		return RespondInternalError(c, "Failed to check health record", err.Error())
+	}
+	if item == nil {
+		return RespondNotFound(c, "Health record", "")
+	}
+	if item.Status != database.HealthStatusCorrupted && item.Status != database.HealthStatusDegraded {
+		return RespondConflict(c, "Only corrupted or degraded files can be filled", fmt.Sprintf("Current status: %s", item.Status))
+	}
+
+	_, alt, respondErr := s.readNzbRequest(c)
+	if respondErr != nil {
+		return respondErr()
+	}
+
+	opts := health.FillOptions{}
+	if s.configManager != nil {
+		cfg := s.configManager.GetConfig()
+		opts.MaxConnections = cfg.GetMaxConnectionsForHealthChecks()
+		opts.Timeout = cfg.GetHealthReadTimeout()
+	}
+	result, err := health.FillFromAlternate(c.Context(), s.metadataService, s.poolManager, item.FilePath, alt, opts)
+	if err != nil {
+		if errors.Is(err, health.ErrFillNoStore) {
+			return RespondConflict(c, "File cannot be filled", "Its metadata predates the NZB store; re-import it first")
+		}
+		return RespondInternalError(c, "Failed to fill from alternate NZB", err.Error())
+	}
+
+	slog.InfoContext(c.Context(), "Filled missing segments from alternate NZB",
+		"file_path", item.FilePath,
+		"missing", result.Missing,
+		"filled", result.Filled,
+		"unmatched", result.Unmatched,
+		"rejected", result.Rejected)
+
+	checkStarted := false
+	if result.Filled > 0 && s.healthWorker != nil {
+		if err := s.healthRepo.SetFileCheckingByID(c.Context(), id); err != nil {
+			return RespondInternalError(c, "Failed to set checking status", err.Error())
+		}
+		if err := s.healthWorker.PerformBackgroundCheck(context.Background(), item.FilePath); err != nil {
+			return RespondInternalError(c, "Failed to start background health check", err.Error())
+		}
+		checkStarted = true
+	}
+
+	return RespondSuccess(c, fiber.Map{
+		"id":                   id,
+		"fill":                 result,
+		"health_check_started": checkStarted,
+	})
+}
+
 // handleRestartHealthChecksBulk handles POST /api/health/bulk/restart
 //
 //	@Summary		Bulk restart health checks
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/nzbfile"
+)
+
+// BundleEntryResult reports the outcome of queueing one NZB taken from a
+// bundle (.zip/.tar) or a bzip2/xz-compressed upload.
+type BundleEntryResult struct {
+	Name         string `json:"name"`
+	Success      bool   `json:"success"`
+	QueueID      *int64 `json:"queue_id,omitempty"`
+	DownloadID   string `json:"download_id,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// bundleQueueOptions are the queue settings every NZB in a bundle inherits.
+type bundleQueueOptions struct {
+	basePath   *string
+	category   *string
+	priority   *database.QueuePriority
+	metadata   *string
+	downloadID string
+	indexer    *string
+}
+
+// queueExpandedNzbs expands srcPath with nzbfile.Expand and adds every
+// contained NZB to the queue with the shared options. srcPath is removed once
+// expanded. Entries that fail are reported in the results instead of aborting
+// the rest of the bundle; the error is only returned when nothing could be read.
+func (s *Server) queueExpandedNzbs(ctx context.Context, srcPath string, opts bundleQueueOptions) ([]BundleEntryResult, error) {
+	expandDir := filepath.Join(os.TempDir(), "altmount-uploads", "bundles", uuid.New().String())
+	defer os.RemoveAll(expandDir)
+
+	entries, err := nzbfile.Expand(srcPath, expandDir)
+	if err != nil && len(entries) == 0 {
+		return nil, err
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "NZB bundle partially expanded", "file", srcPath, "error", err)
+	}
+	_ = os.Remove(srcPath)
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no NZB files found in %s", filepath.Base(srcPath))
+	}
+
+	results := make([]BundleEntryResult, 0, len(entries))
+	for i, entry := range entries {
+		result := BundleEntryResult{Name: entry.Name}
+		if entry.Err != nil {
+			result.ErrorMessage = entry.Err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		var downloadID *string
+		if opts.downloadID != "" {
+			id := opts.downloadID
+			if len(entries) > 1 {
+				id = fmt.Sprintf("%s-%d", opts.downloadID, i+1)
+			}
+			downloadID = &id
+		}
+
+		item, err := s.importerService.AddToQueue(ctx, entry.Path, opts.basePath, opts.category, opts.priority, opts.metadata, downloadID, opts.indexer)
+		if err != nil {
+			result.ErrorMessage = "Failed to add to queue: " + err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		result.Success = true
+		result.QueueID = &item.ID
+		if downloadID != nil {
+			result.DownloadID = *downloadID
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javi11/altmount/internal/httpclient"
+	"github.com/javi11/altmount/internal/importer/parser"
+	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
+	"github.com/javi11/altmount/internal/nzbcheck"
+	"github.com/javi11/nzbparser"
+)
+
+// maxNzbCheckSize caps NZBs uploaded or fetched by readNzbRequest.
+const maxNzbCheckSize = 100 * 1024 * 1024 // 100 MB
+
+// NzbCheckResponse is the outcome of an NZB completeness check.
+type NzbCheckResponse struct {
+	Filename string `json:"filename"`
+	*nzbcheck.Result
+}
+
+// handleNzbCheck handles POST /api/nzb/check
+//
+//	@Summary		Check NZB completeness
+//	@Description	Stats the segments of an NZB (upload or URL) on every provider without importing it, using the health check connection budget. Returns per-file and per-provider availability, a PAR2 repairability estimate and a verdict under the holes policy (clean, degraded, failed or unknown).
+//	@Tags			Import
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file				formData	file	false	"NZB file to check (mutually exclusive with nzb_url)"
+//	@Param			nzb_url				formData	string	false	"URL to download the NZB from (mutually exclusive with file)"
+//	@Param			sample_percentage	formData	int		false	"Share of each file's segments to check, 1-100 (default: 100, every segment)"
+//	@Success		200	{object}	APIResponse{data=NzbCheckResponse}
+//	@Failure		400	{object}	APIResponse
+//	@Failure		503	{object}	APIResponse
+//	@Security		BearerAuth
+//	@Router			/nzb/check [post]
+func (s *Server) handleNzbCheck(c *fiber.Ctx) error {
+	if s.poolManager == nil || !s.poolManager.HasPool() {
+		return RespondServiceUnavailable(c, "Usenet connection pool not available", "Configure at least one provider")
+	}
+
+	samplePercentage := 100
+	if v := formOrQuery(c, "sample_percentage"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return RespondValidationError(c, "Invalid sample_percentage", "sample_percentage must be between 1 and 100")
+		}
+		samplePercentage = n
+	}
+
+	filename, n, respondErr := s.readNzbRequest(c)
+	if respondErr != nil {
+		return respondErr()
+	}
+
+	opts := nzbcheck.Options{SamplePercentage: samplePercentage}
+	if s.configManager != nil {
+		cfg := s.configManager.GetConfig()
+		opts.MaxConnections = cfg.GetMaxConnectionsForHealthChecks()
+		opts.Timeout = cfg.GetHealthReadTimeout()
+	}
+	result, err := nzbcheck.Check(c.Context(), n, s.poolManager, opts)
+	if err != nil {
+		return RespondInternalError(c, "Failed to check NZB", err.Error())
+	}
+
+	return RespondSuccess(c, NzbCheckResponse{Filename: filename, Result: result})
+}
+
+// readNzbRequest reads the NZB of a request, uploaded as the "file" form field
+// or fetched from nzb_url, and parses it. On failure it returns a function
+// writing the error response instead.
+func (s *Server) readNzbRequest(c *fiber.Ctx) (string, *nzbparser.Nzb, func() error) {
+	var filename string
+	var data []byte
+	if nzbURL := formOrQuery(c, "nzb_url"); nzbURL != "" {
+		req, err := http.NewRequestWithContext(c.Context(), http.MethodGet, nzbURL, nil)
+		if err != nil {
+			return "", nil, func() error { return RespondBadRequest(c, "Invalid nzb_url", err.Error()) }
+		}
+		req.Header.Set("User-Agent", "altmount")
+		client := httpclient.NewLong()
+		if s.configManager != nil {
+			client = httpclient.NewForExternal(s.configManager.GetConfig().Network, httpclient.LongTimeout)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", nil, func() error { return RespondBadRequest(c, "Failed to fetch NZB from URL", err.Error()) }
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", nil, func() error {
+				return RespondBadRequest(c, "Failed to fetch NZB from URL", fmt.Sprintf("HTTP %d", resp.StatusCode))
+			}
+		}
+		data, err = io.ReadAll(io.LimitReader(resp.Body, maxNzbCheckSize))
+		if err != nil {
+			return "", nil, func() error { return RespondBadRequest(c, "Failed to read NZB from URL", err.Error()) }
+		}
+		filename = filepath.Base(req.URL.Path)
+	} else {
+		file, err := c.FormFile("file")
+		if err != nil {
+			return "", nil, func() error { return RespondBadRequest(c, "No file provided", "Upload a .nzb file or provide nzb_url") }
+		}
+		if !nzbtrim.HasNzbExtension(file.Filename) {
+			return "", nil, func() error {
+				return RespondValidationError(c, "Invalid file type", "Only .nzb or .nzb.gz files are allowed")
+			}
+		}
+		if file.Size > maxNzbCheckSize {
+			return "", nil, func() error { return RespondValidationError(c, "File too large", "File size must be less than 100MB") }
+		}
+		f, err := file.Open()
+		if err != nil {
+			return "", nil, func() error { return RespondInternalError(c, "Failed to open uploaded file", err.Error()) }
+		}
+		defer f.Close()
+		data, err = io.ReadAll(f)
+		if err != nil {
+			return "", nil, func() error { return RespondInternalError(c, "Failed to read uploaded file", err.Error()) }
+		}
+		filename = file.Filename
+	}
+
+	n, err := parseCheckedNzb(data)
+	if err != nil {
+		return "", nil, func() error { return RespondValidationError(c, "Invalid NZB", err.Error()) }
+	}
+	return filename, n, nil
+}
+
+// parseCheckedNzb parses NZB bytes, transparently decompressing gzip.
+func parseCheckedNzb(data []byte) (*nzbparser.Nzb, error) {
+	var r io.Reader = bytes.NewReader(data)
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("decompress NZB: %w", err)
+		}
+		defer zr.Close()
+		r = io.LimitReader(zr, maxNzbCheckSize)
+	}
+	n, err := nzbparser.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse NZB: %w", err)
+	}
+	if len(n.Files) == 0 {
+		return nil, fmt.Errorf("NZB contains no files")
+	}
+	parser.SanitizeNzbFilenames(n)
+	return n, nil
+}
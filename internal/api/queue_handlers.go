@@ -18,6 +18,7 @@ import (
 	"github.com/javi11/altmount/internal/database"
 	internalerrors "github.com/javi11/altmount/internal/errors"
 	"github.com/javi11/altmount/internal/httpclient"
+	"github.com/javi11/altmount/internal/importer/report"
 	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
 	"github.com/javi11/altmount/internal/nzbfile"
 	"github.com/javi11/altmount/internal/nzblnk"
@@ -562,14 +563,17 @@ func (s *Server) handleDeleteQueueBulk(c *fiber.Ctx) error {
 // handleUploadToQueue handles POST /api/queue/upload
 //
 //	@Summary		Upload NZB file to queue
-//	@Description	Uploads an NZB file and adds it to the download queue.
+//	@Description	Uploads an NZB file and adds it to the download queue. Bundles (.zip, .tar, .tar.gz)
+//	@Description	and bzip2/xz-compressed NZBs are expanded so every contained NZB becomes its own queue
+//	@Description	item; those uploads respond with per-entry results instead of a single queue item.
 //	@Tags			Queue
 //	@Accept			multipart/form-data
 //	@Produce		json
-//	@Param			file			formData	file	true	"NZB file to upload (max 100MB)"
+//	@Param			file			formData	file	true	"NZB file or NZB bundle to upload (max 100MB)"
 //	@Param			category		formData	string	false	"Optional category"
 //	@Param			relative_path	formData	string	false	"Optional relative path under CompleteDir"
 //	@Param			priority		formData	int		false	"Priority: 1=high, 2=normal, 3=low"
+//	@Param			hold_until		formData	string	false	"Optional RFC3339 timestamp before which the item will not be processed"
 //	@Success		201				{object}	APIResponse{data=QueueItemResponse}
 //	@Failure		400				{object}	APIResponse
 //	@Failure		503				{object}	APIResponse
@@ -583,8 +587,9 @@ func (s *Server) handleUploadToQueue(c *fiber.Ctx) error {
 	}
 
 	// Validate file extension
-	if !nzbtrim.HasNzbExtension(file.Filename) {
-		return RespondValidationError(c, "Invalid file type", "Only .nzb or .nzb.gz files are allowed")
+	expandable := nzbfile.IsExpandable(file.Filename)
+	if !nzbtrim.HasNzbExtension(file.Filename) && !expandable {
+		return RespondValidationError(c, "Invalid file type", "Only .nzb, .nzb.gz, .nzb.bz2, .nzb.xz, .zip or .tar files are allowed")
 	}
 
 	// Validate file size (100MB limit)
@@ -611,6 +616,16 @@ func (s *Server) handleUploadToQueue(c *fiber.Ctx) error {
 		priority = database.QueuePriorityNormal
 	}
 
+	// Get optional hold_until from form
+	var holdUntil *time.Time
+	if holdStr := c.FormValue("hold_until"); holdStr != "" {
+		t, err := time.Parse(time.RFC3339, holdStr)
+		if err != nil {
+			return RespondValidationError(c, "Invalid hold_until value", "hold_until must be an RFC3339 timestamp")
+		}
+		holdUntil = &t
+	}
+
 	// Add to queue using importer service
 	if s.importerService == nil {
 		return RespondServiceUnavailable(c, "Importer service not available", "The import service is not configured or running")
@@ -622,10 +637,13 @@ func (s *Server) handleUploadToQueue(c *fiber.Ctx) error {
 	}
 
 	// De-dupe re-uploads: update the existing pending item instead of creating a duplicate.
-	if existing, err := s.importerService.FindAndUpdatePendingUpload(c.Context(), file.Filename, categoryPtr, &priority); err != nil {
-		return RespondInternalError(c, "Failed to update existing queue item", err.Error())
-	} else if existing != nil {
-		return RespondCreated(c, ToQueueItemResponse(existing))
+	// Bundles are matched per contained NZB by AddToQueue's persistence instead.
+	if !expandable {
+		if existing, err := s.importerService.FindAndUpdatePendingUpload(c.Context(), file.Filename, categoryPtr, &priority); err != nil {
+			return RespondInternalError(c, "Failed to update existing queue item", err.Error())
+		} else if existing != nil {
+			return RespondCreated(c, ToQueueItemResponse(existing))
+		}
 	}
 
 	// Create temporary directory for upload
@@ -657,6 +675,10 @@ func (s *Server) handleUploadToQueue(c *fiber.Ctx) error {
 		}
 	}
 
+	if expandable {
+		return s.respondBundleUpload(c, tempFile, basePath, categoryPtr, &priority, holdUntil)
+	}
+
 	// For manually uploaded files, pass CompleteDir as the base path (not the temp upload directory)
 	// The category will be appended to this by processNzbItem in the service
 	item, err := s.importerService.AddToQueue(c.Context(), tempFile, basePath, categoryPtr, &priority, nil, nil, nil)
@@ -666,11 +688,51 @@ func (s *Server) handleUploadToQueue(c *fiber.Ctx) error {
 		return RespondInternalError(c, "Failed to add file to queue", err.Error())
 	}
 
+	if holdUntil != nil {
+		if err := s.queueRepo.SetQueueItemHold(c.Context(), item.ID, holdUntil); err != nil {
+			return RespondInternalError(c, "Failed to set hold on queue item", err.Error())
+		}
+		item.HoldUntil = holdUntil
+	}
+
 	// Convert to API response format
 	response := ToQueueItemResponse(item)
 	return RespondCreated(c, response)
 }
 
+// respondBundleUpload queues every NZB contained in an uploaded bundle or
+// compressed NZB and responds with per-entry results.
+func (s *Server) respondBundleUpload(c *fiber.Ctx, tempFile string, basePath, category *string, priority *database.QueuePriority, holdUntil *time.Time) error {
+	results, err := s.queueExpandedNzbs(c.Context(), tempFile, bundleQueueOptions{
+		basePath: basePath,
+		category: category,
+		priority: priority,
+	})
+	if err != nil {
+		os.Remove(tempFile)
+		return RespondValidationError(c, "Failed to expand NZB bundle", err.Error())
+	}
+
+	successCount := 0
+	for i := range results {
+		if !results[i].Success {
+			continue
+		}
+		if holdUntil != nil {
+			if err := s.queueRepo.SetQueueItemHold(c.Context(), *results[i].QueueID, holdUntil); err != nil {
+				results[i].ErrorMessage = "Failed to set hold on queue item: " + err.Error()
+			}
+		}
+		successCount++
+	}
+
+	return RespondCreated(c, fiber.Map{
+		"results":       results,
+		"success_count": successCount,
+		"failed_count":  len(results) - successCount,
+	})
+}
+
 // handleUploadNZBLnk handles POST /api/queue/upload-nzblnk
 //
 //	@Summary		Add NZBLnk links to queue
@@ -1278,6 +1340,61 @@ func (s *Server) handleUpdateQueueItemPriority(c *fiber.Ctx) error {
 	return RespondSuccess(c, ToQueueItemResponse(updated))
 }
 
+// handleUpdateQueueItemHold handles PATCH /api/queue/{id}/hold
+//
+//	@Summary		Hold queue item until a time
+//	@Description	Sets or clears the timestamp before which a pending item will not be claimed by a worker.
+//	@Tags			Queue
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Queue item ID"
+//	@Param			body	body		object{hold_until=string}	true	"RFC3339 timestamp, or null to release the hold"
+//	@Success		200		{object}	APIResponse{data=QueueItemResponse}
+//	@Failure		400		{object}	APIResponse
+//	@Failure		404		{object}	APIResponse
+//	@Failure		409		{object}	APIResponse
+//	@Security		BearerAuth
+//	@Router			/queue/{id}/hold [patch]
+func (s *Server) handleUpdateQueueItemHold(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return RespondBadRequest(c, "Invalid queue item ID", "ID must be a valid integer")
+	}
+
+	var req struct {
+		HoldUntil *time.Time `json:"hold_until"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return RespondBadRequest(c, "Invalid request body", err.Error())
+	}
+
+	item, err := s.queueRepo.GetQueueItem(c.Context(), id)
+	if err != nil {
+		return RespondInternalError(c, "Failed to check queue item", err.Error())
+	}
+	if item == nil {
+		return RespondNotFound(c, "Queue item", "")
+	}
+	if item.Status == database.QueueStatusProcessing {
+		return RespondConflict(c, "Cannot hold an item currently being processed", "")
+	}
+
+	if err := s.queueRepo.SetQueueItemHold(c.Context(), id, req.HoldUntil); err != nil {
+		return RespondInternalError(c, "Failed to update hold", err.Error())
+	}
+
+	if s.progressBroadcaster != nil {
+		s.progressBroadcaster.BroadcastQueueChanged()
+	}
+
+	updated, err := s.queueRepo.GetQueueItem(c.Context(), id)
+	if err != nil {
+		return RespondInternalError(c, "Failed to retrieve updated queue item", err.Error())
+	}
+
+	return RespondSuccess(c, ToQueueItemResponse(updated))
+}
+
 // handleBulkUpdateQueuePriority handles PATCH /api/queue/bulk/priority
 //
 //	@Summary		Bulk update queue item priorities
@@ -1416,3 +1533,47 @@ func (s *Server) handleDownloadNZB(c *fiber.Ctx) error {
 
 	return c.SendFile(resolved)
 }
+
+// handleGetQueueReport handles GET /api/queue/{id}/report
+//
+//	@Summary		Get import report
+//	@Description	Returns the import report of a queue item: stage timings, fast-fail sampling results with missing segments and provider responses, archive layout, password attempts, virtual paths and post-processing outcome. With download=true the report is sent as a JSON attachment.
+//	@Tags			Queue
+//	@Produce		json
+//	@Param			id			path	int		true	"Queue item ID"
+//	@Param			download	query	bool	false	"Send the report as a file attachment"
+//	@Success		200	{object}	APIResponse
+//	@Failure		400	{object}	APIResponse
+//	@Failure		404	{object}	APIResponse
+//	@Security		BearerAuth
+//	@Router			/queue/{id}/report [get]
+func (s *Server) handleGetQueueReport(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	if idStr == "" {
+		return RespondBadRequest(c, "Queue item ID is required", "")
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return RespondBadRequest(c, "Invalid queue item ID", "ID must be a valid integer")
+	}
+
+	stored, err := s.queueRepo.GetImportReport(c.Context(), id)
+	if err != nil {
+		return RespondInternalError(c, "Failed to retrieve import report", err.Error())
+	}
+	if stored == nil {
+		return RespondNotFound(c, "Import report", "The queue item has no import report yet")
+	}
+
+	rep, err := report.Unmarshal(stored.Report)
+	if err != nil {
+		return RespondInternalError(c, "Failed to decode import report", err.Error())
+	}
+
+	if c.QueryBool("download", false) {
+		c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("import-report-%d.json", id)))
+		return c.JSON(rep)
+	}
+	return RespondSuccess(c, rep)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/javi11/altmount/internal/httpclient"
 	"github.com/javi11/altmount/internal/importer/utils"
 	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
+	"github.com/javi11/altmount/internal/nzbfile"
 	apputils "github.com/javi11/altmount/internal/utils"
 )
 
@@ -352,8 +353,8 @@ func (s *Server) handleSABnzbdAddFile(c *fiber.Ctx) error {
 	}
 
 	// Validate file extension
-	if !nzbtrim.HasNzbExtension(file.Filename) {
-		return s.writeSABnzbdErrorFiber(c, "Invalid file type, must be .nzb or .nzb.gz")
+	if !nzbtrim.HasNzbExtension(file.Filename) && !nzbfile.IsExpandable(file.Filename) {
+		return s.writeSABnzbdErrorFiber(c, "Invalid file type, must be .nzb, .nzb.gz, .nzb.bz2, .nzb.xz, .zip or .tar")
 	}
 
 	// Get and validate category from form first
@@ -424,6 +425,15 @@ func (s *Server) handleSABnzbdAddFile(c *fiber.Ctx) error {
 	// Add the file to the processing queue using centralized method
 	completeDir := s.configManager.GetConfig().SABnzbd.CompleteDir
 	priority := s.parseSABnzbdPriority(c.FormValue("priority"))
+	if nzbfile.IsExpandable(tempFile) {
+		return s.writeSABnzbdBundleResponse(c, tempFile, bundleQueueOptions{
+			basePath:   &completeDir,
+			category:   &validatedCategory,
+			priority:   &priority,
+			metadata:   metadataJSON,
+			downloadID: downloadID,
+		})
+	}
 	_, err = s.importerService.AddToQueue(c.Context(), tempFile, &completeDir, &validatedCategory, &priority, metadataJSON, &downloadID, nil)
 	if err != nil {
 		return s.writeSABnzbdErrorFiber(c, "Failed to add to queue")
@@ -509,8 +519,8 @@ func (s *Server) handleSABnzbdAddUrl(c *fiber.Ctx) error {
 		filename = "downloaded.nzb"
 	}
 
-	// Ensure .nzb or .nzb.gz extension
-	if !nzbtrim.HasNzbExtension(filename) {
+	// Ensure a supported NZB or bundle extension
+	if !nzbtrim.HasNzbExtension(filename) && !nzbfile.IsExpandable(filename) {
 		filename += ".nzb"
 	}
 
@@ -576,6 +586,17 @@ func (s *Server) handleSABnzbdAddUrl(c *fiber.Ctx) error {
 		}
 	}
 
+	if nzbfile.IsExpandable(tempFile) {
+		outFile.Close()
+		return s.writeSABnzbdBundleResponse(c, tempFile, bundleQueueOptions{
+			basePath:   &completeDir,
+			category:   &validatedCategory,
+			priority:   &priority,
+			metadata:   metadataJSON,
+			downloadID: downloadID,
+		})
+	}
+
 	_, err = s.importerService.AddToQueue(c.Context(), tempFile, &completeDir, &validatedCategory, &priority, metadataJSON, &downloadID, nil)
 	if err != nil {
 		return s.writeSABnzbdErrorFiber(c, "Failed to add to queue")
@@ -590,6 +611,41 @@ func (s *Server) handleSABnzbdAddUrl(c *fiber.Ctx) error {
 	return s.writeSABnzbdResponseFiber(c, response)
 }
 
+// writeSABnzbdBundleResponse queues the NZBs inside a bundle or compressed NZB
+// and reports one nzo_id per queued entry. Entries that failed are listed in
+// the error field while the request still succeeds if anything was queued.
+func (s *Server) writeSABnzbdBundleResponse(c *fiber.Ctx, tempFile string, opts bundleQueueOptions) error {
+	results, err := s.queueExpandedNzbs(c.Context(), tempFile, opts)
+	if err != nil {
+		os.Remove(tempFile)
+		return s.writeSABnzbdErrorFiber(c, fmt.Sprintf("Failed to expand NZB bundle: %v", err))
+	}
+
+	var nzoIDs, failures []string
+	for _, r := range results {
+		if r.Success {
+			nzoIDs = append(nzoIDs, r.DownloadID)
+		} else {
+			failures = append(failures, fmt.Sprintf("%s: %s", r.Name, r.ErrorMessage))
+		}
+	}
+
+	if len(nzoIDs) == 0 {
+		return s.writeSABnzbdErrorFiber(c, "Failed to add to queue: "+strings.Join(failures, "; "))
+	}
+
+	response := SABnzbdAddResponse{
+		Status: true,
+		NzoIds: nzoIDs,
+	}
+	if len(failures) > 0 {
+		msg := strings.Join(failures, "; ")
+		response.Error = &msg
+	}
+
+	return s.writeSABnzbdResponseFiber(c, response)
+}
+
 // handleSABnzbdQueue handles queue operations
 func (s *Server) handleSABnzbdQueue(c *fiber.Ctx) error {
 	// Check for operations
@@ -129,6 +129,11 @@ func NewServer(
 		poolManager.SetStreamSource(streamTracker)
 	}
 
+	// Finished streams feed playback-weighted health checking.
+	if healthRepo != nil && streamTracker != nil {
+		streamTracker.SetPlaybackRecorder(healthRepo)
+	}
+
 	return server
 }
 
@@ -262,7 +267,9 @@ func (s *Server) SetupRoutes(app *fiber.App) {
 	api.Post("/queue/:id/retry", s.handleRetryQueue)
 	api.Post("/queue/:id/cancel", s.handleCancelQueue)
 	api.Patch("/queue/:id/priority", s.handleUpdateQueueItemPriority)
+	api.Patch("/queue/:id/hold", s.handleUpdateQueueItemHold)
 	api.Get("/queue/:id/download", s.handleDownloadNZB)
+	api.Get("/queue/:id/report", s.handleGetQueueReport)
 
 	// Health endpoints
 	api.Get("/health", s.handleListHealth)
@@ -271,6 +278,8 @@ func (s *Server) SetupRoutes(app *fiber.App) {
 	api.Post("/health/bulk/repair", s.handleRepairHealthBulk)
 	api.Get("/health/corrupted", s.handleListCorrupted)
 	api.Get("/health/stats", s.handleGetHealthStats)
+	api.Get("/health/report", s.handleGetHealthReport)
+	api.Get("/health/provider-coverage", s.handleGetProviderCoverage)
 	api.Delete("/health/cleanup", s.handleCleanupHealth)
 	api.Post("/health/reset-all", s.handleResetAllHealthChecks)
 	api.Post("/health/regenerate-symlinks", s.handleRegenerateLibraryFiles)
@@ -279,6 +288,7 @@ func (s *Server) SetupRoutes(app *fiber.App) {
 	api.Post("/health/:id/repair", s.handleRepairHealth)
 	api.Post("/health/:id/unmask", s.handleUnmaskHealth)
 	api.Post("/health/:id/check-now", s.handleDirectHealthCheck)
+	api.Post("/health/:id/fill", s.handleFillHealthFromNzb)
 	api.Post("/health/:id/priority", s.handleSetHealthPriority)
 	api.Post("/health/:id/cancel", s.handleCancelHealthCheck)
 	api.Get("/health/:id", s.handleGetHealth)
@@ -299,6 +309,8 @@ func (s *Server) SetupRoutes(app *fiber.App) {
 	api.Post("/files/export-batch", s.handleBatchExportNZB)
 	// Note: /files/stream is handled by StreamHandler at HTTP server level
 
+	api.Post("/nzb/check", s.handleNzbCheck)
+
 	api.Post("/import/scan", s.handleStartManualScan)
 	api.Get("/logs", s.handleGetLogs)
 	// Note: /logs/stream is handled by ServeLogsSSE at HTTP server level (bypasses adaptor)
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/javi11/altmount/internal/database"
 	"github.com/javi11/altmount/internal/nzbfilesystem"
 	"github.com/javi11/altmount/internal/usenet"
 )
@@ -16,6 +17,21 @@ import (
 // Default timeout for stale streams (4 hours - covers most movie lengths)
 const defaultStreamTimeout = 4 * time.Hour
 
+const (
+	// minPlaybackBytes is how much a stream must send to count as a playback;
+	// media-server scans and thumbnail probes read less.
+	minPlaybackBytes = 32 * 1024 * 1024
+	// finishedPlaybackRatio is the share of a file a stream must reach for the
+	// file to count as watched rather than "continue watching".
+	finishedPlaybackRatio = 0.9
+)
+
+// PlaybackRecorder records playbacks for health-check prioritization.
+// Implemented by *database.HealthRepository.
+type PlaybackRecorder interface {
+	RecordPlayback(ctx context.Context, p database.Playback) error
+}
+
 // StreamChangeNotifier is notified whenever the active stream count changes.
 // Implemented by pool.Manager; declared here to avoid an api -> pool import
 // dependency for the StreamTracker itself.
@@ -40,6 +56,10 @@ type StreamTracker struct {
 	// notifier, when set, is notified after every stream add/remove so the
 	// import-admission cap can react to streams starting/stopping.
 	notifier StreamChangeNotifier
+
+	// playback, when set, is told about every finished stream long enough to
+	// be a playback.
+	playback PlaybackRecorder
 }
 
 type streamSample struct {
@@ -286,6 +306,33 @@ func (t *StreamTracker) ActiveStreams() int {
 	return int(t.activeCount.Load())
 }
 
+// SetPlaybackRecorder wires the recorder (typically the health repository)
+// that finished playbacks are reported to. Pass nil to clear.
+func (t *StreamTracker) SetPlaybackRecorder(r PlaybackRecorder) {
+	t.playback = r
+}
+
+// recordPlayback reports a finished stream to the playback recorder, off the
+// caller's goroutine: Remove runs on stream teardown paths.
+func (t *StreamTracker) recordPlayback(s nzbfilesystem.ActiveStream) {
+	if t.playback == nil || s.BytesSent < minPlaybackBytes {
+		return
+	}
+	progress := max(s.BytesSent, s.CurrentOffset)
+	p := database.Playback{
+		FilePath:   s.FilePath,
+		At:         time.Now(),
+		InProgress: s.TotalSize > 0 && float64(progress) < float64(s.TotalSize)*finishedPlaybackRatio,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := t.playback.RecordPlayback(ctx, p); err != nil {
+			slog.WarnContext(ctx, "Failed to record playback", "file_path", p.FilePath, "error", err)
+		}
+	}()
+}
+
 func (t *StreamTracker) notifyChange() {
 	if t.notifier != nil {
 		t.notifier.NotifyStreamChange()
@@ -367,6 +414,7 @@ func (t *StreamTracker) Remove(id string) {
 		finalStream := *internal.ActiveStream
 		finalStream.BytesSent = atomic.LoadInt64(&internal.BytesSent)
 		finalStream.BytesDownloaded = atomic.LoadInt64(&internal.BytesDownloaded)
+		finalStream.CurrentOffset = atomic.LoadInt64(&internal.CurrentOffset)
 		finalStream.BytesPerSecond = 0
 		finalStream.DownloadSpeed = 0
 		finalStream.Status = "Completed"
@@ -379,6 +427,8 @@ func (t *StreamTracker) Remove(id string) {
 		t.history = append(t.history, finalStream)
 		t.mu.Unlock()
 
+		t.recordPlayback(finalStream)
+
 		t.streams.Delete(id)
 		t.activeCount.Add(-1)
 		t.notifyChange()
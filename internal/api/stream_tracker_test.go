@@ -1,10 +1,12 @@
 package api
 
 import (
+	"context"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/javi11/altmount/internal/database"
 	"github.com/javi11/altmount/internal/nzbfilesystem"
 	"github.com/stretchr/testify/assert"
 )
@@ -72,3 +74,50 @@ func TestStreamTracker_GetAll_Sorting(t *testing.T) {
 	assert.Equal(t, "/new.mkv", streams[0].FilePath)
 	assert.Equal(t, "/old.mkv", streams[1].FilePath)
 }
+
+// chanPlaybackRecorder hands recorded playbacks to the test.
+type chanPlaybackRecorder chan database.Playback
+
+func (c chanPlaybackRecorder) RecordPlayback(_ context.Context, p database.Playback) error {
+	c <- p
+	return nil
+}
+
+func TestStreamTracker_RecordsPlayback(t *testing.T) {
+	tracker := NewStreamTracker(nil)
+	defer tracker.Stop()
+	recorded := make(chanPlaybackRecorder, 2)
+	tracker.SetPlaybackRecorder(recorded)
+
+	const size = 1000 * 1024 * 1024
+
+	// A probe reading a few megabytes is not a playback.
+	probe := tracker.AddStream("/movies/movie.mkv", "FUSE", "user", "", "", size)
+	atomic.AddInt64(&probe.BytesSent, 4*1024*1024)
+	tracker.Remove(probe.ID)
+
+	// A stream stopped half-way leaves the file in "continue watching".
+	partial := tracker.AddStream("/movies/movie.mkv", "FUSE", "user", "", "", size)
+	atomic.AddInt64(&partial.BytesSent, size/2)
+	tracker.Remove(partial.ID)
+
+	select {
+	case p := <-recorded:
+		assert.Equal(t, "/movies/movie.mkv", p.FilePath)
+		assert.True(t, p.InProgress)
+	case <-time.After(5 * time.Second):
+		t.Fatal("playback was not recorded")
+	}
+
+	finished := tracker.AddStream("/movies/movie.mkv", "FUSE", "user", "", "", size)
+	atomic.AddInt64(&finished.BytesSent, size)
+	tracker.Remove(finished.ID)
+
+	select {
+	case p := <-recorded:
+		assert.False(t, p.InProgress)
+	case <-time.After(5 * time.Second):
+		t.Fatal("playback was not recorded")
+	}
+	assert.Empty(t, recorded, "the probe was not recorded")
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -489,10 +490,12 @@ type QueueItemResponse struct {
 	BatchID        *string                `json:"batch_id"`
 	Metadata       *string                `json:"metadata"`
 	FileSize       *int64                 `json:"file_size"`
-	Indexer        *string                `json:"indexer,omitempty"`      // Indexer name
-	Percentage     *int                   `json:"percentage,omitempty"`   // Progress percentage (0-100), only for items being processed
-	Stage          string                 `json:"stage,omitempty"`        // Progress stage (e.g. "Validating segments")
-	StoragePath    *string                `json:"storage_path,omitempty"` // Internal FUSE mount path (populated after completion)
+	Indexer        *string                `json:"indexer,omitempty"`       // Indexer name
+	Percentage     *int                   `json:"percentage,omitempty"`    // Progress percentage (0-100), only for items being processed
+	Stage          string                 `json:"stage,omitempty"`         // Progress stage (e.g. "Validating segments")
+	StoragePath    *string                `json:"storage_path,omitempty"`  // Internal FUSE mount path (populated after completion)
+	HoldUntil      *time.Time             `json:"hold_until,omitempty"`    // Item is not claimed before this time
+	Deprioritized  bool                   `json:"deprioritized,omitempty"` // Sorted behind same-priority items after slow sampling
 }
 
 // QueueStatsResponse represents queue statistics in API responses
@@ -514,17 +517,19 @@ type QueueHistoryRange struct {
 
 // ImportHistoryResponse represents a persistent import record in API responses
 type ImportHistoryResponse struct {
-	ID          int64     `json:"id"`
-	NzbID       *int64    `json:"nzb_id"`
-	NzbName     string    `json:"nzb_name"`
-	FileName    string    `json:"file_name"`
-	FileSize    int64     `json:"file_size"`
-	VirtualPath string    `json:"virtual_path"`
-	LibraryPath *string   `json:"library_path,omitempty"`
-	Category    *string   `json:"category"`
-	Indexer     *string   `json:"indexer,omitempty"` // Added indexer
-	Metadata    *string   `json:"metadata,omitempty"`
-	CompletedAt time.Time `json:"completed_at"`
+	ID          int64   `json:"id"`
+	NzbID       *int64  `json:"nzb_id"`
+	NzbName     string  `json:"nzb_name"`
+	FileName    string  `json:"file_name"`
+	FileSize    int64   `json:"file_size"`
+	VirtualPath string  `json:"virtual_path"`
+	LibraryPath *string `json:"library_path,omitempty"`
+	Category    *string `json:"category"`
+	Indexer     *string `json:"indexer,omitempty"` // Added indexer
+	Metadata    *string `json:"metadata,omitempty"`
+	// OriginalNzbName is the obfuscated NZB filename when NzbName was recovered.
+	OriginalNzbName *string   `json:"original_nzb_name,omitempty"`
+	CompletedAt     time.Time `json:"completed_at"`
 }
 
 // DailyStat represents statistics for a single day
@@ -568,6 +573,40 @@ type HealthItemResponse struct {
 	// Failure masking fields
 	StreamingFailureCount int  `json:"streaming_failure_count"`
 	IsMasked              bool `json:"is_masked"`
+	// Per-provider completeness of the import's fast-fail sample
+	ProviderCompleteness []database.ProviderCompleteness `json:"provider_completeness,omitempty"`
+	// Article-expiry model: the release's posting, the predicted probability
+	// of losing segments within a week, and why the next check is scheduled
+	// when it is
+	Newsgroup       *string    `json:"newsgroup,omitempty"`
+	Poster          *string    `json:"poster,omitempty"`
+	FirstLossAt     *time.Time `json:"first_loss_at,omitempty"`
+	ExpiryRisk      *float64   `json:"expiry_risk,omitempty"`
+	NextCheckReason *string    `json:"next_check_reason,omitempty"`
+	// Playback: checks and repairs of played files go first
+	LastStreamedAt   *time.Time `json:"last_streamed_at,omitempty"`
+	StreamCount      int        `json:"stream_count"`
+	ContinueWatching bool       `json:"continue_watching"`
+	// Sampling: missing-rate estimate of the last check's segment sample
+	SampleConfidence *database.SampleConfidence `json:"sample_confidence,omitempty"`
+	// PAR2: whether the release's recovery volumes cover the missing data
+	Repairability *database.Repairability `json:"repairability,omitempty"`
+	// Repair: state machine position and, for a single record, its audit log
+	RepairState  database.RepairState  `json:"repair_state,omitempty"`
+	RepairEvents []RepairEventResponse `json:"repair_events,omitempty"`
+}
+
+// RepairEventResponse represents one repair audit log entry in API responses
+type RepairEventResponse struct {
+	ID          int64                `json:"id"`
+	FromState   database.RepairState `json:"from_state"`
+	ToState     database.RepairState `json:"to_state"`
+	Action      string               `json:"action"`
+	ArrType     *string              `json:"arr_type,omitempty"`
+	ArrInstance *string              `json:"arr_instance,omitempty"`
+	Details     json.RawMessage      `json:"details,omitempty"`
+	Result      *string              `json:"result,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
 }
 
 // HealthStatsResponse represents health statistics in API responses
@@ -746,6 +785,8 @@ func ToQueueItemResponse(item *database.ImportQueueItem) *QueueItemResponse {
 		FileSize:       item.FileSize,
 		StoragePath:    item.StoragePath,
 		Indexer:        item.Indexer,
+		HoldUntil:      item.HoldUntil,
+		Deprioritized:  item.Deprioritized,
 	}
 }
 
@@ -839,17 +880,18 @@ func ToImportHistoryResponse(h *database.ImportHistory) *ImportHistoryResponse {
 		return nil
 	}
 	return &ImportHistoryResponse{
-		ID:          h.ID,
-		NzbID:       h.NzbID,
-		NzbName:     h.NzbName,
-		FileName:    h.FileName,
-		FileSize:    h.FileSize,
-		VirtualPath: h.VirtualPath,
-		LibraryPath: h.LibraryPath,
-		Category:    h.Category,
-		Indexer:     h.Indexer, // Fixed: use pointer directly
-		Metadata:    h.Metadata,
-		CompletedAt: h.CompletedAt,
+		ID:              h.ID,
+		NzbID:           h.NzbID,
+		NzbName:         h.NzbName,
+		FileName:        h.FileName,
+		FileSize:        h.FileSize,
+		VirtualPath:     h.VirtualPath,
+		LibraryPath:     h.LibraryPath,
+		Category:        h.Category,
+		Indexer:         h.Indexer, // Fixed: use pointer directly
+		Metadata:        h.Metadata,
+		OriginalNzbName: h.OriginalNzbName,
+		CompletedAt:     h.CompletedAt,
 	}
 }
 
@@ -879,9 +921,43 @@ func ToHealthItemResponse(item *database.FileHealth) *HealthItemResponse {
 		Priority:              item.Priority,
 		StreamingFailureCount: item.StreamingFailureCount,
 		IsMasked:              item.IsMasked,
+		ProviderCompleteness:  database.ParseProviderCompleteness(item.ProviderCompleteness),
+		Newsgroup:             item.Newsgroup,
+		Poster:                item.Poster,
+		FirstLossAt:           item.FirstLossAt,
+		ExpiryRisk:            item.ExpiryRisk,
+		NextCheckReason:       item.NextCheckReason,
+		LastStreamedAt:        item.LastStreamedAt,
+		StreamCount:           item.StreamCount,
+		ContinueWatching:      item.ContinueWatching,
+		SampleConfidence:      database.ParseSampleConfidence(item.SampleConfidence),
+		RepairState:           item.RepairState,
+		Repairability:         database.ParseRepairability(item.Repairability),
 	}
 }
 
+// ToRepairEventResponses converts repair audit log entries to API responses
+func ToRepairEventResponses(events []*database.RepairEvent) []RepairEventResponse {
+	responses := make([]RepairEventResponse, 0, len(events))
+	for _, ev := range events {
+		r := RepairEventResponse{
+			ID:          ev.ID,
+			FromState:   ev.FromState,
+			ToState:     ev.ToState,
+			Action:      ev.Action,
+			ArrType:     ev.ArrType,
+			ArrInstance: ev.ArrInstance,
+			Result:      ev.Result,
+			CreatedAt:   ev.CreatedAt,
+		}
+		if ev.Details != nil && json.Valid([]byte(*ev.Details)) {
+			r.Details = json.RawMessage(*ev.Details)
+		}
+		responses = append(responses, r)
+	}
+	return responses
+}
+
 // ToHealthStatsResponse converts health stats map to HealthStatsResponse
 func ToHealthStatsResponse(stats map[database.HealthStatus]int) *HealthStatsResponse {
 	pending := stats[database.HealthStatusPending]
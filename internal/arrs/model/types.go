@@ -10,6 +10,9 @@ var (
 	ErrPathMatchFailed         = fmt.Errorf("path match failed")
 	ErrEpisodeAlreadySatisfied = fmt.Errorf("item already satisfied by another file in ARR")
 	ErrInstanceNotFound        = fmt.Errorf("instance not found")
+	// ErrInstanceUnreachable is returned instead of ErrInstanceNotFound when
+	// no instance claimed a file but some could not be asked.
+	ErrInstanceUnreachable = fmt.Errorf("instance unreachable")
 )
 
 // ConfigInstance represents an arrs instance from configuration
@@ -29,3 +32,41 @@ type ConfigManager interface {
 	UpdateConfig(config *config.Config) error
 	SaveConfig() error
 }
+
+// RescanResult records what a repair rescan did in the ARR: the instance it
+// went to, the media it targeted and the IDs of every blocklist, queue, delete
+// and search action it took, so the repair can be audited and followed up.
+type RescanResult struct {
+	InstanceType          string  `json:"instance_type"`
+	InstanceName          string  `json:"instance_name"`
+	MediaIDs              []int64 `json:"media_ids,omitempty"`
+	BlocklistedHistoryIDs []int64 `json:"blocklisted_history_ids,omitempty"`
+	FailedQueueIDs        []int64 `json:"failed_queue_ids,omitempty"`
+	DeletedFileIDs        []int64 `json:"deleted_file_ids,omitempty"`
+	SearchCommandIDs      []int64 `json:"search_command_ids,omitempty"`
+	// Unmonitored is set when the failure breaker gave up on the media
+	// instead of searching it again.
+	Unmonitored bool `json:"unmonitored,omitempty"`
+}
+
+// ErrVerificationUnsupported is returned when repair progress cannot be
+// polled for an instance type.
+var ErrVerificationUnsupported = fmt.Errorf("repair verification not supported for this instance type")
+
+// RepairProgress is how far an ARR got with the search of a repair.
+type RepairProgress string
+
+const (
+	RepairProgressPending    RepairProgress = "pending"     // Search running, or done without a grab yet
+	RepairProgressGrabbed    RepairProgress = "grabbed"     // A replacement release was grabbed
+	RepairProgressNoReleases RepairProgress = "no_releases" // The search finished without grabbing a release
+	RepairProgressFailed     RepairProgress = "failed"      // A search command failed in the ARR
+)
+
+// RepairVerification is the polled progress of a repair in the ARR.
+type RepairVerification struct {
+	Progress RepairProgress `json:"progress"`
+	// Message is the grabbed release title, or the ARR's message of a
+	// finished or failed search command.
+	Message string `json:"message,omitempty"`
+}
@@ -3,6 +3,7 @@ package scanner
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"path/filepath"
@@ -56,16 +57,28 @@ func (m *Manager) findInstanceForFilePath(ctx context.Context, filePath string,
 
 	allInstances := m.instances.GetAllInstances()
 
+	// unreachable collects the instances that could not answer. Only when every
+	// enabled instance answered is "no instance manages the file" a verdict.
+	var unreachable []error
+
 	// Strategy 1: Fast Path - Check Root Folders
 	for _, instance := range allInstances {
 		if !instance.Enabled {
 			continue
 		}
 
-		if client, err := m.clients.GetOrCreateClient(instance); err == nil {
-			if m.managesFile(ctx, instance.Type, client, filePath) {
-				return instance.Type, instance.Name, nil
-			}
+		client, err := m.clients.GetOrCreateClient(instance)
+		if err != nil {
+			unreachable = append(unreachable, fmt.Errorf("%s: %w", instance.Name, err))
+			continue
+		}
+		manages, err := m.managesFile(ctx, instance.Type, client, filePath)
+		if err != nil {
+			unreachable = append(unreachable, fmt.Errorf("%s: %w", instance.Name, err))
+			continue
+		}
+		if manages {
+			return instance.Type, instance.Name, nil
 		}
 	}
 
@@ -108,80 +121,91 @@ func (m *Manager) findInstanceForFilePath(ctx context.Context, filePath string,
 				continue
 			}
 
-			if client, err := m.clients.GetOrCreateClient(instance); err == nil {
-				if m.hasFile(ctx, instance.Type, client, instance.Name, relativePath) {
-					slog.InfoContext(ctx, "Found managing instance by relative path", "instance", instance.Name, "type", instance.Type)
-					return instance.Type, instance.Name, nil
-				}
+			client, err := m.clients.GetOrCreateClient(instance)
+			if err != nil {
+				continue // already recorded by the root folder pass
+			}
+			has, err := m.hasFile(ctx, instance.Type, client, instance.Name, relativePath)
+			if err != nil {
+				unreachable = append(unreachable, fmt.Errorf("%s: %w", instance.Name, err))
+				continue
+			}
+			if has {
+				slog.InfoContext(ctx, "Found managing instance by relative path", "instance", instance.Name, "type", instance.Type)
+				return instance.Type, instance.Name, nil
 			}
 		}
 	}
 
-	return "", "", fmt.Errorf("no ARR instance found managing file path: %s", filePath)
+	if len(unreachable) > 0 {
+		return "", "", fmt.Errorf("no reachable ARR instance manages file path %s: %w: %w",
+			filePath, model.ErrInstanceUnreachable, errors.Join(unreachable...))
+	}
+	return "", "", fmt.Errorf("no ARR instance found managing file path %s: %w", filePath, model.ErrInstanceNotFound)
 }
 
-func (m *Manager) managesFile(ctx context.Context, instanceType string, client any, filePath string) bool {
+func (m *Manager) managesFile(ctx context.Context, instanceType string, client any, filePath string) (bool, error) {
 	switch instanceType {
 	case "radarr":
 		rc, ok := client.(*radarr.Radarr)
 		if !ok {
-			return false
+			return false, nil
 		}
 		return m.radarrManagesFile(ctx, rc, filePath)
 	case "sonarr":
 		sc, ok := client.(*sonarr.Sonarr)
 		if !ok {
-			return false
+			return false, nil
 		}
 		return m.sonarrManagesFile(ctx, sc, filePath)
 	case "lidarr":
 		lc, ok := client.(*lidarr.Lidarr)
 		if !ok {
-			return false
+			return false, nil
 		}
 		return m.lidarrManagesFile(ctx, lc, filePath)
 	case "readarr":
 		rc, ok := client.(*readarr.Readarr)
 		if !ok {
-			return false
+			return false, nil
 		}
 		return m.readarrManagesFile(ctx, rc, filePath)
 	case "whisparr":
 		wc, ok := client.(*sonarr.Sonarr)
 		if !ok {
-			return false
+			return false, nil
 		}
 		return m.sonarrManagesFile(ctx, wc, filePath)
 	default:
-		return false
+		return false, nil
 	}
 }
 
-func (m *Manager) hasFile(ctx context.Context, instanceType string, client any, instanceName, relativePath string) bool {
+func (m *Manager) hasFile(ctx context.Context, instanceType string, client any, instanceName, relativePath string) (bool, error) {
 	switch instanceType {
 	case "radarr":
 		rc, ok := client.(*radarr.Radarr)
 		if !ok {
-			return false
+			return false, nil
 		}
 		return m.radarrHasFile(ctx, rc, instanceName, relativePath)
 	case "sonarr":
 		sc, ok := client.(*sonarr.Sonarr)
 		if !ok {
-			return false
+			return false, nil
 		}
 		return m.sonarrHasFile(ctx, sc, instanceName, relativePath)
 	case "lidarr", "readarr", "whisparr":
 		// For now, these don't have a slow path search implementation
 		// They rely on the Root Folder (Strategy 1) or Category (Strategy 2)
-		return false
+		return false, nil
 	default:
-		return false
+		return false, nil
 	}
 }
 
 // radarrManagesFile checks if Radarr manages the given file path using root folders (checkrr approach)
-func (m *Manager) radarrManagesFile(ctx context.Context, client *radarr.Radarr, filePath string) bool {
+func (m *Manager) radarrManagesFile(ctx context.Context, client *radarr.Radarr, filePath string) (bool, error) {
 	slog.DebugContext(ctx, "Checking Radarr root folders for file ownership",
 		"file_path", filePath)
 
@@ -189,7 +213,7 @@ func (m *Manager) radarrManagesFile(ctx context.Context, client *radarr.Radarr,
 	rootFolders, err := client.GetRootFoldersContext(ctx)
 	if err != nil {
 		slog.DebugContext(ctx, "Failed to get root folders from Radarr for file check", "error", err)
-		return false
+		return false, err
 	}
 
 	// Check if file path starts with any root folder path
@@ -198,16 +222,16 @@ func (m *Manager) radarrManagesFile(ctx context.Context, client *radarr.Radarr,
 		// Check for direct prefix match or if the filePath contains the folder.Path (common in Docker/Remote setups)
 		if strings.HasPrefix(filePath, folder.Path) {
 			slog.DebugContext(ctx, "File matches Radarr root folder", "folder_path", folder.Path)
-			return true
+			return true, nil
 		}
 	}
 
 	slog.DebugContext(ctx, "File does not match any Radarr root folders")
-	return false
+	return false, nil
 }
 
 // sonarrManagesFile checks if Sonarr manages the given file path using root folders (checkrr approach)
-func (m *Manager) sonarrManagesFile(ctx context.Context, client *sonarr.Sonarr, filePath string) bool {
+func (m *Manager) sonarrManagesFile(ctx context.Context, client *sonarr.Sonarr, filePath string) (bool, error) {
 	slog.DebugContext(ctx, "Checking Sonarr root folders for file ownership",
 		"file_path", filePath)
 
@@ -215,7 +239,7 @@ func (m *Manager) sonarrManagesFile(ctx context.Context, client *sonarr.Sonarr,
 	rootFolders, err := client.GetRootFoldersContext(ctx)
 	if err != nil {
 		slog.DebugContext(ctx, "Failed to get root folders from Sonarr for file check", "error", err)
-		return false
+		return false, err
 	}
 
 	// Check if file path starts with any root folder path
@@ -223,52 +247,52 @@ func (m *Manager) sonarrManagesFile(ctx context.Context, client *sonarr.Sonarr,
 		slog.DebugContext(ctx, "Checking Sonarr root folder", "folder_path", folder.Path, "file_path", filePath)
 		if strings.HasPrefix(filePath, folder.Path) {
 			slog.DebugContext(ctx, "File matches Sonarr root folder", "folder_path", folder.Path)
-			return true
+			return true, nil
 		}
 	}
 
 	slog.DebugContext(ctx, "File does not match any Sonarr root folders")
-	return false
+	return false, nil
 }
 
 // lidarrManagesFile checks if Lidarr manages the given file path using root folders
-func (m *Manager) lidarrManagesFile(ctx context.Context, client *lidarr.Lidarr, filePath string) bool {
+func (m *Manager) lidarrManagesFile(ctx context.Context, client *lidarr.Lidarr, filePath string) (bool, error) {
 	slog.DebugContext(ctx, "Checking Lidarr root folders for file ownership", "file_path", filePath)
 	rootFolders, err := client.GetRootFoldersContext(ctx)
 	if err != nil {
 		slog.DebugContext(ctx, "Failed to get root folders from Lidarr", "error", err)
-		return false
+		return false, err
 	}
 	for _, folder := range rootFolders {
 		if strings.HasPrefix(filePath, folder.Path) {
-			return true
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
 
 // readarrManagesFile checks if Readarr manages the given file path using root folders
-func (m *Manager) readarrManagesFile(ctx context.Context, client *readarr.Readarr, filePath string) bool {
+func (m *Manager) readarrManagesFile(ctx context.Context, client *readarr.Readarr, filePath string) (bool, error) {
 	slog.DebugContext(ctx, "Checking Readarr root folders for file ownership", "file_path", filePath)
 	rootFolders, err := client.GetRootFoldersContext(ctx)
 	if err != nil {
 		slog.DebugContext(ctx, "Failed to get root folders from Readarr", "error", err)
-		return false
+		return false, err
 	}
 	for _, folder := range rootFolders {
 		if strings.HasPrefix(filePath, folder.Path) {
-			return true
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
 
 // radarrHasFile checks if any movie in the instance contains the given relative path
-func (m *Manager) radarrHasFile(ctx context.Context, client *radarr.Radarr, instanceName, relativePath string) bool {
+func (m *Manager) radarrHasFile(ctx context.Context, client *radarr.Radarr, instanceName, relativePath string) (bool, error) {
 	movies, err := m.data.GetMovies(ctx, client, instanceName)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to get movies for relative path check", "instance", instanceName, "error", err)
-		return false
+		return false, err
 	}
 
 	strippedRelative := strings.TrimSuffix(relativePath, ".strm")
@@ -277,19 +301,19 @@ func (m *Manager) radarrHasFile(ctx context.Context, client *radarr.Radarr, inst
 		if movie.HasFile && movie.MovieFile != nil {
 			if strings.HasSuffix(movie.MovieFile.Path, relativePath) ||
 				strings.HasSuffix(strings.TrimSuffix(movie.MovieFile.Path, filepath.Ext(movie.MovieFile.Path)), strippedRelative) {
-				return true
+				return true, nil
 			}
 		}
 	}
-	return false
+	return false, nil
 }
 
 // sonarrHasFile checks if any series in the instance contains the given relative path
-func (m *Manager) sonarrHasFile(ctx context.Context, client *sonarr.Sonarr, instanceName, relativePath string) bool {
+func (m *Manager) sonarrHasFile(ctx context.Context, client *sonarr.Sonarr, instanceName, relativePath string) (bool, error) {
 	seriesList, err := m.data.GetSeries(ctx, client, instanceName)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to get series for relative path check", "instance", instanceName, "error", err)
-		return false
+		return false, err
 	}
 
 	// Normalize relative path for comparison
@@ -300,14 +324,14 @@ func (m *Manager) sonarrHasFile(ctx context.Context, client *sonarr.Sonarr, inst
 		// Check if the series folder name is part of the relative path
 		folderName := filepath.Base(series.Path)
 		if strings.Contains(relativePath, folderName) || strings.Contains(strippedRelative, folderName) {
-			return true
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
 
 // TriggerFileRescan triggers a rescan for a specific file path through the appropriate ARR instance
-func (m *Manager) TriggerFileRescan(ctx context.Context, pathForRescan string, relativePath string, metadataStr *string) error {
+func (m *Manager) TriggerFileRescan(ctx context.Context, pathForRescan string, relativePath string, metadataStr *string) (*model.RescanResult, error) {
 	hasMeta := "false"
 	if metadataStr != nil && *metadataStr != "" {
 		hasMeta = "true"
@@ -367,6 +391,8 @@ func (m *Manager) TriggerFileRescan(ctx context.Context, pathForRescan string, r
 			return nil, fmt.Errorf("instance %s/%s is disabled", instanceType, instanceName)
 		}
 
+		result := &model.RescanResult{InstanceType: instanceType, InstanceName: instanceName}
+
 		// Trigger rescan based on instance type
 		switch instanceType {
 		case "radarr":
@@ -374,48 +400,43 @@ func (m *Manager) TriggerFileRescan(ctx context.Context, pathForRescan string, r
 			if err != nil {
 				return nil, fmt.Errorf("failed to create Radarr client: %w", err)
 			}
-			return nil, m.triggerRadarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata)
+			return result, m.triggerRadarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata, result)
 
 		case "sonarr":
 			client, err := m.clients.GetOrCreateSonarrClient(instanceName, instanceConfig.URL, instanceConfig.APIKey)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create Sonarr client: %w", err)
 			}
-			return nil, m.triggerSonarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata)
+			return result, m.triggerSonarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata, result)
 
 		case "lidarr":
 			client, err := m.clients.GetOrCreateLidarrClient(instanceName, instanceConfig.URL, instanceConfig.APIKey)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create Lidarr client: %w", err)
 			}
-			return nil, m.triggerLidarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata)
+			return result, m.triggerLidarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata, result)
 
 		case "readarr":
 			client, err := m.clients.GetOrCreateReadarrClient(instanceName, instanceConfig.URL, instanceConfig.APIKey)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create Readarr client: %w", err)
 			}
-			return nil, m.triggerReadarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata)
+			return result, m.triggerReadarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata, result)
 
 		case "whisparr":
 			client, err := m.clients.GetOrCreateWhisparrClient(instanceName, instanceConfig.URL, instanceConfig.APIKey)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create Whisparr client: %w", err)
 			}
-			return nil, m.triggerSonarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata)
+			return result, m.triggerSonarrRescanByPath(bgCtx, client, pathForRescan, relativePath, instanceName, metadata, result)
 
 		default:
 			return nil, fmt.Errorf("unsupported instance type: %s", instanceType)
 		}
 	})
 
-	if err != nil {
-		return err
-	}
-	if res != nil {
-		return res.(error)
-	}
-	return nil
+	result, _ := res.(*model.RescanResult)
+	return result, err
 }
 
 // TriggerScanForFile finds the ARR instance managing the file and triggers a download scan on it.
@@ -597,7 +618,7 @@ func radarrFileMatchesTarget(movie *radarr.Movie, filePath, relativePath string)
 }
 
 // triggerRadarrRescanByPath triggers a rescan in Radarr for the given file path
-func (m *Manager) triggerRadarrRescanByPath(ctx context.Context, client *radarr.Radarr, filePath, relativePath, instanceName string, metadata *model.WebhookMetadata) error {
+func (m *Manager) triggerRadarrRescanByPath(ctx context.Context, client *radarr.Radarr, filePath, relativePath, instanceName string, metadata *model.WebhookMetadata, res *model.RescanResult) error {
 	slog.InfoContext(ctx, "Searching Radarr for matching movie",
 		"instance", instanceName,
 		"file_path", filePath,
@@ -750,7 +771,7 @@ func (m *Manager) triggerRadarrRescanByPath(ctx context.Context, client *radarr.
 			"file_path", filePath)
 
 		// Fallback: search in Radarr download queue for active/stuck imports
-		if err := m.failRadarrQueueItemByPath(ctx, client, filePath); err == nil {
+		if err := m.failRadarrQueueItemByPath(ctx, client, filePath, res); err == nil {
 			return nil
 		}
 
@@ -767,7 +788,7 @@ func (m *Manager) triggerRadarrRescanByPath(ctx context.Context, client *radarr.
 	// If we found the movie and have a file ID, try to blocklist and delete the file
 	if targetMovieFileID > 0 {
 		// Try to blocklist the release associated with this file
-		if err := m.blocklistRadarrMovieFile(ctx, client, targetMovie.ID, targetMovieFileID, relativePath, sceneName); err != nil {
+		if err := m.blocklistRadarrMovieFile(ctx, client, targetMovie.ID, targetMovieFileID, relativePath, sceneName, res); err != nil {
 			slog.WarnContext(ctx, "Failed to blocklist Radarr release", "error", err)
 		}
 
@@ -779,12 +800,14 @@ func (m *Manager) triggerRadarrRescanByPath(ctx context.Context, client *radarr.
 				"movie_id", targetMovie.ID,
 				"file_id", targetMovieFileID,
 				"error", err)
+		} else {
+			res.DeletedFileIDs = append(res.DeletedFileIDs, targetMovieFileID)
 		}
 	} else {
 		slog.InfoContext(ctx, "Movie has no specific file ID linked in Radarr, attempting release blocklist using metadata",
 			"movie", targetMovie.Title)
 		if metadata != nil && metadata.Movie != nil && metadata.Movie.Id > 0 {
-			if err := m.blocklistRadarrMovieFile(ctx, client, targetMovie.ID, 0, relativePath, sceneName); err != nil {
+			if err := m.blocklistRadarrMovieFile(ctx, client, targetMovie.ID, 0, relativePath, sceneName, res); err != nil {
 				slog.WarnContext(ctx, "Failed to blocklist Radarr release using metadata fallback", "error", err)
 			}
 		}
@@ -793,8 +816,10 @@ func (m *Manager) triggerRadarrRescanByPath(ctx context.Context, client *radarr.
 	// Failure breaker: every targeted re-search counts one failure-driven action
 	// against the movie. At the threshold the movie is unmonitored instead of
 	// re-searched so a dead release can't drive an endless re-grab storm.
+	res.MediaIDs = []int64{targetMovie.ID}
 	if m.movieBreakerTripped(instanceName, targetMovie.ID) {
 		unmonitorRadarrMovie(ctx, client, instanceName, targetMovie.ID)
+		res.Unmonitored = true
 		return nil
 	}
 
@@ -808,6 +833,7 @@ func (m *Manager) triggerRadarrRescanByPath(ctx context.Context, client *radarr.
 	if err != nil {
 		return fmt.Errorf("failed to trigger Radarr search for movie ID %d: %w", targetMovie.ID, err)
 	}
+	res.SearchCommandIDs = append(res.SearchCommandIDs, response.ID)
 
 	slog.InfoContext(ctx, "Successfully triggered Radarr targeted search for re-download",
 		"instance", instanceName,
@@ -818,7 +844,7 @@ func (m *Manager) triggerRadarrRescanByPath(ctx context.Context, client *radarr.
 }
 
 // triggerSonarrRescanByPath triggers a rescan in Sonarr for the given file path
-func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.Sonarr, filePath, relativePath, instanceName string, metadata *model.WebhookMetadata) error {
+func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.Sonarr, filePath, relativePath, instanceName string, metadata *model.WebhookMetadata, res *model.RescanResult) error {
 	slog.InfoContext(ctx, "Searching Sonarr for matching series",
 		"instance", instanceName,
 		"file_path", filePath,
@@ -894,7 +920,7 @@ func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.
 				"file_path", filePath)
 
 			// Fallback: search in Sonarr download queue for active/stuck imports
-			if err := m.failSonarrQueueItemByPath(ctx, client, filePath); err == nil {
+			if err := m.failSonarrQueueItemByPath(ctx, client, filePath, res); err == nil {
 				return nil
 			}
 
@@ -1002,7 +1028,7 @@ func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.
 				"episode_file_id", targetEpisodeFileID)
 
 			// Try to blocklist the release associated with this file
-			if err := m.blocklistSonarrEpisodeFile(ctx, client, targetSeriesID, targetEpisodeFileID, relativePath, episodeIDs, sceneName); err != nil {
+			if err := m.blocklistSonarrEpisodeFile(ctx, client, targetSeriesID, targetEpisodeFileID, relativePath, episodeIDs, sceneName, res); err != nil {
 				slog.WarnContext(ctx, "Failed to blocklist Sonarr release", "error", err)
 			}
 
@@ -1013,6 +1039,8 @@ func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.
 					"instance", instanceName,
 					"episode_file_id", targetEpisodeFileID,
 					"error", err)
+			} else {
+				res.DeletedFileIDs = append(res.DeletedFileIDs, targetEpisodeFileID)
 			}
 		}
 	} else {
@@ -1021,7 +1049,7 @@ func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.
 			"file_path", filePath)
 
 		// Fallback: search in Sonarr download queue
-		if err := m.failSonarrQueueItemByPath(ctx, client, filePath); err == nil {
+		if err := m.failSonarrQueueItemByPath(ctx, client, filePath, res); err == nil {
 			return nil
 		}
 
@@ -1033,7 +1061,7 @@ func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.
 			}
 
 			// Try to blocklist the release associated with these episodes
-			if err := m.blocklistSonarrEpisodeFile(ctx, client, targetSeriesID, 0, relativePath, episodeIDs, sceneName); err != nil {
+			if err := m.blocklistSonarrEpisodeFile(ctx, client, targetSeriesID, 0, relativePath, episodeIDs, sceneName, res); err != nil {
 				slog.WarnContext(ctx, "Failed to blocklist Sonarr release using metadata fallback", "error", err)
 			}
 		}
@@ -1046,8 +1074,10 @@ func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.
 	// Failure breaker: every targeted re-search counts one failure-driven action
 	// against each episode. Episodes at the threshold are unmonitored instead of
 	// re-searched so a dead release can't drive an endless re-grab storm.
+	res.MediaIDs = episodeIDs
 	searchIDs, giveUpIDs := m.splitEpisodesByBreaker(instanceName, episodeIDs)
 	unmonitorSonarrEpisodes(ctx, client, instanceName, giveUpIDs)
+	res.Unmonitored = len(giveUpIDs) > 0
 	if len(searchIDs) == 0 {
 		return nil
 	}
@@ -1062,6 +1092,7 @@ func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.
 	if err != nil {
 		return fmt.Errorf("failed to trigger Sonarr episode search: %w", err)
 	}
+	res.SearchCommandIDs = append(res.SearchCommandIDs, response.ID)
 
 	slog.InfoContext(ctx, "Successfully triggered Sonarr targeted episode search for re-download",
 		"instance", instanceName,
@@ -1073,7 +1104,7 @@ func (m *Manager) triggerSonarrRescanByPath(ctx context.Context, client *sonarr.
 }
 
 // failRadarrQueueItemByPath searches for an item in the active Radarr queue by path and marks it as failed
-func (m *Manager) failRadarrQueueItemByPath(ctx context.Context, client *radarr.Radarr, path string) error {
+func (m *Manager) failRadarrQueueItemByPath(ctx context.Context, client *radarr.Radarr, path string, res *model.RescanResult) error {
 	queue, err := client.GetQueueContext(ctx, 0, 500)
 	if err != nil {
 		return fmt.Errorf("failed to get Radarr queue: %w", err)
@@ -1093,7 +1124,11 @@ func (m *Manager) failRadarrQueueItemByPath(ctx context.Context, client *radarr.
 				BlockList:        true,
 				SkipRedownload:   false,
 			}
-			return client.DeleteQueueContext(ctx, q.ID, opts)
+			if err := client.DeleteQueueContext(ctx, q.ID, opts); err != nil {
+				return err
+			}
+			res.FailedQueueIDs = append(res.FailedQueueIDs, q.ID)
+			return nil
 		}
 	}
 
@@ -1101,7 +1136,7 @@ func (m *Manager) failRadarrQueueItemByPath(ctx context.Context, client *radarr.
 }
 
 // failSonarrQueueItemByPath searches for an item in the active Sonarr queue by path and marks it as failed
-func (m *Manager) failSonarrQueueItemByPath(ctx context.Context, client *sonarr.Sonarr, path string) error {
+func (m *Manager) failSonarrQueueItemByPath(ctx context.Context, client *sonarr.Sonarr, path string, res *model.RescanResult) error {
 	queue, err := client.GetQueueContext(ctx, 0, 500)
 	if err != nil {
 		return fmt.Errorf("failed to get Sonarr queue: %w", err)
@@ -1121,7 +1156,11 @@ func (m *Manager) failSonarrQueueItemByPath(ctx context.Context, client *sonarr.
 				BlockList:        true,
 				SkipRedownload:   false,
 			}
-			return client.DeleteQueueContext(ctx, q.ID, opts)
+			if err := client.DeleteQueueContext(ctx, q.ID, opts); err != nil {
+				return err
+			}
+			res.FailedQueueIDs = append(res.FailedQueueIDs, q.ID)
+			return nil
 		}
 	}
 
@@ -1129,7 +1168,7 @@ func (m *Manager) failSonarrQueueItemByPath(ctx context.Context, client *sonarr.
 }
 
 // blocklistRadarrMovieFile finds the history event for the given file and marks it as failed (blocklisting the release)
-func (m *Manager) blocklistRadarrMovieFile(ctx context.Context, client *radarr.Radarr, movieID int64, fileID int64, relativePath string, sceneName string) error {
+func (m *Manager) blocklistRadarrMovieFile(ctx context.Context, client *radarr.Radarr, movieID int64, fileID int64, relativePath string, sceneName string, res *model.RescanResult) error {
 	slog.DebugContext(ctx, "Attempting to find and blocklist release for movie file", "movie_id", movieID, "file_id", fileID, "scene_name", sceneName)
 
 	var downloadID string
@@ -1178,6 +1217,7 @@ func (m *Manager) blocklistRadarrMovieFile(ctx context.Context, client *radarr.R
 					if failErr := client.FailContext(ctx, record.ID); failErr != nil {
 						return fmt.Errorf("failed to fail Radarr grab event %d: %w", record.ID, failErr)
 					}
+					res.BlocklistedHistoryIDs = append(res.BlocklistedHistoryIDs, record.ID)
 					return nil
 				}
 
@@ -1188,6 +1228,7 @@ func (m *Manager) blocklistRadarrMovieFile(ctx context.Context, client *radarr.R
 					if failErr := client.FailContext(ctx, record.ID); failErr != nil {
 						return fmt.Errorf("failed to fail Radarr grab event %d: %w", record.ID, failErr)
 					}
+					res.BlocklistedHistoryIDs = append(res.BlocklistedHistoryIDs, record.ID)
 					return nil
 				}
 			}
@@ -1205,6 +1246,7 @@ func (m *Manager) blocklistRadarrMovieFile(ctx context.Context, client *radarr.R
 			if failErr := client.FailContext(ctx, record.ID); failErr != nil {
 				return fmt.Errorf("failed to fail Radarr grab event %d: %w", record.ID, failErr)
 			}
+			res.BlocklistedHistoryIDs = append(res.BlocklistedHistoryIDs, record.ID)
 			return nil
 		}
 	}
@@ -1214,7 +1256,7 @@ func (m *Manager) blocklistRadarrMovieFile(ctx context.Context, client *radarr.R
 }
 
 // blocklistSonarrEpisodeFile finds the grabbed history event for the given file and marks it as failed (blocklisting the release)
-func (m *Manager) blocklistSonarrEpisodeFile(ctx context.Context, client *sonarr.Sonarr, seriesID int64, fileID int64, relativePath string, episodeIDs []int64, sceneName string) error {
+func (m *Manager) blocklistSonarrEpisodeFile(ctx context.Context, client *sonarr.Sonarr, seriesID int64, fileID int64, relativePath string, episodeIDs []int64, sceneName string, res *model.RescanResult) error {
 	slog.DebugContext(ctx, "Attempting to find and blocklist release for episode file", "series_id", seriesID, "file_id", fileID, "scene_name", sceneName)
 
 	var downloadID string
@@ -1278,6 +1320,7 @@ func (m *Manager) blocklistSonarrEpisodeFile(ctx context.Context, client *sonarr
 					if failErr := client.FailContext(ctx, record.ID); failErr != nil {
 						return fmt.Errorf("failed to fail Sonarr grab event %d: %w", record.ID, failErr)
 					}
+					res.BlocklistedHistoryIDs = append(res.BlocklistedHistoryIDs, record.ID)
 					return nil
 				}
 
@@ -1290,6 +1333,7 @@ func (m *Manager) blocklistSonarrEpisodeFile(ctx context.Context, client *sonarr
 							if failErr := client.FailContext(ctx, record.ID); failErr != nil {
 								return fmt.Errorf("failed to fail Sonarr grab event %d: %w", record.ID, failErr)
 							}
+							res.BlocklistedHistoryIDs = append(res.BlocklistedHistoryIDs, record.ID)
 							return nil
 						}
 					}
@@ -1309,6 +1353,7 @@ func (m *Manager) blocklistSonarrEpisodeFile(ctx context.Context, client *sonarr
 			if failErr := client.FailContext(ctx, record.ID); failErr != nil {
 				return fmt.Errorf("failed to fail Sonarr grab event %d: %w", record.ID, failErr)
 			}
+			res.BlocklistedHistoryIDs = append(res.BlocklistedHistoryIDs, record.ID)
 			return nil
 		}
 	}
@@ -1317,7 +1362,7 @@ func (m *Manager) blocklistSonarrEpisodeFile(ctx context.Context, client *sonarr
 }
 
 // triggerLidarrRescanByPath triggers a rescan in Lidarr
-func (m *Manager) triggerLidarrRescanByPath(ctx context.Context, client *lidarr.Lidarr, filePath, relativePath, instanceName string, metadata *model.WebhookMetadata) error {
+func (m *Manager) triggerLidarrRescanByPath(ctx context.Context, client *lidarr.Lidarr, filePath, relativePath, instanceName string, metadata *model.WebhookMetadata, res *model.RescanResult) error {
 	slog.InfoContext(ctx, "Searching Lidarr for matching track", "instance", instanceName, "file_path", filePath)
 
 	var targetAlbumID int64
@@ -1334,26 +1379,30 @@ func (m *Manager) triggerLidarrRescanByPath(ctx context.Context, client *lidarr.
 	}
 
 	if targetTrackFileID > 0 {
-		if err := m.blocklistLidarrTrackFile(ctx, client, targetAlbumID, targetTrackFileID); err != nil {
+		if err := m.blocklistLidarrTrackFile(ctx, client, targetAlbumID, targetTrackFileID, res); err != nil {
 			slog.WarnContext(ctx, "Failed to blocklist Lidarr release", "error", err)
 		}
-		_ = client.DeleteTrackFileContext(ctx, targetTrackFileID)
+		if err := client.DeleteTrackFileContext(ctx, targetTrackFileID); err == nil {
+			res.DeletedFileIDs = append(res.DeletedFileIDs, targetTrackFileID)
+		}
 	}
+	res.MediaIDs = []int64{targetAlbumID}
 
 	searchCmd := &lidarr.CommandRequest{
 		Name:     "AlbumSearch",
 		AlbumIDs: []int64{targetAlbumID},
 	}
-	_, err := client.SendCommandContext(ctx, searchCmd)
+	response, err := client.SendCommandContext(ctx, searchCmd)
 	if err != nil {
 		return fmt.Errorf("failed to trigger Lidarr search: %w", err)
 	}
+	res.SearchCommandIDs = append(res.SearchCommandIDs, response.ID)
 
 	return nil
 }
 
 // blocklistLidarrTrackFile marks a track file as failed
-func (m *Manager) blocklistLidarrTrackFile(ctx context.Context, client *lidarr.Lidarr, albumID int64, fileID int64) error {
+func (m *Manager) blocklistLidarrTrackFile(ctx context.Context, client *lidarr.Lidarr, albumID int64, fileID int64, res *model.RescanResult) error {
 	req := &starr.PageReq{PageSize: 100, SortKey: "date", SortDir: starr.SortDescend}
 	req.Set("albumId", strconv.FormatInt(albumID, 10))
 
@@ -1379,14 +1428,18 @@ func (m *Manager) blocklistLidarrTrackFile(ctx context.Context, client *lidarr.L
 
 	for _, record := range history.Records {
 		if record.DownloadID == downloadID && record.EventType == "grabbed" {
-			return client.FailContext(ctx, record.ID)
+			if err := client.FailContext(ctx, record.ID); err != nil {
+				return err
+			}
+			res.BlocklistedHistoryIDs = append(res.BlocklistedHistoryIDs, record.ID)
+			return nil
 		}
 	}
 	return nil
 }
 
 // triggerReadarrRescanByPath triggers a rescan in Readarr
-func (m *Manager) triggerReadarrRescanByPath(ctx context.Context, client *readarr.Readarr, filePath, relativePath, instanceName string, metadata *model.WebhookMetadata) error {
+func (m *Manager) triggerReadarrRescanByPath(ctx context.Context, client *readarr.Readarr, filePath, relativePath, instanceName string, metadata *model.WebhookMetadata, res *model.RescanResult) error {
 	slog.InfoContext(ctx, "Searching Readarr for matching book", "instance", instanceName, "file_path", filePath)
 
 	var targetBookID int64
@@ -1403,26 +1456,30 @@ func (m *Manager) triggerReadarrRescanByPath(ctx context.Context, client *readar
 	}
 
 	if targetBookFileID > 0 {
-		if err := m.blocklistReadarrBookFile(ctx, client, targetBookID, targetBookFileID); err != nil {
+		if err := m.blocklistReadarrBookFile(ctx, client, targetBookID, targetBookFileID, res); err != nil {
 			slog.WarnContext(ctx, "Failed to blocklist Readarr release", "error", err)
 		}
-		_ = client.DeleteBookFileContext(ctx, targetBookFileID)
+		if err := client.DeleteBookFileContext(ctx, targetBookFileID); err == nil {
+			res.DeletedFileIDs = append(res.DeletedFileIDs, targetBookFileID)
+		}
 	}
+	res.MediaIDs = []int64{targetBookID}
 
 	searchCmd := &readarr.CommandRequest{
 		Name:    "BookSearch",
 		BookIDs: []int64{targetBookID},
 	}
-	_, err := client.SendCommandContext(ctx, searchCmd)
+	response, err := client.SendCommandContext(ctx, searchCmd)
 	if err != nil {
 		return fmt.Errorf("failed to trigger Readarr search: %w", err)
 	}
+	res.SearchCommandIDs = append(res.SearchCommandIDs, response.ID)
 
 	return nil
 }
 
 // blocklistReadarrBookFile marks a book file as failed
-func (m *Manager) blocklistReadarrBookFile(ctx context.Context, client *readarr.Readarr, bookID int64, fileID int64) error {
+func (m *Manager) blocklistReadarrBookFile(ctx context.Context, client *readarr.Readarr, bookID int64, fileID int64, res *model.RescanResult) error {
 	req := &starr.PageReq{PageSize: 100, SortKey: "date", SortDir: starr.SortDescend}
 	req.Set("bookId", strconv.FormatInt(bookID, 10))
 
@@ -1446,7 +1503,11 @@ func (m *Manager) blocklistReadarrBookFile(ctx context.Context, client *readarr.
 
 	for _, record := range history.Records {
 		if record.DownloadID == downloadID && record.EventType == "grabbed" {
-			return client.FailContext(ctx, record.ID)
+			if err := client.FailContext(ctx, record.ID); err != nil {
+				return err
+			}
+			res.BlocklistedHistoryIDs = append(res.BlocklistedHistoryIDs, record.ID)
+			return nil
 		}
 	}
 	return nil
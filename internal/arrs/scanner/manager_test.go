@@ -2,10 +2,15 @@ package scanner
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/javi11/altmount/internal/arrs/clients"
 	"github.com/javi11/altmount/internal/arrs/instances"
+	"github.com/javi11/altmount/internal/arrs/model"
 	"github.com/javi11/altmount/internal/config"
 )
 
@@ -91,3 +96,45 @@ func TestFindInstanceForFilePath_CategoryMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestFindInstanceForFilePath_UnreachableIsNotNotFound(t *testing.T) {
+	enabled := true
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":1,"path":"/media/movies"}]`))
+	}))
+	defer up.Close()
+
+	newManager := func(urls ...string) *Manager {
+		cfg := &config.Config{}
+		for i, u := range urls {
+			cfg.Arrs.RadarrInstances = append(cfg.Arrs.RadarrInstances, config.ArrsInstanceConfig{
+				Name: fmt.Sprintf("radarr%d", i), URL: u, APIKey: "key", Enabled: &enabled,
+			})
+		}
+		configGetter := func() *config.Config { return cfg }
+		return NewManager(configGetter, instances.NewManager(configGetter, nil), clients.NewManager(nil), nil, nil, nil)
+	}
+
+	// Every instance answered and none manages the file.
+	_, _, err := newManager(up.URL).findInstanceForFilePath(context.Background(), "/downloads/manual/file.mkv", "")
+	if !errors.Is(err, model.ErrInstanceNotFound) {
+		t.Fatalf("err = %v, want ErrInstanceNotFound", err)
+	}
+
+	// One instance could not be asked: that is not a verdict.
+	_, _, err = newManager(up.URL, down.URL).findInstanceForFilePath(context.Background(), "/downloads/manual/file.mkv", "")
+	if !errors.Is(err, model.ErrInstanceUnreachable) || errors.Is(err, model.ErrInstanceNotFound) {
+		t.Fatalf("err = %v, want ErrInstanceUnreachable only", err)
+	}
+
+	// A reachable instance managing the file still wins.
+	gotType, gotName, err := newManager(down.URL, up.URL).findInstanceForFilePath(context.Background(), "/media/movies/Film/file.mkv", "")
+	if err != nil || gotType != "radarr" || gotName != "radarr1" {
+		t.Fatalf("got %q/%q, %v; want radarr/radarr1", gotType, gotName, err)
+	}
+}
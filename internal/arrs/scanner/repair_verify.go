@@ -0,0 +1,173 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/javi11/altmount/internal/arrs/model"
+	"golift.io/starr"
+	"golift.io/starr/radarr"
+	"golift.io/starr/sonarr"
+)
+
+// reportsDownloadedPattern matches the summary a finished Radarr/Sonarr search
+// command leaves in its message, e.g. "Episode search completed. 0 reports
+// downloaded."
+var reportsDownloadedPattern = regexp.MustCompile(`(\d+) reports? downloaded`)
+
+// searchCommand is the state of one ARR search command.
+type searchCommand struct {
+	Status  string
+	Message string
+}
+
+// VerifyRepair polls the ARR a repair was triggered in: whether a replacement
+// for the repaired media was grabbed since the repair (queued or in history),
+// and otherwise whether its search commands are still running, failed or
+// finished without downloading a release.
+func (m *Manager) VerifyRepair(ctx context.Context, rescan *model.RescanResult, since time.Time) (*model.RepairVerification, error) {
+	if rescan == nil || len(rescan.MediaIDs) == 0 {
+		return nil, fmt.Errorf("repair has no media to verify")
+	}
+
+	instanceConfig, err := m.instances.FindConfigInstance(rescan.InstanceType, rescan.InstanceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find instance config: %w", err)
+	}
+
+	switch rescan.InstanceType {
+	case "radarr":
+		client, err := m.clients.GetOrCreateRadarrClient(rescan.InstanceName, instanceConfig.URL, instanceConfig.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Radarr client: %w", err)
+		}
+		return m.verifyRadarrRepair(ctx, client, rescan, since)
+
+	case "sonarr", "whisparr":
+		client, err := m.clients.GetOrCreateSonarrClient(rescan.InstanceName, instanceConfig.URL, instanceConfig.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Sonarr client: %w", err)
+		}
+		return m.verifySonarrRepair(ctx, client, rescan, since)
+
+	default:
+		return nil, model.ErrVerificationUnsupported
+	}
+}
+
+// verifyRadarrRepair looks for a grab of the repaired movie and polls the
+// repair's search commands.
+func (m *Manager) verifyRadarrRepair(ctx context.Context, client *radarr.Radarr, rescan *model.RescanResult, since time.Time) (*model.RepairVerification, error) {
+	queue, err := client.GetQueueContext(ctx, 0, 500)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Radarr queue: %w", err)
+	}
+	for _, q := range queue.Records {
+		if slices.Contains(rescan.MediaIDs, q.MovieID) {
+			return &model.RepairVerification{Progress: model.RepairProgressGrabbed, Message: q.Title}, nil
+		}
+	}
+
+	for _, movieID := range rescan.MediaIDs {
+		req := &starr.PageReq{PageSize: 50, SortKey: "date", SortDir: starr.SortDescend}
+		req.Set("movieId", strconv.FormatInt(movieID, 10))
+		history, err := client.GetHistoryPageContext(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch Radarr history: %w", err)
+		}
+		for _, record := range history.Records {
+			if record.EventType == "grabbed" && record.Date.After(since) {
+				return &model.RepairVerification{Progress: model.RepairProgressGrabbed, Message: record.SourceTitle}, nil
+			}
+		}
+	}
+
+	commands := make([]searchCommand, 0, len(rescan.SearchCommandIDs))
+	for _, id := range rescan.SearchCommandIDs {
+		// Radarr's client has no single-command getter; fetch /command/{id} directly.
+		var cmd radarr.CommandResponse
+		req := starr.Request{URI: path.Join(radarr.APIver, "command", strconv.FormatInt(id, 10))}
+		if err := client.GetInto(ctx, req, &cmd); err != nil {
+			return nil, fmt.Errorf("failed to get Radarr command %d: %w", id, err)
+		}
+		commands = append(commands, searchCommand{Status: cmd.Status, Message: cmd.Message})
+	}
+
+	return judgeSearchCommands(commands), nil
+}
+
+// verifySonarrRepair looks for a grab of any repaired episode and polls the
+// repair's search commands.
+func (m *Manager) verifySonarrRepair(ctx context.Context, client *sonarr.Sonarr, rescan *model.RescanResult, since time.Time) (*model.RepairVerification, error) {
+	queue, err := client.GetQueueContext(ctx, 0, 500)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Sonarr queue: %w", err)
+	}
+	for _, q := range queue.Records {
+		if slices.Contains(rescan.MediaIDs, q.EpisodeID) {
+			return &model.RepairVerification{Progress: model.RepairProgressGrabbed, Message: q.Title}, nil
+		}
+	}
+
+	for _, episodeID := range rescan.MediaIDs {
+		req := &starr.PageReq{PageSize: 50, SortKey: "date", SortDir: starr.SortDescend}
+		req.Set("episodeId", strconv.FormatInt(episodeID, 10))
+		history, err := client.GetHistoryPageContext(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch Sonarr history: %w", err)
+		}
+		for _, record := range history.Records {
+			if record.EventType == "grabbed" && record.Date.After(since) {
+				return &model.RepairVerification{Progress: model.RepairProgressGrabbed, Message: record.SourceTitle}, nil
+			}
+		}
+	}
+
+	commands := make([]searchCommand, 0, len(rescan.SearchCommandIDs))
+	for _, id := range rescan.SearchCommandIDs {
+		cmd, err := client.GetCommandStatusContext(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Sonarr command %d: %w", id, err)
+		}
+		commands = append(commands, searchCommand{Status: cmd.Status, Message: cmd.Message})
+	}
+
+	return judgeSearchCommands(commands), nil
+}
+
+// judgeSearchCommands tells the progress of a repair nothing was grabbed for
+// yet from its search commands: failed when one failed, no releases when all
+// completed without downloading a report, pending otherwise (still running,
+// or a download that has not reached the queue yet).
+func judgeSearchCommands(commands []searchCommand) *model.RepairVerification {
+	if len(commands) == 0 {
+		return &model.RepairVerification{Progress: model.RepairProgressPending}
+	}
+
+	downloaded := 0
+	for _, cmd := range commands {
+		switch cmd.Status {
+		case "failed", "aborted", "cancelled", "orphaned":
+			return &model.RepairVerification{Progress: model.RepairProgressFailed, Message: fmt.Sprintf("search command %s: %s", cmd.Status, cmd.Message)}
+		case "completed":
+			match := reportsDownloadedPattern.FindStringSubmatch(cmd.Message)
+			if match == nil {
+				return &model.RepairVerification{Progress: model.RepairProgressPending, Message: cmd.Message}
+			}
+			n, _ := strconv.Atoi(match[1])
+			downloaded += n
+		default:
+			return &model.RepairVerification{Progress: model.RepairProgressPending}
+		}
+	}
+
+	if downloaded == 0 {
+		return &model.RepairVerification{Progress: model.RepairProgressNoReleases, Message: commands[len(commands)-1].Message}
+	}
+	return &model.RepairVerification{Progress: model.RepairProgressPending}
+}
@@ -0,0 +1,82 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/arrs/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/starr"
+	"golift.io/starr/radarr"
+)
+
+func TestJudgeSearchCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []searchCommand
+		want     model.RepairProgress
+	}{
+		{"no commands", nil, model.RepairProgressPending},
+		{"still running", []searchCommand{{Status: "started"}}, model.RepairProgressPending},
+		{"no releases", []searchCommand{{Status: "completed", Message: "Episode search completed. 0 reports downloaded."}}, model.RepairProgressNoReleases},
+		{"downloaded, not queued yet", []searchCommand{{Status: "completed", Message: "Search completed. 1 report downloaded."}}, model.RepairProgressPending},
+		{"unknown message", []searchCommand{{Status: "completed", Message: "Completed"}}, model.RepairProgressPending},
+		{"failed", []searchCommand{{Status: "completed", Message: "0 reports downloaded"}, {Status: "failed", Message: "indexer error"}}, model.RepairProgressFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, judgeSearchCommands(tt.commands).Progress)
+		})
+	}
+}
+
+func TestVerifyRadarrRepair(t *testing.T) {
+	since := time.Now().Add(-time.Hour)
+	var grabbedAt time.Time
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/queue":
+			_ = json.NewEncoder(w).Encode(map[string]any{"totalRecords": 0, "records": []any{}})
+		case "/api/v3/history":
+			assert.Equal(t, "7", r.URL.Query().Get("movieId"))
+			records := []any{}
+			if !grabbedAt.IsZero() {
+				records = append(records, map[string]any{"movieId": 7, "eventType": "grabbed", "sourceTitle": "Movie.2020.1080p", "date": grabbedAt})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"totalRecords": len(records), "records": records})
+		case "/api/v3/command/42":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": 42, "status": "completed", "message": "0 reports downloaded."})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := radarr.New(starr.New("key", srv.URL, 5*time.Second))
+	rescan := &model.RescanResult{InstanceType: "radarr", InstanceName: "radarr", MediaIDs: []int64{7}, SearchCommandIDs: []int64{42}}
+	m := &Manager{}
+
+	v, err := m.verifyRadarrRepair(context.Background(), client, rescan, since)
+	require.NoError(t, err)
+	assert.Equal(t, model.RepairProgressNoReleases, v.Progress)
+
+	// A grab older than the repair does not count.
+	grabbedAt = since.Add(-time.Minute)
+	v, err = m.verifyRadarrRepair(context.Background(), client, rescan, since)
+	require.NoError(t, err)
+	assert.Equal(t, model.RepairProgressNoReleases, v.Progress)
+
+	grabbedAt = since.Add(time.Minute)
+	v, err = m.verifyRadarrRepair(context.Background(), client, rescan, since)
+	require.NoError(t, err)
+	assert.Equal(t, model.RepairProgressGrabbed, v.Progress)
+	assert.Equal(t, "Movie.2020.1080p", v.Message)
+}
@@ -30,6 +30,7 @@ var (
 	ErrPathMatchFailed         = model.ErrPathMatchFailed
 	ErrEpisodeAlreadySatisfied = model.ErrEpisodeAlreadySatisfied
 	ErrInstanceNotFound        = model.ErrInstanceNotFound
+	ErrInstanceUnreachable     = model.ErrInstanceUnreachable
 )
 
 // IsTemporarilyUnreachable reports whether err indicates the *arr was only
@@ -45,6 +46,11 @@ func IsTemporarilyUnreachable(err error) bool {
 		return false
 	}
 
+	// No instance claimed the file, but some could not be asked.
+	if errors.Is(err, ErrInstanceUnreachable) {
+		return true
+	}
+
 	// Typed 5xx response from the starr app (server-side, almost always transient).
 	var reqErr *starr.ReqError
 	if errors.As(err, &reqErr) && reqErr.Code >= 500 && reqErr.Code <= 599 {
@@ -276,10 +282,15 @@ func (s *Service) RegisterConfigChangeHandler(ctx context.Context, configManager
 }
 
 // TriggerFileRescan triggers a rescan for a specific file path through the appropriate ARR instance
-func (s *Service) TriggerFileRescan(ctx context.Context, pathForRescan string, relativePath string, metadataStr *string) error {
+func (s *Service) TriggerFileRescan(ctx context.Context, pathForRescan string, relativePath string, metadataStr *string) (*model.RescanResult, error) {
 	return s.scanner.TriggerFileRescan(ctx, pathForRescan, relativePath, metadataStr)
 }
 
+// VerifyRepair polls the ARR a repair was triggered in for its progress
+func (s *Service) VerifyRepair(ctx context.Context, rescan *model.RescanResult, since time.Time) (*model.RepairVerification, error) {
+	return s.scanner.VerifyRepair(ctx, rescan, since)
+}
+
 // ClearInstanceCache clears all movie and series caches for a specific instance
 func (s *Service) ClearInstanceCache(ctx context.Context, instanceName string) {
 	if instanceName == "" || s.data == nil {
@@ -1,6 +1,9 @@
 package config
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Health config accessor methods with default fallbacks.
 // These methods provide safe access to health configuration values
@@ -51,6 +54,16 @@ func (c *Config) GetSegmentSamplePercentage() int {
 	return c.Health.SegmentSamplePercentage
 }
 
+// GetSampleConfidenceMissingRate returns the missing-segment rate (0..1) whose
+// 95% upper bound lets a sampled health check stop early, with a default
+// fallback.
+func (c *Config) GetSampleConfidenceMissingRate() float64 {
+	if c.Health.SampleConfidenceMissingPercentage <= 0 || c.Health.SampleConfidenceMissingPercentage > 100 {
+		return 0.10 // Default: 10%
+	}
+	return c.Health.SampleConfidenceMissingPercentage / 100
+}
+
 // GetLibrarySyncInterval returns the library sync interval with a default fallback.
 func (c *Config) GetLibrarySyncInterval() time.Duration {
 	if c.Health.LibrarySyncIntervalMinutes <= 0 {
@@ -59,6 +72,15 @@ func (c *Config) GetLibrarySyncInterval() time.Duration {
 	return time.Duration(c.Health.LibrarySyncIntervalMinutes) * time.Minute
 }
 
+// GetLibrarySyncFullInterval returns how often library sync walks the metadata
+// and library trees in full instead of diffing against the file index.
+func (c *Config) GetLibrarySyncFullInterval() time.Duration {
+	if c.Health.LibrarySyncFullIntervalHours <= 0 {
+		return 24 * time.Hour // Default: once a day
+	}
+	return time.Duration(c.Health.LibrarySyncFullIntervalHours) * time.Hour
+}
+
 // GetLibrarySyncConcurrency returns the library sync concurrency with a default fallback.
 func (c *Config) GetLibrarySyncConcurrency() int {
 	if c.Health.LibrarySyncConcurrency <= 0 {
@@ -83,6 +105,24 @@ func (c *Config) GetCheckAllSegments() bool {
 	return *c.Health.CheckAllSegments
 }
 
+// GetProviderCoverageAudit returns whether health checks audit every provider
+// separately for the provider coverage report.
+func (c *Config) GetProviderCoverageAudit() bool {
+	if c.Health.ProviderCoverageAudit == nil {
+		return false // Default: false
+	}
+	return *c.Health.ProviderCoverageAudit
+}
+
+// GetProviderCoverageWindow returns how long provider audits are kept, which
+// is also the default window of the provider coverage report.
+func (c *Config) GetProviderCoverageWindow() time.Duration {
+	if c.Health.ProviderCoverageDays <= 0 {
+		return 30 * 24 * time.Hour // Default: 30 days
+	}
+	return time.Duration(c.Health.ProviderCoverageDays) * 24 * time.Hour
+}
+
 // GetHealthReadTimeout returns the health check read timeout as a duration with a default fallback.
 func (c *Config) GetHealthReadTimeout() time.Duration {
 	if c.Health.ReadTimeoutSeconds <= 0 {
@@ -182,6 +222,58 @@ func (c *Config) GetIsoAnalyzeTimeout() time.Duration {
 	return time.Duration(*c.Import.IsoAnalyzeTimeoutSeconds) * time.Second
 }
 
+// GetBlurayExtraPlaylistMin returns the minimum duration of a Blu-ray
+// playlist exposed next to the main feature. Zero disables extras.
+func (c *Config) GetBlurayExtraPlaylistMin() time.Duration {
+	if c.Import.BlurayExtraPlaylistMinMinutes == nil {
+		return 20 * time.Minute
+	}
+	if *c.Import.BlurayExtraPlaylistMinMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(*c.Import.BlurayExtraPlaylistMinMinutes) * time.Minute
+}
+
+// GetSubtitleSidecars reports whether subtitle files are kept and renamed
+// after their video. Defaults to false.
+func (c *Config) GetSubtitleSidecars() bool {
+	return c.Import.SubtitleSidecars != nil && *c.Import.SubtitleSidecars
+}
+
+// GetMaterializeSubtitles reports whether subtitle sidecars are written as
+// real files into the SYMLINK/STRM library. Defaults to false.
+func (c *Config) GetMaterializeSubtitles() bool {
+	return c.Import.MaterializeSubtitles != nil && *c.Import.MaterializeSubtitles
+}
+
+// GetPerProviderSampling reports whether the fast-fail sample is also checked
+// on each provider separately. Defaults to false.
+func (c *Config) GetPerProviderSampling() bool {
+	return c.Import.PerProviderSampling != nil && *c.Import.PerProviderSampling
+}
+
+// GetWatchMode returns the watch directory monitoring mode, defaulting to auto.
+func (c *Config) GetWatchMode() WatchMode {
+	switch c.Import.WatchMode {
+	case WatchModeEvents, WatchModePoll:
+		return c.Import.WatchMode
+	default:
+		return WatchModeAuto
+	}
+}
+
+// GetSlowSamplingThreshold returns how long fast-fail sampling may take before
+// the queue item is deprioritized. Zero disables de-prioritization.
+func (c *Config) GetSlowSamplingThreshold() time.Duration {
+	if c.Import.SlowSamplingThresholdSeconds == nil {
+		return 60 * time.Second
+	}
+	if *c.Import.SlowSamplingThresholdSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(*c.Import.SlowSamplingThresholdSeconds) * time.Second
+}
+
 // GetMetadataBackupKeep returns the number of metadata backups to keep with a default fallback.
 func (c *Config) GetMetadataBackupKeep() int {
 	if c.Metadata.Backup.KeepBackups <= 0 {
@@ -249,3 +341,97 @@ func (c *Config) GetRepairExponentialBackoff() bool {
 	}
 	return *c.Health.Repair.ExponentialBackoff
 }
+
+// GetRepairAlternateRelease reports whether corrupted files no ARR manages are
+// repaired from an alternate Prowlarr release (defaults to false). It also
+// requires the Prowlarr integration to be enabled.
+func (c *Config) GetRepairAlternateRelease() bool {
+	if c.Health.Repair.AlternateRelease == nil || !*c.Health.Repair.AlternateRelease {
+		return false
+	}
+	return c.Stremio.Prowlarr.Enabled != nil && *c.Stremio.Prowlarr.Enabled && c.Stremio.Prowlarr.Host != ""
+}
+
+// GetRepairVerifyCompletion reports whether triggered repairs are followed up
+// in the ARR (defaults to true).
+func (c *Config) GetRepairVerifyCompletion() bool {
+	if c.Health.Repair.VerifyCompletion == nil {
+		return true
+	}
+	return *c.Health.Repair.VerifyCompletion
+}
+
+// GetRepairStallTimeout returns how long a triggered repair may go without a
+// grab before it is marked stalled (default 24 hours).
+func (c *Config) GetRepairStallTimeout() time.Duration {
+	if c.Health.Repair.StallHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.Health.Repair.StallHours) * time.Hour
+}
+
+// GetRepairEscalation returns the escalation of stalled and failed repairs
+// (defaults to "none"). "alternate_release" also requires the Prowlarr
+// integration to be enabled.
+func (c *Config) GetRepairEscalation() string {
+	switch c.Health.Repair.Escalation {
+	case RepairEscalationAlternateRelease:
+		if c.Stremio.Prowlarr.Enabled == nil || !*c.Stremio.Prowlarr.Enabled || c.Stremio.Prowlarr.Host == "" {
+			return RepairEscalationNone
+		}
+		return RepairEscalationAlternateRelease
+	case RepairEscalationNotify:
+		return RepairEscalationNotify
+	default:
+		return RepairEscalationNone
+	}
+}
+
+// GetMediaServers returns the enabled media servers, with their mount path
+// defaulted to mount_path.
+func (c *Config) GetMediaServers() []MediaServerConfig {
+	var servers []MediaServerConfig
+	for _, ms := range c.MediaServers {
+		if ms.Enabled != nil && !*ms.Enabled {
+			continue
+		}
+		if ms.MountPath == "" {
+			ms.MountPath = c.MountPath
+		}
+		servers = append(servers, ms)
+	}
+	return servers
+}
+
+// GetPostImportHooks returns the enabled post-import hooks that apply to an
+// import in category, in configured order.
+func (c *Config) GetPostImportHooks(category string) []PostImportHook {
+	var hooks []PostImportHook
+	for _, h := range c.Import.PostImportHooks {
+		if h.Enabled != nil && !*h.Enabled {
+			continue
+		}
+		if len(h.Categories) > 0 && !containsFold(h.Categories, category) {
+			continue
+		}
+		hooks = append(hooks, h)
+	}
+	return hooks
+}
+
+// Timeout returns the per-attempt timeout of the hook, defaulting to 30s.
+func (h PostImportHook) Timeout() time.Duration {
+	if h.TimeoutSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(h.TimeoutSeconds) * time.Second
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
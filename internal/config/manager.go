@@ -36,27 +36,28 @@ const (
 
 // Config represents the complete application configuration
 type Config struct {
-	WebDAV          WebDAVConfig       `yaml:"webdav" mapstructure:"webdav" json:"webdav"`
-	API             APIConfig          `yaml:"api" mapstructure:"api" json:"api"`
-	Auth            AuthConfig         `yaml:"auth" mapstructure:"auth" json:"auth"`
-	Database        DatabaseConfig     `yaml:"database" mapstructure:"database" json:"database"`
-	Metadata        MetadataConfig     `yaml:"metadata" mapstructure:"metadata" json:"metadata"`
-	Streaming       StreamingConfig    `yaml:"streaming" mapstructure:"streaming" json:"streaming"`
-	Health          HealthConfig       `yaml:"health" mapstructure:"health" json:"health"`
-	RClone          RCloneConfig       `yaml:"rclone" mapstructure:"rclone" json:"rclone"`
-	Import          ImportConfig       `yaml:"import" mapstructure:"import" json:"import"`
-	Log             LogConfig          `yaml:"log" mapstructure:"log" json:"log"`
-	SABnzbd         SABnzbdConfig      `yaml:"sabnzbd" mapstructure:"sabnzbd" json:"sabnzbd"`
-	Arrs            ArrsConfig         `yaml:"arrs" mapstructure:"arrs" json:"arrs"`
-	Stremio         StremioConfig      `yaml:"stremio" mapstructure:"stremio" json:"stremio"`
-	Fuse            FuseConfig         `yaml:"fuse" mapstructure:"fuse" json:"fuse"`
-	SegmentCache    SegmentCacheConfig `yaml:"segment_cache" mapstructure:"segment_cache" json:"segment_cache"`
-	Providers       []ProviderConfig   `yaml:"providers" mapstructure:"providers" json:"providers"`
-	Nzblnk          NzblnkConfig       `yaml:"nzblnk" mapstructure:"nzblnk" json:"nzblnk"`
-	Network         NetworkConfig      `yaml:"network" mapstructure:"network" json:"network"`
-	MountPath       string             `yaml:"mount_path" mapstructure:"mount_path" json:"mount_path"`
-	MountType       MountType          `yaml:"mount_type" mapstructure:"mount_type" json:"mount_type"`
-	ProfilerEnabled bool               `yaml:"profiler_enabled" mapstructure:"profiler_enabled" json:"profiler_enabled" default:"false"`
+	WebDAV          WebDAVConfig        `yaml:"webdav" mapstructure:"webdav" json:"webdav"`
+	API             APIConfig           `yaml:"api" mapstructure:"api" json:"api"`
+	Auth            AuthConfig          `yaml:"auth" mapstructure:"auth" json:"auth"`
+	Database        DatabaseConfig      `yaml:"database" mapstructure:"database" json:"database"`
+	Metadata        MetadataConfig      `yaml:"metadata" mapstructure:"metadata" json:"metadata"`
+	Streaming       StreamingConfig     `yaml:"streaming" mapstructure:"streaming" json:"streaming"`
+	Health          HealthConfig        `yaml:"health" mapstructure:"health" json:"health"`
+	RClone          RCloneConfig        `yaml:"rclone" mapstructure:"rclone" json:"rclone"`
+	Import          ImportConfig        `yaml:"import" mapstructure:"import" json:"import"`
+	Log             LogConfig           `yaml:"log" mapstructure:"log" json:"log"`
+	SABnzbd         SABnzbdConfig       `yaml:"sabnzbd" mapstructure:"sabnzbd" json:"sabnzbd"`
+	Arrs            ArrsConfig          `yaml:"arrs" mapstructure:"arrs" json:"arrs"`
+	Stremio         StremioConfig       `yaml:"stremio" mapstructure:"stremio" json:"stremio"`
+	Fuse            FuseConfig          `yaml:"fuse" mapstructure:"fuse" json:"fuse"`
+	SegmentCache    SegmentCacheConfig  `yaml:"segment_cache" mapstructure:"segment_cache" json:"segment_cache"`
+	Providers       []ProviderConfig    `yaml:"providers" mapstructure:"providers" json:"providers"`
+	MediaServers    []MediaServerConfig `yaml:"media_servers" mapstructure:"media_servers" json:"media_servers"`
+	Nzblnk          NzblnkConfig        `yaml:"nzblnk" mapstructure:"nzblnk" json:"nzblnk"`
+	Network         NetworkConfig       `yaml:"network" mapstructure:"network" json:"network"`
+	MountPath       string              `yaml:"mount_path" mapstructure:"mount_path" json:"mount_path"`
+	MountType       MountType           `yaml:"mount_type" mapstructure:"mount_type" json:"mount_type"`
+	ProfilerEnabled bool                `yaml:"profiler_enabled" mapstructure:"profiler_enabled" json:"profiler_enabled" default:"false"`
 }
 
 // NzblnkConfig configures the NZBLNK resolver (used for nzblnk:// link resolution via public indexers).
@@ -294,6 +295,15 @@ const (
 	ImportStrategySTRM    ImportStrategy = "STRM"
 )
 
+// WatchMode selects how the import watch directory is monitored
+type WatchMode string
+
+const (
+	WatchModeAuto   WatchMode = "auto"
+	WatchModeEvents WatchMode = "events"
+	WatchModePoll   WatchMode = "poll"
+)
+
 // ImportConfig represents import processing configuration
 type ImportConfig struct {
 	MaxProcessorWorkers            int      `yaml:"max_processor_workers" mapstructure:"max_processor_workers" json:"max_processor_workers"`
@@ -312,10 +322,41 @@ type ImportConfig struct {
 	ImportDir                          *string        `yaml:"import_dir" mapstructure:"import_dir" json:"import_dir,omitempty"`
 	WatchDir                           *string        `yaml:"watch_dir" mapstructure:"watch_dir" json:"watch_dir,omitempty"`
 	WatchIntervalSeconds               *int           `yaml:"watch_interval_seconds" mapstructure:"watch_interval_seconds" json:"watch_interval_seconds,omitempty"`
+	// WatchMode selects how WatchDir is monitored: "auto" (default) uses
+	// filesystem events and falls back to polling on network/FUSE mounts,
+	// "events" always uses events, "poll" always re-walks every interval.
+	WatchMode WatchMode `yaml:"watch_mode" mapstructure:"watch_mode" json:"watch_mode,omitempty"`
 	AllowNestedRarExtraction           *bool          `yaml:"allow_nested_rar_extraction" mapstructure:"allow_nested_rar_extraction" json:"allow_nested_rar_extraction,omitempty"`
+	// ExpandBlurayIso replaces Blu-ray/DVD ISO images with their main feature
+	// and adds a main-title file for DVD VIDEO_TS folders. nil defaults to true.
 	ExpandBlurayIso                    *bool          `yaml:"expand_bluray_iso" mapstructure:"expand_bluray_iso" json:"expand_bluray_iso,omitempty"`
+	// BlurayExtraPlaylistMinMinutes exposes every other Blu-ray playlist at
+	// least this long (extended cuts, bonus features, TV episodes) as its own
+	// file under Extras/. nil defaults to 20; 0 disables.
+	BlurayExtraPlaylistMinMinutes *int `yaml:"bluray_extra_playlist_min_minutes" mapstructure:"bluray_extra_playlist_min_minutes" json:"bluray_extra_playlist_min_minutes,omitempty"`
 	RenameToNzbName                    *bool          `yaml:"rename_to_nzb_name" mapstructure:"rename_to_nzb_name" json:"rename_to_nzb_name,omitempty"`
 	FilterSampleFiles                  *bool          `yaml:"filter_sample_files" mapstructure:"filter_sample_files" json:"filter_sample_files,omitempty"`
+	// RecoverObfuscatedNames replaces an obfuscated NZB filename with a release
+	// name recovered from the NZB title, PAR2 index or archive contents when
+	// naming the release folder and history entry. nil defaults to true.
+	RecoverObfuscatedNames *bool `yaml:"recover_obfuscated_names" mapstructure:"recover_obfuscated_names" json:"recover_obfuscated_names,omitempty"`
+	// StitchMultiPart adds one concatenated virtual file next to multi-part
+	// movies (CD1/CD2, part1/part2, VTS_01_1..N.VOB) in MPEG-TS/PS, AVI, DV
+	// and Ogg formats. The parts stay available. nil defaults to true.
+	StitchMultiPart *bool `yaml:"stitch_multi_part" mapstructure:"stitch_multi_part" json:"stitch_multi_part,omitempty"`
+	// SubtitleSidecars keeps subtitle files (also from inside RAR/7z) even when
+	// AllowedFileExtensions excludes them, and renames each one after the video
+	// it belongs to ("Movie.en.srt") in the video's folder. nil defaults to false.
+	SubtitleSidecars *bool `yaml:"subtitle_sidecars" mapstructure:"subtitle_sidecars" json:"subtitle_sidecars,omitempty"`
+	// MaterializeSubtitles writes subtitle sidecars as real files into the
+	// SYMLINK/STRM import_dir instead of symlinks/.strm files, so media servers
+	// read them without going through the mount. nil defaults to false.
+	MaterializeSubtitles *bool `yaml:"materialize_subtitles" mapstructure:"materialize_subtitles" json:"materialize_subtitles,omitempty"`
+	// PerProviderSampling additionally Stats the fast-fail sample on every
+	// provider on its own and stores a per-provider completeness estimate on
+	// the import and its health records. It does not change whether an import
+	// passes: that is still decided by the pool as a whole. nil defaults to false.
+	PerProviderSampling *bool `yaml:"per_provider_sampling" mapstructure:"per_provider_sampling" json:"per_provider_sampling,omitempty"`
 	FailedItemRetentionHours           *int           `yaml:"failed_item_retention_hours" mapstructure:"failed_item_retention_hours" json:"failed_item_retention_hours,omitempty"`
 	HistoryRetentionDays               *int           `yaml:"history_retention_days" mapstructure:"history_retention_days" json:"history_retention_days,omitempty"`
 	// DamagePolicy governs standalone video files whose fast-fail sweep finds
@@ -325,6 +366,40 @@ type ImportConfig struct {
 	// grab a different release. Damage beyond the caps, archive-set members
 	// and non-video files fail either way.
 	DamagePolicy string `yaml:"damage_policy" mapstructure:"damage_policy" json:"damage_policy,omitempty"`
+	// SlowSamplingThresholdSeconds marks a queue item as deprioritized when its
+	// fast-fail segment sampling takes longer than this, so retries of releases
+	// the providers struggle with sort behind their priority peers. nil uses
+	// the 60s default; 0 disables de-prioritization.
+	SlowSamplingThresholdSeconds *int `yaml:"slow_sampling_threshold_seconds" mapstructure:"slow_sampling_threshold_seconds" json:"slow_sampling_threshold_seconds,omitempty"`
+	// PostImportHooks run in order once an import's metadata is written and
+	// before symlinks, STRM files, health checks and ARR notifications. A hook
+	// can veto the import (it is marked failed) or rewrite its paths.
+	PostImportHooks []PostImportHook `yaml:"post_import_hooks" mapstructure:"post_import_hooks" json:"post_import_hooks,omitempty"`
+}
+
+// PostImportHook is a user script or HTTP endpoint called after an import.
+// Exactly one of Command and URL is set. The hook receives a JSON payload
+// describing the import (on stdin for commands, as the POST body for URLs)
+// and may answer with a JSON object on stdout / in the response body.
+type PostImportHook struct {
+	Name    string `yaml:"name" mapstructure:"name" json:"name"`
+	Enabled *bool  `yaml:"enabled" mapstructure:"enabled" json:"enabled,omitempty"`
+	// Command is an executable run with Args; a non-zero exit is a failure.
+	Command string   `yaml:"command" mapstructure:"command" json:"command,omitempty"`
+	Args    []string `yaml:"args" mapstructure:"args" json:"args,omitempty"`
+	// URL receives a POST; a non-2xx status is a failure.
+	URL     string            `yaml:"url" mapstructure:"url" json:"url,omitempty"`
+	Headers map[string]string `yaml:"headers" mapstructure:"headers" json:"headers,omitempty"`
+	// Categories limits the hook to these SABnzbd categories (case-insensitive).
+	// Empty runs it for every import.
+	Categories []string `yaml:"categories" mapstructure:"categories" json:"categories,omitempty"`
+	// TimeoutSeconds bounds each attempt. 0 uses 30s.
+	TimeoutSeconds int `yaml:"timeout_seconds" mapstructure:"timeout_seconds" json:"timeout_seconds,omitempty"`
+	// Retries is the number of extra attempts after a failed one.
+	Retries int `yaml:"retries" mapstructure:"retries" json:"retries,omitempty"`
+	// FailImportOnError marks the import failed when the hook still fails
+	// after its retries. By default the failure is logged and ignored.
+	FailImportOnError bool `yaml:"fail_import_on_error" mapstructure:"fail_import_on_error" json:"fail_import_on_error,omitempty"`
 }
 
 // LogConfig represents logging configuration with rotation support
@@ -345,8 +420,32 @@ type RepairConfig struct {
 	MaxRepairRetries int   `yaml:"max_repair_retries" mapstructure:"max_repair_retries" json:"max_repair_retries"`
 
 	ExponentialBackoff *bool `yaml:"exponential_backoff" mapstructure:"exponential_backoff" json:"exponential_backoff,omitempty"`
+	// AlternateRelease repairs corrupted files that no ARR instance manages by
+	// searching Prowlarr (stremio.prowlarr settings) for another release of the
+	// same title, checking its completeness and importing it next to the
+	// corrupted file. The corrupted file is retired once the import succeeds;
+	// if it fails, the repair sweep searches again on its next retry.
+	AlternateRelease *bool `yaml:"alternate_release" mapstructure:"alternate_release" json:"alternate_release,omitempty"`
+	// VerifyCompletion polls the ARR's search commands, queue and history after
+	// a repair is triggered, resolving the repair once a replacement is grabbed.
+	VerifyCompletion *bool `yaml:"verify_completion" mapstructure:"verify_completion" json:"verify_completion,omitempty"`
+	// StallHours is how long a triggered repair may go without a grab before
+	// it is marked stalled and escalated.
+	StallHours int `yaml:"stall_hours" mapstructure:"stall_hours" json:"stall_hours,omitempty"`
+	// Escalation is what happens to a stalled repair or one whose search found
+	// no releases: "none" (record it only), "alternate_release" (search
+	// Prowlarr for another release) or "notify" (POST it to NotifyURL).
+	Escalation string `yaml:"escalation" mapstructure:"escalation" json:"escalation,omitempty"`
+	NotifyURL  string `yaml:"notify_url" mapstructure:"notify_url" json:"notify_url,omitempty"`
 }
 
+// Repair escalations (RepairConfig.Escalation).
+const (
+	RepairEscalationNone             = "none"
+	RepairEscalationAlternateRelease = "alternate_release"
+	RepairEscalationNotify           = "notify"
+)
+
 // HealthConfig represents health checker configuration
 type HealthConfig struct {
 	Enabled                             *bool        `yaml:"enabled" mapstructure:"enabled" json:"enabled,omitempty"`
@@ -360,11 +459,21 @@ type HealthConfig struct {
 	MaxRetries                          int          `yaml:"max_retries" mapstructure:"max_retries" json:"max_retries"`
 	LibrarySyncIntervalMinutes          int          `yaml:"library_sync_interval_minutes" mapstructure:"library_sync_interval_minutes" json:"library_sync_interval_minutes,omitempty"`
 	LibrarySyncConcurrency              int          `yaml:"library_sync_concurrency" mapstructure:"library_sync_concurrency" json:"library_sync_concurrency,omitempty"`
+	LibrarySyncFullIntervalHours        int          `yaml:"library_sync_full_interval_hours" mapstructure:"library_sync_full_interval_hours" json:"library_sync_full_interval_hours,omitempty"`
 	ResolveRepairOnImport               *bool        `yaml:"resolve_repair_on_import" mapstructure:"resolve_repair_on_import" json:"resolve_repair_on_import,omitempty"`
 	VerifyData                          *bool        `yaml:"verify_data" mapstructure:"verify_data" json:"verify_data,omitempty"`
 	CheckAllSegments                    *bool        `yaml:"check_all_segments" mapstructure:"check_all_segments" json:"check_all_segments,omitempty"`
+	// SampleConfidenceMissingPercentage lets a sampled check stop early once
+	// the 95% upper bound on the file's missing-segment rate is below it.
+	SampleConfidenceMissingPercentage float64 `yaml:"sample_confidence_missing_percentage" mapstructure:"sample_confidence_missing_percentage" json:"sample_confidence_missing_percentage,omitempty"`
 	ReadTimeoutSeconds                  int          `yaml:"read_timeout_seconds" mapstructure:"read_timeout_seconds" json:"read_timeout_seconds,omitempty"`
 	AcceptableMissingSegmentsPercentage float64      `yaml:"acceptable_missing_segments_percentage" mapstructure:"acceptable_missing_segments_percentage" json:"acceptable_missing_segments_percentage"`
+	// ProviderCoverageAudit re-checks each checked file's sample on every
+	// provider separately and records which provider held which articles;
+	// ProviderCoverageDays is how long those audits are kept and the default
+	// window of the provider coverage report.
+	ProviderCoverageAudit *bool `yaml:"provider_coverage_audit" mapstructure:"provider_coverage_audit" json:"provider_coverage_audit,omitempty"`
+	ProviderCoverageDays  int   `yaml:"provider_coverage_days" mapstructure:"provider_coverage_days" json:"provider_coverage_days,omitempty"`
 	// ExcludedCategories lists SABnzbd category names whose files must never be
 	// registered for health checking by the library-sync discovery pass. Matching
 	// is by the category's configured directory under CompleteDir and is
@@ -374,12 +483,35 @@ type HealthConfig struct {
 	// CorruptionAction controls what happens when the health checker or a streaming read
 	// confirms real (non-degraded) corruption: "repair" (default) triggers an Arr rescan;
 	// "delete" removes the file's metadata/NZB/health record and cleans up now-empty
-	// parent directories instead. Degraded files are never affected either way.
+	// parent directories instead. Degraded files are never affected either way, and
+	// "delete" keeps files whose release's PAR2 volumes cover the damage, marked
+	// corrupted without a repair, since their NZB can still rebuild them.
 	CorruptionAction string `yaml:"corruption_action" mapstructure:"corruption_action" json:"corruption_action,omitempty"`
 }
 
 // Path validation functions have been moved to internal/utils/path.go
 
+// Media server types
+const (
+	MediaServerTypePlex     = "plex"
+	MediaServerTypeJellyfin = "jellyfin"
+	MediaServerTypeEmby     = "emby"
+)
+
+// MediaServerConfig configures a Plex, Jellyfin or Emby server. Library sync
+// keeps the files it references, imports and repairs refresh its library, and
+// its users' watch state moves the files they play up the health check order.
+type MediaServerConfig struct {
+	Name    string `yaml:"name" mapstructure:"name" json:"name"`
+	Type    string `yaml:"type" mapstructure:"type" json:"type"`
+	URL     string `yaml:"url" mapstructure:"url" json:"url"`
+	Token   string `yaml:"token" mapstructure:"token" json:"token"` // Plex token or Jellyfin/Emby API key
+	Enabled *bool  `yaml:"enabled" mapstructure:"enabled" json:"enabled,omitempty"`
+	// MountPath is where the server sees the AltMount mount, when it differs
+	// from mount_path (e.g. another container). Defaults to mount_path.
+	MountPath string `yaml:"mount_path" mapstructure:"mount_path" json:"mount_path,omitempty"`
+}
+
 // ProviderConfig represents a single NNTP provider configuration
 type ProviderConfig struct {
 	ID                       string     `yaml:"id" mapstructure:"id" json:"id"`
@@ -432,6 +564,55 @@ type SABnzbdCategory struct {
 	Priority int    `yaml:"priority" mapstructure:"priority" json:"priority"`
 	Dir      string `yaml:"dir" mapstructure:"dir" json:"dir"`
 	Type     string `yaml:"type" mapstructure:"type" json:"type"` // "sonarr" or "radarr"
+	// ScheduleWindow restricts when queued items of this category may start
+	// processing, as "HH:MM-HH:MM" in local time. A window whose end is before
+	// its start wraps past midnight. Empty means always.
+	ScheduleWindow string `yaml:"schedule_window" mapstructure:"schedule_window" json:"schedule_window,omitempty"`
+	// MaxConcurrent caps how many items of this category may process at the
+	// same time. 0 means unlimited.
+	MaxConcurrent int `yaml:"max_concurrent" mapstructure:"max_concurrent" json:"max_concurrent,omitempty"`
+}
+
+// parseScheduleWindow parses an "HH:MM-HH:MM" window into minutes since midnight.
+func parseScheduleWindow(window string) (start, end int, err error) {
+	from, to, ok := strings.Cut(window, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("schedule window %q must be in HH:MM-HH:MM format", window)
+	}
+	if start, err = parseClockMinutes(from); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClockMinutes(to); err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// parseClockMinutes parses "HH:MM" into minutes since midnight.
+func parseClockMinutes(clock string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: expected HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// InScheduleWindow reports whether items of this category may start at t.
+// Categories without a window (or with an unparsable one) are always open.
+func (c SABnzbdCategory) InScheduleWindow(t time.Time) bool {
+	if c.ScheduleWindow == "" {
+		return true
+	}
+	start, end, err := parseScheduleWindow(c.ScheduleWindow)
+	if err != nil || start == end {
+		return true
+	}
+	now := t.Hour()*60 + t.Minute()
+	if start < end {
+		return now >= start && now < end
+	}
+	// Window wraps past midnight, e.g. 22:00-06:00.
+	return now >= start || now < end
 }
 
 // IgnoredMessage represents an error message to ignore during queue cleanup
@@ -713,6 +894,56 @@ func (c *Config) Validate() error {
 		if c.Import.WatchIntervalSeconds != nil && *c.Import.WatchIntervalSeconds <= 0 {
 			return fmt.Errorf("import watch_interval_seconds must be greater than 0")
 		}
+		switch c.Import.WatchMode {
+		case "", WatchModeAuto, WatchModeEvents, WatchModePoll:
+		default:
+			return fmt.Errorf("import watch_mode must be one of: auto, events, poll")
+		}
+	}
+
+	// Validate post-import hooks
+	for i, hook := range c.Import.PostImportHooks {
+		if (hook.Command == "") == (hook.URL == "") {
+			return fmt.Errorf("import post_import_hooks[%d] must set exactly one of command or url", i)
+		}
+		if hook.URL != "" && !strings.HasPrefix(hook.URL, "http://") && !strings.HasPrefix(hook.URL, "https://") {
+			return fmt.Errorf("import post_import_hooks[%d] url must start with http:// or https://", i)
+		}
+		if hook.TimeoutSeconds < 0 || hook.Retries < 0 {
+			return fmt.Errorf("import post_import_hooks[%d] timeout_seconds and retries must be non-negative", i)
+		}
+	}
+
+	// Validate repair escalation
+	switch c.Health.Repair.Escalation {
+	case "", RepairEscalationNone, RepairEscalationAlternateRelease:
+	case RepairEscalationNotify:
+		if !strings.HasPrefix(c.Health.Repair.NotifyURL, "http://") && !strings.HasPrefix(c.Health.Repair.NotifyURL, "https://") {
+			return fmt.Errorf("health repair notify_url must start with http:// or https:// when escalation is notify")
+		}
+	default:
+		return fmt.Errorf("health repair escalation must be one of: none, alternate_release, notify")
+	}
+	if c.Health.Repair.StallHours < 0 {
+		return fmt.Errorf("health repair stall_hours must be non-negative")
+	}
+
+	// Validate media servers
+	for i, ms := range c.MediaServers {
+		switch ms.Type {
+		case MediaServerTypePlex, MediaServerTypeJellyfin, MediaServerTypeEmby:
+		default:
+			return fmt.Errorf("media_servers[%d] type must be one of: plex, jellyfin, emby", i)
+		}
+		if !strings.HasPrefix(ms.URL, "http://") && !strings.HasPrefix(ms.URL, "https://") {
+			return fmt.Errorf("media_servers[%d] url must start with http:// or https://", i)
+		}
+		if ms.Token == "" {
+			return fmt.Errorf("media_servers[%d] token is required", i)
+		}
+		if ms.MountPath != "" && !strings.HasPrefix(ms.MountPath, "/") {
+			return fmt.Errorf("media_servers[%d] mount_path must be an absolute path", i)
+		}
 	}
 
 	// Validate log level (both old and new config)
@@ -784,6 +1015,12 @@ func (c *Config) Validate() error {
 	if c.Health.LibrarySyncIntervalMinutes < 0 {
 		return fmt.Errorf("health library_sync_interval_minutes must be non-negative")
 	}
+	if c.Health.LibrarySyncFullIntervalHours < 0 {
+		return fmt.Errorf("health library_sync_full_interval_hours must be non-negative")
+	}
+	if c.Health.ProviderCoverageDays < 0 {
+		return fmt.Errorf("health provider_coverage_days must be non-negative")
+	}
 	if c.Health.SegmentSamplePercentage < 1 || c.Health.SegmentSamplePercentage > 100 {
 		return fmt.Errorf("health segment_sample_percentage must be between 1 and 100")
 	}
@@ -881,6 +1118,14 @@ func (c *Config) Validate() error {
 				return fmt.Errorf("sabnzbd category %d: duplicate category name '%s'", i, category.Name)
 			}
 			categoryNames[category.Name] = true
+			if category.ScheduleWindow != "" {
+				if _, _, err := parseScheduleWindow(category.ScheduleWindow); err != nil {
+					return fmt.Errorf("sabnzbd category '%s': %w", category.Name, err)
+				}
+			}
+			if category.MaxConcurrent < 0 {
+				return fmt.Errorf("sabnzbd category '%s': max_concurrent cannot be negative", category.Name)
+			}
 		}
 
 		// Validate fallback configuration if host is provided
@@ -1624,7 +1869,10 @@ func DefaultConfig(configDir ...string) *Config {
 			CheckBatchSize:                      50,
 			MaxConcurrentJobs:                   1,                      // Default: 1 concurrent job
 			SegmentSamplePercentage:             5,                      // Default: 5% segment sampling
+			SampleConfidenceMissingPercentage:   10,                     // Default: stop once <10% missing is 95% certain
 			LibrarySyncIntervalMinutes:          360,                    // Default: sync every 6 hours
+			LibrarySyncFullIntervalHours:        24,                     // Default: full walk once a day
+			ProviderCoverageDays:                30,                     // Default: keep a month of provider audits
 			ResolveRepairOnImport:               &resolveRepairOnImport, // Enabled by default
 			AcceptableMissingSegmentsPercentage: 0,                      // Default: no missing segments allowed
 			Repair: RepairConfig{
@@ -2,8 +2,10 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -454,3 +456,155 @@ func TestMigrateArrsCleanup_AutoFailureFlag_FalseClearsOnly(t *testing.T) {
 	assert.Equal(t, rules, cfg.Arrs.QueueCleanupRules)
 	assert.Nil(t, cfg.Arrs.CleanupAutomaticImportFailure)
 }
+
+func TestSABnzbdCategory_InScheduleWindow(t *testing.T) {
+	at := func(clock string) time.Time {
+		ts, err := time.Parse("15:04", clock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+
+	tests := []struct {
+		name   string
+		window string
+		clock  string
+		want   bool
+	}{
+		{"no window", "", "12:00", true},
+		{"inside day window", "01:00-07:00", "03:30", true},
+		{"window end is exclusive", "01:00-07:00", "07:00", false},
+		{"outside day window", "01:00-07:00", "12:00", false},
+		{"inside wrapping window late", "22:00-06:00", "23:15", true},
+		{"inside wrapping window early", "22:00-06:00", "05:59", true},
+		{"outside wrapping window", "22:00-06:00", "12:00", false},
+		{"malformed window stays open", "bogus", "12:00", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat := SABnzbdCategory{Name: "backfill", ScheduleWindow: tt.window}
+			assert.Equal(t, tt.want, cat.InScheduleWindow(at(tt.clock)))
+		})
+	}
+}
+
+func TestParseScheduleWindow_Invalid(t *testing.T) {
+	for _, window := range []string{"01:00", "1-7", "25:00-07:00", "01:00-07:61"} {
+		_, _, err := parseScheduleWindow(window)
+		assert.Error(t, err, window)
+	}
+}
+
+func TestConfig_Validate_PostImportHooks(t *testing.T) {
+	newValidConfig := func(hooks ...PostImportHook) *Config {
+		return &Config{
+			MountType: MountTypeNone,
+			Metadata:  MetadataConfig{RootPath: "/metadata"},
+			WebDAV:    WebDAVConfig{Port: 8080},
+			Streaming: StreamingConfig{MaxPrefetch: 30},
+			Import: ImportConfig{
+				MaxProcessorWorkers:            2,
+				QueueProcessingIntervalSeconds: 5,
+				MaxDownloadPrefetch:            3,
+				SegmentSamplePercentage:        1,
+				ImportStrategy:                 ImportStrategyNone,
+				PostImportHooks:                hooks,
+			},
+			Health: HealthConfig{
+				CheckIntervalSeconds:          5,
+				MaxConnectionsForHealthChecks: 5,
+				MaxConcurrentJobs:             1,
+				SegmentSamplePercentage:       5,
+			},
+		}
+	}
+
+	assert.NoError(t, newValidConfig(
+		PostImportHook{Command: "/usr/local/bin/index.sh"},
+		PostImportHook{URL: "http://localhost:9000/hook", Retries: 2},
+	).Validate())
+
+	err := newValidConfig(PostImportHook{Command: "a", URL: "http://b"}).Validate()
+	assert.ErrorContains(t, err, "exactly one of command or url")
+	err = newValidConfig(PostImportHook{}).Validate()
+	assert.ErrorContains(t, err, "exactly one of command or url")
+	err = newValidConfig(PostImportHook{URL: "ftp://host/hook"}).Validate()
+	assert.ErrorContains(t, err, "http://")
+	err = newValidConfig(PostImportHook{Command: "a", Retries: -1}).Validate()
+	assert.ErrorContains(t, err, "non-negative")
+}
+
+func TestConfig_GetPostImportHooks(t *testing.T) {
+	disabled := false
+	cfg := &Config{Import: ImportConfig{PostImportHooks: []PostImportHook{
+		{Name: "all", Command: "a"},
+		{Name: "off", Command: "b", Enabled: &disabled},
+		{Name: "movies", Command: "c", Categories: []string{"Movies"}},
+	}}}
+
+	names := func(hooks []PostImportHook) []string {
+		var out []string
+		for _, h := range hooks {
+			out = append(out, h.Name)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"all", "movies"}, names(cfg.GetPostImportHooks("movies")))
+	assert.Equal(t, []string{"all"}, names(cfg.GetPostImportHooks("tv")))
+	assert.Equal(t, 30*time.Second, PostImportHook{}.Timeout())
+	assert.Equal(t, 5*time.Second, PostImportHook{TimeoutSeconds: 5}.Timeout())
+}
+
+func TestConfig_Validate_MediaServers(t *testing.T) {
+	newValidConfig := func(servers ...MediaServerConfig) *Config {
+		return &Config{
+			MountType:    MountTypeNone,
+			Metadata:     MetadataConfig{RootPath: "/metadata"},
+			WebDAV:       WebDAVConfig{Port: 8080},
+			Streaming:    StreamingConfig{MaxPrefetch: 30},
+			MediaServers: servers,
+			Import: ImportConfig{
+				MaxProcessorWorkers:            2,
+				QueueProcessingIntervalSeconds: 5,
+				MaxDownloadPrefetch:            3,
+				SegmentSamplePercentage:        1,
+				ImportStrategy:                 ImportStrategyNone,
+			},
+			Health: HealthConfig{
+				CheckIntervalSeconds:          5,
+				MaxConnectionsForHealthChecks: 5,
+				MaxConcurrentJobs:             1,
+				SegmentSamplePercentage:       5,
+			},
+		}
+	}
+
+	assert.NoError(t, newValidConfig(
+		MediaServerConfig{Name: "plex", Type: MediaServerTypePlex, URL: "http://plex:32400", Token: "t"},
+		MediaServerConfig{Name: "emby", Type: MediaServerTypeEmby, URL: "https://emby", Token: "k", MountPath: "/data/altmount"},
+	).Validate())
+
+	err := newValidConfig(MediaServerConfig{Type: "kodi", URL: "http://kodi", Token: "t"}).Validate()
+	assert.ErrorContains(t, err, "type must be one of")
+	err = newValidConfig(MediaServerConfig{Type: MediaServerTypeJellyfin, URL: "jellyfin:8096", Token: "t"}).Validate()
+	assert.ErrorContains(t, err, "http://")
+	err = newValidConfig(MediaServerConfig{Type: MediaServerTypeJellyfin, URL: "http://jellyfin"}).Validate()
+	assert.ErrorContains(t, err, "token is required")
+}
+
+func TestConfig_GetMediaServers(t *testing.T) {
+	disabled := false
+	cfg := &Config{MountPath: "/mnt/altmount", MediaServers: []MediaServerConfig{
+		{Name: "plex", MountPath: "/data/altmount"},
+		{Name: "off", Enabled: &disabled},
+		{Name: "jellyfin"},
+	}}
+
+	servers := cfg.GetMediaServers()
+	require.Len(t, servers, 2)
+	assert.Equal(t, "/data/altmount", servers[0].MountPath)
+	assert.Equal(t, "jellyfin", servers[1].Name)
+	assert.Equal(t, "/mnt/altmount", servers[1].MountPath, "defaults to mount_path")
+	assert.Empty(t, cfg.MediaServers[2].MountPath, "the config itself is not modified")
+}
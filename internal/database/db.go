@@ -24,6 +24,7 @@ type DB struct {
 	Repository    *QueueRepository
 	MigrationRepo *ImportMigrationRepository
 	StoreRefRepo  *StoreRefRepository
+	FileIndexRepo *FileIndexRepository
 }
 
 // Config holds database configuration.
@@ -92,6 +93,7 @@ func newSQLiteDB(config Config) (*DB, error) {
 	db.Repository = NewQueueRepository(conn, DialectSQLite)
 	db.MigrationRepo = NewImportMigrationRepository(conn, DialectSQLite)
 	db.StoreRefRepo = NewStoreRefRepository(conn, DialectSQLite)
+	db.FileIndexRepo = NewFileIndexRepository(conn, DialectSQLite)
 	return db, nil
 }
 
@@ -122,6 +124,7 @@ func newPostgresDB(config Config) (*DB, error) {
 	db.Repository = NewQueueRepository(conn, DialectPostgres)
 	db.MigrationRepo = NewImportMigrationRepository(conn, DialectPostgres)
 	db.StoreRefRepo = NewStoreRefRepository(conn, DialectPostgres)
+	db.FileIndexRepo = NewFileIndexRepository(conn, DialectPostgres)
 	return db, nil
 }
 
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ExpiryObservation is one release's article-expiry history, the input of the
+// health scheduler's learned expiry model.
+type ExpiryObservation struct {
+	Newsgroup string
+	Poster    string
+	// Provider is the primary provider of the release's import sample.
+	Provider string
+	// Age is how old the release was when it first lost segments (Lost), or
+	// when a check last found it intact.
+	Age  time.Duration
+	Lost bool
+}
+
+// GetExpiryObservations returns the article-expiry history of the library:
+// every healthy file with a release date, aged at its last check, and every
+// recorded segment loss.
+func (r *HealthRepository) GetExpiryObservations(ctx context.Context) ([]ExpiryObservation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT newsgroup, poster, provider_completeness, release_date, last_checked
+		FROM file_health
+		WHERE status = 'healthy'
+		  AND first_loss_at IS NULL
+		  AND release_date IS NOT NULL
+		  AND last_checked IS NOT NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expiry observations: %w", err)
+	}
+	defer rows.Close()
+
+	var observations []ExpiryObservation
+	for rows.Next() {
+		var newsgroup, poster, completeness sql.NullString
+		var releaseDate, lastChecked time.Time
+		if err := rows.Scan(&newsgroup, &poster, &completeness, &releaseDate, &lastChecked); err != nil {
+			return nil, fmt.Errorf("failed to scan expiry observation: %w", err)
+		}
+		observations = append(observations, ExpiryObservation{
+			Newsgroup: newsgroup.String,
+			Poster:    poster.String,
+			Provider:  PrimaryProvider(ParseProviderCompleteness(&completeness.String)),
+			Age:       lastChecked.Sub(releaseDate),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate expiry observations: %w", err)
+	}
+
+	lossRows, err := r.db.QueryContext(ctx, `
+		SELECT newsgroup, poster, provider, release_date, lost_at
+		FROM segment_loss_events
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query segment loss events: %w", err)
+	}
+	defer lossRows.Close()
+
+	for lossRows.Next() {
+		var newsgroup, poster, provider sql.NullString
+		var releaseDate, lostAt time.Time
+		if err := lossRows.Scan(&newsgroup, &poster, &provider, &releaseDate, &lostAt); err != nil {
+			return nil, fmt.Errorf("failed to scan segment loss event: %w", err)
+		}
+		observations = append(observations, ExpiryObservation{
+			Newsgroup: newsgroup.String,
+			Poster:    poster.String,
+			Provider:  provider.String,
+			Age:       lostAt.Sub(releaseDate),
+			Lost:      true,
+		})
+	}
+	if err := lossRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate segment loss events: %w", err)
+	}
+
+	return observations, nil
+}
+
+// RecordSegmentLoss marks the first time a check confirmed the file missing
+// segments and logs the loss for the expiry model. Later losses of the same
+// release are ignored until a re-import resets first_loss_at.
+func (r *HealthRepository) RecordSegmentLoss(ctx context.Context, fh *FileHealth) error {
+	releaseDate := fh.CreatedAt
+	if fh.ReleaseDate != nil {
+		releaseDate = *fh.ReleaseDate
+	}
+	var provider *string
+	if p := PrimaryProvider(ParseProviderCompleteness(fh.ProviderCompleteness)); p != "" {
+		provider = &p
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE file_health
+		SET first_loss_at = datetime('now')
+		WHERE file_path = ? AND first_loss_at IS NULL
+	`, normalizeHealthPath(fh.FilePath))
+	if err != nil {
+		return fmt.Errorf("failed to mark segment loss: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO segment_loss_events (file_path, newsgroup, poster, provider, indexer, release_date)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, normalizeHealthPath(fh.FilePath), fh.Newsgroup, fh.Poster, provider, fh.Indexer, releaseDate.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return fmt.Errorf("failed to record segment loss: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiryObservations_RecordSegmentLoss(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 46)
+	repo := NewHealthRepository(db, DialectSQLite)
+
+	_, err := db.Exec(`
+		INSERT INTO file_health (file_path, status, release_date, last_checked, newsgroup, poster, provider_completeness)
+		VALUES
+		  ('movies/a.mkv', 'healthy', datetime('now', '-10 days'), datetime('now'), 'alt.binaries.a', 'poster@a', '[{"provider":"p1","sampled":10,"missing":0,"completeness":1}]'),
+		  ('movies/b.mkv', 'healthy', datetime('now', '-3 days'), datetime('now'), 'alt.binaries.b', 'poster@b', NULL)
+	`)
+	require.NoError(t, err)
+
+	obs, err := repo.GetExpiryObservations(ctx)
+	require.NoError(t, err)
+	require.Len(t, obs, 2)
+	assert.Equal(t, "alt.binaries.a", obs[0].Newsgroup)
+	assert.Equal(t, "poster@a", obs[0].Poster)
+	assert.Equal(t, "p1", obs[0].Provider)
+	assert.InDelta(t, (10 * 24 * time.Hour).Hours(), obs[0].Age.Hours(), 1)
+	assert.False(t, obs[0].Lost)
+
+	fh, err := repo.GetFileHealth(ctx, "movies/b.mkv")
+	require.NoError(t, err)
+	require.NotNil(t, fh)
+	require.NoError(t, repo.RecordSegmentLoss(ctx, fh))
+	// Only the first loss of a release is recorded.
+	require.NoError(t, repo.RecordSegmentLoss(ctx, fh))
+
+	var events int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM segment_loss_events`).Scan(&events))
+	assert.Equal(t, 1, events)
+
+	obs, err = repo.GetExpiryObservations(ctx)
+	require.NoError(t, err)
+	require.Len(t, obs, 2, "the lost file is now observed through its loss event")
+	lost := obs[1]
+	assert.True(t, lost.Lost)
+	assert.Equal(t, "alt.binaries.b", lost.Newsgroup)
+	assert.InDelta(t, (3 * 24 * time.Hour).Hours(), lost.Age.Hours(), 1)
+}
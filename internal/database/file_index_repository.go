@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// File index kinds.
+const (
+	// FileIndexKindMeta rows are mount-relative .meta paths, without the extension.
+	FileIndexKindMeta = "meta"
+	// FileIndexKindLink rows are library symlink/STRM paths; target is the
+	// mount-relative file they point at.
+	FileIndexKindLink = "link"
+)
+
+// FileIndexRepository maintains the persisted index of metadata files and
+// library links that lets library sync diff against the database instead of
+// walking both trees on every run.
+type FileIndexRepository struct {
+	db      *dialectAwareDB
+	dialect dialectHelper
+}
+
+// NewFileIndexRepository creates a new FileIndexRepository.
+func NewFileIndexRepository(db *sql.DB, d Dialect) *FileIndexRepository {
+	return &FileIndexRepository{
+		db:      newDialectAwareDB(db, d),
+		dialect: dialectHelper{d: d},
+	}
+}
+
+// normalizeIndexPath strips leading/trailing slashes so metadata paths match
+// however the caller spelled the virtual path.
+func normalizeIndexPath(p string) string {
+	return strings.Trim(strings.ReplaceAll(p, "\\", "/"), "/")
+}
+
+// upsertIndexQuery inserts an index entry or refreshes its target and timestamp.
+const upsertIndexQuery = `
+	INSERT INTO file_index (kind, path, target, indexed_at)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT(kind, path) DO UPDATE SET
+		target = excluded.target,
+		indexed_at = excluded.indexed_at
+`
+
+func (r *FileIndexRepository) upsert(ctx context.Context, kind, path, target string) error {
+	_, err := r.db.ExecContext(ctx, upsertIndexQuery, kind, path, target, time.Now().UnixNano())
+	return err
+}
+
+// IndexMetadata records that a .meta file exists for the mount-relative path.
+func (r *FileIndexRepository) IndexMetadata(ctx context.Context, path string) error {
+	if err := r.upsert(ctx, FileIndexKindMeta, normalizeIndexPath(path), ""); err != nil {
+		return fmt.Errorf("index metadata %q: %w", path, err)
+	}
+	return nil
+}
+
+// RemoveMetadata drops the .meta entry for the mount-relative path.
+func (r *FileIndexRepository) RemoveMetadata(ctx context.Context, path string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM file_index WHERE kind = ? AND path = ?`,
+		FileIndexKindMeta, normalizeIndexPath(path))
+	if err != nil {
+		return fmt.Errorf("remove metadata %q: %w", path, err)
+	}
+	return nil
+}
+
+// RemoveMetadataDir drops every .meta entry under the mount-relative directory.
+func (r *FileIndexRepository) RemoveMetadataDir(ctx context.Context, dir string) error {
+	prefix := normalizeIndexPath(dir) + "/"
+	_, err := r.db.ExecContext(ctx, `DELETE FROM file_index WHERE kind = ? AND substr(path, 1, ?) = ?`,
+		FileIndexKindMeta, utf8.RuneCountInString(prefix), prefix)
+	if err != nil {
+		return fmt.Errorf("remove metadata dir %q: %w", dir, err)
+	}
+	return nil
+}
+
+// RenameMetadata moves a .meta entry to its new mount-relative path.
+func (r *FileIndexRepository) RenameMetadata(ctx context.Context, oldPath, newPath string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("rename metadata %q: begin tx: %w", oldPath, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM file_index WHERE kind = ? AND path = ?`,
+		FileIndexKindMeta, normalizeIndexPath(oldPath)); err != nil {
+		return fmt.Errorf("rename metadata %q: delete: %w", oldPath, err)
+	}
+	if _, err := tx.ExecContext(ctx, upsertIndexQuery,
+		FileIndexKindMeta, normalizeIndexPath(newPath), "", time.Now().UnixNano()); err != nil {
+		return fmt.Errorf("rename metadata %q: insert: %w", oldPath, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("rename metadata %q: commit: %w", oldPath, err)
+	}
+	return nil
+}
+
+// RenameMetadataDir moves every .meta entry under oldDir to newDir.
+func (r *FileIndexRepository) RenameMetadataDir(ctx context.Context, oldDir, newDir string) error {
+	oldPrefix := normalizeIndexPath(oldDir) + "/"
+	newPrefix := normalizeIndexPath(newDir) + "/"
+	n := utf8.RuneCountInString(oldPrefix)
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE file_index
+		SET path = ? || substr(path, ?), indexed_at = ?
+		WHERE kind = ? AND substr(path, 1, ?) = ?
+	`, newPrefix, n+1, time.Now().UnixNano(), FileIndexKindMeta, n, oldPrefix)
+	if err != nil {
+		return fmt.Errorf("rename metadata dir %q: %w", oldDir, err)
+	}
+	return nil
+}
+
+// IndexLink records a library symlink or STRM file at linkPath pointing at
+// the mount-relative target.
+func (r *FileIndexRepository) IndexLink(ctx context.Context, linkPath, target string) error {
+	if err := r.upsert(ctx, FileIndexKindLink, linkPath, normalizeIndexPath(target)); err != nil {
+		return fmt.Errorf("index link %q: %w", linkPath, err)
+	}
+	return nil
+}
+
+// RemoveLink drops the entry for a library symlink or STRM file.
+func (r *FileIndexRepository) RemoveLink(ctx context.Context, linkPath string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM file_index WHERE kind = ? AND path = ?`,
+		FileIndexKindLink, linkPath)
+	if err != nil {
+		return fmt.Errorf("remove link %q: %w", linkPath, err)
+	}
+	return nil
+}
+
+// ListFileIndex returns every entry of the given kind as path -> target.
+func (r *FileIndexRepository) ListFileIndex(ctx context.Context, kind string) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT path, target FROM file_index WHERE kind = ?`, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query file index: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make(map[string]string)
+	for rows.Next() {
+		var path, target string
+		if err := rows.Scan(&path, &target); err != nil {
+			return nil, fmt.Errorf("failed to scan file index entry: %w", err)
+		}
+		entries[path] = target
+	}
+
+	return entries, rows.Err()
+}
+
+// ReplaceFileIndex rebuilds the entries of the given kind from a full walk.
+// Entries are upserted, then every entry of the kind not refreshed since
+// `since` (the start of the walk) is removed, so entries indexed by writes
+// that raced the walk are kept.
+func (r *FileIndexRepository) ReplaceFileIndex(ctx context.Context, kind string, entries map[string]string, since time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("replace file index: begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, upsertIndexQuery)
+	if err != nil {
+		return fmt.Errorf("replace file index: prepare: %w", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now().UnixNano()
+	for path, target := range entries {
+		if kind == FileIndexKindMeta {
+			path = normalizeIndexPath(path)
+		}
+		if _, err := stmt.ExecContext(ctx, kind, path, normalizeIndexPath(target), now); err != nil {
+			return fmt.Errorf("replace file index: insert %q: %w", path, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM file_index WHERE kind = ? AND indexed_at < ?`,
+		kind, since.UnixNano()); err != nil {
+		return fmt.Errorf("replace file index: prune: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("replace file index: commit: %w", err)
+	}
+	return nil
+}
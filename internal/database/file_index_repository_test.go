@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileIndexRepository_Metadata(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFileIndexRepository(openMigratedTo(t, 45), DialectSQLite)
+
+	require.NoError(t, repo.IndexMetadata(ctx, "/movies/a.mkv"))
+	require.NoError(t, repo.IndexMetadata(ctx, "movies/b.mkv"))
+	require.NoError(t, repo.IndexMetadata(ctx, "movies/sub/c.mkv"))
+	require.NoError(t, repo.IndexMetadata(ctx, "movies-extra/d.mkv"))
+	require.NoError(t, repo.IndexMetadata(ctx, "movies/a.mkv"), "re-indexing is idempotent")
+
+	require.NoError(t, repo.RenameMetadata(ctx, "movies/b.mkv", "tv/b.mkv"))
+	require.NoError(t, repo.RenameMetadataDir(ctx, "movies/sub", "movies/renamed"))
+	require.NoError(t, repo.RemoveMetadata(ctx, "movies/a.mkv"))
+
+	entries, err := repo.ListFileIndex(ctx, FileIndexKindMeta)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"tv/b.mkv":             "",
+		"movies/renamed/c.mkv": "",
+		"movies-extra/d.mkv":   "",
+	}, entries)
+
+	// A directory delete matches whole path segments only.
+	require.NoError(t, repo.RemoveMetadataDir(ctx, "/movies"))
+	entries, err = repo.ListFileIndex(ctx, FileIndexKindMeta)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tv/b.mkv": "", "movies-extra/d.mkv": ""}, entries)
+}
+
+func TestFileIndexRepository_ReplaceFileIndex(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFileIndexRepository(openMigratedTo(t, 45), DialectSQLite)
+
+	require.NoError(t, repo.IndexLink(ctx, "/library/stale.mkv", "/movies/stale.mkv"))
+	require.NoError(t, repo.IndexMetadata(ctx, "movies/stale.mkv"))
+
+	since := time.Now()
+	// Indexed while the walk ran: kept although the walk did not see it.
+	require.NoError(t, repo.IndexLink(ctx, "/library/raced.mkv", "movies/raced.mkv"))
+
+	require.NoError(t, repo.ReplaceFileIndex(ctx, FileIndexKindLink, map[string]string{
+		"/library/a.mkv": "/movies/a.mkv",
+	}, since))
+
+	links, err := repo.ListFileIndex(ctx, FileIndexKindLink)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"/library/a.mkv":     "movies/a.mkv",
+		"/library/raced.mkv": "movies/raced.mkv",
+	}, links)
+
+	// Other kinds are left alone.
+	metas, err := repo.ListFileIndex(ctx, FileIndexKindMeta)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"movies/stale.mkv": ""}, metas)
+
+	require.NoError(t, repo.RemoveLink(ctx, "/library/a.mkv"))
+	links, err = repo.ListFileIndex(ctx, FileIndexKindLink)
+	require.NoError(t, err)
+	assert.NotContains(t, links, "/library/a.mkv")
+}
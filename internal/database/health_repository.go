@@ -121,7 +121,9 @@ const fileHealthSelectColumns = `
 	       repair_retry_count, max_repair_retries, source_nzb_path,
 	       error_details, created_at, updated_at, release_date, priority,
 		   streaming_failure_count, is_masked
-	, metadata, indexer, download_id
+	, metadata, indexer, download_id, provider_completeness
+	, newsgroup, poster, first_loss_at, expiry_risk, next_check_reason
+	, last_streamed_at, stream_count, continue_watching, sample_confidence, repair_state, repairability
 	FROM file_health
 	`
 
@@ -142,7 +144,10 @@ func scanFileHealth(s rowScanner) (*FileHealth, error) {
 		&health.SourceNzbPath, &health.ErrorDetails,
 		&health.CreatedAt, &health.UpdatedAt, &health.ReleaseDate, &health.Priority,
 		&health.StreamingFailureCount, &health.IsMasked,
-		&health.Metadata, &health.Indexer, &health.DownloadID,
+		&health.Metadata, &health.Indexer, &health.DownloadID, &health.ProviderCompleteness,
+		&health.Newsgroup, &health.Poster, &health.FirstLossAt, &health.ExpiryRisk, &health.NextCheckReason,
+		&health.LastStreamedAt, &health.StreamCount, &health.ContinueWatching, &health.SampleConfidence, &health.RepairState,
+		&health.Repairability,
 	)
 	if err != nil {
 		return nil, err
@@ -232,14 +237,17 @@ func (r *HealthRepository) UnmaskFile(ctx context.Context, filePath string) erro
 	return nil
 }
 
-// GetUnhealthyFiles returns files that need health checks
+// GetUnhealthyFiles returns files that need health checks. Among files of the
+// same priority, played files come before never-played ones (playbackRankSQL).
 func (r *HealthRepository) GetUnhealthyFiles(ctx context.Context, limit int, strategy string, libraryDir string, maxRetries int) ([]*FileHealth, error) {
 	query := `
 		SELECT id, file_path, status, last_checked, last_error, retry_count, max_retries,
 		       repair_retry_count, max_repair_retries, source_nzb_path,
 		       error_details, created_at, updated_at, release_date, scheduled_check_at,
 			   library_path, priority, streaming_failure_count, is_masked
-		, metadata, indexer, download_id
+		, metadata, indexer, download_id, provider_completeness
+		, newsgroup, poster, first_loss_at, expiry_risk, next_check_reason
+		, last_streamed_at, stream_count, continue_watching, sample_confidence, repair_state, repairability
 		FROM file_health
 		WHERE scheduled_check_at IS NOT NULL
 		  AND scheduled_check_at <= datetime('now')
@@ -261,6 +269,7 @@ func (r *HealthRepository) GetUnhealthyFiles(ctx context.Context, limit int, str
 		  )
 		ORDER BY priority DESC, 
 		         (CASE WHEN status = 'pending' THEN 0 ELSE 1 END) ASC, 
+		         ` + playbackRankSQL + ` ASC,
 		         scheduled_check_at ASC
 		LIMIT ?
 	`
@@ -272,7 +281,7 @@ func (r *HealthRepository) GetUnhealthyFiles(ctx context.Context, limit int, str
 	libraryBase := strings.TrimRight(strings.ReplaceAll(libraryDir, `\`, "/"), "/")
 	libraryPrefix := libraryBase + "/%"
 	libraryPrefixAlt := strings.ReplaceAll(libraryBase, "/", `\`) + `\%`
-	rows, err := r.db.QueryContext(ctx, query, maxRetries, strategy, libraryPrefix, libraryPrefixAlt, limit)
+	rows, err := r.db.QueryContext(ctx, query, maxRetries, strategy, libraryPrefix, libraryPrefixAlt, playbackCutoff(), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query files due for check: %w", err)
 	}
@@ -292,7 +301,10 @@ func (r *HealthRepository) GetUnhealthyFiles(ctx context.Context, limit int, str
 			&health.Priority,
 			&health.StreamingFailureCount,
 			&health.IsMasked,
-			&health.Metadata, &health.Indexer, &health.DownloadID,
+			&health.Metadata, &health.Indexer, &health.DownloadID, &health.ProviderCompleteness,
+			&health.Newsgroup, &health.Poster, &health.FirstLossAt, &health.ExpiryRisk, &health.NextCheckReason,
+			&health.LastStreamedAt, &health.StreamCount, &health.ContinueWatching, &health.SampleConfidence, &health.RepairState,
+			&health.Repairability,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan file health: %w", err)
@@ -328,6 +340,8 @@ func (r *HealthRepository) SetPriority(ctx context.Context, id int64, priority H
 // Records whose repair_retry_count has reached max_repair_retries are returned too: the worker
 // finalizes them as corrupted (prepareRepairNotificationUpdate). Filtering them out here would
 // leave them permanently stuck in repair_triggered — no other query ever selects that status.
+// Played files are followed up first (playbackRankSQL). Files whose alternate-release
+// replacement is still queued are skipped until the import finishes.
 func (r *HealthRepository) GetFilesForRepairNotification(ctx context.Context, limit int) ([]*FileHealth, error) {
 	query := `
 		SELECT id, file_path, status, last_checked, last_error, retry_count, max_retries,
@@ -337,11 +351,17 @@ func (r *HealthRepository) GetFilesForRepairNotification(ctx context.Context, li
 		FROM file_health
 		WHERE status = 'repair_triggered'
 		  AND (scheduled_check_at IS NULL OR scheduled_check_at <= datetime('now'))
-		ORDER BY last_checked ASC
+		  AND NOT EXISTS (
+		      SELECT 1 FROM replacement_imports ri
+		      JOIN import_queue q ON q.id = ri.queue_id
+		      WHERE ri.file_path = file_health.file_path
+		        AND q.status IN ('pending', 'processing', 'paused')
+		  )
+		ORDER BY ` + playbackRankSQL + ` ASC, last_checked ASC
 		LIMIT ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, limit)
+	rows, err := r.db.QueryContext(ctx, query, playbackCutoff(), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query files for repair notification: %w", err)
 	}
@@ -732,6 +752,13 @@ type HealthCheckUpsert struct {
 	ReleaseDate      *time.Time
 	Metadata         *string
 	DownloadID       *string
+	// ProviderCompleteness is the JSON per-provider sample result of the
+	// import; nil keeps the stored value.
+	ProviderCompleteness *string
+	// Newsgroup and Poster identify the release's posting for the
+	// article-expiry model; nil keeps the stored value.
+	Newsgroup *string
+	Poster    *string
 }
 
 // BatchAddFileToHealthCheck upserts many health records in a few multi-row statements
@@ -744,8 +771,8 @@ func (r *HealthRepository) BatchAddFileToHealthCheck(ctx context.Context, record
 		return nil
 	}
 
-	// 10 bound params per row; keep batches under SQLite's ~999 parameter limit.
-	const batchSize = 95
+	// 13 bound params per row; keep batches under SQLite's ~999 parameter limit.
+	const batchSize = 75
 
 	for i := 0; i < len(records); i += batchSize {
 		end := min(i+batchSize, len(records))
@@ -760,12 +787,12 @@ func (r *HealthRepository) BatchAddFileToHealthCheck(ctx context.Context, record
 // batchUpsertFileHealthCheck performs a single multi-row upsert.
 func (r *HealthRepository) batchUpsertFileHealthCheck(ctx context.Context, records []HealthCheckUpsert) error {
 	valueStrings := make([]string, len(records))
-	args := make([]any, 0, len(records)*10)
+	args := make([]any, 0, len(records)*13)
 
 	for i, rec := range records {
 		// status, retry_count and repair_retry_count are literals so excluded.status is
 		// always 'pending' (matching the single-row upsert's bound HealthStatusPending).
-		valueStrings[i] = "(?, ?, 'pending', datetime('now'), 0, ?, 0, ?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'), datetime('now'))"
+		valueStrings[i] = "(?, ?, 'pending', datetime('now'), 0, ?, 0, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'), datetime('now'))"
 
 		var releaseDateStr any = nil
 		if rec.ReleaseDate != nil {
@@ -775,11 +802,12 @@ func (r *HealthRepository) batchUpsertFileHealthCheck(ctx context.Context, recor
 		args = append(args,
 			normalizeHealthPath(rec.FilePath), rec.LibraryPath,
 			rec.MaxRetries, rec.MaxRepairRetries,
-			rec.SourceNzbPath, rec.Priority, releaseDateStr, rec.Metadata, rec.Indexer, rec.DownloadID)
+			rec.SourceNzbPath, rec.Priority, releaseDateStr, rec.Metadata, rec.Indexer, rec.DownloadID, rec.ProviderCompleteness,
+			rec.Newsgroup, rec.Poster)
 	}
 
 	query := fmt.Sprintf(`
-		INSERT INTO file_health (file_path, library_path, status, last_checked, retry_count, max_retries, repair_retry_count, max_repair_retries, source_nzb_path, priority, release_date, metadata, indexer, download_id, created_at, updated_at, scheduled_check_at)
+		INSERT INTO file_health (file_path, library_path, status, last_checked, retry_count, max_retries, repair_retry_count, max_repair_retries, source_nzb_path, priority, release_date, metadata, indexer, download_id, provider_completeness, newsgroup, poster, created_at, updated_at, scheduled_check_at)
 		VALUES %s
 		ON CONFLICT(file_path) DO UPDATE SET
 			library_path = COALESCE(excluded.library_path, library_path),
@@ -795,6 +823,13 @@ func (r *HealthRepository) batchUpsertFileHealthCheck(ctx context.Context, recor
 			metadata = COALESCE(excluded.metadata, metadata),
 			indexer = COALESCE(excluded.indexer, indexer),
 			download_id = COALESCE(excluded.download_id, download_id),
+			provider_completeness = COALESCE(excluded.provider_completeness, provider_completeness),
+			newsgroup = COALESCE(excluded.newsgroup, newsgroup),
+			poster = COALESCE(excluded.poster, poster),
+			-- A re-import is a new release: its loss history starts over.
+			first_loss_at = NULL,
+			expiry_risk = NULL,
+			next_check_reason = NULL,
 			updated_at = datetime('now'),
 			scheduled_check_at = datetime('now')
 	`, strings.Join(valueStrings, ","))
@@ -807,7 +842,7 @@ func (r *HealthRepository) batchUpsertFileHealthCheck(ctx context.Context, recor
 }
 
 // ListHealthItems returns all health records with optional filtering, sorting and pagination
-func (r *HealthRepository) ListHealthItems(ctx context.Context, statusFilter *HealthStatus, limit, offset int, sinceFilter *time.Time, search string, sortBy string, sortOrder string) ([]*FileHealth, error) {
+func (r *HealthRepository) ListHealthItems(ctx context.Context, statusFilter *HealthStatus, limit, offset int, sinceFilter *time.Time, streamedSinceFilter *time.Time, search string, sortBy string, sortOrder string) ([]*FileHealth, error) {
 	// Validate and prepare ORDER BY clause
 	orderClause := "created_at DESC"
 	if sortBy != "" {
@@ -819,6 +854,8 @@ func (r *HealthRepository) ListHealthItems(ctx context.Context, statusFilter *He
 			"priority":           "priority",
 			"last_checked":       "last_checked",
 			"scheduled_check_at": "scheduled_check_at",
+			"last_streamed_at":   "last_streamed_at",
+			"stream_count":       "stream_count",
 		}
 
 		if field, ok := allowedFields[sortBy]; ok {
@@ -835,10 +872,13 @@ func (r *HealthRepository) ListHealthItems(ctx context.Context, statusFilter *He
 		       repair_retry_count, max_repair_retries, source_nzb_path,
 		       error_details, created_at, updated_at, scheduled_check_at,
 			   library_path, streaming_failure_count, is_masked
-		, metadata, indexer
+		, metadata, indexer, provider_completeness
+		, newsgroup, poster, first_loss_at, expiry_risk, next_check_reason
+		, last_streamed_at, stream_count, continue_watching, sample_confidence, repair_state, repairability
 		FROM file_health
 		WHERE (? IS NULL OR status = ?)
 		  AND (? IS NULL OR created_at >= ?)
+		  AND (? IS NULL OR last_streamed_at >= ?)
 		  AND (? = '' OR file_path LIKE ? OR (source_nzb_path IS NOT NULL AND source_nzb_path LIKE ?))
 		ORDER BY %s
 		LIMIT ? OFFSET ?
@@ -855,12 +895,18 @@ func (r *HealthRepository) ListHealthItems(ctx context.Context, statusFilter *He
 		sinceParam = sinceFilter.Format("2006-01-02 15:04:05")
 	}
 
+	var streamedSinceParam any = nil
+	if streamedSinceFilter != nil {
+		streamedSinceParam = streamedSinceFilter.UTC().Format("2006-01-02 15:04:05")
+	}
+
 	// Prepare search parameter with wildcards
 	searchPattern := "%" + search + "%"
 
 	args := []any{
 		statusParam, statusParam, // status filter (checked twice in WHERE clause)
 		sinceParam, sinceParam, // since filter (checked twice in WHERE clause)
+		streamedSinceParam, streamedSinceParam, // streamed-since filter (checked twice in WHERE clause)
 		search, searchPattern, searchPattern, // search filter (file_path and source_nzb_path)
 		limit, offset,
 	}
@@ -881,7 +927,10 @@ func (r *HealthRepository) ListHealthItems(ctx context.Context, statusFilter *He
 			&health.SourceNzbPath, &health.ErrorDetails,
 			&health.CreatedAt, &health.UpdatedAt, &health.ScheduledCheckAt,
 			&health.LibraryPath, &health.StreamingFailureCount, &health.IsMasked,
-			&health.Metadata, &health.Indexer,
+			&health.Metadata, &health.Indexer, &health.ProviderCompleteness,
+			&health.Newsgroup, &health.Poster, &health.FirstLossAt, &health.ExpiryRisk, &health.NextCheckReason,
+			&health.LastStreamedAt, &health.StreamCount, &health.ContinueWatching, &health.SampleConfidence, &health.RepairState,
+			&health.Repairability,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan health item: %w", err)
@@ -897,12 +946,13 @@ func (r *HealthRepository) ListHealthItems(ctx context.Context, statusFilter *He
 }
 
 // CountHealthItems returns the total count of health records with optional filtering
-func (r *HealthRepository) CountHealthItems(ctx context.Context, statusFilter *HealthStatus, sinceFilter *time.Time, search string) (int, error) {
+func (r *HealthRepository) CountHealthItems(ctx context.Context, statusFilter *HealthStatus, sinceFilter *time.Time, streamedSinceFilter *time.Time, search string) (int, error) {
 	query := `
 		SELECT COUNT(*) 
 		FROM file_health
 		WHERE (? IS NULL OR status = ?)
 		  AND (? IS NULL OR created_at >= ?)
+		  AND (? IS NULL OR last_streamed_at >= ?)
 		  AND (? = '' OR file_path LIKE ? OR (source_nzb_path IS NOT NULL AND source_nzb_path LIKE ?))
 	`
 
@@ -917,12 +967,18 @@ func (r *HealthRepository) CountHealthItems(ctx context.Context, statusFilter *H
 		sinceParam = sinceFilter.Format("2006-01-02 15:04:05")
 	}
 
+	var streamedSinceParam any = nil
+	if streamedSinceFilter != nil {
+		streamedSinceParam = streamedSinceFilter.UTC().Format("2006-01-02 15:04:05")
+	}
+
 	// Prepare search parameter with wildcards
 	searchPattern := "%" + search + "%"
 
 	args := []any{
 		statusParam, statusParam, // status filter (checked twice in WHERE clause)
 		sinceParam, sinceParam, // since filter (checked twice in WHERE clause)
+		streamedSinceParam, streamedSinceParam, // streamed-since filter (checked twice in WHERE clause)
 		search, searchPattern, searchPattern, // search filter (file_path and source_nzb_path)
 	}
 
@@ -1317,6 +1373,7 @@ func (r *HealthRepository) UpdateHealthStatusBulk(ctx context.Context, updates [
 		UPDATE file_health
 		SET status = 'healthy', scheduled_check_at = ?, retry_count = 0,
 		    repair_retry_count = 0, last_error = NULL, error_details = NULL,
+		    repairability = NULL, expiry_risk = ?, next_check_reason = ?,
 		    updated_at = datetime('now'), last_checked = datetime('now')
 		WHERE file_path = ? AND (status = ? OR ? = '')
 	`)
@@ -1380,7 +1437,7 @@ func (r *HealthRepository) UpdateHealthStatusBulk(ctx context.Context, updates [
 	stmtDegraded, err := tx.PrepareContext(ctx, `
 		UPDATE file_health
 		SET status = 'degraded', last_error = ?, error_details = ?,
-		    scheduled_check_at = ?,
+		    scheduled_check_at = ?, expiry_risk = ?, next_check_reason = ?,
 		    updated_at = datetime('now'), last_checked = datetime('now')
 		WHERE file_path = ? AND (status = ? OR ? = '')
 	`)
@@ -1389,6 +1446,22 @@ func (r *HealthRepository) UpdateHealthStatusBulk(ctx context.Context, updates [
 	}
 	defer stmtDegraded.Close()
 
+	stmtSample, err := tx.PrepareContext(ctx, `
+		UPDATE file_health SET sample_confidence = ? WHERE file_path = ?
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare sample confidence statement: %w", err)
+	}
+	defer stmtSample.Close()
+
+	stmtRepairability, err := tx.PrepareContext(ctx, `
+		UPDATE file_health SET repairability = ? WHERE file_path = ?
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare repairability statement: %w", err)
+	}
+	defer stmtRepairability.Close()
+
 	for _, update := range updates {
 		if update.Skip {
 			continue
@@ -1400,24 +1473,41 @@ func (r *HealthRepository) UpdateHealthStatusBulk(ctx context.Context, updates [
 		if update.ExpectedStatus != nil {
 			expected = string(*update.ExpectedStatus)
 		}
+		var res sql.Result
 		switch update.Type {
 		case UpdateTypeHealthy:
-			_, err = stmtHealthy.ExecContext(ctx, update.ScheduledCheckAt, filePath, expected, expected)
+			res, err = stmtHealthy.ExecContext(ctx, update.ScheduledCheckAt, update.ExpiryRisk, update.NextCheckReason, filePath, expected, expected)
 		case UpdateTypeRetry:
-			_, err = stmtRetry.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, update.ScheduledCheckAt, filePath, expected, expected)
+			res, err = stmtRetry.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, update.ScheduledCheckAt, filePath, expected, expected)
 		case UpdateTypeRepairTrigger:
-			_, err = stmtRepairTrigger.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, update.ScheduledCheckAt, filePath, expected, expected)
+			res, err = stmtRepairTrigger.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, update.ScheduledCheckAt, filePath, expected, expected)
 		case UpdateTypeRepairRetry:
-			_, err = stmtRepair.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, update.ScheduledCheckAt, filePath, expected, expected)
+			res, err = stmtRepair.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, update.ScheduledCheckAt, filePath, expected, expected)
 		case UpdateTypeCorrupted:
-			_, err = stmtCorrupted.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, filePath, expected, expected)
+			res, err = stmtCorrupted.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, filePath, expected, expected)
 		case UpdateTypeDegraded:
-			_, err = stmtDegraded.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, update.ScheduledCheckAt, filePath, expected, expected)
+			res, err = stmtDegraded.ExecContext(ctx, update.ErrorMessage, update.ErrorDetails, update.ScheduledCheckAt, update.ExpiryRisk, update.NextCheckReason, filePath, expected, expected)
 		}
 
 		if err != nil {
 			return fmt.Errorf("failed to execute update for %s: %w", update.FilePath, err)
 		}
+
+		// Only alongside a status write that landed (see ExpectedStatus).
+		if update.SampleConfidence != nil && res != nil {
+			if n, _ := res.RowsAffected(); n > 0 {
+				if _, err := stmtSample.ExecContext(ctx, update.SampleConfidence, filePath); err != nil {
+					return fmt.Errorf("failed to store sample confidence for %s: %w", update.FilePath, err)
+				}
+			}
+		}
+		if update.Repairability != nil && res != nil {
+			if n, _ := res.RowsAffected(); n > 0 {
+				if _, err := stmtRepairability.ExecContext(ctx, update.Repairability, filePath); err != nil {
+					return fmt.Errorf("failed to store repairability for %s: %w", update.FilePath, err)
+				}
+			}
+		}
 	}
 
 	return tx.Commit()
@@ -1451,6 +1541,16 @@ type HealthStatusUpdate struct {
 	// guarded UPDATE matches no rows and the concurrent actor's decision wins instead of
 	// being silently clobbered (last-writer-wins re-entering the repair loop).
 	ExpectedStatus *HealthStatus
+	// ExpiryRisk and NextCheckReason explain ScheduledCheckAt for healthy and
+	// degraded updates (see health.ExpiryModel).
+	ExpiryRisk      *float64
+	NextCheckReason *string
+	// SampleConfidence is the JSON missing-rate estimate of the check behind
+	// the update (see SampleConfidence); nil leaves the stored value as is.
+	SampleConfidence *string
+	// Repairability is the JSON PAR2 recovery estimate of the check behind
+	// the update (see Repairability); nil leaves the stored value as is.
+	Repairability *string
 }
 
 // BackfillRecord represents a record used for metadata backfilling
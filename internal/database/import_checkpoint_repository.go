@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ImportCheckpoint is a stored stage checkpoint of a queue item's import.
+// Fingerprint identifies the NZB the checkpoint was taken from; Data is the
+// stage payload produced by the importer's checkpoint package.
+type ImportCheckpoint struct {
+	QueueID     int64
+	Stage       string
+	Fingerprint string
+	Data        []byte
+}
+
+// SaveImportCheckpoint stores a stage checkpoint of a queue item, replacing
+// an earlier one for the same stage.
+func (r *QueueRepository) SaveImportCheckpoint(ctx context.Context, queueID int64, stage, fingerprint string, data []byte) error {
+	query := r.dialect.q(`
+		INSERT INTO import_checkpoints (queue_id, stage, fingerprint, data)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(queue_id, stage) DO UPDATE SET
+			fingerprint = excluded.fingerprint,
+			data = excluded.data,
+			created_at = CURRENT_TIMESTAMP
+	`)
+	if _, err := r.db.ExecContext(ctx, query, queueID, stage, fingerprint, data); err != nil {
+		return fmt.Errorf("save %s checkpoint for queue item %d: %w", stage, queueID, err)
+	}
+	return nil
+}
+
+// GetImportCheckpoint returns a stage checkpoint of a queue item, or nil when
+// the item has none for that stage.
+func (r *QueueRepository) GetImportCheckpoint(ctx context.Context, queueID int64, stage string) (*ImportCheckpoint, error) {
+	query := r.dialect.q(`
+		SELECT queue_id, stage, fingerprint, data
+		FROM import_checkpoints WHERE queue_id = ? AND stage = ?
+	`)
+	var cp ImportCheckpoint
+	err := r.db.QueryRowContext(ctx, query, queueID, stage).Scan(&cp.QueueID, &cp.Stage, &cp.Fingerprint, &cp.Data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get %s checkpoint for queue item %d: %w", stage, queueID, err)
+	}
+	return &cp, nil
+}
+
+// DeleteImportCheckpoints removes every checkpoint of a queue item.
+func (r *QueueRepository) DeleteImportCheckpoints(ctx context.Context, queueID int64) error {
+	query := r.dialect.q(`DELETE FROM import_checkpoints WHERE queue_id = ?`)
+	if _, err := r.db.ExecContext(ctx, query, queueID); err != nil {
+		return fmt.Errorf("delete checkpoints for queue item %d: %w", queueID, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCheckpoint_SaveGetDelete(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 39)
+
+	res, err := db.Exec(`INSERT INTO import_queue (nzb_path, status) VALUES ('/nzbs/a.nzb', 'processing')`)
+	require.NoError(t, err)
+	queueID, err := res.LastInsertId()
+	require.NoError(t, err)
+
+	repo := NewQueueRepository(db, DialectSQLite)
+
+	got, err := repo.GetImportCheckpoint(ctx, queueID, "parsed")
+	require.NoError(t, err)
+	assert.Nil(t, got, "no checkpoint before the first save")
+
+	require.NoError(t, repo.SaveImportCheckpoint(ctx, queueID, "parsed", "fp1", []byte("first")))
+	// A later attempt replaces the stage's checkpoint.
+	require.NoError(t, repo.SaveImportCheckpoint(ctx, queueID, "parsed", "fp2", []byte("second")))
+	require.NoError(t, repo.SaveImportCheckpoint(ctx, queueID, "archive:rar:a.rar", "fp2", []byte("listing")))
+
+	got, err = repo.GetImportCheckpoint(ctx, queueID, "parsed")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, ImportCheckpoint{QueueID: queueID, Stage: "parsed", Fingerprint: "fp2", Data: []byte("second")}, *got)
+
+	require.NoError(t, repo.DeleteImportCheckpoints(ctx, queueID))
+	for _, stage := range []string{"parsed", "archive:rar:a.rar"} {
+		got, err = repo.GetImportCheckpoint(ctx, queueID, stage)
+		require.NoError(t, err)
+		assert.Nil(t, got, stage)
+	}
+}
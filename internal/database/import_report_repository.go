@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ImportReport is the stored import report of a queue item. Report holds the
+// gzip-compressed JSON produced by the importer's report package.
+type ImportReport struct {
+	QueueID   int64
+	Status    string
+	Report    []byte
+	CreatedAt sql.NullTime
+	UpdatedAt sql.NullTime
+}
+
+// SaveImportReport stores the import report of a queue item, replacing the
+// report of a previous attempt.
+func (r *QueueRepository) SaveImportReport(ctx context.Context, queueID int64, status string, report []byte) error {
+	query := r.dialect.q(`
+		INSERT INTO import_reports (queue_id, status, report)
+		VALUES (?, ?, ?)
+		ON CONFLICT(queue_id) DO UPDATE SET
+			status = excluded.status,
+			report = excluded.report,
+			updated_at = CURRENT_TIMESTAMP
+	`)
+	if _, err := r.db.ExecContext(ctx, query, queueID, status, report); err != nil {
+		return fmt.Errorf("save import report for queue item %d: %w", queueID, err)
+	}
+	return nil
+}
+
+// GetImportReport returns the stored import report of a queue item, or nil
+// when the item has none.
+func (r *Repository) GetImportReport(ctx context.Context, queueID int64) (*ImportReport, error) {
+	query := r.dialect.q(`
+		SELECT queue_id, status, report, created_at, updated_at
+		FROM import_reports WHERE queue_id = ?
+	`)
+	var rep ImportReport
+	err := r.db.QueryRowContext(ctx, query, queueID).Scan(&rep.QueueID, &rep.Status, &rep.Report, &rep.CreatedAt, &rep.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get import report for queue item %d: %w", queueID, err)
+	}
+	return &rep, nil
+}
+
+// DeleteImportReportsOlderThan deletes the import reports last written before
+// olderThan. Reports are not removed with their queue item, so a purged
+// failed item keeps its report until then.
+func (r *QueueRepository) DeleteImportReportsOlderThan(ctx context.Context, olderThan time.Time) (int64, error) {
+	query := r.dialect.q(`DELETE FROM import_reports WHERE updated_at < ?`)
+	res, err := r.db.ExecContext(ctx, query, olderThan.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old import reports: %w", err)
+	}
+	return res.RowsAffected()
+}
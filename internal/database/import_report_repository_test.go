@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportReport_SaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 37)
+
+	res, err := db.Exec(`INSERT INTO import_queue (nzb_path, status) VALUES ('/nzbs/a.nzb', 'processing')`)
+	require.NoError(t, err)
+	queueID, err := res.LastInsertId()
+	require.NoError(t, err)
+
+	queueRepo := NewQueueRepository(db, DialectSQLite)
+	repo := NewRepository(db, DialectSQLite)
+
+	got, err := repo.GetImportReport(ctx, queueID)
+	require.NoError(t, err)
+	assert.Nil(t, got, "no report before the first save")
+
+	require.NoError(t, queueRepo.SaveImportReport(ctx, queueID, "failed", []byte("first")))
+	// A retry replaces the previous attempt's report.
+	require.NoError(t, queueRepo.SaveImportReport(ctx, queueID, "completed", []byte("second")))
+
+	got, err = repo.GetImportReport(ctx, queueID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, queueID, got.QueueID)
+	assert.Equal(t, "completed", got.Status)
+	assert.Equal(t, []byte("second"), got.Report)
+}
+
+func TestImportReport_OutlivesQueueItem(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 48)
+	_, err := db.Exec(`PRAGMA foreign_keys = ON`)
+	require.NoError(t, err)
+
+	res, err := db.Exec(`INSERT INTO import_queue (nzb_path, status) VALUES ('/nzbs/a.nzb', 'failed')`)
+	require.NoError(t, err)
+	queueID, err := res.LastInsertId()
+	require.NoError(t, err)
+
+	queueRepo := NewQueueRepository(db, DialectSQLite)
+	repo := NewRepository(db, DialectSQLite)
+	require.NoError(t, queueRepo.SaveImportReport(ctx, queueID, "failed", []byte("post-mortem")))
+
+	// Purging the failed item keeps its report.
+	_, err = db.Exec(`DELETE FROM import_queue WHERE id = ?`, queueID)
+	require.NoError(t, err)
+	got, err := repo.GetImportReport(ctx, queueID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, []byte("post-mortem"), got.Report)
+
+	deleted, err := queueRepo.DeleteImportReportsOlderThan(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = queueRepo.DeleteImportReportsOlderThan(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	got, err = repo.GetImportReport(ctx, queueID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,7 @@
+-- +goose Up
+-- original_nzb_name keeps the obfuscated NZB filename when the import
+-- recovered a readable release name from PAR2, archive or NZB metadata.
+ALTER TABLE import_history ADD COLUMN original_nzb_name TEXT DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE import_history DROP COLUMN IF EXISTS original_nzb_name;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS import_reports (
+    queue_id   BIGINT      NOT NULL PRIMARY KEY REFERENCES import_queue(id) ON DELETE CASCADE,
+    status     TEXT        NOT NULL,
+    report     BYTEA       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS import_reports;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- provider_completeness holds a JSON array of per-provider sample results
+-- ({provider, sampled, missing, completeness}) from the fast-fail sweep.
+ALTER TABLE import_queue ADD COLUMN provider_completeness TEXT DEFAULT NULL;
+ALTER TABLE file_health ADD COLUMN provider_completeness TEXT DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE file_health DROP COLUMN IF EXISTS provider_completeness;
+ALTER TABLE import_queue DROP COLUMN IF EXISTS provider_completeness;
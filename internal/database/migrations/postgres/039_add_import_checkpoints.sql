@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS import_checkpoints (
+    queue_id    BIGINT      NOT NULL REFERENCES import_queue(id) ON DELETE CASCADE,
+    stage       TEXT        NOT NULL,
+    fingerprint TEXT        NOT NULL,
+    data        BYTEA       NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (queue_id, stage)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS import_checkpoints;
+-- +goose StatementEnd
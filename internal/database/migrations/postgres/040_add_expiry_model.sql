@@ -0,0 +1,33 @@
+-- +goose Up
+-- newsgroup/poster identify the posting of a file's release for the learned
+-- article-expiry model; first_loss_at is when a check first found missing
+-- segments. expiry_risk and next_check_reason explain the last schedule.
+ALTER TABLE file_health ADD COLUMN newsgroup TEXT DEFAULT NULL;
+ALTER TABLE file_health ADD COLUMN poster TEXT DEFAULT NULL;
+ALTER TABLE file_health ADD COLUMN first_loss_at TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE file_health ADD COLUMN expiry_risk DOUBLE PRECISION DEFAULT NULL;
+ALTER TABLE file_health ADD COLUMN next_check_reason TEXT DEFAULT NULL;
+
+-- segment_loss_events keeps one row per release that lost segments, so the
+-- history survives the repair that replaces the file's health record.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS segment_loss_events (
+    id           BIGSERIAL   PRIMARY KEY,
+    file_path    TEXT        NOT NULL,
+    newsgroup    TEXT        DEFAULT NULL,
+    poster       TEXT        DEFAULT NULL,
+    provider     TEXT        DEFAULT NULL,
+    release_date TIMESTAMPTZ NOT NULL,
+    lost_at      TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS segment_loss_events;
+-- +goose StatementEnd
+ALTER TABLE file_health DROP COLUMN IF EXISTS next_check_reason;
+ALTER TABLE file_health DROP COLUMN IF EXISTS expiry_risk;
+ALTER TABLE file_health DROP COLUMN IF EXISTS first_loss_at;
+ALTER TABLE file_health DROP COLUMN IF EXISTS poster;
+ALTER TABLE file_health DROP COLUMN IF EXISTS newsgroup;
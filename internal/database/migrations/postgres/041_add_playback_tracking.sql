@@ -0,0 +1,14 @@
+-- +goose Up
+-- last_streamed_at / stream_count record playback of a file (streams and
+-- media-server watch state); continue_watching marks a partially watched
+-- item. The health worker checks and repairs played files first.
+ALTER TABLE file_health ADD COLUMN last_streamed_at TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE file_health ADD COLUMN stream_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE file_health ADD COLUMN continue_watching BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_file_health_last_streamed_at ON file_health(last_streamed_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_file_health_last_streamed_at;
+ALTER TABLE file_health DROP COLUMN IF EXISTS continue_watching;
+ALTER TABLE file_health DROP COLUMN IF EXISTS stream_count;
+ALTER TABLE file_health DROP COLUMN IF EXISTS last_streamed_at;
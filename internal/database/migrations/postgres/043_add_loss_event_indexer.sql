@@ -0,0 +1,8 @@
+-- +goose Up
+-- indexer records which indexer the lost release was grabbed from, so the
+-- library health report can rank indexers by how fast their releases rot
+-- after the repair has replaced the file's health record.
+ALTER TABLE segment_loss_events ADD COLUMN indexer TEXT DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE segment_loss_events DROP COLUMN IF EXISTS indexer;
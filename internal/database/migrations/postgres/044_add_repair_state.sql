@@ -0,0 +1,30 @@
+-- +goose Up
+-- repair_state is where the file is in the repair state machine (see
+-- database.RepairState); '' means no repair was ever attempted.
+ALTER TABLE file_health ADD COLUMN repair_state TEXT NOT NULL DEFAULT '';
+
+-- repair_events is the append-only audit log of repair transitions: what was
+-- tried, through which arr, the arr's blocklist/queue/search IDs (details) and
+-- the result. It is kept when the health record is deleted.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS repair_events (
+    id           BIGSERIAL   PRIMARY KEY,
+    file_path    TEXT        NOT NULL,
+    from_state   TEXT        NOT NULL DEFAULT '',
+    to_state     TEXT        NOT NULL,
+    action       TEXT        NOT NULL,
+    arr_type     TEXT        DEFAULT NULL,
+    arr_instance TEXT        DEFAULT NULL,
+    details      TEXT        DEFAULT NULL,
+    result       TEXT        DEFAULT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+CREATE INDEX IF NOT EXISTS idx_repair_events_file_path ON repair_events(file_path);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_repair_events_file_path;
+-- +goose StatementBegin
+DROP TABLE IF EXISTS repair_events;
+-- +goose StatementEnd
+ALTER TABLE file_health DROP COLUMN IF EXISTS repair_state;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- file_index is the persisted index library sync diffs against instead of
+-- walking the metadata and library trees on every run. kind 'meta' rows are
+-- mount-relative .meta paths (without the extension); kind 'link' rows are
+-- library symlink/STRM paths with the mount-relative file they point at in
+-- target. indexed_at is a unix nanosecond timestamp so a rebuild can prune
+-- only the rows it did not see.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS file_index (
+    kind       TEXT    NOT NULL,
+    path       TEXT    NOT NULL,
+    target     TEXT    NOT NULL DEFAULT '',
+    indexed_at BIGINT  NOT NULL DEFAULT 0,
+    PRIMARY KEY (kind, path)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS file_index;
+-- +goose StatementEnd
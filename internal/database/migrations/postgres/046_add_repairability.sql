@@ -0,0 +1,7 @@
+-- +goose Up
+-- repairability is the JSON PAR2 recovery estimate of the file's last check
+-- that found missing segments (see database.Repairability).
+ALTER TABLE file_health ADD COLUMN repairability TEXT DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE file_health DROP COLUMN IF EXISTS repairability;
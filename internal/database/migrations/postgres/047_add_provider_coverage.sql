@@ -0,0 +1,22 @@
+-- +goose Up
+-- provider_coverage keeps one row per provider per audited health check: how
+-- many of the file's sampled articles the provider answered for, held, and
+-- held when no other provider did.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS provider_coverage (
+    id               BIGSERIAL   PRIMARY KEY,
+    file_path        TEXT        NOT NULL,
+    provider         TEXT        NOT NULL,
+    checked          INTEGER     NOT NULL DEFAULT 0,
+    available        INTEGER     NOT NULL DEFAULT 0,
+    unique_available INTEGER     NOT NULL DEFAULT 0,
+    checked_at       TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+CREATE INDEX IF NOT EXISTS idx_provider_coverage_checked_at ON provider_coverage(checked_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_provider_coverage_checked_at;
+-- +goose StatementBegin
+DROP TABLE IF EXISTS provider_coverage;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Import reports outlive their queue item: a failed item is purged after
+-- failed_item_retention_hours, but its report is what a post-mortem needs.
+-- Reports are pruned on their own with the import history instead.
+-- +goose StatementBegin
+ALTER TABLE import_reports DROP CONSTRAINT IF EXISTS import_reports_queue_id_fkey;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_import_reports_updated_at ON import_reports(updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_import_reports_updated_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM import_reports WHERE queue_id NOT IN (SELECT id FROM import_queue);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE import_reports ADD CONSTRAINT import_reports_queue_id_fkey
+    FOREIGN KEY (queue_id) REFERENCES import_queue(id) ON DELETE CASCADE;
+-- +goose StatementEnd
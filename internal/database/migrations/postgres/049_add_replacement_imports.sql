@@ -0,0 +1,18 @@
+-- +goose Up
+-- replacement_imports links an alternate-release import to the corrupted
+-- file it replaces. The corrupted file and its health record are kept until
+-- the import succeeds; the post-processor then retires them.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS replacement_imports (
+    queue_id   BIGINT NOT NULL PRIMARY KEY REFERENCES import_queue(id) ON DELETE CASCADE,
+    file_path  TEXT   NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_replacement_imports_file_path ON replacement_imports(file_path);
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS replacement_imports;
+-- +goose StatementEnd
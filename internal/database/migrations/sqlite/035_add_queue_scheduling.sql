@@ -0,0 +1,12 @@
+-- +goose Up
+-- hold_until keeps a pending item out of the claim query until the timestamp
+-- passes; deprioritized sorts an item behind its priority peers after a slow
+-- fast-fail sampling pass.
+ALTER TABLE import_queue ADD COLUMN hold_until DATETIME DEFAULT NULL;
+ALTER TABLE import_queue ADD COLUMN deprioritized BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_queue_claim_order ON import_queue(status, priority, deprioritized, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_queue_claim_order;
+-- SQLite does not support DROP COLUMN in older versions; intentional no-op
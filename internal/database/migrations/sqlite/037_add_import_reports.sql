@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS import_reports (
+    queue_id   INTEGER  NOT NULL PRIMARY KEY REFERENCES import_queue(id) ON DELETE CASCADE,
+    status     TEXT     NOT NULL,
+    report     BLOB     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+    updated_at DATETIME NOT NULL DEFAULT (datetime('now'))
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS import_reports;
+-- +goose StatementEnd
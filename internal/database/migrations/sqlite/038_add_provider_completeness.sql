@@ -0,0 +1,8 @@
+-- +goose Up
+-- provider_completeness holds a JSON array of per-provider sample results
+-- ({provider, sampled, missing, completeness}) from the fast-fail sweep.
+ALTER TABLE import_queue ADD COLUMN provider_completeness TEXT DEFAULT NULL;
+ALTER TABLE file_health ADD COLUMN provider_completeness TEXT DEFAULT NULL;
+
+-- +goose Down
+-- SQLite does not support DROP COLUMN in older versions; intentional no-op
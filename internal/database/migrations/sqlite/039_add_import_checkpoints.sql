@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS import_checkpoints (
+    queue_id    INTEGER  NOT NULL REFERENCES import_queue(id) ON DELETE CASCADE,
+    stage       TEXT     NOT NULL,
+    fingerprint TEXT     NOT NULL,
+    data        BLOB     NOT NULL,
+    created_at  DATETIME NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (queue_id, stage)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS import_checkpoints;
+-- +goose StatementEnd
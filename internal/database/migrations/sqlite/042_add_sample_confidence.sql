@@ -0,0 +1,7 @@
+-- +goose Up
+-- sample_confidence is the JSON missing-rate estimate and confidence interval
+-- of the file's last sampled health check (see database.SampleConfidence).
+ALTER TABLE file_health ADD COLUMN sample_confidence TEXT DEFAULT NULL;
+
+-- +goose Down
+-- SQLite does not support DROP COLUMN in older versions; intentional no-op
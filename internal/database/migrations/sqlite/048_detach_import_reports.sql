@@ -0,0 +1,41 @@
+-- +goose Up
+-- Import reports outlive their queue item: a failed item is purged after
+-- failed_item_retention_hours, but its report is what a post-mortem needs.
+-- Reports are pruned on their own with the import history instead.
+-- SQLite cannot drop a foreign key, so the table is recreated without it.
+-- +goose StatementBegin
+CREATE TABLE import_reports_new (
+    queue_id   INTEGER  NOT NULL PRIMARY KEY,
+    status     TEXT     NOT NULL,
+    report     BLOB     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+    updated_at DATETIME NOT NULL DEFAULT (datetime('now'))
+);
+-- +goose StatementEnd
+
+INSERT INTO import_reports_new SELECT queue_id, status, report, created_at, updated_at FROM import_reports;
+
+DROP TABLE import_reports;
+
+ALTER TABLE import_reports_new RENAME TO import_reports;
+
+CREATE INDEX IF NOT EXISTS idx_import_reports_updated_at ON import_reports(updated_at);
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE import_reports_old (
+    queue_id   INTEGER  NOT NULL PRIMARY KEY REFERENCES import_queue(id) ON DELETE CASCADE,
+    status     TEXT     NOT NULL,
+    report     BLOB     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+    updated_at DATETIME NOT NULL DEFAULT (datetime('now'))
+);
+-- +goose StatementEnd
+
+INSERT INTO import_reports_old
+SELECT queue_id, status, report, created_at, updated_at FROM import_reports
+WHERE queue_id IN (SELECT id FROM import_queue);
+
+DROP TABLE import_reports;
+
+ALTER TABLE import_reports_old RENAME TO import_reports;
@@ -51,6 +51,23 @@ type ImportQueueItem struct {
 	SkipArrNotification bool          `db:"skip_arr_notification"`
 	SkipPostImportLinks bool          `db:"skip_post_import_links"`
 	Indexer             *string       `db:"indexer"`
+	// HoldUntil keeps a pending item from being claimed before this time.
+	HoldUntil *time.Time `db:"hold_until"`
+	// Deprioritized sorts the item behind others of the same priority; set
+	// when its fast-fail sampling pass was slow on the configured providers.
+	Deprioritized bool `db:"deprioritized"`
+}
+
+// ClaimRules restricts which pending items ClaimNextQueueItemWithRules may pick.
+// The zero value applies no restrictions beyond hold_until.
+type ClaimRules struct {
+	// BlockedCategories are categories whose scheduling window is closed.
+	BlockedCategories []string
+	// CategoryLimits caps how many items per category may be processing at
+	// once. Categories absent from the map (or with a limit <= 0) are unlimited.
+	CategoryLimits map[string]int
+	// DefaultCategory is the category name NULL/empty item categories count as.
+	DefaultCategory string
 }
 
 // BulkOperationResult represents the result of a bulk queue operation
@@ -121,6 +138,31 @@ type FileHealth struct {
 	IsMasked              bool    `db:"is_masked"`
 	Indexer               *string `db:"indexer"`
 	DownloadID            *string `db:"download_id"`
+	// ProviderCompleteness is the JSON per-provider sample result of the
+	// import that produced the file (see ProviderCompleteness).
+	ProviderCompleteness *string `db:"provider_completeness"`
+	// Article-expiry model fields: the posting the file came from, when a
+	// check first found it missing segments, and the predicted risk and
+	// reason behind its current schedule.
+	Newsgroup       *string    `db:"newsgroup"`
+	Poster          *string    `db:"poster"`
+	FirstLossAt     *time.Time `db:"first_loss_at"`
+	ExpiryRisk      *float64   `db:"expiry_risk"`
+	NextCheckReason *string    `db:"next_check_reason"`
+	// Playback fields: when and how often the file was played, and whether
+	// it is partially watched ("continue watching").
+	LastStreamedAt   *time.Time `db:"last_streamed_at"`
+	StreamCount      int        `db:"stream_count"`
+	ContinueWatching bool       `db:"continue_watching"`
+	// SampleConfidence is the JSON missing-rate estimate of the last sampled
+	// check (see SampleConfidence).
+	SampleConfidence *string `db:"sample_confidence"`
+	// RepairState is where the file is in the repair state machine; its
+	// transitions are logged in repair_events.
+	RepairState RepairState `db:"repair_state"`
+	// Repairability is the JSON PAR2 recovery estimate of the last check
+	// that found missing segments (see Repairability).
+	Repairability *string `db:"repairability"`
 }
 
 // IsImported reports whether library_path points to a real, ARR-relinked library
@@ -193,6 +235,7 @@ type ImportHistory struct {
 	Category            *string   `db:"category"`
 	Metadata            *string   `db:"metadata"`
 	Indexer             *string   `db:"indexer"`
+	OriginalNzbName     *string   `db:"original_nzb_name"` // Obfuscated NZB filename when NzbName was recovered
 	CompletedAt         time.Time `db:"completed_at"`
 }
 
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// PlaybackRecentWindow is how long after its last playback a file still
+	// counts as recently played for check and repair ordering.
+	PlaybackRecentWindow = 30 * 24 * time.Hour
+	// playbackFrequentCount is the play count from which a file counts as
+	// frequently played, however long ago it was last played.
+	playbackFrequentCount = 3
+	// playbackSessionGap separates two plays of a file: streams (and the
+	// parallel connections of one stream) closer together are one play.
+	playbackSessionGap = time.Hour
+)
+
+// playbackRankSQL orders files by how much their playback matters: continue
+// watching first, then recently or frequently played, then played once long
+// ago, then never played. It takes one parameter, the recent-play cutoff
+// (see playbackCutoff).
+var playbackRankSQL = fmt.Sprintf(`(CASE
+			WHEN continue_watching THEN 0
+			WHEN last_streamed_at >= ? OR stream_count >= %d THEN 1
+			WHEN last_streamed_at IS NOT NULL THEN 2
+			ELSE 3
+		END)`, playbackFrequentCount)
+
+// playbackCutoff is the parameter of playbackRankSQL.
+func playbackCutoff() string {
+	return time.Now().UTC().Add(-PlaybackRecentWindow).Format("2006-01-02 15:04:05")
+}
+
+// Playback is one observed playback of a file, from a stream or from a media
+// server's watch state.
+type Playback struct {
+	FilePath string
+	At       time.Time
+	// InProgress marks a partially watched file, one a media server lists
+	// under "continue watching".
+	InProgress bool
+}
+
+// RecordPlayback records a playback of a file. Only a play more than
+// playbackSessionGap after the last one counts again, so an older play (a
+// media server's history) neither moves last_streamed_at back nor overrides
+// the newer continue-watching state. Files without a health record are
+// ignored.
+func (r *HealthRepository) RecordPlayback(ctx context.Context, p Playback) error {
+	at := p.At.UTC().Format("2006-01-02 15:04:05")
+	sessionStart := p.At.UTC().Add(-playbackSessionGap).Format("2006-01-02 15:04:05")
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE file_health
+		SET stream_count = stream_count + CASE WHEN last_streamed_at IS NULL OR last_streamed_at < ? THEN 1 ELSE 0 END,
+		    last_streamed_at = CASE WHEN last_streamed_at IS NULL OR last_streamed_at < ? THEN ? ELSE last_streamed_at END,
+		    continue_watching = CASE WHEN last_streamed_at IS NULL OR last_streamed_at <= ? THEN ? ELSE continue_watching END
+		WHERE file_path = ?
+	`, sessionStart, at, at, at, p.InProgress, normalizeHealthPath(p.FilePath))
+	if err != nil {
+		return fmt.Errorf("failed to record playback: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordPlayback_CountsSessions(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 46)
+	repo := NewHealthRepository(db, DialectSQLite)
+
+	_, err := db.Exec(`INSERT INTO file_health (file_path, status) VALUES ('movies/a.mkv', 'healthy')`)
+	require.NoError(t, err)
+
+	start := time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Second)
+	require.NoError(t, repo.RecordPlayback(ctx, Playback{FilePath: "/movies/a.mkv", At: start, InProgress: true}))
+	// A second connection of the same session.
+	require.NoError(t, repo.RecordPlayback(ctx, Playback{FilePath: "/movies/a.mkv", At: start.Add(10 * time.Minute), InProgress: true}))
+	// The next day the file is watched to the end.
+	require.NoError(t, repo.RecordPlayback(ctx, Playback{FilePath: "/movies/a.mkv", At: start.Add(24 * time.Hour)}))
+	// Older media-server history neither counts again nor rewinds or resumes it.
+	require.NoError(t, repo.RecordPlayback(ctx, Playback{FilePath: "/movies/a.mkv", At: start.Add(-72 * time.Hour), InProgress: true}))
+	// Playback of a file without a health record is ignored.
+	require.NoError(t, repo.RecordPlayback(ctx, Playback{FilePath: "/movies/unknown.mkv", At: start}))
+
+	fh, err := repo.GetFileHealth(ctx, "movies/a.mkv")
+	require.NoError(t, err)
+	require.NotNil(t, fh)
+	assert.Equal(t, 2, fh.StreamCount)
+	require.NotNil(t, fh.LastStreamedAt)
+	assert.True(t, fh.LastStreamedAt.Equal(start.Add(24*time.Hour)), "got %v", fh.LastStreamedAt)
+	assert.False(t, fh.ContinueWatching)
+}
+
+func TestGetUnhealthyFiles_PlayedFilesFirst(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 46)
+	repo := NewHealthRepository(db, DialectSQLite)
+
+	// All due; the never-played file is the most overdue.
+	_, err := db.Exec(`
+		INSERT INTO file_health (file_path, status, scheduled_check_at, last_streamed_at, stream_count, continue_watching)
+		VALUES
+		  ('never.mkv',    'healthy', datetime('now', '-5 days'), NULL,                         0, FALSE),
+		  ('old.mkv',      'healthy', datetime('now', '-4 days'), datetime('now', '-200 days'), 1, FALSE),
+		  ('recent.mkv',   'healthy', datetime('now', '-3 days'), datetime('now', '-2 days'),   1, FALSE),
+		  ('frequent.mkv', 'healthy', datetime('now', '-2 days'), datetime('now', '-100 days'), 5, FALSE),
+		  ('resume.mkv',   'healthy', datetime('now', '-1 days'), datetime('now', '-90 days'),  1, TRUE)
+	`)
+	require.NoError(t, err)
+
+	files, err := repo.GetUnhealthyFiles(ctx, 10, "NONE", "/library", 5)
+	require.NoError(t, err)
+
+	var got []string
+	for _, f := range files {
+		got = append(got, f.FilePath)
+	}
+	assert.Equal(t, []string{"resume.mkv", "recent.mkv", "frequent.mkv", "old.mkv", "never.mkv"}, got)
+}
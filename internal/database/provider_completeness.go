@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ProviderCompleteness is one provider's share of a sampled segment set, as
+// stored (JSON array) in import_queue.provider_completeness and
+// file_health.provider_completeness.
+type ProviderCompleteness struct {
+	Provider string `json:"provider"`
+	Sampled  int    `json:"sampled"`
+	Missing  int    `json:"missing"`
+	// Completeness is the fraction of sampled segments the provider holds
+	// (0..1). Providers that could not be sampled have Sampled == 0 and
+	// Completeness 0.
+	Completeness float64 `json:"completeness"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// NewProviderCompleteness builds an entry from a sample result.
+func NewProviderCompleteness(provider string, sampled, missing int) ProviderCompleteness {
+	pc := ProviderCompleteness{Provider: provider, Sampled: sampled, Missing: missing}
+	if sampled > 0 {
+		pc.Completeness = float64(sampled-missing) / float64(sampled)
+	}
+	return pc
+}
+
+// MarshalProviderCompleteness renders the entries for storage, returning nil
+// for an empty list so the column stays NULL.
+func MarshalProviderCompleteness(entries []ProviderCompleteness) *string {
+	if len(entries) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+// ParseProviderCompleteness decodes a stored column value. NULL, empty or
+// malformed values yield nil.
+func ParseProviderCompleteness(raw *string) []ProviderCompleteness {
+	if raw == nil || *raw == "" {
+		return nil
+	}
+	var entries []ProviderCompleteness
+	if err := json.Unmarshal([]byte(*raw), &entries); err != nil {
+		return nil
+	}
+	return entries
+}
+
+// PrimaryProvider is the provider holding the largest share of the sample,
+// or "" when no provider was sampled. Ties keep the first entry.
+func PrimaryProvider(entries []ProviderCompleteness) string {
+	best := -1
+	for i, e := range entries {
+		if e.Sampled == 0 {
+			continue
+		}
+		if best < 0 || e.Completeness > entries[best].Completeness {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return entries[best].Provider
+}
+
+// SetQueueItemProviderCompleteness stores the per-provider sample results of
+// an import on its queue item.
+func (r *QueueRepository) SetQueueItemProviderCompleteness(ctx context.Context, id int64, entries []ProviderCompleteness) error {
+	query := `UPDATE import_queue SET provider_completeness = ? WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, MarshalProviderCompleteness(entries), id); err != nil {
+		return fmt.Errorf("failed to set provider completeness for queue item %d: %w", id, err)
+	}
+	return nil
+}
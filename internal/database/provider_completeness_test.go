@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProviderCompleteness(t *testing.T) {
+	pc := NewProviderCompleteness("backup", 50, 6)
+	assert.Equal(t, "backup", pc.Provider)
+	assert.InDelta(t, 0.88, pc.Completeness, 1e-9)
+
+	unsampled := NewProviderCompleteness("down", 0, 0)
+	assert.Zero(t, unsampled.Completeness, "an unsampled provider has no completeness estimate")
+}
+
+func TestParseProviderCompleteness(t *testing.T) {
+	assert.Nil(t, MarshalProviderCompleteness(nil), "empty list stores NULL")
+	assert.Nil(t, ParseProviderCompleteness(nil))
+
+	bad := "not json"
+	assert.Nil(t, ParseProviderCompleteness(&bad))
+
+	raw := MarshalProviderCompleteness([]ProviderCompleteness{
+		NewProviderCompleteness("primary", 20, 0),
+		{Provider: "backup", Error: "timeout"},
+	})
+	require.NotNil(t, raw)
+	got := ParseProviderCompleteness(raw)
+	require.Len(t, got, 2)
+	assert.Equal(t, 1.0, got[0].Completeness)
+	assert.Equal(t, "timeout", got[1].Error)
+}
+
+func TestBatchAddFileToHealthCheck_StoresProviderCompleteness(t *testing.T) {
+	repo := setupTestDB(t)
+	ctx := context.Background()
+
+	lib := "movies/Movie/Movie.mkv"
+	pc := MarshalProviderCompleteness([]ProviderCompleteness{
+		NewProviderCompleteness("primary", 10, 0),
+		NewProviderCompleteness("backup", 10, 3),
+	})
+	require.NoError(t, repo.BatchAddFileToHealthCheck(ctx, []HealthCheckUpsert{
+		{FilePath: lib, LibraryPath: &lib, Priority: HealthPriorityNext, MaxRetries: 3, MaxRepairRetries: 3, ProviderCompleteness: pc},
+	}))
+
+	h, err := repo.GetFileHealth(ctx, lib)
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	got := ParseProviderCompleteness(h.ProviderCompleteness)
+	require.Len(t, got, 2)
+	assert.Equal(t, "backup", got[1].Provider)
+	assert.InDelta(t, 0.7, got[1].Completeness, 1e-9)
+
+	// A re-import without a per-provider sample keeps the previous estimate.
+	require.NoError(t, repo.BatchAddFileToHealthCheck(ctx, []HealthCheckUpsert{
+		{FilePath: lib, LibraryPath: &lib, Priority: HealthPriorityNext, MaxRetries: 3, MaxRepairRetries: 3},
+	}))
+	h, err = repo.GetFileHealth(ctx, lib)
+	require.NoError(t, err)
+	assert.Len(t, ParseProviderCompleteness(h.ProviderCompleteness), 2)
+}
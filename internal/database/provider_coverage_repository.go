@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ProviderCoverage is one provider's answer for a file's audited sample.
+type ProviderCoverage struct {
+	Provider string `json:"provider"`
+	// Checked counts the sampled articles the provider answered for
+	// (held or definitively missing).
+	Checked   int `json:"checked"`
+	Available int `json:"available"`
+	// Unique counts the articles the provider held when no other provider did.
+	Unique int `json:"unique"`
+}
+
+// ProviderCoverageTotals aggregates one provider's audits over a window.
+type ProviderCoverageTotals struct {
+	Provider  string
+	Files     int
+	Checked   int
+	Available int
+	Unique    int
+	// FilesWithUnique counts the files the provider held an article of that
+	// no other provider did.
+	FilesWithUnique int
+	LastCheckedAt   *time.Time
+	LastUniqueAt    *time.Time
+}
+
+// RecordProviderCoverage stores the per-provider outcome of one audited check.
+func (r *HealthRepository) RecordProviderCoverage(ctx context.Context, filePath string, entries []ProviderCoverage) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	filePath = normalizeHealthPath(filePath)
+	for _, e := range entries {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO provider_coverage (file_path, provider, checked, available, unique_available)
+			VALUES (?, ?, ?, ?, ?)
+		`, filePath, e.Provider, e.Checked, e.Available, e.Unique); err != nil {
+			return fmt.Errorf("failed to record provider coverage: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetProviderCoverageTotals aggregates the audits recorded since `since`,
+// one row per provider.
+func (r *HealthRepository) GetProviderCoverageTotals(ctx context.Context, since time.Time) ([]ProviderCoverageTotals, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT provider,
+		       COUNT(DISTINCT file_path),
+		       SUM(checked), SUM(available), SUM(unique_available),
+		       COUNT(DISTINCT CASE WHEN unique_available > 0 THEN file_path END),
+		       MAX(checked_at),
+		       MAX(CASE WHEN unique_available > 0 THEN checked_at END)
+		FROM provider_coverage
+		WHERE checked_at >= ?
+		GROUP BY provider
+		ORDER BY provider
+	`, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query provider coverage: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []ProviderCoverageTotals
+	for rows.Next() {
+		var t ProviderCoverageTotals
+		var lastChecked, lastUnique sql.NullString
+		if err := rows.Scan(&t.Provider, &t.Files, &t.Checked, &t.Available, &t.Unique,
+			&t.FilesWithUnique, &lastChecked, &lastUnique); err != nil {
+			return nil, fmt.Errorf("failed to scan provider coverage: %w", err)
+		}
+		t.LastCheckedAt = parseAggregateTime(lastChecked)
+		t.LastUniqueAt = parseAggregateTime(lastUnique)
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate provider coverage: %w", err)
+	}
+	return totals, nil
+}
+
+// PruneProviderCoverage deletes the audits recorded before `before`.
+func (r *HealthRepository) PruneProviderCoverage(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM provider_coverage WHERE checked_at < ?`,
+		before.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune provider coverage: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// parseAggregateTime parses a MAX() over a timestamp column, which loses the
+// column type and comes back as text on SQLite.
+func parseAggregateTime(s sql.NullString) *time.Time {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04:05.999999999-07:00"} {
+		if t, err := time.Parse(layout, s.String); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderCoverageTotals(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 47)
+	repo := NewHealthRepository(db, DialectSQLite)
+
+	require.NoError(t, repo.RecordProviderCoverage(ctx, "/movies/a.mkv", []ProviderCoverage{
+		{Provider: "main", Checked: 10, Available: 10},
+		{Provider: "block", Checked: 10, Available: 8},
+	}))
+	require.NoError(t, repo.RecordProviderCoverage(ctx, "movies/b.mkv", []ProviderCoverage{
+		{Provider: "main", Checked: 10, Available: 7},
+		{Provider: "block", Checked: 10, Available: 9, Unique: 2},
+	}))
+	// An audit outside the window.
+	_, err := db.Exec(`
+		INSERT INTO provider_coverage (file_path, provider, checked, available, unique_available, checked_at)
+		VALUES ('movies/old.mkv', 'block', 10, 10, 5, datetime('now', '-40 days'))
+	`)
+	require.NoError(t, err)
+
+	totals, err := repo.GetProviderCoverageTotals(ctx, time.Now().Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, totals, 2)
+
+	block, main := totals[0], totals[1]
+	assert.Equal(t, "block", block.Provider)
+	assert.Equal(t, 2, block.Files)
+	assert.Equal(t, 17, block.Available)
+	assert.Equal(t, 2, block.Unique)
+	assert.Equal(t, 1, block.FilesWithUnique)
+	assert.NotNil(t, block.LastUniqueAt)
+
+	assert.Equal(t, "main", main.Provider)
+	assert.Equal(t, 20, main.Checked)
+	assert.Zero(t, main.Unique)
+	assert.Nil(t, main.LastUniqueAt)
+	assert.NotNil(t, main.LastCheckedAt)
+
+	pruned, err := repo.PruneProviderCoverage(ctx, time.Now().Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+}
@@ -257,20 +257,44 @@ func (r *QueueRepository) IsFileInQueue(ctx context.Context, filePath string) (b
 
 // ClaimNextQueueItem atomically claims and returns the next available queue item
 func (r *QueueRepository) ClaimNextQueueItem(ctx context.Context) (*ImportQueueItem, error) {
+	return r.ClaimNextQueueItemWithRules(ctx, ClaimRules{})
+}
+
+// ClaimNextQueueItemWithRules atomically claims the next pending item that is
+// not on hold, not in a blocked category and whose category is below its
+// concurrency cap. Items that cannot run are skipped in SQL rather than claimed
+// and released, so workers never spin on them.
+func (r *QueueRepository) ClaimNextQueueItemWithRules(ctx context.Context, rules ClaimRules) (*ImportQueueItem, error) {
 	// Use immediate transaction to atomically claim an item
 	var claimedItem *ImportQueueItem
 
 	err := r.withQueueTransaction(ctx, func(txRepo *QueueRepository) error {
+		blocked, err := txRepo.blockedCategories(ctx, rules)
+		if err != nil {
+			return err
+		}
+
 		// First, get the next available item ID within the transaction
-		var itemID int64
 		selectQuery := `
 			SELECT id FROM import_queue
 			WHERE status = 'pending'
-			ORDER BY priority ASC, created_at ASC
+			  AND (hold_until IS NULL OR hold_until <= datetime('now'))`
+		var args []any
+		if len(blocked) > 0 {
+			selectQuery += fmt.Sprintf(`
+			  AND COALESCE(NULLIF(category, ''), ?) NOT IN (%s)`, inPlaceholders(len(blocked)))
+			args = append(args, rules.DefaultCategory)
+			for _, c := range blocked {
+				args = append(args, c)
+			}
+		}
+		selectQuery += `
+			ORDER BY priority ASC, deprioritized ASC, created_at ASC
 			LIMIT 1
 		`
 
-		err := txRepo.db.QueryRowContext(ctx, selectQuery).Scan(&itemID)
+		var itemID int64
+		err = txRepo.db.QueryRowContext(ctx, selectQuery, args...).Scan(&itemID)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				// No items available
@@ -304,7 +328,7 @@ func (r *QueueRepository) ClaimNextQueueItem(ctx context.Context) (*ImportQueueI
 		// Get the complete claimed item data
 		getQuery := `
 			SELECT id, download_id, nzb_path, relative_path, category, priority, status, created_at, updated_at,
-			       started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer
+			       started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer, hold_until, deprioritized
 			FROM import_queue
 			WHERE id = ?
 		`
@@ -313,7 +337,7 @@ func (r *QueueRepository) ClaimNextQueueItem(ctx context.Context) (*ImportQueueI
 		err = txRepo.db.QueryRowContext(ctx, getQuery, itemID).Scan(
 			&item.ID, &item.DownloadID, &item.NzbPath, &item.RelativePath, &item.Category, &item.Priority, &item.Status,
 			&item.CreatedAt, &item.UpdatedAt, &item.StartedAt, &item.CompletedAt,
-			&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer,
+			&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer, &item.HoldUntil, &item.Deprioritized,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to get claimed item: %w", err)
@@ -330,6 +354,75 @@ func (r *QueueRepository) ClaimNextQueueItem(ctx context.Context) (*ImportQueueI
 	return claimedItem, nil
 }
 
+// categoryClaimLockKey is the Postgres advisory lock serializing capped claims.
+const categoryClaimLockKey = 0x616c74636c61696d // "altclaim"
+
+// blockedCategories returns the categories the claim query must skip: those
+// outside their scheduling window plus those already at their concurrency cap.
+// Runs inside the claim transaction. With caps it first takes the claim lock,
+// so two workers cannot both count a category below its cap and both claim.
+func (r *QueueRepository) blockedCategories(ctx context.Context, rules ClaimRules) ([]string, error) {
+	blocked := append([]string(nil), rules.BlockedCategories...)
+	if len(rules.CategoryLimits) == 0 {
+		return blocked, nil
+	}
+
+	if err := r.lockCategoryClaims(ctx); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT COALESCE(NULLIF(category, ''), ?), COUNT(*)
+		FROM import_queue
+		WHERE status = 'processing'
+		GROUP BY COALESCE(NULLIF(category, ''), ?)
+	`
+	rows, err := r.db.QueryContext(ctx, query, rules.DefaultCategory, rules.DefaultCategory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count processing items per category: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category string
+		var count int
+		if err := rows.Scan(&category, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan category count: %w", err)
+		}
+		if limit := rules.CategoryLimits[category]; limit > 0 && count >= limit {
+			blocked = append(blocked, category)
+		}
+	}
+
+	return blocked, rows.Err()
+}
+
+// lockCategoryClaims serializes capped claims until the transaction ends. On
+// Postgres it takes a transaction-scoped advisory lock. On SQLite a no-op write
+// takes the database write lock before anything is read: a deferred
+// transaction that read first could not upgrade once another claim committed.
+func (r *QueueRepository) lockCategoryClaims(ctx context.Context) error {
+	var err error
+	if r.dialect.IsPostgres() {
+		_, err = r.db.ExecContext(ctx, `SELECT pg_advisory_xact_lock(?)`, int64(categoryClaimLockKey))
+	} else {
+		_, err = r.db.ExecContext(ctx, `UPDATE import_queue SET status = status WHERE 0`)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock category claims: %w", err)
+	}
+	return nil
+}
+
+// SetQueueItemDeprioritized flags a queue item so it sorts behind its priority peers.
+func (r *QueueRepository) SetQueueItemDeprioritized(ctx context.Context, id int64, deprioritized bool) error {
+	query := `UPDATE import_queue SET deprioritized = ?, updated_at = datetime('now') WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, deprioritized, id); err != nil {
+		return fmt.Errorf("failed to update queue item deprioritized flag: %w", err)
+	}
+	return nil
+}
+
 // UpdateQueueItemStatus updates the status of a queue item
 func (r *QueueRepository) UpdateQueueItemStatus(ctx context.Context, id int64, status QueueStatus, errorMessage *string) error {
 	now := time.Now()
@@ -483,12 +576,12 @@ func (r *QueueRepository) GetImportHistory(ctx context.Context, days int) ([]*Im
 // AddImportHistory records a successful file import in the persistent history table
 func (r *QueueRepository) AddImportHistory(ctx context.Context, history *ImportHistory) error {
 	query := `
-		INSERT INTO import_history (download_id, nzb_id, nzb_name, file_name, file_size, virtual_path, category, metadata, indexer, completed_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'))
+		INSERT INTO import_history (download_id, nzb_id, nzb_name, file_name, file_size, virtual_path, category, metadata, indexer, original_nzb_name, completed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'))
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		history.DownloadID, history.NzbID, history.NzbName, history.FileName, history.FileSize,
-		history.VirtualPath, history.Category, history.Metadata, history.Indexer)
+		history.VirtualPath, history.Category, history.Metadata, history.Indexer, history.OriginalNzbName)
 	if err != nil {
 		return fmt.Errorf("failed to add import history: %w", err)
 	}
@@ -498,7 +591,7 @@ func (r *QueueRepository) AddImportHistory(ctx context.Context, history *ImportH
 // ListImportHistory retrieves the last N successful imports from the persistent history
 func (r *QueueRepository) ListImportHistory(ctx context.Context, limit int) ([]*ImportHistory, error) {
 	query := `
-		SELECT h.id, h.download_id, h.nzb_id, h.nzb_name, h.file_name, h.file_size, h.virtual_path, f.library_path, h.category, h.metadata, h.indexer, h.completed_at
+		SELECT h.id, h.download_id, h.nzb_id, h.nzb_name, h.file_name, h.file_size, h.virtual_path, f.library_path, h.category, h.metadata, h.indexer, h.original_nzb_name, h.completed_at
 		FROM import_history h
 		LEFT JOIN file_health f ON h.virtual_path = f.file_path
 		ORDER BY h.completed_at DESC
@@ -513,7 +606,7 @@ func (r *QueueRepository) ListImportHistory(ctx context.Context, limit int) ([]*
 	var history []*ImportHistory
 	for rows.Next() {
 		var h ImportHistory
-		err := rows.Scan(&h.ID, &h.DownloadID, &h.NzbID, &h.NzbName, &h.FileName, &h.FileSize, &h.VirtualPath, &h.LibraryPath, &h.Category, &h.Metadata, &h.Indexer, &h.CompletedAt)
+		err := rows.Scan(&h.ID, &h.DownloadID, &h.NzbID, &h.NzbName, &h.FileName, &h.FileSize, &h.VirtualPath, &h.LibraryPath, &h.Category, &h.Metadata, &h.Indexer, &h.OriginalNzbName, &h.CompletedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan import history: %w", err)
 		}
@@ -595,7 +688,7 @@ func (r *QueueRepository) GetPendingQueueItemsByPathPrefix(ctx context.Context,
 func (r *QueueRepository) GetQueueItemByNzbPath(ctx context.Context, nzbPath string) (*ImportQueueItem, error) {
 	query := `
 		SELECT id, download_id, nzb_path, relative_path, category, priority, status, created_at, updated_at,
-		       started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer
+		       started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer, hold_until, deprioritized
 		FROM import_queue WHERE nzb_path = ? LIMIT 1
 	`
 
@@ -603,7 +696,7 @@ func (r *QueueRepository) GetQueueItemByNzbPath(ctx context.Context, nzbPath str
 	err := r.db.QueryRowContext(ctx, query, nzbPath).Scan(
 		&item.ID, &item.DownloadID, &item.NzbPath, &item.RelativePath, &item.Category, &item.Priority, &item.Status,
 		&item.CreatedAt, &item.UpdatedAt, &item.StartedAt, &item.CompletedAt,
-		&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer,
+		&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer, &item.HoldUntil, &item.Deprioritized,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
@@ -751,7 +844,7 @@ func (r *QueueRepository) AddBatchToQueue(ctx context.Context, items []*ImportQu
 func (r *QueueRepository) GetQueueItem(ctx context.Context, id int64) (*ImportQueueItem, error) {
 	query := `
 		SELECT id, download_id, nzb_path, relative_path, category, priority, status, created_at, updated_at,
-		       started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer
+		       started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer, hold_until, deprioritized
 		FROM import_queue WHERE id = ?
 	`
 
@@ -759,7 +852,7 @@ func (r *QueueRepository) GetQueueItem(ctx context.Context, id int64) (*ImportQu
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&item.ID, &item.DownloadID, &item.NzbPath, &item.RelativePath, &item.Category, &item.Priority, &item.Status,
 		&item.CreatedAt, &item.UpdatedAt, &item.StartedAt, &item.CompletedAt,
-		&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer,
+		&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer, &item.HoldUntil, &item.Deprioritized,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -775,7 +868,7 @@ func (r *QueueRepository) GetQueueItem(ctx context.Context, id int64) (*ImportQu
 func (r *QueueRepository) GetQueueItemByDownloadID(ctx context.Context, downloadID string) (*ImportQueueItem, error) {
 	query := `
 		SELECT id, download_id, nzb_path, relative_path, category, priority, status, created_at, updated_at,
-		       started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer
+		       started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer, hold_until, deprioritized
 		FROM import_queue WHERE download_id = ?
 	`
 
@@ -783,7 +876,7 @@ func (r *QueueRepository) GetQueueItemByDownloadID(ctx context.Context, download
 	err := r.db.QueryRowContext(ctx, query, downloadID).Scan(
 		&item.ID, &item.DownloadID, &item.NzbPath, &item.RelativePath, &item.Category, &item.Priority, &item.Status,
 		&item.CreatedAt, &item.UpdatedAt, &item.StartedAt, &item.CompletedAt,
-		&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer,
+		&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer, &item.HoldUntil, &item.Deprioritized,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -833,7 +926,7 @@ func (r *QueueRepository) DeleteFailedItemsOlderThan(ctx context.Context, olderT
 	err := r.withQueueTransaction(ctx, func(txRepo *QueueRepository) error {
 		// Select failed items older than the threshold
 		selectQuery := `SELECT id, download_id, nzb_path, relative_path, category, priority, status, created_at, updated_at,
-			started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer
+			started_at, completed_at, retry_count, max_retries, error_message, batch_id, metadata, file_size, storage_path, target_path, skip_arr_notification, skip_post_import_links, indexer, hold_until, deprioritized
 			FROM import_queue WHERE status = 'failed' AND updated_at < ?`
 
 		rows, err := txRepo.db.QueryContext(ctx, selectQuery, olderThan)
@@ -847,7 +940,7 @@ func (r *QueueRepository) DeleteFailedItemsOlderThan(ctx context.Context, olderT
 			if err := rows.Scan(
 				&item.ID, &item.DownloadID, &item.NzbPath, &item.RelativePath, &item.Category, &item.Priority, &item.Status,
 				&item.CreatedAt, &item.UpdatedAt, &item.StartedAt, &item.CompletedAt,
-				&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer,
+				&item.RetryCount, &item.MaxRetries, &item.ErrorMessage, &item.BatchID, &item.Metadata, &item.FileSize, &item.StoragePath, &item.TargetPath, &item.SkipArrNotification, &item.SkipPostImportLinks, &item.Indexer, &item.HoldUntil, &item.Deprioritized,
 			); err != nil {
 				return fmt.Errorf("failed to scan failed queue item: %w", err)
 			}
@@ -3,6 +3,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -197,3 +201,122 @@ func TestResetStaleItems_UpdatedAtFieldUpdated(t *testing.T) {
 	assert.True(t, newUpdatedAt.After(originalUpdatedAt),
 		"updated_at should be updated when item is reset")
 }
+
+func TestClaimNextQueueItemWithRules_SkipsHeldItems(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	setupQueueSchema(t, db)
+	insertQueueItem(t, db, 1, "held.nzb", "pending")
+	insertQueueItem(t, db, 2, "ready.nzb", "pending")
+
+	future := time.Now().UTC().Add(time.Hour).Format("2006-01-02 15:04:05")
+	_, err = db.Exec(`UPDATE import_queue SET hold_until = ? WHERE id = 1`, future)
+	require.NoError(t, err)
+
+	repo := NewQueueRepository(db, DialectSQLite)
+
+	item, err := repo.ClaimNextQueueItemWithRules(context.Background(), ClaimRules{})
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, int64(2), item.ID, "held item must be skipped")
+
+	item, err = repo.ClaimNextQueueItemWithRules(context.Background(), ClaimRules{})
+	require.NoError(t, err)
+	assert.Nil(t, item, "only the held item remains")
+}
+
+func TestClaimNextQueueItemWithRules_CategoryWindowAndLimit(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	setupQueueSchema(t, db)
+	insertQueueItem(t, db, 1, "backfill.nzb", "pending")
+	insertQueueItem(t, db, 2, "tv-running.nzb", "processing")
+	insertQueueItem(t, db, 3, "tv-next.nzb", "pending")
+	insertQueueItem(t, db, 4, "default.nzb", "pending")
+	_, err = db.Exec(`UPDATE import_queue SET category = 'backfill' WHERE id = 1`)
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE import_queue SET category = 'tv' WHERE id IN (2, 3)`)
+	require.NoError(t, err)
+
+	repo := NewQueueRepository(db, DialectSQLite)
+	rules := ClaimRules{
+		BlockedCategories: []string{"backfill"},
+		CategoryLimits:    map[string]int{"tv": 1, "Default": 1},
+		DefaultCategory:   "Default",
+	}
+
+	item, err := repo.ClaimNextQueueItemWithRules(context.Background(), rules)
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, int64(4), item.ID, "blocked and capped categories must be skipped")
+
+	// Default is now at its cap as well, so nothing is claimable.
+	item, err = repo.ClaimNextQueueItemWithRules(context.Background(), rules)
+	require.NoError(t, err)
+	assert.Nil(t, item)
+}
+
+func TestClaimNextQueueItemWithRules_DeprioritizedSortsLast(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	setupQueueSchema(t, db)
+	insertQueueItem(t, db, 1, "slow.nzb", "pending")
+	insertQueueItem(t, db, 2, "fast.nzb", "pending")
+
+	repo := NewQueueRepository(db, DialectSQLite)
+	require.NoError(t, repo.SetQueueItemDeprioritized(context.Background(), 1, true))
+
+	item, err := repo.ClaimNextQueueItemWithRules(context.Background(), ClaimRules{})
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, int64(2), item.ID)
+}
+
+func TestClaimNextQueueItemWithRules_ConcurrentClaimsRespectLimit(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "queue.db")
+	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_busy_timeout=30000")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(8)
+
+	setupQueueSchema(t, db)
+	for i := int64(1); i <= 20; i++ {
+		insertQueueItem(t, db, i, fmt.Sprintf("tv-%d.nzb", i), "pending")
+	}
+	_, err = db.Exec(`UPDATE import_queue SET category = 'tv'`)
+	require.NoError(t, err)
+
+	repo := NewQueueRepository(db, DialectSQLite)
+	rules := ClaimRules{CategoryLimits: map[string]int{"tv": 2}, DefaultCategory: "Default"}
+
+	var wg sync.WaitGroup
+	var claimed atomic.Int32
+	errs := make(chan error, 16)
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, err := repo.ClaimNextQueueItemWithRules(context.Background(), rules)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if item != nil {
+				claimed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), claimed.Load(), "concurrent workers must not exceed the category cap")
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// RecordRepairTransition moves a file's repair state to ev.ToState and
+// appends ev to the repair audit log, in one transaction. The current state is
+// read from the health record; ev.FromState is only used when the record is
+// already gone (a repair that removed the file). A transition the state
+// machine does not allow returns ErrInvalidRepairTransition and records
+// nothing. On success ev.ID and ev.FromState are filled in.
+func (r *HealthRepository) RecordRepairTransition(ctx context.Context, ev *RepairEvent) error {
+	filePath := normalizeHealthPath(ev.FilePath)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, `SELECT repair_state FROM file_health WHERE file_path = ?`, filePath).Scan(&current)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		current = string(ev.FromState)
+	case err != nil:
+		return fmt.Errorf("failed to read repair state: %w", err)
+	}
+	from := RepairState(current)
+	if !from.CanTransition(ev.ToState) {
+		return fmt.Errorf("%w: %q to %q", ErrInvalidRepairTransition, from, ev.ToState)
+	}
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO repair_events (file_path, from_state, to_state, action, arr_type, arr_instance, details, result)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`, filePath, from, ev.ToState, ev.Action, ev.ArrType, ev.ArrInstance, ev.Details, ev.Result).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to append repair event: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE file_health SET repair_state = ? WHERE file_path = ?
+	`, ev.ToState, filePath); err != nil {
+		return fmt.Errorf("failed to update repair state: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit repair transition: %w", err)
+	}
+	ev.ID = id
+	ev.FilePath = filePath
+	ev.FromState = from
+	return nil
+}
+
+// GetRepairEvents returns the repair audit log of a file, oldest first.
+func (r *HealthRepository) GetRepairEvents(ctx context.Context, filePath string) ([]*RepairEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, file_path, from_state, to_state, action, arr_type, arr_instance, details, result, created_at
+		FROM repair_events
+		WHERE file_path = ?
+		ORDER BY id
+	`, normalizeHealthPath(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query repair events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRepairEvents(rows)
+}
+
+// GetRepairsAwaitingVerification returns, for up to limit files whose repair
+// is triggered, the event that triggered it, oldest first. Its details hold
+// the ARR actions to follow up.
+func (r *HealthRepository) GetRepairsAwaitingVerification(ctx context.Context, limit int) ([]*RepairEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.id, e.file_path, e.from_state, e.to_state, e.action, e.arr_type, e.arr_instance, e.details, e.result, e.created_at
+		FROM repair_events e
+		JOIN file_health fh ON fh.file_path = e.file_path
+		WHERE fh.repair_state = ?
+		  AND e.id = (SELECT MAX(id) FROM repair_events WHERE file_path = e.file_path)
+		ORDER BY e.id
+		LIMIT ?
+	`, RepairStateTriggered, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query repairs awaiting verification: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRepairEvents(rows)
+}
+
+// AddReplacementImport links queue item queueID, an alternate release, to
+// the corrupted file it replaces. While the item is queued the repair sweep
+// leaves the file alone. The link lives as long as the queue item, so a
+// failed import retried later still retires the file once it succeeds.
+func (r *HealthRepository) AddReplacementImport(ctx context.Context, queueID int64, filePath string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO replacement_imports (queue_id, file_path) VALUES (?, ?)
+	`, queueID, normalizeHealthPath(filePath))
+	if err != nil {
+		return fmt.Errorf("failed to link replacement import: %w", err)
+	}
+	return nil
+}
+
+// GetReplacementImport returns the file queue item queueID replaces, or ""
+// when the item is not a replacement.
+func (r *HealthRepository) GetReplacementImport(ctx context.Context, queueID int64) (string, error) {
+	var filePath string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT file_path FROM replacement_imports WHERE queue_id = ?
+	`, queueID).Scan(&filePath)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get replacement import: %w", err)
+	}
+	return filePath, nil
+}
+
+// TakeReplacementImport returns the file queue item queueID replaces, or ""
+// when the item is not a replacement, and removes every link to that file:
+// once one replacement is in, earlier failed attempts must not retire it
+// again when retried.
+func (r *HealthRepository) TakeReplacementImport(ctx context.Context, queueID int64) (string, error) {
+	filePath, err := r.GetReplacementImport(ctx, queueID)
+	if err != nil || filePath == "" {
+		return filePath, err
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		DELETE FROM replacement_imports WHERE file_path = ?
+	`, filePath); err != nil {
+		return "", fmt.Errorf("failed to take replacement import: %w", err)
+	}
+	return filePath, nil
+}
+
+// scanRepairEvents reads repair_events rows selected in column order.
+func scanRepairEvents(rows *sql.Rows) ([]*RepairEvent, error) {
+	var events []*RepairEvent
+	for rows.Next() {
+		var ev RepairEvent
+		if err := rows.Scan(&ev.ID, &ev.FilePath, &ev.FromState, &ev.ToState, &ev.Action,
+			&ev.ArrType, &ev.ArrInstance, &ev.Details, &ev.Result, &ev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan repair event: %w", err)
+		}
+		events = append(events, &ev)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,151 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairState_CanTransition(t *testing.T) {
+	assert.True(t, RepairStateNone.CanTransition(RepairStateTriggered))
+	assert.True(t, RepairStateTriggered.CanTransition(RepairStateTriggered), "a re-trigger stays triggered")
+	assert.True(t, RepairStateDeferred.CanTransition(RepairStateResolved))
+	assert.True(t, RepairStateExhausted.CanTransition(RepairStateTriggered), "a manual repair reopens an exhausted one")
+	assert.True(t, RepairStateResolved.CanTransition(RepairStateTriggered), "a new loss starts a new repair")
+
+	assert.False(t, RepairStateNone.CanTransition(RepairStateResolved), "nothing to resolve")
+	assert.False(t, RepairStateExhausted.CanTransition(RepairStateExhausted))
+	assert.False(t, RepairStateExhausted.CanTransition(RepairStateDeferred))
+	assert.False(t, RepairStateRemoved.CanTransition(RepairStateResolved))
+}
+
+func TestRecordRepairTransition(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 46)
+	repo := NewHealthRepository(db, DialectSQLite)
+
+	_, err := db.Exec(`INSERT INTO file_health (file_path, status) VALUES ('movies/a.mkv', 'corrupted')`)
+	require.NoError(t, err)
+
+	arr, instance := "radarr", "radarr-main"
+	details := `{"search_command_ids":[42]}`
+	trigger := &RepairEvent{
+		FilePath:    "/movies/a.mkv",
+		ToState:     RepairStateTriggered,
+		Action:      "trigger",
+		ArrType:     &arr,
+		ArrInstance: &instance,
+		Details:     &details,
+	}
+	require.NoError(t, repo.RecordRepairTransition(ctx, trigger))
+	assert.NotZero(t, trigger.ID)
+	assert.Equal(t, RepairStateNone, trigger.FromState)
+
+	fh, err := repo.GetFileHealth(ctx, "movies/a.mkv")
+	require.NoError(t, err)
+	require.NotNil(t, fh)
+	assert.Equal(t, RepairStateTriggered, fh.RepairState)
+
+	// The state machine rejects a transition and records nothing.
+	err = repo.RecordRepairTransition(ctx, &RepairEvent{FilePath: "movies/a.mkv", ToState: RepairStateNone, Action: "bogus"})
+	assert.ErrorIs(t, err, ErrInvalidRepairTransition)
+
+	require.NoError(t, repo.RecordRepairTransition(ctx, &RepairEvent{FilePath: "movies/a.mkv", ToState: RepairStateResolved, Action: "verify"}))
+
+	// The audit log outlives the health record; the caller's from state is
+	// used once the record is gone.
+	require.NoError(t, repo.DeleteHealthRecord(ctx, "movies/a.mkv"))
+	require.NoError(t, repo.RecordRepairTransition(ctx, &RepairEvent{
+		FilePath: "movies/a.mkv", FromState: RepairStateResolved, ToState: RepairStateRemoved, Action: "delete",
+	}))
+
+	events, err := repo.GetRepairEvents(ctx, "movies/a.mkv")
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, RepairStateNone, events[0].FromState)
+	assert.Equal(t, RepairStateTriggered, events[0].ToState)
+	assert.Equal(t, "trigger", events[0].Action)
+	require.NotNil(t, events[0].ArrInstance)
+	assert.Equal(t, "radarr-main", *events[0].ArrInstance)
+	require.NotNil(t, events[0].Details)
+	assert.JSONEq(t, details, *events[0].Details)
+	assert.False(t, events[0].CreatedAt.IsZero())
+	assert.Equal(t, RepairStateTriggered, events[1].FromState)
+	assert.Equal(t, RepairStateResolved, events[1].ToState)
+	assert.Equal(t, RepairStateResolved, events[2].FromState)
+	assert.Equal(t, RepairStateRemoved, events[2].ToState)
+}
+
+func TestGetRepairsAwaitingVerification(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 46)
+	repo := NewHealthRepository(db, DialectSQLite)
+
+	for _, p := range []string{"movies/a.mkv", "movies/b.mkv"} {
+		_, err := db.Exec(`INSERT INTO file_health (file_path, status) VALUES (?, 'corrupted')`, p)
+		require.NoError(t, err)
+	}
+	details := `{"media_ids":[7]}`
+	require.NoError(t, repo.RecordRepairTransition(ctx, &RepairEvent{FilePath: "movies/a.mkv", ToState: RepairStateTriggered, Action: "trigger"}))
+	require.NoError(t, repo.RecordRepairTransition(ctx, &RepairEvent{FilePath: "movies/a.mkv", ToState: RepairStateTriggered, Action: "retrigger", Details: &details}))
+	require.NoError(t, repo.RecordRepairTransition(ctx, &RepairEvent{FilePath: "movies/b.mkv", ToState: RepairStateTriggered, Action: "trigger"}))
+	require.NoError(t, repo.RecordRepairTransition(ctx, &RepairEvent{FilePath: "movies/b.mkv", ToState: RepairStateResolved, Action: "verify"}))
+
+	// Only the open repair is returned, with its latest trigger.
+	events, err := repo.GetRepairsAwaitingVerification(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "movies/a.mkv", events[0].FilePath)
+	assert.Equal(t, "retrigger", events[0].Action)
+	require.NotNil(t, events[0].Details)
+	assert.JSONEq(t, details, *events[0].Details)
+}
+
+func TestReplacementImport_HoldsRepairSweep(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 49)
+	repo := NewHealthRepository(db, DialectSQLite)
+
+	_, err := db.Exec(`INSERT INTO file_health (file_path, status) VALUES ('movies/a.mkv', 'repair_triggered')`)
+	require.NoError(t, err)
+	res, err := db.Exec(`INSERT INTO import_queue (nzb_path, status) VALUES ('/nzbs/a.nzb', 'pending')`)
+	require.NoError(t, err)
+	queueID, err := res.LastInsertId()
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddReplacementImport(ctx, queueID, "/movies/a.mkv"))
+
+	// The repair sweep leaves the file alone while its replacement is queued.
+	files, err := repo.GetFilesForRepairNotification(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	// A replacement that is no longer queued holds nothing.
+	_, err = db.Exec(`UPDATE import_queue SET status = 'failed' WHERE id = ?`, queueID)
+	require.NoError(t, err)
+	files, err = repo.GetFilesForRepairNotification(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// A failed replacement stays linked; the sweep queues a second one.
+	filePath, err := repo.GetReplacementImport(ctx, queueID)
+	require.NoError(t, err)
+	assert.Equal(t, "movies/a.mkv", filePath)
+	res, err = db.Exec(`INSERT INTO import_queue (nzb_path, status) VALUES ('/nzbs/b.nzb', 'pending')`)
+	require.NoError(t, err)
+	secondID, err := res.LastInsertId()
+	require.NoError(t, err)
+	require.NoError(t, repo.AddReplacementImport(ctx, secondID, "/movies/a.mkv"))
+
+	// The successful replacement consumes every link to the file.
+	filePath, err = repo.TakeReplacementImport(ctx, secondID)
+	require.NoError(t, err)
+	assert.Equal(t, "movies/a.mkv", filePath)
+	for _, id := range []int64{queueID, secondID} {
+		filePath, err = repo.TakeReplacementImport(ctx, id)
+		require.NoError(t, err)
+		assert.Empty(t, filePath, "a file is retired once")
+	}
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"errors"
+	"time"
+)
+
+// RepairState is where a file is in the repair state machine, persisted in
+// file_health.repair_state. Every transition is appended to repair_events.
+type RepairState string
+
+const (
+	RepairStateNone        RepairState = ""            // No repair was ever attempted
+	RepairStateTriggered   RepairState = "triggered"   // The ARR blocklisted the release and searched for a replacement, or an alternate release was queued
+	RepairStateDeferred    RepairState = "deferred"    // The ARR was temporarily unreachable; retried on the next cycle
+	RepairStateStalled     RepairState = "stalled"     // The ARR grabbed nothing within the stall timeout
+	RepairStateFailed      RepairState = "failed"      // The repair attempt failed; the file is corrupted
+	RepairStateExhausted   RepairState = "exhausted"   // The repair budget is spent; the file is finalized as corrupted
+	RepairStateResolved    RepairState = "resolved"    // A health check passed after the repair
+	RepairStateRemoved     RepairState = "removed"     // The file was removed (replaced in the ARR, zombie, alternate release or deleted)
+	RepairStateRegenerated RepairState = "regenerated" // The metadata was regenerated from the NZB
+)
+
+// ErrInvalidRepairTransition is returned for a transition the repair state
+// machine does not allow.
+var ErrInvalidRepairTransition = errors.New("invalid repair state transition")
+
+// repairStarts are the states a new repair can enter.
+var repairStarts = []RepairState{
+	RepairStateTriggered, RepairStateDeferred, RepairStateFailed,
+	RepairStateExhausted, RepairStateRemoved, RepairStateRegenerated,
+}
+
+// repairTransitions lists the allowed next states of each state. A closed
+// repair (none, resolved, removed, regenerated) can only start a new one; an
+// open one (triggered, deferred, stalled, failed) can be retried, resolved or
+// given up, and only a triggered one can stall; an exhausted one only leaves
+// through a manual repair (triggered or failed), a passing check or the
+// file's removal.
+var repairTransitions = map[RepairState][]RepairState{
+	RepairStateNone:        repairStarts,
+	RepairStateResolved:    repairStarts,
+	RepairStateRemoved:     repairStarts,
+	RepairStateRegenerated: repairStarts,
+	RepairStateTriggered:   append(repairStarts, RepairStateResolved, RepairStateStalled),
+	RepairStateDeferred:    append(repairStarts, RepairStateResolved),
+	RepairStateStalled:     append(repairStarts, RepairStateResolved),
+	RepairStateFailed:      append(repairStarts, RepairStateResolved),
+	RepairStateExhausted:   {RepairStateTriggered, RepairStateFailed, RepairStateRemoved, RepairStateResolved},
+}
+
+// CanTransition reports whether the repair state machine allows moving from
+// one state to another.
+func (s RepairState) CanTransition(to RepairState) bool {
+	for _, next := range repairTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether a repair is in progress: triggered, deferred,
+// stalled or failed (still retried).
+func (s RepairState) IsOpen() bool {
+	switch s {
+	case RepairStateTriggered, RepairStateDeferred, RepairStateStalled, RepairStateFailed:
+		return true
+	}
+	return false
+}
+
+// RepairEvent is one entry of the append-only repair audit log.
+type RepairEvent struct {
+	ID        int64       `db:"id"`
+	FilePath  string      `db:"file_path"`
+	FromState RepairState `db:"from_state"`
+	ToState   RepairState `db:"to_state"`
+	// Action is what was tried (see health.RepairAction*).
+	Action      string  `db:"action"`
+	ArrType     *string `db:"arr_type"`
+	ArrInstance *string `db:"arr_instance"`
+	// Details is the JSON record of the ARR actions taken: blocklisted
+	// history, failed queue items, deleted files and search command IDs.
+	Details *string `db:"details"`
+	// Result is the error of a failed or deferred attempt.
+	Result    *string   `db:"result"`
+	CreatedAt time.Time `db:"created_at"`
+}
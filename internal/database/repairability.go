@@ -0,0 +1,38 @@
+package database
+
+import (
+	"encoding/json"
+
+	"github.com/javi11/altmount/internal/holes"
+)
+
+// Repairability is the PAR2 recovery estimate of a health check (see
+// holes.Repair), as stored (JSON) in file_health.repairability.
+type Repairability = holes.Repair
+
+// MarshalRepairability renders an estimate for storage, returning nil for a
+// nil estimate or on the (practically impossible) marshal error.
+func MarshalRepairability(r *Repairability) *string {
+	if r == nil {
+		return nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+// ParseRepairability decodes a stored column value. NULL, empty or malformed
+// values yield nil.
+func ParseRepairability(raw *string) *Repairability {
+	if raw == nil || *raw == "" {
+		return nil
+	}
+	var r Repairability
+	if err := json.Unmarshal([]byte(*raw), &r); err != nil {
+		return nil
+	}
+	return &r
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ReportFile is one health record as seen by the library health report.
+type ReportFile struct {
+	FilePath      string
+	SourceNzbPath string
+	Status        HealthStatus
+	// CheckedAt is when the file was last checked, or added when it never was.
+	CheckedAt time.Time
+	Indexer   string
+	// Metadata is the record's JSON arr metadata (see model.WebhookMetadata).
+	Metadata *string
+	// Provider is the primary provider of the file's import sample.
+	Provider string
+}
+
+// ReportLoss is one recorded segment loss as seen by the library health
+// report, joined to the health record now at the lost release's path.
+type ReportLoss struct {
+	FilePath string
+	Indexer  string
+	Provider string
+	// Age is how old the release was when it first lost segments.
+	Age time.Duration
+	// Status is the current status of the file at the lost release's path,
+	// empty when the record is gone (the file was renamed or removed).
+	Status   HealthStatus
+	Metadata *string
+}
+
+// GetReportFiles returns every health record of the library for the health
+// report.
+func (r *HealthRepository) GetReportFiles(ctx context.Context) ([]ReportFile, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT file_path, source_nzb_path, status, last_checked, created_at,
+		       indexer, metadata, provider_completeness
+		FROM file_health
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report files: %w", err)
+	}
+	defer rows.Close()
+
+	var files []ReportFile
+	for rows.Next() {
+		var f ReportFile
+		var sourceNzb, indexer, completeness sql.NullString
+		var lastChecked *time.Time
+		if err := rows.Scan(&f.FilePath, &sourceNzb, &f.Status, &lastChecked, &f.CheckedAt, &indexer, &f.Metadata, &completeness); err != nil {
+			return nil, fmt.Errorf("failed to scan report file: %w", err)
+		}
+		if lastChecked != nil {
+			f.CheckedAt = *lastChecked
+		}
+		f.SourceNzbPath = sourceNzb.String
+		f.Indexer = indexer.String
+		f.Provider = PrimaryProvider(ParseProviderCompleteness(&completeness.String))
+		files = append(files, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate report files: %w", err)
+	}
+	return files, nil
+}
+
+// GetReportLosses returns every recorded segment loss for the health report.
+func (r *HealthRepository) GetReportLosses(ctx context.Context) ([]ReportLoss, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.file_path, e.indexer, e.provider, e.release_date, e.lost_at,
+		       fh.status, fh.metadata
+		FROM segment_loss_events e
+		LEFT JOIN file_health fh ON fh.file_path = e.file_path
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report losses: %w", err)
+	}
+	defer rows.Close()
+
+	var losses []ReportLoss
+	for rows.Next() {
+		var l ReportLoss
+		var indexer, provider, status sql.NullString
+		var releaseDate, lostAt time.Time
+		if err := rows.Scan(&l.FilePath, &indexer, &provider, &releaseDate, &lostAt, &status, &l.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to scan report loss: %w", err)
+		}
+		l.Indexer = indexer.String
+		l.Provider = provider.String
+		l.Age = lostAt.Sub(releaseDate)
+		l.Status = HealthStatus(status.String)
+		losses = append(losses, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate report losses: %w", err)
+	}
+	return losses, nil
+}
+
+// GetIndexerHealthStats aggregates all historical records to calculate success/failure rates.
+func (r *HealthRepository) GetIndexerHealthStats(ctx context.Context) ([]*IndexerAggregatedHealth, error) {
+	return getIndexerHealthStats(ctx, r.db)
+}
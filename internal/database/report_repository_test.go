@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportQueries(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTo(t, 46)
+	repo := NewHealthRepository(db, DialectSQLite)
+
+	_, err := db.Exec(`
+		INSERT INTO file_health (file_path, status, last_checked, indexer, source_nzb_path, provider_completeness)
+		VALUES
+		  ('tv/a.mkv', 'corrupted', datetime('now', '-2 days'), 'idx', 'a.nzb', '[{"provider":"p1","sampled":10,"missing":2,"completeness":0.8}]'),
+		  ('tv/b.mkv', 'pending', NULL, NULL, NULL, NULL)
+	`)
+	require.NoError(t, err)
+
+	fh, err := repo.GetFileHealth(ctx, "tv/a.mkv")
+	require.NoError(t, err)
+	require.NotNil(t, fh)
+	require.NoError(t, repo.RecordSegmentLoss(ctx, fh))
+	_, err = db.Exec(`
+		INSERT INTO segment_loss_events (file_path, indexer, release_date, lost_at)
+		VALUES ('tv/gone.mkv', 'idx', datetime('now', '-10 days'), datetime('now', '-7 days'))
+	`)
+	require.NoError(t, err)
+
+	files, err := repo.GetReportFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "idx", files[0].Indexer)
+	assert.Equal(t, "a.nzb", files[0].SourceNzbPath)
+	assert.Equal(t, "p1", files[0].Provider)
+	assert.InDelta(t, (48 * time.Hour).Hours(), time.Since(files[0].CheckedAt).Hours(), 1)
+	assert.False(t, files[1].CheckedAt.IsZero(), "never-checked files fall back to when they were added")
+
+	losses, err := repo.GetReportLosses(ctx)
+	require.NoError(t, err)
+	require.Len(t, losses, 2)
+	assert.Equal(t, "idx", losses[0].Indexer, "the loss event keeps the release's indexer")
+	assert.Equal(t, "p1", losses[0].Provider)
+	assert.Equal(t, HealthStatusCorrupted, losses[0].Status)
+	assert.Equal(t, HealthStatus(""), losses[1].Status)
+	assert.InDelta(t, (3 * 24 * time.Hour).Hours(), losses[1].Age.Hours(), 1)
+}
@@ -28,6 +28,7 @@ func setupImportHistorySchema(t *testing.T, db *sql.DB) {
 			category TEXT,
 			metadata TEXT DEFAULT NULL,
 			indexer TEXT DEFAULT NULL,
+			original_nzb_name TEXT DEFAULT NULL,
 			completed_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		);
 	`
@@ -0,0 +1,56 @@
+package database
+
+import "encoding/json"
+
+// SampleConfidence is what a sampled health check says about a file's
+// missing-segment rate, as stored (JSON) in file_health.sample_confidence:
+// the share of checked segments that were missing and its confidence
+// interval. A full check measures the rate exactly (Lower == Upper).
+type SampleConfidence struct {
+	// Checked and Missing count the stratified sample the rate is estimated
+	// from; known-hole neighbours are probed on top and counted separately.
+	Checked     int     `json:"checked"`
+	Missing     int     `json:"missing"`
+	MissingRate float64 `json:"missing_rate"`
+	Lower       float64 `json:"lower"`
+	Upper       float64 `json:"upper"`
+	// Level is the interval's coverage (0.95).
+	Level float64 `json:"level"`
+	// Planned is the sample size the check would have used without stopping
+	// early; EarlyStopped marks a check that stopped once the upper bound was
+	// below the configured target.
+	Planned      int  `json:"planned"`
+	EarlyStopped bool `json:"early_stopped,omitempty"`
+	Full         bool `json:"full,omitempty"`
+	// HoleEdgesChecked and HoleEdgesMissing count the probes of segments
+	// adjacent to known holes.
+	HoleEdgesChecked int `json:"hole_edges_checked,omitempty"`
+	HoleEdgesMissing int `json:"hole_edges_missing,omitempty"`
+}
+
+// Marshal renders the confidence for storage, returning nil on the
+// (practically impossible) marshal error.
+func (c *SampleConfidence) Marshal() *string {
+	if c == nil {
+		return nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+// ParseSampleConfidence decodes a stored column value. NULL, empty or
+// malformed values yield nil.
+func ParseSampleConfidence(raw *string) *SampleConfidence {
+	if raw == nil || *raw == "" {
+		return nil
+	}
+	var c SampleConfidence
+	if err := json.Unmarshal([]byte(*raw), &c); err != nil {
+		return nil
+	}
+	return &c
+}
@@ -35,6 +35,8 @@ func setupQueueSchema(t *testing.T, db *sql.DB) {
 			skip_arr_notification BOOLEAN NOT NULL DEFAULT FALSE,
 			skip_post_import_links BOOLEAN NOT NULL DEFAULT FALSE,
 			indexer TEXT DEFAULT NULL,
+			hold_until DATETIME DEFAULT NULL,
+			deprioritized BOOLEAN NOT NULL DEFAULT FALSE,
 			UNIQUE(nzb_path)
 		);
 
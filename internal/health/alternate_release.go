@@ -0,0 +1,272 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/holes"
+	"github.com/javi11/altmount/internal/httpclient"
+	"github.com/javi11/altmount/internal/importer/parser"
+	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
+	"github.com/javi11/altmount/internal/nzbcheck"
+	"github.com/javi11/altmount/internal/prowlarr"
+	"github.com/javi11/nzbparser"
+)
+
+// maxAlternateCandidates caps how many ranked releases are downloaded and
+// checked per repair, so a long result list does not hammer the indexers.
+const maxAlternateCandidates = 3
+
+// errNoAlternateRelease is returned when no complete alternate release was
+// found; the file is then marked corrupted like a failed ARR repair.
+var errNoAlternateRelease = errors.New("no complete alternate release found")
+
+// ReleaseSearcher searches an indexer for releases and downloads their NZBs.
+// *prowlarr.Client implements it.
+type ReleaseSearcher interface {
+	SearchTerm(ctx context.Context, term, searchType string, categories, indexers []int, season, episode int) ([]prowlarr.NZBResult, error)
+	DownloadNZB(ctx context.Context, downloadURL string) ([]byte, error)
+}
+
+// newProwlarrSearcher builds the ReleaseSearcher from the Prowlarr settings.
+func newProwlarrSearcher(cfg *config.Config) ReleaseSearcher {
+	return prowlarr.NewClient(
+		cfg.Stremio.Prowlarr.Host,
+		cfg.Stremio.Prowlarr.APIKey,
+		httpclient.NewForExternal(cfg.Network, httpclient.LongTimeout),
+	)
+}
+
+// repairFromAlternateRelease repairs a corrupted file no ARR manages: it
+// searches Prowlarr for the same title (and episode), ranks the results by
+// how closely they match the original release, checks the best candidates'
+// completeness and queues the first complete one into the file's virtual
+// directory under the original release name. The corrupted file and its
+// health record stay in place, linked to the queue item, until the import
+// finishes: the post-processor retires them once the replacement is in and
+// the repair sweep leaves them alone meanwhile.
+func (hw *HealthWorker) repairFromAlternateRelease(ctx context.Context, item *database.FileHealth) (repairOutcome, error) {
+	cfg := hw.configGetter()
+	releaseName := originalReleaseName(item)
+	want := prowlarr.InferReleaseMeta(releaseName)
+	if want.ParsedTitle == "" {
+		return repairOutcomeCorrupted, fmt.Errorf("cannot infer a title from %q", releaseName)
+	}
+
+	searchType := "movie"
+	if want.Season > 0 || want.Episode > 0 {
+		searchType = "tvsearch"
+	}
+	prowlarrCfg := cfg.Stremio.Prowlarr
+	searcher := hw.releaseSearcher(cfg)
+	results, err := searcher.SearchTerm(ctx, want.ParsedTitle, searchType, prowlarrCfg.Categories, prowlarrCfg.Indexers, want.Season, want.Episode)
+	if err != nil {
+		return repairOutcomeCorrupted, fmt.Errorf("alternate release search: %w", err)
+	}
+	ranked := rankAlternateReleases(releaseName, want, results)
+
+	slog.InfoContext(ctx, "Searching alternate release for file no ARR manages",
+		"file_path", item.FilePath,
+		"release", releaseName,
+		"results", len(results),
+		"candidates", len(ranked))
+
+	opts := nzbcheck.Options{
+		SamplePercentage: cfg.GetSegmentSamplePercentage(),
+		MaxConnections:   cfg.GetMaxConnectionsForHealthChecks(),
+		Timeout:          cfg.GetHealthReadTimeout(),
+	}
+	for _, r := range ranked[:min(len(ranked), maxAlternateCandidates)] {
+		data, verdict, err := hw.checkAlternateRelease(ctx, searcher, r, opts)
+		if err != nil {
+			slog.WarnContext(ctx, "Alternate release check failed", "file_path", item.FilePath, "release", r.Title, "error", err)
+			continue
+		}
+		if verdict != holes.VerdictClean && verdict != holes.VerdictDegraded {
+			slog.InfoContext(ctx, "Alternate release rejected", "file_path", item.FilePath, "release", r.Title, "verdict", verdict)
+			continue
+		}
+		queueID, err := hw.queueAlternateRelease(ctx, item, releaseName, r, data)
+		if err != nil {
+			return repairOutcomeCorrupted, err
+		}
+		if err := hw.healthRepo.AddReplacementImport(ctx, queueID, item.FilePath); err != nil {
+			slog.WarnContext(ctx, "Failed to link alternate release to corrupted file", "file_path", item.FilePath, "queue_id", queueID, "error", err)
+		}
+		slog.InfoContext(ctx, "Queued alternate release to replace corrupted file",
+			"file_path", item.FilePath,
+			"queue_id", queueID,
+			"release", r.Title,
+			"indexer", r.Indexer,
+			"verdict", verdict)
+		return repairOutcomeTriggered, nil
+	}
+
+	return repairOutcomeCorrupted, errNoAlternateRelease
+}
+
+// checkAlternateRelease downloads a candidate's NZB and checks its
+// completeness, returning the raw NZB and its verdict.
+func (hw *HealthWorker) checkAlternateRelease(ctx context.Context, searcher ReleaseSearcher, r prowlarr.NZBResult, opts nzbcheck.Options) ([]byte, holes.Verdict, error) {
+	data, err := searcher.DownloadNZB(ctx, r.DownloadURL)
+	if err != nil {
+		return nil, "", err
+	}
+	n, err := nzbparser.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("parse NZB: %w", err)
+	}
+	if len(n.Files) == 0 {
+		return nil, "", fmt.Errorf("NZB contains no files")
+	}
+	parser.SanitizeNzbFilenames(n)
+	res, err := nzbcheck.Check(ctx, n, hw.healthChecker.poolManager, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, res.Verdict, nil
+}
+
+// queueAlternateRelease queues the NZB under the original release name, so
+// the replacement lands in the same release folder of the same virtual
+// directory, and returns the queue item ID.
+func (hw *HealthWorker) queueAlternateRelease(ctx context.Context, item *database.FileHealth, releaseName string, r prowlarr.NZBResult, data []byte) (int64, error) {
+	if hw.importerService == nil {
+		return 0, fmt.Errorf("importer service not available")
+	}
+
+	uploadDir := filepath.Join(os.TempDir(), "altmount-uploads")
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	stageDir, err := os.MkdirTemp(uploadDir, "repair-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	// The importer moves the NZB out; this clears the staged file / empty dir.
+	defer os.RemoveAll(stageDir)
+
+	tempPath := filepath.Join(stageDir, releaseName+".nzb")
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write NZB temp file: %w", err)
+	}
+
+	relativePath := alternateReleaseDir(item.FilePath, releaseName)
+	priority := database.QueuePriorityHigh
+	var indexer *string
+	if r.Indexer != "" {
+		indexer = &r.Indexer
+	}
+	queued, err := hw.importerService.AddToQueue(ctx, tempPath, &relativePath, nil, &priority, nil, nil, indexer)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue alternate release: %w", err)
+	}
+	return queued.ID, nil
+}
+
+// originalReleaseName is the release name of a file: its source NZB name,
+// else its release folder, else its own name without extension.
+func originalReleaseName(item *database.FileHealth) string {
+	if item.SourceNzbPath != nil && *item.SourceNzbPath != "" {
+		if name := trimReleaseNzbExtension(filepath.Base(*item.SourceNzbPath)); name != "" {
+			return name
+		}
+	}
+	if dir := path.Base(path.Dir(item.FilePath)); dir != "." && dir != "/" {
+		return dir
+	}
+	base := path.Base(item.FilePath)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// trimReleaseNzbExtension strips an NZB extension from a release name, leaving
+// dotted release names ("Movie.2019.1080p.x264-GRP") intact.
+func trimReleaseNzbExtension(name string) string {
+	if !nzbtrim.HasNzbExtension(name) {
+		return name
+	}
+	return nzbtrim.TrimNzbExtension(name)
+}
+
+// alternateReleaseDir is the virtual directory to import a replacement into:
+// the parent of the file's release folder, or the file's own directory when
+// it does not sit in one.
+func alternateReleaseDir(filePath, releaseName string) string {
+	dir := path.Dir(filePath)
+	if path.Base(dir) == releaseName {
+		dir = path.Dir(dir)
+	}
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return dir
+}
+
+// rankAlternateReleases keeps the results for the same title, year, season
+// and episode as want, drops the original release itself, and orders the rest
+// by how closely they match it: resolution, then source quality, codec and
+// language. Ties keep Prowlarr's newest-first order.
+func rankAlternateReleases(original string, want prowlarr.ReleaseMeta, results []prowlarr.NZBResult) []prowlarr.NZBResult {
+	type ranked struct {
+		result prowlarr.NZBResult
+		score  int
+	}
+	title := normalizeReleaseTitle(want.ParsedTitle)
+	var out []ranked
+	for _, r := range results {
+		if strings.EqualFold(trimReleaseNzbExtension(r.Title), original) {
+			continue
+		}
+		meta := prowlarr.InferReleaseMeta(r.Title)
+		if normalizeReleaseTitle(meta.ParsedTitle) != title {
+			continue
+		}
+		if want.Year > 0 && meta.Year > 0 && meta.Year != want.Year {
+			continue
+		}
+		// A season pack or another episode cannot replace a single episode.
+		if meta.Season != want.Season || meta.Episode != want.Episode {
+			continue
+		}
+		score := 0
+		if want.Resolution != "" && strings.EqualFold(meta.Resolution, want.Resolution) {
+			score += 8
+		}
+		if want.Quality != "" && strings.EqualFold(meta.Quality, want.Quality) {
+			score += 4
+		}
+		if want.Codec != "" && strings.EqualFold(meta.Codec, want.Codec) {
+			score += 2
+		}
+		if meta.Language == want.Language {
+			score++
+		}
+		out = append(out, ranked{result: r, score: score})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].score > out[j].score })
+
+	releases := make([]prowlarr.NZBResult, len(out))
+	for i, r := range out {
+		releases[i] = r.result
+	}
+	return releases
+}
+
+// normalizeReleaseTitle lowercases a title and reduces it to its words, so
+// "The.Movie" and "The Movie" compare equal.
+func normalizeReleaseTitle(title string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
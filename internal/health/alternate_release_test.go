@@ -0,0 +1,290 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/arrs"
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/prowlarr"
+	"github.com/javi11/altmount/internal/testsupport/fakepool"
+	"github.com/javi11/altmount/internal/testsupport/nzbbuild"
+	"github.com/javi11/nntppool/v4"
+	"github.com/javi11/nzbparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReleaseSearcher serves canned Prowlarr results and NZBs by URL.
+type fakeReleaseSearcher struct {
+	results []prowlarr.NZBResult
+	nzbs    map[string][]byte
+	terms   []string
+}
+
+func (f *fakeReleaseSearcher) SearchTerm(_ context.Context, term, _ string, _, _ []int, _, _ int) ([]prowlarr.NZBResult, error) {
+	f.terms = append(f.terms, term)
+	return f.results, nil
+}
+
+func (f *fakeReleaseSearcher) DownloadNZB(_ context.Context, downloadURL string) ([]byte, error) {
+	data, ok := f.nzbs[downloadURL]
+	if !ok {
+		return nil, fmt.Errorf("unknown download URL %s", downloadURL)
+	}
+	return data, nil
+}
+
+// queueRecordingImporter records AddToQueue calls and the staged NZB names.
+type queueRecordingImporter struct {
+	mockImportService
+	mu     sync.Mutex
+	queued []queuedNzb
+}
+
+type queuedNzb struct {
+	name         string
+	relativePath string
+	indexer      string
+}
+
+func (m *queueRecordingImporter) AddToQueue(_ context.Context, filePath string, relativePath *string, _ *string, _ *database.QueuePriority, _ *string, _ *string, indexer *string) (*database.ImportQueueItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q := queuedNzb{name: filepath.Base(filePath)}
+	if relativePath != nil {
+		q.relativePath = *relativePath
+	}
+	if indexer != nil {
+		q.indexer = *indexer
+	}
+	m.queued = append(m.queued, q)
+	return &database.ImportQueueItem{ID: int64(len(m.queued)), NzbPath: filePath}, nil
+}
+
+func alternateNzbBytes(t *testing.T, idPrefix string) []byte {
+	t.Helper()
+	data, err := nzbparser.Write(nzbbuild.Build(nzbbuild.File{
+		Subject: "Some.Movie.2019.1080p.WEB-DL.x264.mkv",
+		Segments: []nzbbuild.Segment{
+			{ID: idPrefix + "1@y", Bytes: 700000},
+			{ID: idPrefix + "2@y", Bytes: 700000},
+		},
+	}))
+	require.NoError(t, err)
+	return data
+}
+
+func TestRankAlternateReleases(t *testing.T) {
+	original := "Some.Movie.2019.1080p.WEB-DL.x264-GRP"
+	want := prowlarr.InferReleaseMeta(original)
+	results := []prowlarr.NZBResult{
+		{Title: "Some.Movie.2019.2160p.BluRay.x265-OTHER"},
+		{Title: original},
+		{Title: "Other.Movie.2019.1080p.WEB-DL.x264-GRP"},
+		{Title: "Some.Movie.2012.1080p.WEB-DL.x264-OLD"},
+		{Title: "Some Movie 2019 1080p WEB-DL x264-NEW"},
+	}
+
+	ranked := rankAlternateReleases(original, want, results)
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "Some Movie 2019 1080p WEB-DL x264-NEW", ranked[0].Title, "closest match first")
+	assert.Equal(t, "Some.Movie.2019.2160p.BluRay.x265-OTHER", ranked[1].Title)
+}
+
+func TestRankAlternateReleases_EpisodeMustMatch(t *testing.T) {
+	original := "Show.Name.S02E05.1080p.WEB-DL-GRP"
+	want := prowlarr.InferReleaseMeta(original)
+	results := []prowlarr.NZBResult{
+		{Title: "Show.Name.S02.1080p.WEB-DL-PACK"},
+		{Title: "Show.Name.S02E06.1080p.WEB-DL-GRP"},
+		{Title: "Show.Name.S02E05.720p.HDTV-OTHER"},
+	}
+
+	ranked := rankAlternateReleases(original, want, results)
+	require.Len(t, ranked, 1)
+	assert.Equal(t, "Show.Name.S02E05.720p.HDTV-OTHER", ranked[0].Title)
+}
+
+func TestAlternateReleaseDir(t *testing.T) {
+	assert.Equal(t, "complete/movies", alternateReleaseDir("complete/movies/Rel/Rel.mkv", "Rel"))
+	assert.Equal(t, "movies/Rel", alternateReleaseDir("movies/Rel/file.mkv", "Other"))
+	assert.Equal(t, "", alternateReleaseDir("Rel.mkv", "Rel"))
+}
+
+func TestTriggerFileRepair_AlternateReleaseForUnmanagedFile(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	arrErr := fmt.Errorf("failed to find ARR instance: %w", arrs.ErrInstanceNotFound)
+	env := newRepairTestEnv(t, tempDir, arrErr, func(cfg *config.Config) {
+		enabled := true
+		cfg.Health.Repair.AlternateRelease = &enabled
+		cfg.Stremio.Prowlarr.Enabled = &enabled
+		cfg.Stremio.Prowlarr.Host = "http://prowlarr.test"
+		cfg.Health.SegmentSamplePercentage = 100
+	})
+
+	client := fakepool.New()
+	client.SetBehavior("bad1@y", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	client.SetBehavior("bad2@y", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	env.healthChecker.poolManager = fillPoolManager{client: client}
+
+	searcher := &fakeReleaseSearcher{
+		results: []prowlarr.NZBResult{
+			{Title: "Some.Movie.2019.1080p.WEB-DL.x264-BROKEN", DownloadURL: "u1", Indexer: "idx1", PublishDate: time.Now()},
+			{Title: "Some.Movie.2019.1080p.WEB-DL.x264-GOOD", DownloadURL: "u2", Indexer: "idx2"},
+		},
+		nzbs: map[string][]byte{
+			"u1": alternateNzbBytes(t, "bad"),
+			"u2": alternateNzbBytes(t, "good"),
+		},
+	}
+	env.hw.releaseSearcher = func(*config.Config) ReleaseSearcher { return searcher }
+	importer := &queueRecordingImporter{}
+	env.hw.importerService = importer
+
+	const releaseName = "Some.Movie.2019.1080p.WEB-DL.x264-GRP"
+	filePath := "complete/movies/" + releaseName + "/movie.mkv"
+	require.NoError(t, env.metadataService.WriteFileMetadata(filePath, validSegmentMeta(env.metadataService, 1000)))
+	insertFileHealth(t, env.db, filePath, filePath, 3, 3)
+	fh, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+
+	outcome, err := env.hw.triggerFileRepair(ctx, fh, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, repairOutcomeTriggered, outcome)
+
+	assert.Equal(t, []string{"Some Movie"}, searcher.terms)
+	require.Len(t, importer.queued, 1, "only the complete release is queued")
+	assert.Equal(t, queuedNzb{name: releaseName + ".nzb", relativePath: "complete/movies", indexer: "idx2"}, importer.queued[0])
+
+	// The corrupted file stays until the replacement import succeeds.
+	meta, err := env.metadataService.ReadFileMetadata(filePath)
+	require.NoError(t, err)
+	assert.NotNil(t, meta, "corrupted metadata is kept until the replacement is in")
+	kept, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	require.NotNil(t, kept, "health record is kept until the replacement is in")
+	assert.Equal(t, database.RepairStateTriggered, kept.RepairState)
+
+	linked, err := env.healthRepo.TakeReplacementImport(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, filePath, linked, "queue item is linked to the file it replaces")
+}
+
+func TestRepairSweep_SearchesAgainAfterFailedReplacement(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	arrErr := fmt.Errorf("failed to find ARR instance: %w", arrs.ErrInstanceNotFound)
+	env := newRepairTestEnv(t, tempDir, arrErr, func(cfg *config.Config) {
+		enabled := true
+		cfg.Health.Repair.AlternateRelease = &enabled
+		cfg.Stremio.Prowlarr.Enabled = &enabled
+		cfg.Stremio.Prowlarr.Host = "http://prowlarr.test"
+		cfg.Health.SegmentSamplePercentage = 100
+	})
+	env.healthChecker.poolManager = fillPoolManager{client: fakepool.New()}
+
+	searcher := &fakeReleaseSearcher{
+		results: []prowlarr.NZBResult{
+			{Title: "Some.Movie.2019.1080p.WEB-DL.x264-GOOD", DownloadURL: "u1", Indexer: "idx1"},
+		},
+		nzbs: map[string][]byte{"u1": alternateNzbBytes(t, "good")},
+	}
+	env.hw.releaseSearcher = func(*config.Config) ReleaseSearcher { return searcher }
+	importer := &queueRecordingImporter{}
+	env.hw.importerService = importer
+
+	filePath := "complete/movies/Some.Movie.2019.1080p.WEB-DL.x264-GRP/movie.mkv"
+	require.NoError(t, env.metadataService.WriteFileMetadata(filePath, validSegmentMeta(env.metadataService, 1000)))
+	insertFileHealth(t, env.db, filePath, filePath, 3, 3)
+	fh, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+
+	outcome, err := env.hw.triggerFileRepair(ctx, fh, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, repairOutcomeTriggered, outcome)
+	require.Len(t, importer.queued, 1)
+
+	// The first replacement import fails; the file is still awaiting repair.
+	_, err = env.db.Exec(`INSERT INTO import_queue (id, status) VALUES (1, 'failed')`)
+	require.NoError(t, err)
+	_, err = env.db.Exec(`UPDATE file_health SET status = 'repair_triggered', scheduled_check_at = datetime('now', '-1 second') WHERE file_path = ?`, filePath)
+	require.NoError(t, err)
+
+	// The repair sweep searches Prowlarr again and queues a second replacement.
+	require.NoError(t, env.hw.runHealthCheckCycle(ctx))
+	require.Len(t, importer.queued, 2, "the sweep searches for another alternate release")
+	assert.Len(t, searcher.terms, 2)
+
+	linked, err := env.healthRepo.GetReplacementImport(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, filePath, linked, "the second replacement is linked to the file")
+
+	kept, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	require.NotNil(t, kept)
+	assert.Equal(t, database.HealthStatusRepairTriggered, kept.Status)
+	assert.Equal(t, 1, kept.RepairRetryCount)
+}
+
+func TestTriggerFileRepair_NoAlternateReleaseMarksCorrupted(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	arrErr := fmt.Errorf("failed to find ARR instance: %w", arrs.ErrInstanceNotFound)
+	env := newRepairTestEnv(t, tempDir, arrErr, func(cfg *config.Config) {
+		enabled := true
+		cfg.Health.Repair.AlternateRelease = &enabled
+		cfg.Stremio.Prowlarr.Enabled = &enabled
+		cfg.Stremio.Prowlarr.Host = "http://prowlarr.test"
+	})
+	env.hw.releaseSearcher = func(*config.Config) ReleaseSearcher { return &fakeReleaseSearcher{} }
+
+	filePath := "complete/movies/Lonely.Movie.2020.720p-GRP/lonely.mkv"
+	require.NoError(t, env.metadataService.WriteFileMetadata(filePath, validSegmentMeta(env.metadataService, 1000)))
+	insertFileHealth(t, env.db, filePath, filePath, 3, 3)
+	fh, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+
+	outcome, err := env.hw.triggerFileRepair(ctx, fh, nil, nil)
+	assert.ErrorIs(t, err, errNoAlternateRelease)
+	assert.Equal(t, repairOutcomeCorrupted, outcome)
+
+	meta, err := env.metadataService.ReadFileMetadata(filePath)
+	require.NoError(t, err)
+	assert.NotNil(t, meta, "file is left in place")
+}
+
+func TestTriggerFileRepair_UnreachableArrDefersAlternateRelease(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	arrErr := fmt.Errorf("failed to find ARR instance: %w", arrs.ErrInstanceUnreachable)
+	env := newRepairTestEnv(t, tempDir, arrErr, func(cfg *config.Config) {
+		enabled := true
+		cfg.Health.Repair.AlternateRelease = &enabled
+		cfg.Stremio.Prowlarr.Enabled = &enabled
+		cfg.Stremio.Prowlarr.Host = "http://prowlarr.test"
+	})
+	searcher := &fakeReleaseSearcher{}
+	env.hw.releaseSearcher = func(*config.Config) ReleaseSearcher { return searcher }
+
+	filePath := "complete/movies/Some.Movie.2019.1080p.WEB-DL.x264-GRP/movie.mkv"
+	require.NoError(t, env.metadataService.WriteFileMetadata(filePath, validSegmentMeta(env.metadataService, 1000)))
+	insertFileHealth(t, env.db, filePath, filePath, 3, 3)
+	fh, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+
+	outcome, err := env.hw.triggerFileRepair(ctx, fh, nil, nil)
+	assert.ErrorIs(t, err, arrs.ErrInstanceUnreachable)
+	assert.Equal(t, repairOutcomeDeferred, outcome)
+	assert.Empty(t, searcher.terms, "an ARR outage must not hand the file to alternate-release repair")
+
+	meta, err := env.metadataService.ReadFileMetadata(filePath)
+	require.NoError(t, err)
+	assert.NotNil(t, meta, "file is left in place")
+}
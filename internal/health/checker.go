@@ -40,6 +40,12 @@ type HealthEvent struct {
 	// Classification is the playback-impact verdict for video files with
 	// missing segments (nil when not applicable).
 	Classification *holes.Impact
+	// Confidence is the missing-rate estimate of the segment sweep (nil when
+	// the check ended before it).
+	Confidence *database.SampleConfidence
+	// Repairability is the PAR2 recovery estimate for files with missing
+	// segments (nil when not applicable or unknown).
+	Repairability *database.Repairability
 }
 
 // CheckOptions defines options for health checking
@@ -94,13 +100,13 @@ type healthCheckInput struct {
 
 // preparedCheck is the outcome of the per-file preparation stage shared by the
 // single-file and batch check paths: either an early terminal event (metadata
-// missing/corrupt, no segments) or the sampled segment IDs to Stat. Only the
-// ID strings survive past preparation, so the proto segment slice is
-// collectible before the network sweep begins.
+// missing/corrupt, no segments) or the sample plan to Stat. Only the ID
+// strings survive past preparation, so the proto segment slice is collectible
+// before the network sweep begins.
 type preparedCheck struct {
 	filePath      string
 	sourceNzbPath string
-	sampledIDs    []string
+	plan          usenet.SamplePlan
 	earlyEvent    *HealthEvent
 	// totalSegments is the full (unsampled) segment count, kept as a scalar so
 	// it survives past preparation for error reporting without holding onto
@@ -159,6 +165,14 @@ func (hc *HealthChecker) prepareCheck(ctx context.Context, filePath string, opts
 		return prep
 	}
 
+	if len(fileMeta.InlineData) > 0 {
+		// Generated sidecars are stored in the metadata and cannot go missing.
+		event := baseResultEvent(filePath, fileMeta.SourceNzbPath)
+		event.Type = EventTypeFileHealthy
+		prep.earlyEvent = &event
+		return prep
+	}
+
 	// Extract only the fields needed for validation. The local fileMeta pointer
 	// then falls out of scope and becomes eligible for GC — its proto wrapper
 	// (MessageState, unknownFields, sizeCache, Par2Files, NestedSources, etc.)
@@ -220,23 +234,146 @@ func (hc *HealthChecker) prepareCheck(ctx context.Context, filePath string, opts
 		return prep
 	}
 
-	// Sample and copy the message IDs so the proto segment slice becomes
-	// collectible before the network sweep begins.
-	selected := usenet.SelectSegmentsForValidation(input.segments, samplePercentage)
-	prep.sampledIDs = make([]string, len(selected))
-	for i, seg := range selected {
-		prep.sampledIDs[i] = seg.Id
-	}
+	// Plan the stratified sample; the plan only holds message IDs, so the
+	// proto segment slice becomes collectible before the network sweep begins.
+	prep.plan = usenet.PlanSegmentSample(input.segments, samplePercentage, metadata.KnownHolesFromProto(input.knownHoles))
 
 	return prep
 }
 
+// sampleRoundSize is how many body-stratum segments a round of a sampled
+// check adds before its confidence bound is re-evaluated.
+const sampleRoundSize = 10
+
+// sampleProgress is one file's progress through its sample plan.
+type sampleProgress struct {
+	sample       usenet.ValidationResult // head, tail, index and body strata
+	edges        usenet.ValidationResult // known-hole neighbours
+	nextBody     int
+	earlyStopped bool
+}
+
+// result merges the strata and hole-edge outcomes for judging.
+func (p sampleProgress) result() usenet.ValidationResult {
+	merged := usenet.ValidationResult{MissingIDs: []string{}}
+	mergeValidation(&merged, p.sample)
+	mergeValidation(&merged, p.edges)
+	return merged
+}
+
+// confidence is the missing-rate estimate of the progress so far.
+func (p sampleProgress) confidence(plan usenet.SamplePlan) *database.SampleConfidence {
+	iv := usenet.WilsonInterval(p.sample.MissingCount, p.sample.TotalChecked)
+	c := &database.SampleConfidence{
+		Checked:          iv.Checked,
+		Missing:          iv.Missing,
+		MissingRate:      iv.Estimate,
+		Lower:            iv.Lower,
+		Upper:            iv.Upper,
+		Level:            usenet.SampleConfidenceLevel,
+		Planned:          len(plan.Fixed) + len(plan.Body),
+		EarlyStopped:     p.earlyStopped,
+		Full:             plan.Full,
+		HoleEdgesChecked: p.edges.TotalChecked,
+		HoleEdgesMissing: p.edges.MissingCount,
+	}
+	if plan.Full {
+		// Every segment was checked: the rate is measured, not estimated.
+		c.Lower, c.Upper = iv.Estimate, iv.Estimate
+	}
+	return c
+}
+
+// mergeValidation adds src's outcome to dst, keeping the 50-ID cap.
+func mergeValidation(dst *usenet.ValidationResult, src usenet.ValidationResult) {
+	dst.TotalChecked += src.TotalChecked
+	dst.MissingCount += src.MissingCount
+	for _, id := range src.MissingIDs {
+		if len(dst.MissingIDs) >= 50 {
+			break
+		}
+		dst.MissingIDs = append(dst.MissingIDs, id)
+	}
+}
+
+// sweepSamples checks the sample plans of the prepared files (those without
+// an early event) in rounds of one cross-file StatMany sweep each. The first
+// round carries every file's head, tail and index strata, its known-hole
+// neighbours and its first body round; later rounds add body strata. A file
+// leaves the sweep once its plan is exhausted or, while no sampled segment is
+// missing, once the 95% upper bound on its missing rate is at or below the
+// configured target. Files with misses check their whole plan, so the
+// estimate behind their verdict is as tight as the budget allows.
+func (hc *HealthChecker) sweepSamples(ctx context.Context, preps []preparedCheck) ([]sampleProgress, error) {
+	cfg := hc.configGetter()
+	target := cfg.GetSampleConfidenceMissingRate()
+
+	progress := make([]sampleProgress, len(preps))
+	active := make([]bool, len(preps))
+	for i := range preps {
+		active[i] = preps[i].earlyEvent == nil
+	}
+
+	for round := 0; ; round++ {
+		// Two slots per file: even for the strata, odd for the hole edges.
+		perFileIDs := make([][]string, 2*len(preps))
+		pending := false
+		for i := range preps {
+			if !active[i] {
+				continue
+			}
+			plan, p := preps[i].plan, &progress[i]
+			var ids []string
+			if round == 0 {
+				ids = append(ids, plan.Fixed...)
+				perFileIDs[2*i+1] = plan.HoleEdges
+			}
+			end := min(p.nextBody+sampleRoundSize, len(plan.Body))
+			ids = append(ids, plan.Body[p.nextBody:end]...)
+			p.nextBody = end
+			perFileIDs[2*i] = ids
+			pending = true
+		}
+		if !pending {
+			return progress, nil
+		}
+
+		results, err := usenet.ValidateSegmentAvailabilityBatch(
+			ctx,
+			perFileIDs,
+			hc.poolManager,
+			cfg.GetMaxConnectionsForHealthChecks(),
+			cfg.GetHealthReadTimeout(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range preps {
+			if !active[i] {
+				continue
+			}
+			p := &progress[i]
+			mergeValidation(&p.sample, results[2*i])
+			mergeValidation(&p.edges, results[2*i+1])
+			switch {
+			case p.nextBody >= len(preps[i].plan.Body):
+				active[i] = false
+			case p.sample.MissingCount == 0 &&
+				usenet.WilsonInterval(0, p.sample.TotalChecked).Upper <= target:
+				p.earlyStopped = true
+				active[i] = false
+			}
+		}
+	}
+}
+
 // judgeValidation turns a prepared check's segment-sweep outcome into the
 // terminal HealthEvent, mirroring the pre-batch per-file semantics exactly.
 // It is a method (not a free function) because a missing-segment outcome
 // classifies playback impact via the hole model, which re-reads metadata
 // through hc.metadataService.
-func (hc *HealthChecker) judgeValidation(ctx context.Context, prep preparedCheck, result usenet.ValidationResult, valErr error) HealthEvent {
+func (hc *HealthChecker) judgeValidation(ctx context.Context, prep preparedCheck, progress sampleProgress, valErr error) HealthEvent {
 	event := baseResultEvent(prep.filePath, prep.sourceNzbPath)
 
 	if valErr != nil {
@@ -246,18 +383,28 @@ func (hc *HealthChecker) judgeValidation(ctx context.Context, prep preparedCheck
 		return event
 	}
 
+	result := progress.result()
+	event.Confidence = progress.confidence(prep.plan)
+
 	if result.MissingCount > 0 {
 		event.Type = EventTypeFileCorrupted
 		event.Status = database.HealthStatusCorrupted
 		event.Error = fmt.Errorf("%d of %d checked segments are missing from your Usenet provider",
 			result.MissingCount, result.TotalChecked)
 		event.Classification = hc.classifyHoles(ctx, prep.filePath, result)
+		event.Repairability = hc.assessRepairability(ctx, prep.filePath,
+			projectedMissingRate(event.Confidence, result, prep.totalSegments), prep.plan.Full)
+		if event.Classification != nil && event.Repairability != nil && event.Repairability.NeededBlocks > 0 {
+			score := event.Repairability.Score
+			event.Classification.RecoveryScore = &score
+		}
 		details := database.HealthErrorDetails{
 			ErrorType:       "missing_segments",
 			MissingArticles: result.MissingCount,
 			TotalArticles:   prep.totalSegments,
 			Sampled:         result.TotalChecked,
 			PlaybackImpact:  event.Classification,
+			Repairability:   event.Repairability,
 		}
 		event.Details = details.Marshal()
 		return event
@@ -272,6 +419,24 @@ func (hc *HealthChecker) judgeValidation(ctx context.Context, prep preparedCheck
 	return event
 }
 
+// projectedMissingRate is the missing rate the repairability estimate plans
+// for: the measured rate of a full check, the upper confidence bound of a
+// sampled one, and never less than the misses actually seen (hole-edge probes
+// do not count towards the estimate).
+func projectedMissingRate(c *database.SampleConfidence, result usenet.ValidationResult, totalSegments int) float64 {
+	rate := 0.0
+	if c != nil {
+		rate = c.Upper
+		if c.Full {
+			rate = c.MissingRate
+		}
+	}
+	if totalSegments > 0 {
+		rate = max(rate, float64(result.MissingCount)/float64(totalSegments))
+	}
+	return min(rate, 1)
+}
+
 // CheckFile checks the health of a specific file
 func (hc *HealthChecker) CheckFile(ctx context.Context, filePath string, opts ...CheckOptions) HealthEvent {
 	prep := hc.prepareCheck(ctx, filePath, opts...)
@@ -279,20 +444,17 @@ func (hc *HealthChecker) CheckFile(ctx context.Context, filePath string, opts ..
 		return *prep.earlyEvent
 	}
 
-	cfg := hc.configGetter()
-	results, err := usenet.ValidateSegmentAvailabilityBatch(
-		ctx,
-		[][]string{prep.sampledIDs},
-		hc.poolManager,
-		cfg.GetMaxConnectionsForHealthChecks(),
-		cfg.GetHealthReadTimeout(),
-	)
+	progress, err := hc.sweepSamples(ctx, []preparedCheck{prep})
 
-	var result usenet.ValidationResult
+	var result sampleProgress
 	if err == nil {
-		result = results[0]
+		result = progress[0]
 	}
-	return hc.judgeValidation(ctx, prep, result, err)
+	event := hc.judgeValidation(ctx, prep, result, err)
+	if err == nil {
+		hc.auditProviderCoverage(ctx, []preparedCheck{prep}, progress)
+	}
+	return event
 }
 
 // prepareConcurrency bounds the parallel metadata-read phase of a batch check.
@@ -320,21 +482,7 @@ func (hc *HealthChecker) CheckFilesBatch(ctx context.Context, filePaths []string
 	}
 	pl.Wait()
 
-	perFileIDs := make([][]string, len(preps))
-	for i := range preps {
-		if preps[i].earlyEvent == nil {
-			perFileIDs[i] = preps[i].sampledIDs
-		}
-	}
-
-	cfg := hc.configGetter()
-	results, valErr := usenet.ValidateSegmentAvailabilityBatch(
-		ctx,
-		perFileIDs,
-		hc.poolManager,
-		cfg.GetMaxConnectionsForHealthChecks(),
-		cfg.GetHealthReadTimeout(),
-	)
+	progress, valErr := hc.sweepSamples(ctx, preps)
 
 	events := make([]HealthEvent, len(preps))
 	for i := range preps {
@@ -342,12 +490,15 @@ func (hc *HealthChecker) CheckFilesBatch(ctx context.Context, filePaths []string
 			events[i] = *preps[i].earlyEvent
 			continue
 		}
-		var result usenet.ValidationResult
+		var result sampleProgress
 		if valErr == nil {
-			result = results[i]
+			result = progress[i]
 		}
 		events[i] = hc.judgeValidation(ctx, preps[i], result, valErr)
 	}
+	if valErr == nil {
+		hc.auditProviderCoverage(ctx, preps, progress)
+	}
 	return events
 }
 
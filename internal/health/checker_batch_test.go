@@ -224,3 +224,73 @@ func TestRunHealthCheckCycle_BatchExceedsMaxJobs(t *testing.T) {
 	).Scan(&stuck))
 	assert.Equal(t, 0, stuck, "no files should remain due after one cycle")
 }
+
+// writeSegmentedFile writes metadata for filePath with n one-byte segments and
+// returns their IDs.
+func writeSegmentedFile(t *testing.T, env *repairTestEnv, filePath string, n int) []string {
+	t.Helper()
+	ids := make([]string, n)
+	segments := make([]*metapb.SegmentData, n)
+	for i := range n {
+		ids[i] = fmt.Sprintf("seg-%d-%s@test.example.com", i, filePath)
+		segments[i] = &metapb.SegmentData{Id: ids[i], SegmentSize: 1, StartOffset: 0, EndOffset: 0}
+	}
+	meta := env.metadataService.CreateFileMetadata(
+		int64(n), "test.nzb", metapb.FileStatus_FILE_STATUS_HEALTHY,
+		segments,
+		metapb.Encryption_NONE, "", "", nil, nil, 0, nil, "",
+	)
+	require.NoError(t, env.metadataService.WriteFileMetadata(filePath, meta))
+	return ids
+}
+
+func TestCheckFile_SampleConfidence(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks not supported on Windows")
+	}
+
+	t.Run("clean sample stops once confident", func(t *testing.T) {
+		client := fakepool.New()
+		env := newBatchTestEnv(t, t.TempDir(), client)
+		writeSegmentedFile(t, env, "complete/big.mkv", 2000)
+
+		event := env.healthChecker.CheckFile(context.Background(), "complete/big.mkv")
+		assert.Equal(t, EventTypeFileHealthy, event.Type)
+		require.NotNil(t, event.Confidence)
+		c := event.Confidence
+		assert.True(t, c.EarlyStopped)
+		assert.False(t, c.Full)
+		assert.Equal(t, 55, c.Planned)
+		assert.Less(t, c.Checked, c.Planned)
+		assert.Equal(t, int64(c.Checked), client.StatCalls())
+		assert.Zero(t, c.Missing)
+		assert.LessOrEqual(t, c.Upper, 0.10, "stops at the default 10% target")
+	})
+
+	t.Run("a miss checks the whole plan", func(t *testing.T) {
+		client := fakepool.New()
+		env := newBatchTestEnv(t, t.TempDir(), client)
+		ids := writeSegmentedFile(t, env, "complete/big.mkv", 2000)
+		client.SetBehavior(ids[0], fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+
+		event := env.healthChecker.CheckFile(context.Background(), "complete/big.mkv")
+		require.NotNil(t, event.Confidence)
+		c := event.Confidence
+		assert.False(t, c.EarlyStopped)
+		assert.Equal(t, c.Planned, c.Checked)
+		assert.Equal(t, 1, c.Missing)
+		assert.Greater(t, c.Upper, c.MissingRate)
+	})
+
+	t.Run("small file is checked in full", func(t *testing.T) {
+		client := fakepool.New()
+		env := newBatchTestEnv(t, t.TempDir(), client)
+		writeHealthyFile(t, env, "complete/solo.mkv")
+
+		event := env.healthChecker.CheckFile(context.Background(), "complete/solo.mkv")
+		require.NotNil(t, event.Confidence)
+		assert.True(t, event.Confidence.Full)
+		assert.Equal(t, 1, event.Confidence.Checked)
+		assert.Zero(t, event.Confidence.Upper)
+	})
+}
@@ -0,0 +1,307 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/javi11/altmount/internal/database"
+)
+
+const (
+	// minExpiryObservations is the library history below which the model
+	// defers to the age tiers of CalculateNextCheck.
+	minExpiryObservations = 50
+	// expiryPriorWeight is how many files' worth of full exposure the prior
+	// of a group carries: a newsgroup, poster or provider needs more history
+	// than that before its own loss rate dominates.
+	expiryPriorWeight = 10
+	// expiryTargetRisk is the chance of an undetected loss the scheduler
+	// accepts between two checks.
+	expiryTargetRisk = 0.01
+	// expiryRiskHorizon is the window ExpiryRisk is expressed over.
+	expiryRiskHorizon = 7 * 24 * time.Hour
+	// expiryModelTTL is how long a built model is reused before it is rebuilt
+	// from the database.
+	expiryModelTTL = 6 * time.Hour
+	// playedCheckInterval is the longest a file being watched goes unchecked.
+	playedCheckInterval = 7 * 24 * time.Hour
+)
+
+// expiryAgeBucket is an age range of a release, over which the model assumes
+// a constant loss rate.
+type expiryAgeBucket struct {
+	upTo  time.Duration // exclusive upper bound; 0 for the open last bucket
+	span  time.Duration // exposure a file surviving the whole bucket contributes
+	label string
+	// priorRate is the default daily loss rate, the model's assumption before
+	// the library has history: takedowns cluster in the first days after
+	// posting, retention expiry only matters for old posts.
+	priorRate float64
+}
+
+var expiryAgeBuckets = []expiryAgeBucket{
+	{upTo: 24 * time.Hour, span: 24 * time.Hour, label: "0-1 days", priorRate: 0.01},
+	{upTo: 3 * 24 * time.Hour, span: 2 * 24 * time.Hour, label: "1-3 days", priorRate: 0.005},
+	{upTo: 7 * 24 * time.Hour, span: 4 * 24 * time.Hour, label: "3-7 days", priorRate: 0.002},
+	{upTo: 30 * 24 * time.Hour, span: 23 * 24 * time.Hour, label: "7-30 days", priorRate: 0.0005},
+	{upTo: 90 * 24 * time.Hour, span: 60 * 24 * time.Hour, label: "30-90 days", priorRate: 0.0002},
+	{upTo: 365 * 24 * time.Hour, span: 275 * 24 * time.Hour, label: "90-365 days", priorRate: 0.00005},
+	{span: 365 * 24 * time.Hour, label: "over a year", priorRate: 0.00002},
+}
+
+// expiryBucketFor returns the index of the bucket holding age.
+func expiryBucketFor(age time.Duration) int {
+	for i, b := range expiryAgeBuckets {
+		if b.upTo == 0 || age < b.upTo {
+			return i
+		}
+	}
+	return len(expiryAgeBuckets) - 1
+}
+
+// expiryStats is the loss history of one group of releases in one age bucket.
+type expiryStats struct {
+	files    int     // releases that reached the bucket
+	lost     int     // releases that first lost segments in the bucket
+	exposure float64 // days releases spent in the bucket while intact
+}
+
+// expiryTable is the loss history of one group of releases by age bucket.
+type expiryTable []expiryStats
+
+func newExpiryTable() expiryTable { return make(expiryTable, len(expiryAgeBuckets)) }
+
+// add spreads an observation over the buckets it lived through.
+func (t expiryTable) add(age time.Duration, lost bool) {
+	if age < 0 {
+		age = 0
+	}
+	start := time.Duration(0)
+	for i, b := range expiryAgeBuckets {
+		if age < start {
+			return
+		}
+		t[i].files++
+		in := age - start
+		if b.upTo == 0 || age < b.upTo {
+			t[i].exposure += in.Hours() / 24
+			if lost {
+				t[i].lost++
+			}
+			return
+		}
+		t[i].exposure += b.span.Hours() / 24
+		start = b.upTo
+	}
+}
+
+// rate is the smoothed daily loss rate of bucket i, pulled towards prior by
+// expiryPriorWeight files' worth of exposure.
+func (t expiryTable) rate(i int, prior float64) float64 {
+	weight := expiryPriorWeight * expiryAgeBuckets[i].span.Hours() / 24
+	return (float64(t[i].lost) + weight*prior) / (t[i].exposure + weight)
+}
+
+// ExpiryModel predicts when a release loses articles — DMCA takedowns early
+// on, retention expiry late — from the library's own health history, per
+// newsgroup, poster and primary provider. A release is as risky as its
+// riskiest attribute.
+type ExpiryModel struct {
+	global     expiryTable
+	byGroup    map[string]expiryTable
+	byPoster   map[string]expiryTable
+	byProvider map[string]expiryTable
+	total      int
+}
+
+// NewExpiryModel builds the model from the library's expiry history.
+func NewExpiryModel(observations []database.ExpiryObservation) *ExpiryModel {
+	m := &ExpiryModel{
+		global:     newExpiryTable(),
+		byGroup:    make(map[string]expiryTable),
+		byPoster:   make(map[string]expiryTable),
+		byProvider: make(map[string]expiryTable),
+		total:      len(observations),
+	}
+	add := func(tables map[string]expiryTable, key string, o database.ExpiryObservation) {
+		if key == "" {
+			return
+		}
+		t, ok := tables[key]
+		if !ok {
+			t = newExpiryTable()
+			tables[key] = t
+		}
+		t.add(o.Age, o.Lost)
+	}
+	for _, o := range observations {
+		m.global.add(o.Age, o.Lost)
+		add(m.byGroup, o.Newsgroup, o)
+		add(m.byPoster, o.Poster, o)
+		add(m.byProvider, o.Provider, o)
+	}
+	return m
+}
+
+// CheckSchedule is when a file is checked next, and why.
+type CheckSchedule struct {
+	NextCheck time.Time
+	// Risk is the predicted probability that the file loses segments within
+	// the next week at its current age.
+	Risk   float64
+	Reason string
+}
+
+// Schedule plans the next check of fh after a successful check at now: the
+// interval keeps the chance of an undetected loss under expiryTargetRisk,
+// within [minInterval, normalCheckInterval]. Without enough history (or
+// without a model) it falls back to the age tiers of CalculateNextCheck.
+func (m *ExpiryModel) Schedule(fh *database.FileHealth, now time.Time) CheckSchedule {
+	releaseDate := fh.CreatedAt
+	if fh.ReleaseDate != nil {
+		releaseDate = *fh.ReleaseDate
+	}
+	age := now.Sub(releaseDate)
+	bucket := expiryBucketFor(age)
+
+	if m == nil || m.total < minExpiryObservations {
+		return CheckSchedule{
+			NextCheck: CalculateNextCheck(releaseDate, now),
+			Risk:      expiryRisk(expiryAgeBuckets[bucket].priorRate),
+			Reason:    "age-based schedule: not enough check history yet",
+		}
+	}
+
+	global := m.global.rate(bucket, expiryAgeBuckets[bucket].priorRate)
+	rate, reason := global, describeExpiry("library", "", m.global, bucket)
+	for _, g := range []struct {
+		kind   string
+		key    *string
+		tables map[string]expiryTable
+	}{
+		{"newsgroup", fh.Newsgroup, m.byGroup},
+		{"poster", fh.Poster, m.byPoster},
+		{"provider", primaryProviderOf(fh), m.byProvider},
+	} {
+		if g.key == nil || *g.key == "" {
+			continue
+		}
+		t, ok := g.tables[*g.key]
+		if !ok {
+			continue
+		}
+		if r := t.rate(bucket, global); r > rate {
+			rate, reason = r, describeExpiry(g.kind, *g.key, t, bucket)
+		}
+	}
+
+	interval := normalCheckInterval
+	if days := -math.Log(1-expiryTargetRisk) / rate; days < normalCheckInterval.Hours()/24 {
+		interval = time.Duration(days * float64(24*time.Hour))
+	} else {
+		// Spread the bulk of stable files like the age tiers do (+/- 7 days).
+		interval += time.Duration(rand.Intn(14)-7) * 24 * time.Hour
+	}
+	interval = max(interval, minInterval)
+
+	risk := expiryRisk(rate)
+	return CheckSchedule{
+		NextCheck: now.Add(interval),
+		Risk:      risk,
+		Reason:    fmt.Sprintf("%s: %s", expiryRiskLevel(risk), reason),
+	}
+}
+
+// describeExpiry renders the history behind a rate for the API.
+func describeExpiry(kind, key string, t expiryTable, bucket int) string {
+	s := t[bucket]
+	subject := kind
+	if key != "" {
+		subject = fmt.Sprintf("%s %s", kind, key)
+	}
+	return fmt.Sprintf("%s: %d of %d files lost segments at %s old",
+		subject, s.lost, s.files, expiryAgeBuckets[bucket].label)
+}
+
+// expiryRisk converts a daily loss rate into the probability of a loss
+// within expiryRiskHorizon.
+func expiryRisk(rate float64) float64 {
+	return 1 - math.Exp(-rate*expiryRiskHorizon.Hours()/24)
+}
+
+// expiryRiskLevel names a risk for the schedule reason.
+func expiryRiskLevel(risk float64) string {
+	switch {
+	case risk >= 0.25:
+		return "high takedown risk"
+	case risk >= 0.02:
+		return "elevated risk"
+	default:
+		return "stable"
+	}
+}
+
+// primaryProviderOf returns the primary provider of the file's import sample.
+func primaryProviderOf(fh *database.FileHealth) *string {
+	p := database.PrimaryProvider(database.ParseProviderCompleteness(fh.ProviderCompleteness))
+	if p == "" {
+		return nil
+	}
+	return &p
+}
+
+// scheduleNextCheck plans the next check of a file that just passed one.
+// Files being watched are checked at least every playedCheckInterval.
+func (hw *HealthWorker) scheduleNextCheck(ctx context.Context, fh *database.FileHealth) CheckSchedule {
+	now := time.Now().UTC()
+	schedule := hw.currentExpiryModel(ctx).Schedule(fh, now)
+	if isBeingWatched(fh, now) && schedule.NextCheck.After(now.Add(playedCheckInterval)) {
+		schedule.NextCheck = now.Add(playedCheckInterval)
+		schedule.Reason += "; checked weekly while being watched"
+	}
+	return schedule
+}
+
+// isBeingWatched reports whether fh is in "continue watching" or was played
+// within database.PlaybackRecentWindow.
+func isBeingWatched(fh *database.FileHealth, now time.Time) bool {
+	if fh.ContinueWatching {
+		return true
+	}
+	return fh.LastStreamedAt != nil && now.Sub(*fh.LastStreamedAt) < database.PlaybackRecentWindow
+}
+
+// currentExpiryModel returns the expiry model, rebuilding it once it is older
+// than expiryModelTTL. It returns nil — the age-tier fallback — while no
+// model could be built.
+func (hw *HealthWorker) currentExpiryModel(ctx context.Context) *ExpiryModel {
+	hw.expiryMu.Lock()
+	defer hw.expiryMu.Unlock()
+	if hw.healthRepo == nil || time.Since(hw.expiryBuiltAt) < expiryModelTTL {
+		return hw.expiryModel
+	}
+	// Set before the query so a failing database is not hit for every file.
+	hw.expiryBuiltAt = time.Now()
+	observations, err := hw.healthRepo.GetExpiryObservations(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to build article-expiry model, keeping the previous one", "error", err)
+		return hw.expiryModel
+	}
+	hw.expiryModel = NewExpiryModel(observations)
+	slog.DebugContext(ctx, "Rebuilt article-expiry model", "observations", len(observations))
+	return hw.expiryModel
+}
+
+// recordSegmentLoss logs the first confirmed loss of a file's release.
+func (hw *HealthWorker) recordSegmentLoss(ctx context.Context, fh *database.FileHealth) {
+	if hw.healthRepo == nil {
+		return
+	}
+	if err := hw.healthRepo.RecordSegmentLoss(ctx, fh); err != nil {
+		slog.WarnContext(ctx, "Failed to record segment loss", "file_path", fh.FilePath, "error", err)
+	}
+}
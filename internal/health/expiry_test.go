@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiryHistory is a library where a hot newsgroup loses most releases two
+// days after posting while a calm one keeps them for months.
+func expiryHistory() []database.ExpiryObservation {
+	var obs []database.ExpiryObservation
+	for i := range 40 {
+		obs = append(obs, database.ExpiryObservation{
+			Newsgroup: "alt.binaries.hot",
+			Age:       2 * 24 * time.Hour,
+			Lost:      i < 30,
+		})
+	}
+	for range 60 {
+		obs = append(obs, database.ExpiryObservation{
+			Newsgroup: "alt.binaries.calm",
+			Age:       200 * 24 * time.Hour,
+		})
+	}
+	return obs
+}
+
+func TestExpiryModel_FallsBackWithoutHistory(t *testing.T) {
+	now := time.Now().UTC()
+	release := now.Add(-2 * 24 * time.Hour)
+	fh := &database.FileHealth{ReleaseDate: &release, CreatedAt: release}
+
+	for name, m := range map[string]*ExpiryModel{
+		"no model":      nil,
+		"short history": NewExpiryModel(expiryHistory()[:10]),
+	} {
+		s := m.Schedule(fh, now)
+		assert.Contains(t, s.Reason, "not enough check history", name)
+		// Age tiers: a 2-day-old file is checked again within a day.
+		assert.False(t, s.NextCheck.After(now.Add(24*time.Hour)), name)
+	}
+}
+
+func TestExpiryModel_HotNewsgroupIsCheckedSoon(t *testing.T) {
+	now := time.Now().UTC()
+	release := now.Add(-2 * 24 * time.Hour)
+	group := "alt.binaries.hot"
+	fh := &database.FileHealth{ReleaseDate: &release, CreatedAt: release, Newsgroup: &group}
+
+	s := NewExpiryModel(expiryHistory()).Schedule(fh, now)
+
+	assert.Equal(t, now.Add(minInterval), s.NextCheck)
+	assert.Greater(t, s.Risk, 0.25)
+	assert.Equal(t, "high takedown risk: newsgroup alt.binaries.hot: 30 of 40 files lost segments at 1-3 days old", s.Reason)
+}
+
+func TestExpiryModel_StableReleaseIsCheckedRarely(t *testing.T) {
+	now := time.Now().UTC()
+	release := now.Add(-200 * 24 * time.Hour)
+	group := "alt.binaries.calm"
+	fh := &database.FileHealth{ReleaseDate: &release, CreatedAt: release, Newsgroup: &group}
+
+	s := NewExpiryModel(expiryHistory()).Schedule(fh, now)
+
+	assert.False(t, s.NextCheck.Before(now.Add(normalCheckInterval-7*24*time.Hour)))
+	assert.Less(t, s.Risk, 0.02)
+	assert.Contains(t, s.Reason, "stable: ")
+}
+
+func TestScheduleNextCheck_WatchedFileCheckedWeekly(t *testing.T) {
+	hw := &HealthWorker{expiryModel: NewExpiryModel(expiryHistory()), expiryBuiltAt: time.Now()}
+	release := time.Now().UTC().Add(-200 * 24 * time.Hour)
+	group := "alt.binaries.calm"
+	fh := &database.FileHealth{ReleaseDate: &release, CreatedAt: release, Newsgroup: &group, ContinueWatching: true}
+
+	s := hw.scheduleNextCheck(t.Context(), fh)
+
+	assert.False(t, s.NextCheck.After(time.Now().UTC().Add(playedCheckInterval)))
+	assert.Contains(t, s.Reason, "while being watched")
+}
+
+func TestPrepareUpdateForResult_RecordsOnlyConfirmedLoss(t *testing.T) {
+	ctx := context.Background()
+	env := newRepairTestEnv(t, t.TempDir(), nil)
+	filePath := "movies/lost.mkv"
+	require.NoError(t, env.metadataService.WriteFileMetadata(filePath, validSegmentMeta(env.metadataService, 1024)))
+	insertFileHealth(t, env.db, filePath, filePath, 0, 3)
+	event := HealthEvent{Type: EventTypeFileCorrupted, FilePath: filePath, Status: database.HealthStatusCorrupted}
+
+	lossEvents := func() int {
+		var n int
+		require.NoError(t, env.db.QueryRow(`SELECT COUNT(*) FROM segment_loss_events`).Scan(&n))
+		return n
+	}
+
+	// A miss that will be retried may be transient.
+	fh, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	update, sideEffect := env.hw.prepareUpdateForResult(ctx, fh, event)
+	require.Equal(t, database.UpdateTypeRetry, update.Type)
+	require.NoError(t, sideEffect())
+	fh, err = env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	assert.Nil(t, fh.FirstLossAt, "a retried miss is not a loss")
+	assert.Zero(t, lossEvents())
+
+	// Retries spent: the loss is confirmed, and recorded by the side effect.
+	fh.RetryCount = 2
+	update, sideEffect = env.hw.prepareUpdateForResult(ctx, fh, event)
+	require.Equal(t, database.UpdateTypeRepairTrigger, update.Type)
+	assert.Zero(t, lossEvents(), "nothing is written before the side effect runs")
+	require.NoError(t, sideEffect())
+	fh, err = env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	assert.NotNil(t, fh.FirstLossAt)
+	assert.Equal(t, 1, lossEvents())
+}
@@ -0,0 +1,354 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
+	"github.com/javi11/altmount/internal/metadata"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/javi11/altmount/internal/pool"
+	"github.com/javi11/nntppool/v4"
+	"github.com/javi11/nzbparser"
+	concpool "github.com/sourcegraph/conc/pool"
+)
+
+// ErrFillNoStore is returned by FillFromAlternate for files whose metadata
+// predates the shared NZB store: without it the part numbers the matching
+// relies on are unknown.
+var ErrFillNoStore = errors.New("file metadata has no NZB store")
+
+// fillSizeTolerance is how far (as a fraction) an alternate article's declared
+// size may differ from the original's. Identical yEnc parts only differ in
+// their article headers, so sizes are close but rarely equal.
+const fillSizeTolerance = 0.02
+
+// FillOptions tunes FillFromAlternate.
+type FillOptions struct {
+	// MaxConnections caps the Stats and article fetches in flight.
+	MaxConnections int
+	// Timeout is the per-article timeout, scaled to the batch size for Stats.
+	Timeout time.Duration
+}
+
+// FillResult is the outcome of FillFromAlternate.
+type FillResult struct {
+	FilePath string `json:"file_path"`
+	// Segments is the number of distinct articles the file references.
+	Segments int `json:"segments"`
+	Missing  int `json:"missing"`
+	Filled   int `json:"filled"`
+	// Unmatched counts missing articles with no counterpart in the alternate
+	// NZB (no matching file, part number or size).
+	Unmatched int `json:"unmatched"`
+	// Rejected counts counterparts that were themselves missing or failed
+	// verification (CRC, part number or decoded size).
+	Rejected     int         `json:"rejected"`
+	MatchedFiles []FillMatch `json:"matched_files,omitempty"`
+}
+
+// FillMatch pairs an NZB file of the original release with its counterpart in
+// the alternate NZB.
+type FillMatch struct {
+	Original  string `json:"original"`
+	Alternate string `json:"alternate"`
+	// By is how the files were matched: "name" or "size".
+	By string `json:"by"`
+}
+
+// fillCandidate is a missing article and the alternate article proposed for it.
+type fillCandidate struct {
+	index        int64
+	number       int32
+	alternateID  string
+	expectedSize int64 // decoded size, 0 when unknown
+}
+
+// FillFromAlternate replaces the missing articles of the file at filePath with
+// the matching articles of an alternate NZB of the same upload (another
+// indexer's copy, or a repost with identical yEnc parts). Files are matched
+// by name, falling back to segment count and size; articles by part number
+// and size. Every replacement is fetched and must decode with a valid CRC,
+// the same part number and the same decoded size before it is accepted.
+//
+// Accepted replacements are written to the file's NZB store, so every file of
+// the release that shares an article benefits. The caller re-runs the health
+// check afterwards.
+func FillFromAlternate(ctx context.Context, ms *metadata.MetadataService, poolManager pool.Manager, filePath string, alt *nzbparser.Nzb, opts FillOptions) (*FillResult, error) {
+	meta, err := ms.ReadFileMetadata(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read metadata: %w", err)
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("no metadata for %s", filePath)
+	}
+	if meta.StoreRef == "" {
+		return nil, ErrFillNoStore
+	}
+	store, err := ms.Store().ReadStore(meta.StoreRef)
+	if err != nil {
+		return nil, fmt.Errorf("read NZB store: %w", err)
+	}
+	if alt == nil || len(alt.Files) == 0 {
+		return nil, fmt.Errorf("alternate NZB contains no files")
+	}
+	if poolManager == nil || !poolManager.HasPool() {
+		return nil, fmt.Errorf("usenet connection pool is not available")
+	}
+	usenetPool, err := poolManager.GetPool()
+	if err != nil {
+		return nil, fmt.Errorf("usenet connection pool unavailable: %w", err)
+	}
+	if opts.MaxConnections <= 0 {
+		opts.MaxConnections = 1
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+
+	// Locate every referenced article in the store: flat index → owning file.
+	flat := metadata.FlatSegments(store)
+	owner := make([]int, 0, len(flat))
+	for fi, f := range store.Files {
+		for range f.Segments {
+			owner = append(owner, fi)
+		}
+	}
+	index := make(map[string]int64, len(flat))
+	for i, s := range flat {
+		if _, ok := index[s.Id]; !ok {
+			index[s.Id] = int64(i)
+		}
+	}
+	expected := make(map[int64]int64)
+	addRefs := func(segs []*metapb.SegmentData) {
+		for _, sd := range segs {
+			idx, ok := index[sd.Id]
+			if !ok {
+				continue
+			}
+			// A resolved size below the article's declared size is the recorded
+			// decoded size; otherwise the decoded size was never recorded.
+			size := int64(0)
+			if sd.SegmentSize > 0 && sd.SegmentSize < flat[idx].Bytes {
+				size = sd.SegmentSize
+			}
+			if cur, seen := expected[idx]; !seen || cur == 0 {
+				expected[idx] = size
+			}
+		}
+	}
+	addRefs(meta.SegmentData)
+	for _, ns := range meta.NestedSources {
+		addRefs(ns.Segments)
+	}
+	for _, p := range meta.Par2Files {
+		addRefs(p.SegmentData)
+	}
+
+	res := &FillResult{FilePath: filePath, Segments: len(expected)}
+	if len(expected) == 0 {
+		return res, nil
+	}
+
+	ids := make([]string, 0, len(expected))
+	for idx := range expected {
+		ids = append(ids, flat[idx].Id)
+	}
+	statCtx, cancel := context.WithTimeout(ctx, pool.StatManyTimeout(len(ids), opts.MaxConnections, opts.Timeout))
+	var missing []int64
+	for r := range usenetPool.StatMany(statCtx, ids, nntppool.StatManyOptions{Concurrency: opts.MaxConnections}) {
+		if errors.Is(r.Err, nntppool.ErrArticleNotFound) {
+			missing = append(missing, index[r.MessageID])
+		}
+	}
+	cancel()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	res.Missing = len(missing)
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	// Match the store files holding missing articles to alternate files.
+	matches := make(map[int]int)
+	for _, idx := range missing {
+		fi := owner[idx]
+		if _, done := matches[fi]; done {
+			continue
+		}
+		ai, by := matchAlternateFile(store.Files[fi], alt)
+		matches[fi] = ai
+		if ai >= 0 {
+			res.MatchedFiles = append(res.MatchedFiles, FillMatch{
+				Original:  storeFileName(store.Files[fi]),
+				Alternate: alt.Files[ai].Filename,
+				By:        by,
+			})
+		}
+	}
+
+	var candidates []fillCandidate
+	for _, idx := range missing {
+		ai := matches[owner[idx]]
+		orig := flat[idx]
+		altID, ok := "", false
+		if ai >= 0 {
+			altID, ok = matchAlternateSegment(orig, alt.Files[ai])
+		}
+		if !ok {
+			res.Unmatched++
+			continue
+		}
+		candidates = append(candidates, fillCandidate{
+			index:        idx,
+			number:       orig.Number,
+			alternateID:  altID,
+			expectedSize: expected[idx],
+		})
+	}
+
+	var mu sync.Mutex
+	accepted := make(map[int64]string, len(candidates))
+	pl := concpool.New().WithMaxGoroutines(max(min(opts.MaxConnections, len(candidates)), 1))
+	for _, c := range candidates {
+		pl.Go(func() {
+			fetchCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+			ok := verifyAlternateArticle(fetchCtx, usenetPool, c)
+			mu.Lock()
+			defer mu.Unlock()
+			if ok {
+				accepted[c.index] = c.alternateID
+			} else {
+				res.Rejected++
+			}
+		})
+	}
+	pl.Wait()
+
+	if len(accepted) > 0 {
+		if err := ms.Store().ReplaceSegmentIDs(meta.StoreRef, accepted); err != nil {
+			return nil, fmt.Errorf("update NZB store: %w", err)
+		}
+	}
+	res.Filled = len(accepted)
+	return res, nil
+}
+
+// storeFileName is the filename carried in a stored NZB file's subject.
+func storeFileName(f *metapb.NzbFileEntry) string {
+	subject, err := nzbparser.ParseSubject(f.Subject)
+	if err != nil || subject.Filename == "" {
+		return f.Subject
+	}
+	return nzbtrim.TrimSurroundingQuotes(subject.Filename)
+}
+
+// matchAlternateFile finds the alternate counterpart of a stored NZB file: a
+// file of the same name (preferring one with the same segment count), or else
+// the only file with the same segment count and total size. Returns -1 when
+// nothing matches.
+func matchAlternateFile(f *metapb.NzbFileEntry, alt *nzbparser.Nzb) (int, string) {
+	name := strings.ToLower(storeFileName(f))
+	var size int64
+	for _, s := range f.Segments {
+		size += s.Bytes
+	}
+
+	byName := -1
+	for i, af := range alt.Files {
+		if strings.ToLower(af.Filename) != name {
+			continue
+		}
+		if len(af.Segments) == len(f.Segments) {
+			return i, "name"
+		}
+		if byName < 0 {
+			byName = i
+		}
+	}
+	if byName >= 0 {
+		return byName, "name"
+	}
+
+	bySize := -1
+	for i, af := range alt.Files {
+		if len(af.Segments) != len(f.Segments) {
+			continue
+		}
+		var altSize int64
+		for _, s := range af.Segments {
+			altSize += int64(s.Bytes)
+		}
+		if !sizesMatch(size, altSize) {
+			continue
+		}
+		if bySize >= 0 {
+			return -1, "" // ambiguous
+		}
+		bySize = i
+	}
+	if bySize >= 0 {
+		return bySize, "size"
+	}
+	return -1, ""
+}
+
+// matchAlternateSegment returns the alternate article with the same part
+// number and a matching size. The original article itself never matches.
+func matchAlternateSegment(orig *metapb.NzbSeg, af nzbparser.NzbFile) (string, bool) {
+	for _, s := range af.Segments {
+		if int32(s.Number) != orig.Number {
+			continue
+		}
+		if s.ID == "" || s.ID == orig.Id || !sizesMatch(orig.Bytes, int64(s.Bytes)) {
+			return "", false
+		}
+		return s.ID, true
+	}
+	return "", false
+}
+
+// sizesMatch reports whether two declared sizes are within fillSizeTolerance.
+func sizesMatch(a, b int64) bool {
+	if a <= 0 || b <= 0 {
+		return a == b
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return float64(diff) <= fillSizeTolerance*float64(max(a, b))
+}
+
+// verifyAlternateArticle fetches the alternate article and accepts it only
+// when it decodes with a valid CRC, carries the expected part number and,
+// when the original's decoded size is known, decodes to the same size.
+func verifyAlternateArticle(ctx context.Context, usenetPool pool.NntpClient, c fillCandidate) bool {
+	body, err := usenetPool.Body(ctx, c.alternateID)
+	if err != nil || body == nil {
+		return false
+	}
+	if body.ExpectedCRC != 0 && !body.CRCValid {
+		return false
+	}
+	if body.YEnc.Part != 0 && body.YEnc.Part != int64(c.number) {
+		return false
+	}
+	if c.expectedSize > 0 {
+		decoded := int64(body.BytesDecoded)
+		if body.YEnc.PartSize > 0 {
+			decoded = body.YEnc.PartSize
+		}
+		if decoded != c.expectedSize {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"hash/crc32"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/metadata"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/javi11/altmount/internal/pool"
+	"github.com/javi11/altmount/internal/testsupport/fakepool"
+	"github.com/javi11/altmount/internal/testsupport/nzbbuild"
+	"github.com/javi11/nntppool/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fillPoolManager serves a fakepool client through the otherwise inert mock.
+type fillPoolManager struct {
+	*mockPoolManager
+	client *fakepool.Client
+}
+
+func (m fillPoolManager) GetPool() (pool.NntpClient, error) { return m.client, nil }
+func (m fillPoolManager) HasPool() bool                     { return true }
+
+// setupFillFile writes a four-segment store-backed movie whose parts decode to
+// 100 bytes each, and returns the metadata service and its virtual path.
+func setupFillFile(t *testing.T) (*metadata.MetadataService, string) {
+	t.Helper()
+	ms := metadata.NewMetadataService(t.TempDir())
+	storeRef := filepath.Join(t.TempDir(), "rel.nzbz")
+	store := &metapb.NzbStore{Files: []*metapb.NzbFileEntry{{
+		Subject: `[1/1] - "Movie.mkv" yEnc (1/4)`,
+		Groups:  []string{"alt.binaries.test"},
+		Segments: []*metapb.NzbSeg{
+			{Id: "m1@x", Number: 1, Bytes: 130},
+			{Id: "m2@x", Number: 2, Bytes: 130},
+			{Id: "m3@x", Number: 3, Bytes: 130},
+			{Id: "m4@x", Number: 4, Bytes: 130},
+		},
+	}}}
+	require.NoError(t, ms.Store().WriteStore(storeRef, store))
+
+	vpath := filepath.Join("movies", "Movie.mkv")
+	require.NoError(t, ms.WriteFileMetadata(vpath, &metapb.FileMetadata{
+		FileSize:    400,
+		Status:      metapb.FileStatus_FILE_STATUS_CORRUPTED,
+		StoreRef:    storeRef,
+		SegmentRuns: []*metapb.SegmentRun{{BaseStoreIndex: 0, Count: 4, DecodedBytes: 100}},
+	}))
+	return ms, vpath
+}
+
+func alternateNzbFile(name string) nzbbuild.File {
+	return nzbbuild.File{Subject: name, Segments: []nzbbuild.Segment{
+		{ID: "a1@y", Bytes: 131}, {ID: "a2@y", Bytes: 131}, {ID: "a3@y", Bytes: 131}, {ID: "a4@y", Bytes: 131},
+	}}
+}
+
+func goodPart(part int64) fakepool.SegmentBehavior {
+	payload := bytes.Repeat([]byte{byte(part)}, 100)
+	return fakepool.SegmentBehavior{
+		Bytes:       payload,
+		YEnc:        nntppool.YEncMeta{Part: part, PartSize: 100},
+		ExpectedCRC: crc32.ChecksumIEEE(payload),
+	}
+}
+
+func fillOpts() FillOptions {
+	return FillOptions{MaxConnections: 4, Timeout: time.Second}
+}
+
+func TestFillFromAlternate_ReplacesVerifiedArticles(t *testing.T) {
+	ms, vpath := setupFillFile(t)
+	client := fakepool.New()
+	client.SetBehavior("m2@x", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	client.SetBehavior("m3@x", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	client.SetBehavior("a2@y", goodPart(2))
+	// a3 is a different upload's part 3: its CRC does not match its payload.
+	bad := goodPart(3)
+	bad.ExpectedCRC++
+	client.SetBehavior("a3@y", bad)
+
+	alt := nzbbuild.Build(alternateNzbFile("Movie.mkv"))
+	res, err := FillFromAlternate(context.Background(), ms, fillPoolManager{client: client}, vpath, alt, fillOpts())
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, res.Segments)
+	assert.Equal(t, 2, res.Missing)
+	assert.Equal(t, 1, res.Filled)
+	assert.Equal(t, 1, res.Rejected)
+	assert.Zero(t, res.Unmatched)
+	require.Len(t, res.MatchedFiles, 1)
+	assert.Equal(t, FillMatch{Original: "Movie.mkv", Alternate: "Movie.mkv", By: "name"}, res.MatchedFiles[0])
+
+	meta, err := ms.ReadFileMetadata(vpath)
+	require.NoError(t, err)
+	require.Len(t, meta.SegmentData, 4)
+	assert.Equal(t, "a2@y", meta.SegmentData[1].Id, "the verified alternate replaces the missing article")
+	assert.Equal(t, "m3@x", meta.SegmentData[2].Id, "a rejected alternate leaves the original in place")
+	assert.Equal(t, int64(100), meta.SegmentData[1].SegmentSize)
+}
+
+func TestFillFromAlternate_MatchesRenamedRepostBySize(t *testing.T) {
+	ms, vpath := setupFillFile(t)
+	client := fakepool.New()
+	client.SetBehavior("m4@x", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	client.SetBehavior("a4@y", goodPart(4))
+
+	alt := nzbbuild.Build(
+		nzbbuild.File{Subject: "sample.mkv", Segments: []nzbbuild.Segment{{ID: "s1@y", Bytes: 131}}},
+		alternateNzbFile("a8f3c1d9e2.bin"),
+	)
+	res, err := FillFromAlternate(context.Background(), ms, fillPoolManager{client: client}, vpath, alt, fillOpts())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, res.Filled)
+	require.Len(t, res.MatchedFiles, 1)
+	assert.Equal(t, "size", res.MatchedFiles[0].By)
+}
+
+func TestFillFromAlternate_RejectsWrongPartSize(t *testing.T) {
+	ms, vpath := setupFillFile(t)
+	client := fakepool.New()
+	client.SetBehavior("m1@x", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	part := goodPart(1)
+	part.YEnc.PartSize = 120
+	client.SetBehavior("a1@y", part)
+
+	alt := nzbbuild.Build(alternateNzbFile("Movie.mkv"))
+	res, err := FillFromAlternate(context.Background(), ms, fillPoolManager{client: client}, vpath, alt, fillOpts())
+	require.NoError(t, err)
+
+	assert.Zero(t, res.Filled)
+	assert.Equal(t, 1, res.Rejected)
+}
+
+func TestFillFromAlternate_UnmatchedFile(t *testing.T) {
+	ms, vpath := setupFillFile(t)
+	client := fakepool.New()
+	client.SetBehavior("m1@x", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+
+	alt := nzbbuild.Build(nzbbuild.File{Subject: "Other.mkv", Segments: []nzbbuild.Segment{{ID: "o1@y", Bytes: 131}}})
+	res, err := FillFromAlternate(context.Background(), ms, fillPoolManager{client: client}, vpath, alt, fillOpts())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, res.Unmatched)
+	assert.Empty(t, res.MatchedFiles)
+	assert.Zero(t, client.BodyCalls(), "nothing to verify without a match")
+}
+
+func TestFillFromAlternate_RequiresStore(t *testing.T) {
+	ms := metadata.NewMetadataService(t.TempDir())
+	vpath := filepath.Join("movies", "Legacy.mkv")
+	require.NoError(t, ms.WriteFileMetadata(vpath, &metapb.FileMetadata{
+		FileSize:    100,
+		SegmentData: []*metapb.SegmentData{{Id: "l1@x", SegmentSize: 100, EndOffset: 99}},
+	}))
+
+	alt := nzbbuild.Build(alternateNzbFile("Legacy.mkv"))
+	_, err := FillFromAlternate(context.Background(), ms, fillPoolManager{client: fakepool.New()}, vpath, alt, fillOpts())
+	assert.ErrorIs(t, err, ErrFillNoStore)
+}
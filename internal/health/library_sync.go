@@ -17,6 +17,7 @@ import (
 
 	"github.com/javi11/altmount/internal/config"
 	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/mediaserver"
 	"github.com/javi11/altmount/internal/metadata"
 	"github.com/javi11/altmount/internal/utils"
 	"github.com/javi11/altmount/pkg/rclonecli"
@@ -44,6 +45,7 @@ type SyncResult struct {
 	MetadataDeleted     int           `json:"metadata_deleted"`
 	LibraryFilesDeleted int           `json:"library_files_deleted"`
 	LibraryDirsDeleted  int           `json:"library_dirs_deleted"`
+	Full                bool          `json:"full"`
 	Duration            time.Duration `json:"duration"`
 	CompletedAt         time.Time     `json:"completed_at"`
 }
@@ -75,6 +77,12 @@ type LibrarySyncWorker struct {
 	lastSyncResult  *SyncResult
 	manualTrigger   chan struct{}
 	rcloneClient    rclonecli.RcloneRcClient
+	mediaServers    func(*config.Config) []*mediaserver.Server
+	// fileIndex lets periodic syncs diff against the persisted index instead of
+	// walking the metadata and library trees. nil means every sync is full.
+	fileIndex *database.FileIndexRepository
+	// fullSyncRequested forces the next sync to walk in full (manual triggers).
+	fullSyncRequested atomic.Bool
 }
 
 // NewLibrarySyncWorker creates a new library sync worker
@@ -91,6 +99,7 @@ func NewLibrarySyncWorker(
 		configGetter:    configGetter,
 		configManager:   configManager,
 		rcloneClient:    rcloneClient,
+		mediaServers:    mediaserver.FromConfig,
 		manualTrigger:   make(chan struct{}, 1), // Buffered channel for non-blocking sends
 	}
 
@@ -254,6 +263,7 @@ func (lsw *LibrarySyncWorker) run(ctx context.Context) {
 			lsw.safeSyncLibrary(ctx, false)
 		case <-lsw.manualTrigger:
 			slog.InfoContext(ctx, "Manual library sync trigger received")
+			lsw.fullSyncRequested.Store(true)
 			lsw.safeSyncLibrary(ctx, false)
 		}
 	}
@@ -337,6 +347,7 @@ func (lsw *LibrarySyncWorker) recordSyncResult(
 	cleanup cleanupCounts,
 	totalMetadataFiles int,
 	totalDbRecords int,
+	full bool,
 ) {
 	duration := time.Since(startTime)
 	result := &SyncResult{
@@ -345,6 +356,7 @@ func (lsw *LibrarySyncWorker) recordSyncResult(
 		MetadataDeleted:     cleanup.metadataDeleted,
 		LibraryFilesDeleted: cleanup.libraryFilesDeleted,
 		LibraryDirsDeleted:  cleanup.libraryDirsDeleted,
+		Full:                full,
 		Duration:            duration,
 		CompletedAt:         time.Now(),
 	}
@@ -363,6 +375,7 @@ func (lsw *LibrarySyncWorker) recordSyncResult(
 
 	// Log completion
 	slog.InfoContext(ctx, "Library sync completed",
+		"full", full,
 		"total_metadata_files", totalMetadataFiles,
 		"total_db_records", totalDbRecords,
 		"added", dbCounts.added,
@@ -463,13 +476,21 @@ func (lsw *LibrarySyncWorker) initializeProgressTracking(startTime time.Time) fu
 	}
 }
 
-// SyncLibrary performs a full library synchronization. If dryRun is true,
+// SyncLibrary performs a library synchronization. If dryRun is true,
 // it will count what would be deleted without actually deleting anything,
 // and return a DryRunResult. If dryRun is false, it performs the sync normally
-// and returns nil.
+// and returns nil. With a file index configured, periodic syncs diff against
+// the index and only walk the metadata and library trees in full every
+// health.library_sync_full_interval_hours (see shouldSyncIncrementally).
 func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *DryRunResult {
 	startTime := time.Now()
 	cfg := lsw.configGetter()
+
+	if lsw.shouldSyncIncrementally(ctx, cfg, dryRun) {
+		lsw.syncIncremental(ctx, startTime)
+		return nil
+	}
+
 	slog.InfoContext(ctx, "Starting library sync")
 
 	// Determine mount paths for symlink updates
@@ -495,10 +516,11 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 	var metadataFiles []string
 	var libraryFiles *UsedFiles
 	var importDirFiles *UsedFiles
+	var mediaServerFiles map[string]bool
 	var librarySymlinksUpdated, importSymlinksUpdated int
-	var libraryWalkErrors, importWalkErrors int
+	var libraryWalkErrors, importWalkErrors, mediaServerErrors int
 
-	fsWalkPool := pool.New().WithErrors().WithMaxGoroutines(3)
+	fsWalkPool := pool.New().WithErrors().WithMaxGoroutines(4)
 
 	// Get all metadata files from filesystem
 	fsWalkPool.Go(func() error {
@@ -536,6 +558,12 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 		return nil
 	})
 
+	// Get the files media servers reference directly from the mount
+	fsWalkPool.Go(func() error {
+		mediaServerFiles, mediaServerErrors = lsw.getMediaServerFiles(ctx)
+		return nil
+	})
+
 	if err := fsWalkPool.Wait(); err != nil {
 		if !errors.Is(err, context.Canceled) {
 			slog.ErrorContext(ctx, "Failed to walk filesystem", "error", err)
@@ -543,6 +571,18 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 		return nil
 	}
 
+	// Rebuild the file index from the walk so the following syncs can diff
+	// against it. Cleanup below goes through MetadataService or removes index
+	// entries itself, so the index stays current. Links are only re-indexed
+	// from a walk without errors.
+	if !dryRun {
+		var links map[string]string
+		if libraryWalkErrors+importWalkErrors == 0 {
+			links = linkIndexEntries(cfg, libraryFiles, importDirFiles)
+		}
+		lsw.rebuildFileIndex(ctx, startTime, metadataFiles, links)
+	}
+
 	// Log and clear mount path change flag if symlinks were updated
 	totalSymlinksUpdated := librarySymlinksUpdated + importSymlinksUpdated
 	if totalSymlinksUpdated > 0 && lsw.configManager != nil {
@@ -557,7 +597,7 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 	// it's almost certainly a mount failure or network glitch.
 	// Abort cleanup operations to prevent mass data loss.
 	totalFilesFound := len(libraryFiles.Symlinks) + len(libraryFiles.StrmFiles) +
-		len(importDirFiles.Symlinks) + len(importDirFiles.StrmFiles)
+		len(importDirFiles.Symlinks) + len(importDirFiles.StrmFiles) + len(mediaServerFiles)
 
 	shouldCleanup := cfg.Health.CleanupOrphanedMetadata != nil && *cfg.Health.CleanupOrphanedMetadata
 	if shouldCleanup && totalFilesFound == 0 && len(metadataFiles) > 0 {
@@ -576,6 +616,14 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 		shouldCleanup = false
 	}
 
+	// Guard: a media server could not be listed — the files it references are
+	// unknown, so nothing is orphaned for certain
+	if shouldCleanup && mediaServerErrors > 0 {
+		slog.WarnContext(ctx, "Media server listing failed, skipping cleanup",
+			"media_server_errors", mediaServerErrors)
+		shouldCleanup = false
+	}
+
 	// Update total files count
 	lsw.progressMu.Lock()
 	lsw.progress.TotalFiles = len(metadataFiles)
@@ -604,6 +652,11 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 	normalizeKeys(importDirFiles.Symlinks, false)
 	normalizeKeys(importDirFiles.StrmFiles, false)
 
+	// Files a media server plays straight from the mount are in use too
+	for rel := range mediaServerFiles {
+		filesInLibrary[rel] = true
+	}
+
 	// Get all health check paths from database
 	dbRecords, err := lsw.healthRepo.GetAllHealthCheckRecords(ctx)
 	if err != nil {
@@ -790,7 +843,7 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 		currentMetaOrphans := make(map[string]string) // mount_relative_path -> meta_path
 		for relativeMountPath, metaPath := range metaFileSet {
 			libraryPath := lsw.getLibraryPath(relativeMountPath, filesInUse)
-			if libraryPath == nil {
+			if libraryPath == nil && !mediaServerFiles[relativeMountPath] {
 				currentMetaOrphans[relativeMountPath] = metaPath
 			}
 		}
@@ -959,6 +1012,7 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 						}
 						continue
 					}
+					lsw.removeIndexedLink(ctx, file)
 					slog.InfoContext(ctx, "Deleted confirmed orphaned library file", "path", file, "target", metaPath)
 				}
 				libraryFilesDeletedCount++
@@ -1033,7 +1087,7 @@ func (lsw *LibrarySyncWorker) SyncLibrary(ctx context.Context, dryRun bool) *Dry
 		libraryFilesDeleted: libraryFilesDeletedCount,
 		libraryDirsDeleted:  libraryDirsDeletedCount,
 	}
-	lsw.recordSyncResult(ctx, startTime, dbCounts, cleanup, len(metadataFiles), len(dbRecords))
+	lsw.recordSyncResult(ctx, startTime, dbCounts, cleanup, len(metadataFiles), len(dbRecords), true)
 	return nil
 }
 
@@ -1509,6 +1563,26 @@ func (lsw *LibrarySyncWorker) getAllImportDirFiles(ctx context.Context, oldMount
 	return result, symlinkUpdates, walkErrors, nil
 }
 
+// getMediaServerFiles lists the mount-relative paths of the files every
+// enabled media server references, and how many servers could not be listed.
+func (lsw *LibrarySyncWorker) getMediaServerFiles(ctx context.Context) (map[string]bool, int) {
+	files := make(map[string]bool)
+	failed := 0
+	for _, server := range lsw.mediaServers(lsw.configGetter()) {
+		serverFiles, err := server.MountFiles(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to list media server library", "server", server.Name, "error", err)
+			failed++
+			continue
+		}
+		for rel := range serverFiles {
+			files[rel] = true
+		}
+		slog.DebugContext(ctx, "Listed media server library", "server", server.Name, "files_in_mount", len(serverFiles))
+	}
+	return files, failed
+}
+
 // getLibraryPath looks up the library path for a given mount relative path
 // It checks both the full mount path and the relative path (for STRM files)
 func (lsw *LibrarySyncWorker) getLibraryPath(metaPath string, filesInUse map[string]string) *string {
@@ -1805,6 +1879,11 @@ func (lsw *LibrarySyncWorker) syncMetadataOnly(ctx context.Context, startTime ti
 		return nil
 	}
 
+	if !dryRun {
+		// No library is scanned in this mode, so no links are indexed.
+		lsw.rebuildFileIndex(ctx, startTime, metadataFiles, map[string]string{})
+	}
+
 	// Update total files count
 	lsw.progressMu.Lock()
 	lsw.progress.TotalFiles = len(metadataFiles)
@@ -1900,6 +1979,6 @@ func (lsw *LibrarySyncWorker) syncMetadataOnly(ctx context.Context, startTime ti
 		libraryFilesDeleted: 0,
 		libraryDirsDeleted:  0,
 	}
-	lsw.recordSyncResult(ctx, startTime, dbCounts, cleanup, len(metadataFiles), len(dbRecords))
+	lsw.recordSyncResult(ctx, startTime, dbCounts, cleanup, len(metadataFiles), len(dbRecords), true)
 	return nil
 }
@@ -0,0 +1,257 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/sourcegraph/conc/pool"
+)
+
+// lastFullLibrarySyncKey stores when the file index was last rebuilt by a full sync.
+const lastFullLibrarySyncKey = "last_full_library_sync_at"
+
+// SetFileIndex enables incremental syncs against the persisted file index.
+func (lsw *LibrarySyncWorker) SetFileIndex(idx *database.FileIndexRepository) {
+	lsw.fileIndex = idx
+}
+
+// shouldSyncIncrementally reports whether this sync can diff against the file
+// index. A full walk is needed when there is no index, for dry runs (which
+// report cleanup only a walk can find), after a manual trigger or a mount path
+// change, and once the last full sync is older than the full sync interval.
+func (lsw *LibrarySyncWorker) shouldSyncIncrementally(ctx context.Context, cfg *config.Config, dryRun bool) bool {
+	if lsw.fileIndex == nil || dryRun {
+		return false
+	}
+	if lsw.fullSyncRequested.Swap(false) {
+		return false
+	}
+	if lsw.configManager != nil && lsw.configManager.NeedsLibrarySync() {
+		return false
+	}
+
+	raw, err := lsw.healthRepo.GetSystemState(ctx, lastFullLibrarySyncKey)
+	if err != nil || raw == "" {
+		return false
+	}
+	lastFull, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return false
+	}
+	return time.Since(lastFull) < cfg.GetLibrarySyncFullInterval()
+}
+
+// rebuildFileIndex replaces the file index with what a full walk found and,
+// when links were indexed too, records the full sync. since is the start of
+// the walk: entries indexed by writes that raced it are kept. A nil links map
+// leaves the link entries alone and makes the next sync walk again.
+func (lsw *LibrarySyncWorker) rebuildFileIndex(ctx context.Context, since time.Time, metadataFiles []string, links map[string]string) {
+	if lsw.fileIndex == nil {
+		return
+	}
+
+	metaEntries := make(map[string]string, len(metadataFiles))
+	for _, path := range metadataFiles {
+		metaEntries[lsw.metaPathToMountRelativePath(path)] = ""
+	}
+	if err := lsw.fileIndex.ReplaceFileIndex(ctx, database.FileIndexKindMeta, metaEntries, since); err != nil {
+		slog.ErrorContext(ctx, "Failed to rebuild metadata file index", "error", err)
+		return
+	}
+	if links == nil {
+		return
+	}
+	if err := lsw.fileIndex.ReplaceFileIndex(ctx, database.FileIndexKindLink, links, since); err != nil {
+		slog.ErrorContext(ctx, "Failed to rebuild library link index", "error", err)
+		return
+	}
+
+	if err := lsw.healthRepo.UpdateSystemState(ctx, lastFullLibrarySyncKey, since.UTC().Format(time.RFC3339)); err != nil {
+		slog.ErrorContext(ctx, "Failed to record full library sync", "error", err)
+	}
+}
+
+// removeIndexedLink drops a deleted library file from the file index.
+func (lsw *LibrarySyncWorker) removeIndexedLink(ctx context.Context, linkPath string) {
+	if lsw.fileIndex == nil {
+		return
+	}
+	if err := lsw.fileIndex.RemoveLink(ctx, linkPath); err != nil {
+		slog.WarnContext(ctx, "Failed to remove library link from file index", "path", linkPath, "error", err)
+	}
+}
+
+// linkIndexEntries turns walked library and import dir files into link index
+// entries: library file path -> mount-relative path it points at.
+func linkIndexEntries(cfg *config.Config, files ...*UsedFiles) map[string]string {
+	entries := make(map[string]string)
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		for target, linkPath := range f.Symlinks {
+			entries[linkPath] = mountRelativeTarget(cfg, target)
+		}
+		for virtualPath, linkPath := range f.StrmFiles {
+			entries[linkPath] = mountRelativeTarget(cfg, virtualPath)
+		}
+	}
+	return entries
+}
+
+// mountRelativeTarget strips the mount path and leading slash from a link
+// target, the same way SyncLibrary keys the files in use.
+func mountRelativeTarget(cfg *config.Config, target string) string {
+	rel := strings.TrimPrefix(filepath.ToSlash(target), filepath.ToSlash(cfg.MountPath))
+	return strings.TrimPrefix(rel, "/")
+}
+
+// indexedFilesInUse maps mount-relative paths to the library file linking to
+// them. As in a full sync, a link in the import dir wins over one elsewhere.
+func indexedFilesInUse(cfg *config.Config, links map[string]string) map[string]string {
+	importDir := ""
+	if cfg.Import.ImportDir != nil && *cfg.Import.ImportDir != "" {
+		importDir = filepath.Clean(*cfg.Import.ImportDir) + string(filepath.Separator)
+	}
+	inImportDir := func(path string) bool {
+		return importDir != "" && strings.HasPrefix(path, importDir)
+	}
+
+	filesInUse := make(map[string]string, len(links))
+	for linkPath, target := range links {
+		if prev, ok := filesInUse[target]; ok && inImportDir(prev) && !inImportDir(linkPath) {
+			continue
+		}
+		filesInUse[target] = linkPath
+	}
+	return filesInUse
+}
+
+// syncIncremental brings the health records in line with the file index
+// without walking the metadata or library trees: indexed files without a
+// record are added, records whose library link changed are updated, and
+// records whose metadata is gone are deleted. Orphan cleanup needs a full
+// walk and is left to the next full sync.
+func (lsw *LibrarySyncWorker) syncIncremental(ctx context.Context, startTime time.Time) {
+	cfg := lsw.configGetter()
+	slog.InfoContext(ctx, "Starting incremental library sync")
+
+	defer lsw.initializeProgressTracking(startTime)()
+
+	metaIndex, err := lsw.fileIndex.ListFileIndex(ctx, database.FileIndexKindMeta)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to read metadata file index", "error", err)
+		return
+	}
+	links, err := lsw.fileIndex.ListFileIndex(ctx, database.FileIndexKindLink)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to read library link index", "error", err)
+		return
+	}
+	filesInUse := indexedFilesInUse(cfg, links)
+
+	dbRecords, err := lsw.healthRepo.GetAllHealthCheckRecords(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get automatic health check paths from database", "error", err)
+		return
+	}
+	dbPathSet := make(map[string]database.AutomaticHealthCheckRecord, len(dbRecords))
+	for _, record := range dbRecords {
+		dbPathSet[filepath.ToSlash(record.FilePath)] = record
+	}
+
+	lsw.progressMu.Lock()
+	lsw.progress.TotalFiles = len(metaIndex)
+	lsw.progressMu.Unlock()
+
+	excludedPrefixes := buildExcludedCategoryPrefixes(cfg)
+
+	var filesToAdd []database.AutomaticHealthCheckRecord
+	var staleEntries []string
+	var mu sync.Mutex
+
+	p := pool.New().WithMaxGoroutines(cfg.GetLibrarySyncConcurrency())
+
+	for path := range metaIndex {
+		select {
+		case <-ctx.Done():
+			p.Wait()
+			return
+		default:
+		}
+
+		if pathHasExcludedPrefix(path, excludedPrefixes) {
+			continue
+		}
+
+		existing, exists := dbPathSet[path]
+		libraryPath := lsw.getLibraryPath(path, filesInUse)
+		changed := exists && libraryPath != nil && (existing.LibraryPath == nil || *existing.LibraryPath != *libraryPath)
+		if exists && !changed {
+			lsw.progress.ProcessedFiles.Add(1)
+			continue
+		}
+
+		p.Go(func() {
+			defer lsw.progress.ProcessedFiles.Add(1)
+
+			// The index can be behind a delete that raced a full walk.
+			if !lsw.metadataService.FileExists(path) {
+				mu.Lock()
+				staleEntries = append(staleEntries, path)
+				mu.Unlock()
+				return
+			}
+
+			record, err := lsw.processMetadataForSync(ctx, path, libraryPath)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to read metadata during sync, registering as corrupted",
+					"mount_relative_path", path,
+					"error", err)
+				if regErr := lsw.healthRepo.RegisterCorruptedFile(ctx, path, libraryPath, err.Error()); regErr != nil {
+					slog.ErrorContext(ctx, "Failed to register corrupted file", "path", path, "error", regErr)
+				}
+				return
+			}
+
+			if record != nil {
+				mu.Lock()
+				filesToAdd = append(filesToAdd, *record)
+				mu.Unlock()
+			}
+		})
+	}
+
+	p.Wait()
+
+	for _, path := range staleEntries {
+		if err := lsw.fileIndex.RemoveMetadata(ctx, path); err != nil {
+			slog.WarnContext(ctx, "Failed to remove stale metadata file index entry", "path", path, "error", err)
+		}
+	}
+
+	// Records without an indexed .meta are deleted only once the file is
+	// confirmed gone; one written without updating the index is indexed instead.
+	var filesToDelete []string
+	for key, record := range dbPathSet {
+		if _, indexed := metaIndex[key]; indexed {
+			continue
+		}
+		if lsw.metadataService.FileExists(key) {
+			if err := lsw.fileIndex.IndexMetadata(ctx, key); err != nil {
+				slog.WarnContext(ctx, "Failed to index metadata file", "path", key, "error", err)
+			}
+			continue
+		}
+		filesToDelete = append(filesToDelete, record.FilePath)
+	}
+
+	dbCounts := lsw.syncDatabaseRecords(ctx, filesToAdd, filesToDelete, false)
+	lsw.recordSyncResult(ctx, startTime, dbCounts, cleanupCounts{}, len(metaIndex), len(dbRecords), false)
+}
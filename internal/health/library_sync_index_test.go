@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/metadata"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSyncLibrary_IncrementalAgainstFileIndex checks that once a full sync has
+// built the file index, the next sync only applies the changes the index saw,
+// and that a requested full sync picks up what bypassed it.
+func TestSyncLibrary_IncrementalAgainstFileIndex(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks not supported on Windows")
+	}
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	metaRoot := filepath.Join(tempDir, "metadata")
+	db := newResilienceDB(t)
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS file_index (
+			kind TEXT NOT NULL,
+			path TEXT NOT NULL,
+			target TEXT NOT NULL DEFAULT '',
+			indexed_at INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (kind, path)
+		)
+	`)
+	require.NoError(t, err)
+
+	healthRepo := database.NewHealthRepository(db, database.DialectSQLite)
+	fileIndex := database.NewFileIndexRepository(db, database.DialectSQLite)
+	metadataService := metadata.NewMetadataService(metaRoot)
+	metadataService.SetFileIndex(fileIndex)
+
+	writeMeta := func(ms *metadata.MetadataService, name string) {
+		meta := ms.CreateFileMetadata(
+			1024, "test.nzb", metapb.FileStatus_FILE_STATUS_HEALTHY,
+			nil, metapb.Encryption_NONE, "", "", nil, nil, 0, nil, "",
+		)
+		require.NoError(t, ms.WriteFileMetadata(filepath.Join("movies", name), meta))
+	}
+
+	libraryDir := filepath.Join(tempDir, "library")
+	require.NoError(t, os.MkdirAll(filepath.Join(libraryDir, "movies"), 0755))
+	mountPath := "/mnt/test"
+	for _, name := range []string{"movie_0.mkv", "movie_1.mkv", "movie_2.mkv"} {
+		writeMeta(metadataService, name)
+		require.NoError(t, os.Symlink(filepath.Join(mountPath, "movies", name), filepath.Join(libraryDir, "movies", name)))
+	}
+
+	healthEnabled := true
+	cfg := config.DefaultConfig()
+	cfg.Health.Enabled = &healthEnabled
+	cfg.Health.LibrarySyncConcurrency = 1
+	cfg.Health.LibraryDir = &libraryDir
+	cfg.Metadata.RootPath = metaRoot
+	cfg.MountPath = mountPath
+	cfg.Import.ImportStrategy = config.ImportStrategySYMLINK
+
+	configManager := config.NewManager(cfg, "")
+	worker := NewLibrarySyncWorker(metadataService, healthRepo, configManager.GetConfig, configManager, &MockRcloneClient{})
+	worker.SetFileIndex(fileIndex)
+
+	// No full sync recorded yet: the first sync walks and builds the index.
+	worker.SyncLibrary(ctx, false)
+	require.NotNil(t, worker.GetStatus().LastSyncResult)
+	assert.True(t, worker.GetStatus().LastSyncResult.Full)
+	assert.Equal(t, 3, worker.GetStatus().LastSyncResult.FilesAdded)
+	links, err := fileIndex.ListFileIndex(ctx, database.FileIndexKindLink)
+	require.NoError(t, err)
+	assert.Equal(t, "movies/movie_1.mkv", links[filepath.Join(libraryDir, "movies", "movie_1.mkv")])
+
+	// An import writes a new file and its link, a file is deleted, and a
+	// .meta appears without going through the index.
+	writeMeta(metadataService, "new.mkv")
+	newLink := filepath.Join(libraryDir, "movies", "new.mkv")
+	require.NoError(t, os.Symlink(filepath.Join(mountPath, "movies", "new.mkv"), newLink))
+	require.NoError(t, fileIndex.IndexLink(ctx, newLink, "movies/new.mkv"))
+	require.NoError(t, metadataService.DeleteFileMetadata(filepath.Join("movies", "movie_0.mkv")))
+	writeMeta(metadata.NewMetadataService(metaRoot), "bypass.mkv")
+
+	worker.SyncLibrary(ctx, false)
+	result := worker.GetStatus().LastSyncResult
+	assert.False(t, result.Full)
+	assert.Equal(t, 1, result.FilesAdded)
+	assert.Equal(t, 1, result.FilesDeleted)
+
+	added, err := healthRepo.GetFileHealth(ctx, "movies/new.mkv")
+	require.NoError(t, err)
+	require.NotNil(t, added)
+	require.NotNil(t, added.LibraryPath)
+	assert.Equal(t, newLink, *added.LibraryPath)
+	deleted, err := healthRepo.GetFileHealth(ctx, "movies/movie_0.mkv")
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+	bypass, err := healthRepo.GetFileHealth(ctx, "movies/bypass.mkv")
+	require.NoError(t, err)
+	assert.Nil(t, bypass, "an incremental sync does not walk the metadata tree")
+
+	// A requested full sync walks again and finds it.
+	worker.fullSyncRequested.Store(true)
+	worker.SyncLibrary(ctx, false)
+	assert.True(t, worker.GetStatus().LastSyncResult.Full)
+	bypass, err = healthRepo.GetFileHealth(ctx, "movies/bypass.mkv")
+	require.NoError(t, err)
+	assert.NotNil(t, bypass)
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/metadata"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSyncLibrary_MediaServerProtectsFiles checks that a file no symlink
+// points to is not cleaned up while a media server references it from the
+// mount, nor while a media server cannot be listed.
+func TestSyncLibrary_MediaServerProtectsFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks not supported on Windows")
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"referenced by the server", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]any{"TotalRecordCount": 1, "Items": []any{
+				map[string]any{"Path": "/mnt/test/movies/direct.mkv"},
+			}})
+		}},
+		{"server unreachable", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			tempDir := t.TempDir()
+			db := newResilienceDB(t)
+			healthRepo := database.NewHealthRepository(db, database.DialectSQLite)
+			metadataService := metadata.NewMetadataService(tempDir)
+
+			// 5 files are symlinked into the library; "direct.mkv" is only
+			// played from the mount (≈17% < 20% ratio threshold)
+			allFiles := []string{"movie_0.mkv", "movie_1.mkv", "movie_2.mkv", "movie_3.mkv", "movie_4.mkv", "direct.mkv"}
+			for _, name := range allFiles {
+				meta := metadataService.CreateFileMetadata(
+					1024, "test.nzb", metapb.FileStatus_FILE_STATUS_HEALTHY,
+					nil, metapb.Encryption_NONE, "", "", nil, nil, 0, nil, "",
+				)
+				require.NoError(t, metadataService.WriteFileMetadata(filepath.Join("movies", name), meta))
+			}
+
+			libraryDir := filepath.Join(tempDir, "library")
+			require.NoError(t, os.MkdirAll(filepath.Join(libraryDir, "movies"), 0755))
+			mountPath := "/mnt/test"
+			for _, name := range allFiles[:5] {
+				require.NoError(t, os.Symlink(filepath.Join(mountPath, "movies", name), filepath.Join(libraryDir, "movies", name)))
+			}
+
+			healthEnabled := true
+			cleanupEnabled := true
+			cfg := config.DefaultConfig()
+			cfg.Health.Enabled = &healthEnabled
+			cfg.Health.LibrarySyncConcurrency = 1
+			cfg.Health.CleanupOrphanedMetadata = &cleanupEnabled
+			cfg.Health.LibraryDir = &libraryDir
+			cfg.Metadata.RootPath = tempDir
+			cfg.MountPath = mountPath
+			cfg.Import.ImportStrategy = config.ImportStrategySYMLINK
+			cfg.MediaServers = []config.MediaServerConfig{
+				{Name: "jellyfin", Type: config.MediaServerTypeJellyfin, URL: srv.URL, Token: "key"},
+			}
+
+			configManager := config.NewManager(cfg, "")
+			worker := NewLibrarySyncWorker(metadataService, healthRepo, configManager.GetConfig, configManager, &MockRcloneClient{})
+
+			ctx := context.Background()
+			worker.SyncLibrary(ctx, false)
+			worker.SyncLibrary(ctx, false)
+
+			for _, name := range allFiles {
+				assert.True(t, metadataService.FileExists(filepath.Join("movies", name)), "%s should NOT be deleted", name)
+			}
+			raw, err := healthRepo.GetSystemState(ctx, "pending_metadata_deletions")
+			require.NoError(t, err)
+			assert.NotContains(t, raw, "direct.mkv")
+		})
+	}
+}
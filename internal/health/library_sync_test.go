@@ -62,7 +62,19 @@ func TestSyncLibrary_WorkerPool(t *testing.T) {
 			streaming_failure_count INTEGER DEFAULT 0,
 			is_masked BOOLEAN DEFAULT FALSE,
 			indexer TEXT DEFAULT NULL,
-			download_id TEXT DEFAULT NULL
+			download_id TEXT DEFAULT NULL,
+			provider_completeness TEXT DEFAULT NULL,
+			newsgroup TEXT DEFAULT NULL,
+			poster TEXT DEFAULT NULL,
+			first_loss_at DATETIME DEFAULT NULL,
+			expiry_risk REAL DEFAULT NULL,
+			next_check_reason TEXT DEFAULT NULL,
+			last_streamed_at DATETIME DEFAULT NULL,
+			stream_count INTEGER NOT NULL DEFAULT 0,
+			continue_watching BOOLEAN NOT NULL DEFAULT FALSE,
+			sample_confidence TEXT DEFAULT NULL,
+			repair_state TEXT NOT NULL DEFAULT '',
+			repairability TEXT DEFAULT NULL
 		);
 
 		CREATE TABLE IF NOT EXISTS system_state (
@@ -119,7 +131,7 @@ func TestSyncLibrary_WorkerPool(t *testing.T) {
 	assert.Nil(t, result)
 
 	// Check if files were added to database
-	count, err := healthRepo.CountHealthItems(ctx, nil, nil, "")
+	count, err := healthRepo.CountHealthItems(ctx, nil, nil, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, numFiles, count)
 }
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/holes"
+	"github.com/javi11/altmount/internal/nzbcheck"
+	"github.com/javi11/altmount/internal/usenet"
+)
+
+// assessRepairability estimates whether the release's PAR2 recovery volumes
+// could rebuild the data a check found missing. The volumes' segments are
+// sampled like the file's own (every segment on a full check) and the
+// recovery blocks each volume declares are discounted by its missing share.
+//
+// Like classifyHoles it re-reads metadata instead of holding the PAR2
+// references through the sweep: it only runs for files with missing segments.
+// Returns nil when metadata can no longer be read or the PAR2 sweep fails, so
+// an unknown estimate never reads as "no recovery data".
+func (hc *HealthChecker) assessRepairability(
+	ctx context.Context,
+	filePath string,
+	missingRate float64,
+	fullCheck bool,
+) *database.Repairability {
+	fileMeta, err := hc.metadataService.ReadFileMetadata(filePath)
+	if err != nil || fileMeta == nil {
+		return nil
+	}
+
+	cfg := hc.configGetter()
+	samplePercentage := cfg.GetSegmentSamplePercentage()
+	if fullCheck || cfg.GetCheckAllSegments() {
+		samplePercentage = 100
+	}
+
+	volumes := make([]holes.RecoveryVolume, len(fileMeta.Par2Files))
+	var checkedVolumes []int
+	var perVolumeIDs [][]string
+	for i, p := range fileMeta.Par2Files {
+		blocks := nzbcheck.Par2RecoveryBlocks(p.Filename)
+		if blocks == 0 || len(p.SegmentData) == 0 {
+			// The index file holds no recovery blocks; every volume repeats
+			// its packets, so its loss never blocks a repair.
+			continue
+		}
+		plan := usenet.PlanSegmentSample(p.SegmentData, samplePercentage, nil)
+		volumes[i] = holes.RecoveryVolume{Blocks: blocks, Bytes: p.FileSize}
+		checkedVolumes = append(checkedVolumes, i)
+		perVolumeIDs = append(perVolumeIDs, append(plan.Fixed, plan.Body...))
+	}
+
+	if len(perVolumeIDs) > 0 {
+		results, err := usenet.ValidateSegmentAvailabilityBatch(
+			ctx,
+			perVolumeIDs,
+			hc.poolManager,
+			cfg.GetMaxConnectionsForHealthChecks(),
+			cfg.GetHealthReadTimeout(),
+		)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to check PAR2 segments",
+				"file_path", filePath,
+				"error", err)
+			return nil
+		}
+		for i, r := range results {
+			volumes[checkedVolumes[i]].Checked = r.TotalChecked
+			volumes[checkedVolumes[i]].Missing = r.MissingCount
+		}
+	}
+
+	rep := holes.EstimateRepair(volumes, []holes.Damage{{
+		MissingRate: missingRate,
+		Bytes:       fileMeta.FileSize,
+		Segments:    len(fileMeta.SegmentData),
+	}})
+	return &rep
+}
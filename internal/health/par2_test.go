@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/javi11/altmount/internal/holes"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/javi11/altmount/internal/testsupport/fakepool"
+	"github.com/javi11/nntppool/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addPar2Volume attaches a PAR2 recovery volume of n 1 KB segments to the
+// env's file and returns its segment IDs.
+func (e *holeTestEnv) addPar2Volume(t *testing.T, filename string, n int) []string {
+	t.Helper()
+	meta, err := e.ms.ReadFileMetadata(e.filePath)
+	require.NoError(t, err)
+
+	ref := &metapb.Par2FileReference{Filename: filename, FileSize: int64(n) * 1024}
+	var ids []string
+	for i := range n {
+		id := fmt.Sprintf("%s-seg-%d@test", filename, i)
+		ref.SegmentData = append(ref.SegmentData, &metapb.SegmentData{Id: id, SegmentSize: 1024, EndOffset: 1023})
+		ids = append(ids, id)
+	}
+	meta.Par2Files = append(meta.Par2Files, ref)
+	require.NoError(t, e.ms.WriteFileMetadata(e.filePath, meta))
+	return ids
+}
+
+func TestHealthCheckScoresPar2Repairability(t *testing.T) {
+	env := newHoleTestEnv(t, "movie.mkv", 4*1024*1024, 1024)
+	// 41 of 4096 segments (1%) in one run: a failed playback verdict.
+	for i := 100; i < 141; i++ {
+		env.markSegmentMissing(i)
+	}
+
+	event := env.checker.CheckFile(context.Background(), env.filePath)
+	require.Equal(t, EventTypeFileCorrupted, event.Type)
+	require.NotNil(t, event.Classification)
+	assert.Equal(t, holes.VerdictFailed, event.Classification.Verdict)
+	require.NotNil(t, event.Repairability)
+	assert.Zero(t, event.Repairability.Score, "no recovery data")
+
+	// 10% recovery data: 100 blocks of 4 KB.
+	env.addPar2Volume(t, "movie.par2", 1)
+	volIDs := env.addPar2Volume(t, "movie.vol00+100.par2", 400)
+
+	event = env.checker.CheckFile(context.Background(), env.filePath)
+	require.NotNil(t, event.Repairability)
+	rep := event.Repairability
+	assert.Equal(t, 2, rep.Par2Files)
+	assert.Equal(t, 100, rep.RecoveryBlocks)
+	assert.Equal(t, 400, rep.Par2SegmentsChecked, "only the recovery volume is checked")
+	assert.Equal(t, 52, rep.NeededBlocks)
+	assert.True(t, rep.Repairable(), "score %.2f", rep.Score)
+	require.NotNil(t, event.Details)
+	assert.Contains(t, *event.Details, `"repairability"`)
+
+	// Half the recovery volume is gone too.
+	for _, id := range volIDs[:200] {
+		env.fp.SetBehavior(id, fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	}
+	event = env.checker.CheckFile(context.Background(), env.filePath)
+	require.NotNil(t, event.Repairability)
+	assert.Equal(t, 50, event.Repairability.UsableBlocks)
+	assert.False(t, event.Repairability.Repairable())
+}
@@ -0,0 +1,124 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/pool"
+	"github.com/javi11/nntppool/v4"
+)
+
+// auditProviderCoverage re-checks the articles each file's sweep sampled on
+// every provider separately and records, per file and provider, how many the
+// provider held and how many no other provider did. It runs after the pool
+// sweep judged the files, so it never changes a verdict; a provider that
+// answered nothing (unreachable, quota spent) is left out of the file's audit.
+// Pools with fewer than two providers have nothing to compare and are skipped.
+func (hc *HealthChecker) auditProviderCoverage(ctx context.Context, preps []preparedCheck, progress []sampleProgress) {
+	cfg := hc.configGetter()
+	if !cfg.GetProviderCoverageAudit() || hc.healthRepo == nil {
+		return
+	}
+
+	usenetPool, err := hc.poolManager.GetPool()
+	if err != nil || usenetPool == nil {
+		return
+	}
+	providers := usenetPool.Stats().Providers
+	if len(providers) < 2 {
+		return
+	}
+
+	perFileIDs := make([][]string, len(preps))
+	var ids []string
+	seen := make(map[string]struct{})
+	for i := range preps {
+		if preps[i].earlyEvent != nil {
+			continue
+		}
+		plan := preps[i].plan
+		checked := append(append([]string(nil), plan.Fixed...), plan.Body[:progress[i].nextBody]...)
+		perFileIDs[i] = checked
+		for _, id := range checked {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	budget := pool.ProviderBudget(providers, cfg.GetMaxConnectionsForHealthChecks())
+	timeout := cfg.GetHealthReadTimeout()
+
+	// held[p][id] is provider p's answer for id: true when it holds it.
+	held := make([]map[string]bool, len(providers))
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A provider that answered nothing is left out of the audit.
+			held[i], _ = pool.StatOnProvider(ctx, usenetPool, p.Name, ids, budget[i], timeout)
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	for i := range preps {
+		if len(perFileIDs[i]) == 0 {
+			continue
+		}
+		entries := fileProviderCoverage(providers, held, perFileIDs[i])
+		if err := hc.healthRepo.RecordProviderCoverage(ctx, preps[i].filePath, entries); err != nil {
+			slog.WarnContext(ctx, "Failed to record provider coverage", "file_path", preps[i].filePath, "error", err)
+		}
+	}
+
+	if _, err := hc.healthRepo.PruneProviderCoverage(ctx, time.Now().Add(-cfg.GetProviderCoverageWindow())); err != nil {
+		slog.WarnContext(ctx, "Failed to prune provider coverage", "error", err)
+	}
+}
+
+// fileProviderCoverage summarizes the providers' answers for one file's
+// sampled articles.
+func fileProviderCoverage(providers []nntppool.ProviderStats, held []map[string]bool, ids []string) []database.ProviderCoverage {
+	entries := make([]database.ProviderCoverage, len(providers))
+	for i, p := range providers {
+		entries[i].Provider = p.Name
+	}
+	for _, id := range ids {
+		holder := -1
+		holders := 0
+		for i := range providers {
+			has, answered := held[i][id]
+			if !answered {
+				continue
+			}
+			entries[i].Checked++
+			if has {
+				entries[i].Available++
+				holder = i
+				holders++
+			}
+		}
+		if holders == 1 {
+			entries[holder].Unique++
+		}
+	}
+
+	out := entries[:0]
+	for _, e := range entries {
+		if e.Checked > 0 {
+			out = append(out, e)
+		}
+	}
+	return out
+}
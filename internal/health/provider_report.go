@@ -0,0 +1,192 @@
+package health
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+)
+
+// ProviderVerdict is what the coverage audits say about a provider.
+type ProviderVerdict string
+
+const (
+	// ProviderVerdictUnique providers held articles no other provider did.
+	ProviderVerdictUnique ProviderVerdict = "unique"
+	// ProviderVerdictRedundant providers never served anything the others
+	// did not: dropping them would have lost nothing in the window.
+	ProviderVerdictRedundant ProviderVerdict = "redundant"
+	// ProviderVerdictUnaudited providers have no audit in the window.
+	ProviderVerdictUnaudited ProviderVerdict = "unaudited"
+)
+
+// ProviderCoverage is one provider's share of the audited articles.
+type ProviderCoverage struct {
+	// Provider is the pool name ("host:port+username"); ID and Name are the
+	// configured provider's, empty once it was removed from the config.
+	Provider string          `json:"provider"`
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name,omitempty"`
+	Backup   bool            `json:"backup,omitempty"`
+	Verdict  ProviderVerdict `json:"verdict"`
+	Files    int             `json:"files"`
+	Checked  int             `json:"checked"`
+	// Availability is the share of the checked articles the provider held.
+	Availability *float64 `json:"availability,omitempty"`
+	Unique       int      `json:"unique"`
+	// FilesWithUnique counts the files only this provider could serve part of.
+	FilesWithUnique int        `json:"files_with_unique"`
+	LastCheckedAt   *time.Time `json:"last_checked_at,omitempty"`
+	LastUniqueAt    *time.Time `json:"last_unique_at,omitempty"`
+	// Account fields from the provider config, to weigh the renewal.
+	QuotaBytes            int64  `json:"quota_bytes,omitempty"`
+	QuotaPeriodHours      int    `json:"quota_period_hours,omitempty"`
+	AccountExpirationDate string `json:"account_expiration_date,omitempty"`
+	DaysUntilExpiration   *int   `json:"days_until_expiration,omitempty"`
+}
+
+// ProviderCoverageReport lists every provider's coverage over a window.
+type ProviderCoverageReport struct {
+	GeneratedAt time.Time           `json:"generated_at"`
+	Days        int                 `json:"days"`
+	Providers   []*ProviderCoverage `json:"providers"`
+}
+
+// ProviderCoverageSource is the database side of the provider coverage report.
+type ProviderCoverageSource interface {
+	GetProviderCoverageTotals(ctx context.Context, since time.Time) ([]database.ProviderCoverageTotals, error)
+}
+
+// BuildProviderCoverageReport aggregates the provider audits of the last
+// days (the configured window when days <= 0) and joins them with the
+// configured providers. Providers holding unique articles come first, most
+// unique first; providers without an audit are listed last.
+func BuildProviderCoverageReport(ctx context.Context, src ProviderCoverageSource, cfg *config.Config, days int) (*ProviderCoverageReport, error) {
+	window := cfg.GetProviderCoverageWindow()
+	if days > 0 {
+		window = time.Duration(days) * 24 * time.Hour
+	}
+	now := time.Now()
+	totals, err := src.GetProviderCoverageTotals(ctx, now.Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ProviderCoverageReport{GeneratedAt: now.UTC(), Days: int(window / (24 * time.Hour))}
+	byName := make(map[string]*ProviderCoverage)
+	for i := range cfg.Providers {
+		p := &cfg.Providers[i]
+		entry := &ProviderCoverage{
+			Provider:              p.NNTPPoolName(),
+			ID:                    p.ID,
+			Name:                  p.Name,
+			Backup:                p.IsBackupProvider != nil && *p.IsBackupProvider,
+			Verdict:               ProviderVerdictUnaudited,
+			QuotaBytes:            p.QuotaBytes,
+			QuotaPeriodHours:      p.QuotaPeriodHours,
+			AccountExpirationDate: p.AccountExpirationDate,
+			DaysUntilExpiration:   daysUntil(p.AccountExpirationDate, now),
+		}
+		byName[entry.Provider] = entry
+		report.Providers = append(report.Providers, entry)
+	}
+
+	for _, t := range totals {
+		entry, ok := byName[t.Provider]
+		if !ok {
+			entry = &ProviderCoverage{Provider: t.Provider}
+			byName[t.Provider] = entry
+			report.Providers = append(report.Providers, entry)
+		}
+		entry.Files = t.Files
+		entry.Checked = t.Checked
+		entry.Unique = t.Unique
+		entry.FilesWithUnique = t.FilesWithUnique
+		entry.LastCheckedAt = t.LastCheckedAt
+		entry.LastUniqueAt = t.LastUniqueAt
+		if t.Checked > 0 {
+			availability := float64(t.Available) / float64(t.Checked)
+			entry.Availability = &availability
+		}
+		entry.Verdict = ProviderVerdictRedundant
+		if t.Unique > 0 {
+			entry.Verdict = ProviderVerdictUnique
+		}
+	}
+
+	rank := map[ProviderVerdict]int{ProviderVerdictUnique: 0, ProviderVerdictRedundant: 1, ProviderVerdictUnaudited: 2}
+	sort.SliceStable(report.Providers, func(i, j int) bool {
+		a, b := report.Providers[i], report.Providers[j]
+		if rank[a.Verdict] != rank[b.Verdict] {
+			return rank[a.Verdict] < rank[b.Verdict]
+		}
+		return a.Unique > b.Unique
+	})
+	return report, nil
+}
+
+// daysUntil returns the whole days left until an account expiration date
+// (negative once expired), or nil when the date is empty or unparsable.
+func daysUntil(date string, now time.Time) *int {
+	if date == "" {
+		return nil
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, date); err == nil {
+			days := int(math.Ceil(t.Sub(now).Hours() / 24))
+			return &days
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes the report as CSV, one row per provider.
+func (r *ProviderCoverageReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"provider", "id", "name", "backup", "verdict", "files", "checked", "availability",
+		"unique", "files_with_unique", "last_checked_at", "last_unique_at",
+		"quota_bytes", "quota_period_hours", "account_expiration_date", "days_until_expiration",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, p := range r.Providers {
+		availability := ""
+		if p.Availability != nil {
+			availability = strconv.FormatFloat(*p.Availability*100, 'f', 1, 64)
+		}
+		expires := ""
+		if p.DaysUntilExpiration != nil {
+			expires = strconv.Itoa(*p.DaysUntilExpiration)
+		}
+		row := []string{
+			p.Provider, p.ID, p.Name, strconv.FormatBool(p.Backup), string(p.Verdict),
+			strconv.Itoa(p.Files), strconv.Itoa(p.Checked), availability,
+			strconv.Itoa(p.Unique), strconv.Itoa(p.FilesWithUnique),
+			formatReportTime(p.LastCheckedAt), formatReportTime(p.LastUniqueAt),
+			strconv.FormatInt(p.QuotaBytes, 10), strconv.Itoa(p.QuotaPeriodHours),
+			p.AccountExpirationDate, expires,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatReportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,96 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/nntppool/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCoverageSource struct {
+	totals []database.ProviderCoverageTotals
+	since  time.Time
+}
+
+func (s *fakeCoverageSource) GetProviderCoverageTotals(_ context.Context, since time.Time) ([]database.ProviderCoverageTotals, error) {
+	s.since = since
+	return s.totals, nil
+}
+
+func TestFileProviderCoverage(t *testing.T) {
+	providers := []nntppool.ProviderStats{{Name: "main"}, {Name: "block"}, {Name: "down"}}
+	held := []map[string]bool{
+		{"a": true, "b": true, "c": false},
+		{"a": true, "b": false, "c": true},
+		{},
+	}
+
+	entries := fileProviderCoverage(providers, held, []string{"a", "b", "c", "d"})
+
+	// "down" answered nothing and is left out of the audit.
+	assert.Equal(t, []database.ProviderCoverage{
+		{Provider: "main", Checked: 3, Available: 2, Unique: 1},
+		{Provider: "block", Checked: 3, Available: 2, Unique: 1},
+	}, entries)
+}
+
+func TestBuildProviderCoverageReport(t *testing.T) {
+	backup := true
+	expires := time.Now().Add(10 * 24 * time.Hour).Format(time.RFC3339)
+	cfg := &config.Config{Providers: []config.ProviderConfig{
+		{ID: "p1", Name: "Main", Host: "news.main", Port: 563, Username: "u"},
+		{ID: "p2", Name: "Block", Host: "news.block", Port: 563, Username: "u", IsBackupProvider: &backup,
+			QuotaBytes: 500 << 30, AccountExpirationDate: expires},
+		{ID: "p3", Name: "Idle", Host: "news.idle", Port: 119},
+	}}
+	src := &fakeCoverageSource{totals: []database.ProviderCoverageTotals{
+		{Provider: "news.block:563+u", Files: 4, Checked: 40, Available: 30},
+		{Provider: "news.main:563+u", Files: 4, Checked: 40, Available: 38, Unique: 8, FilesWithUnique: 2},
+		{Provider: "news.gone:563", Files: 1, Checked: 10, Available: 10, Unique: 1, FilesWithUnique: 1},
+	}}
+
+	report, err := BuildProviderCoverageReport(context.Background(), src, cfg, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Days)
+	assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), src.since, time.Minute)
+	require.Len(t, report.Providers, 4)
+
+	main, gone, block, idle := report.Providers[0], report.Providers[1], report.Providers[2], report.Providers[3]
+	assert.Equal(t, "p1", main.ID)
+	assert.Equal(t, ProviderVerdictUnique, main.Verdict)
+	require.NotNil(t, main.Availability)
+	assert.InDelta(t, 0.95, *main.Availability, 0.001)
+
+	// Audits of a provider no longer configured are still listed.
+	assert.Equal(t, "news.gone:563", gone.Provider)
+	assert.Empty(t, gone.ID)
+	assert.Equal(t, ProviderVerdictUnique, gone.Verdict)
+
+	assert.Equal(t, "p2", block.ID)
+	assert.Equal(t, ProviderVerdictRedundant, block.Verdict)
+	assert.True(t, block.Backup)
+	assert.Equal(t, int64(500<<30), block.QuotaBytes)
+	require.NotNil(t, block.DaysUntilExpiration)
+	assert.Equal(t, 10, *block.DaysUntilExpiration)
+
+	assert.Equal(t, "p3", idle.ID)
+	assert.Equal(t, ProviderVerdictUnaudited, idle.Verdict)
+	assert.Nil(t, idle.Availability)
+	assert.Nil(t, idle.DaysUntilExpiration)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+	assert.Equal(t, "verdict", rows[0][4])
+	assert.Equal(t, "95.0", rows[1][7])
+	assert.Equal(t, "redundant", rows[3][4])
+}
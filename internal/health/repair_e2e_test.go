@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/javi11/altmount/internal/arrs"
 	"github.com/javi11/altmount/internal/arrs/model"
@@ -58,9 +59,10 @@ func (m *mockPoolManager) NotifyStreamChange()                         {}
 
 // mockARRsService captures TriggerFileRescan calls and returns a configurable error.
 type mockARRsService struct {
-	mu        sync.Mutex
-	calls     []triggerCall
-	returnErr error
+	mu           sync.Mutex
+	calls        []triggerCall
+	returnErr    error
+	verification *model.RepairVerification
 }
 
 type triggerCall struct {
@@ -68,11 +70,23 @@ type triggerCall struct {
 	relativePath  string
 }
 
-func (m *mockARRsService) TriggerFileRescan(_ context.Context, pathForRescan string, relativePath string, _ *string) error {
+func (m *mockARRsService) TriggerFileRescan(_ context.Context, pathForRescan string, relativePath string, _ *string) (*model.RescanResult, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.calls = append(m.calls, triggerCall{pathForRescan: pathForRescan, relativePath: relativePath})
-	return m.returnErr
+	if m.returnErr != nil {
+		return nil, m.returnErr
+	}
+	return &model.RescanResult{InstanceType: "radarr", InstanceName: "radarr-main", SearchCommandIDs: []int64{42}}, nil
+}
+
+func (m *mockARRsService) VerifyRepair(_ context.Context, _ *model.RescanResult, _ time.Time) (*model.RepairVerification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.verification == nil {
+		return &model.RepairVerification{Progress: model.RepairProgressPending}, nil
+	}
+	return m.verification, nil
 }
 
 func (m *mockARRsService) DiscoverFileMetadata(_ context.Context, _, _, _, _ string) (*model.WebhookMetadata, error) {
@@ -128,7 +142,19 @@ func newRepairTestEnv(t *testing.T, tempDir string, arrsErr error, configure ...
 			streaming_failure_count INTEGER DEFAULT 0,
 			is_masked BOOLEAN DEFAULT FALSE,
 			indexer TEXT DEFAULT NULL,
-			download_id TEXT DEFAULT NULL
+			download_id TEXT DEFAULT NULL,
+			provider_completeness TEXT DEFAULT NULL,
+			newsgroup TEXT DEFAULT NULL,
+			poster TEXT DEFAULT NULL,
+			first_loss_at DATETIME DEFAULT NULL,
+			expiry_risk REAL DEFAULT NULL,
+			next_check_reason TEXT DEFAULT NULL,
+			last_streamed_at DATETIME DEFAULT NULL,
+			stream_count INTEGER NOT NULL DEFAULT 0,
+			continue_watching BOOLEAN NOT NULL DEFAULT FALSE,
+			sample_confidence TEXT DEFAULT NULL,
+			repair_state TEXT NOT NULL DEFAULT '',
+			repairability TEXT DEFAULT NULL
 		);
 
 		CREATE TABLE IF NOT EXISTS system_state (
@@ -136,6 +162,41 @@ func newRepairTestEnv(t *testing.T, tempDir string, arrsErr error, configure ...
 			value TEXT NOT NULL,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		);
+
+		CREATE TABLE IF NOT EXISTS segment_loss_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			file_path TEXT NOT NULL,
+			newsgroup TEXT DEFAULT NULL,
+			poster TEXT DEFAULT NULL,
+			provider TEXT DEFAULT NULL,
+			indexer TEXT DEFAULT NULL,
+			release_date DATETIME NOT NULL,
+			lost_at DATETIME NOT NULL DEFAULT (datetime('now'))
+		);
+
+		CREATE TABLE IF NOT EXISTS repair_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			file_path TEXT NOT NULL,
+			from_state TEXT NOT NULL DEFAULT '',
+			to_state TEXT NOT NULL,
+			action TEXT NOT NULL,
+			arr_type TEXT DEFAULT NULL,
+			arr_instance TEXT DEFAULT NULL,
+			details TEXT DEFAULT NULL,
+			result TEXT DEFAULT NULL,
+			created_at DATETIME NOT NULL DEFAULT (datetime('now'))
+		);
+
+		CREATE TABLE IF NOT EXISTS import_queue (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			status TEXT NOT NULL DEFAULT 'pending'
+		);
+
+		CREATE TABLE IF NOT EXISTS replacement_imports (
+			queue_id INTEGER PRIMARY KEY,
+			file_path TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT (datetime('now'))
+		);
 	`)
 	require.NoError(t, err)
 
@@ -267,6 +328,19 @@ func TestE2E_FileRepairTriggered_ARRResearchCalled(t *testing.T) {
 	require.NotNil(t, fh)
 	assert.Equal(t, database.HealthStatusRepairTriggered, fh.Status)
 
+	// The repair state machine moved to triggered and logged the ARR's actions.
+	assert.Equal(t, database.RepairStateTriggered, fh.RepairState)
+	events, err := env.healthRepo.GetRepairEvents(ctx, filePath)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, database.RepairStateNone, events[0].FromState)
+	assert.Equal(t, RepairActionTrigger, events[0].Action)
+	require.NotNil(t, events[0].ArrInstance)
+	assert.Equal(t, "radarr-main", *events[0].ArrInstance)
+	require.NotNil(t, events[0].Details)
+	assert.Contains(t, *events[0].Details, `"search_command_ids":[42]`)
+	assert.Nil(t, events[0].Result)
+
 	// Metadata should have been moved to the corrupted folder (original path no longer readable).
 	original, readErr := env.metadataService.ReadFileMetadata(filePath)
 	assert.Nil(t, original, "metadata should not be readable at original path after move")
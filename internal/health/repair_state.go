@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/javi11/altmount/internal/arrs/model"
+	"github.com/javi11/altmount/internal/database"
+)
+
+// Repair actions recorded in the repair audit log.
+const (
+	RepairActionTrigger          = "trigger"           // Health check retries exhausted; repair triggered in the ARR
+	RepairActionRetrigger        = "retrigger"         // Repair sweep re-triggered an open repair
+	RepairActionAlternateRelease = "alternate_release" // Alternate release searched on Prowlarr (no ARR manages the file, or escalation)
+	RepairActionManual           = "manual"            // Repair requested through the API
+	RepairActionExhaust          = "exhaust"           // Repair budget spent; file finalized as corrupted
+	RepairActionDelete           = "delete"            // Corrupted file deleted instead of repaired
+	RepairActionVerify           = "verify"            // A health check passed after the repair
+	RepairActionPoll             = "poll"              // The ARR was polled for the repair's grab and search commands
+)
+
+// RepairRecorder persists repair state transitions.
+type RepairRecorder interface {
+	RecordRepairTransition(ctx context.Context, ev *database.RepairEvent) error
+}
+
+// RecordRepair moves fh to the repair state to and appends the transition to
+// the audit log, with the ARR actions of rescan and the error of a failed or
+// deferred attempt. The audit log never blocks a repair: a failure to record
+// is logged and fh keeps its state.
+func RecordRepair(ctx context.Context, rec RepairRecorder, fh *database.FileHealth, to database.RepairState, action string, rescan *model.RescanResult, cause error) {
+	ev := newRepairEvent(fh, to, action, rescan)
+	if cause != nil {
+		result := cause.Error()
+		ev.Result = &result
+	}
+	recordRepairEvent(ctx, rec, fh, ev)
+}
+
+// newRepairEvent builds the audit log entry moving fh to to.
+func newRepairEvent(fh *database.FileHealth, to database.RepairState, action string, rescan *model.RescanResult) *database.RepairEvent {
+	ev := &database.RepairEvent{
+		FilePath:  fh.FilePath,
+		FromState: fh.RepairState,
+		ToState:   to,
+		Action:    action,
+	}
+	if rescan != nil {
+		if rescan.InstanceType != "" {
+			ev.ArrType = &rescan.InstanceType
+		}
+		if rescan.InstanceName != "" {
+			ev.ArrInstance = &rescan.InstanceName
+		}
+		if data, err := json.Marshal(rescan); err == nil {
+			details := string(data)
+			ev.Details = &details
+		}
+	}
+	return ev
+}
+
+// recordRepairEvent persists ev and moves fh to its state, logging failures.
+func recordRepairEvent(ctx context.Context, rec RepairRecorder, fh *database.FileHealth, ev *database.RepairEvent) {
+	if err := rec.RecordRepairTransition(ctx, ev); err != nil {
+		slog.WarnContext(ctx, "Failed to record repair transition",
+			"file_path", fh.FilePath,
+			"from", fh.RepairState,
+			"to", ev.ToState,
+			"action", ev.Action,
+			"error", err)
+		return
+	}
+	fh.RepairState = ev.ToState
+}
+
+// repairState is the repair state a repair attempt with this outcome ends in.
+func (o repairOutcome) repairState() database.RepairState {
+	switch o {
+	case repairOutcomeTriggered:
+		return database.RepairStateTriggered
+	case repairOutcomeDeleted:
+		return database.RepairStateRemoved
+	case repairOutcomeRegenerated:
+		return database.RepairStateRegenerated
+	case repairOutcomeDeferred:
+		return database.RepairStateDeferred
+	default:
+		return database.RepairStateFailed
+	}
+}
@@ -0,0 +1,49 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/javi11/altmount/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRepairStateMachine_DeferThenResolve follows an open repair through a
+// re-trigger against an unreachable ARR (deferred) and a passing health check
+// (resolved), checking both the persisted state and the audit log.
+func TestRepairStateMachine_DeferThenResolve(t *testing.T) {
+	ctx := context.Background()
+	env := newRepairTestEnv(t, t.TempDir(), fmt.Errorf("rescan: %w", context.DeadlineExceeded))
+
+	filePath := "complete/state-machine.mkv"
+	_, err := env.db.Exec(`INSERT INTO file_health (file_path, status, repair_state) VALUES (?, 'repair_triggered', 'triggered')`, filePath)
+	require.NoError(t, err)
+	fh, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	require.NotNil(t, fh)
+
+	_, sideEffect := env.hw.prepareRepairNotificationUpdate(ctx, fh)
+	require.NoError(t, sideEffect())
+	assert.Equal(t, database.RepairStateDeferred, fh.RepairState)
+
+	_, sideEffect = env.hw.prepareUpdateForResult(ctx, fh, HealthEvent{Type: EventTypeFileHealthy, FilePath: filePath})
+	_ = sideEffect()
+
+	stored, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, database.RepairStateResolved, stored.RepairState)
+
+	events, err := env.healthRepo.GetRepairEvents(ctx, filePath)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, RepairActionRetrigger, events[0].Action)
+	assert.Equal(t, database.RepairStateTriggered, events[0].FromState)
+	assert.Equal(t, database.RepairStateDeferred, events[0].ToState)
+	require.NotNil(t, events[0].Result)
+	assert.Contains(t, *events[0].Result, "deadline exceeded")
+	assert.Equal(t, RepairActionVerify, events[1].Action)
+	assert.Equal(t, database.RepairStateResolved, events[1].ToState)
+}
@@ -0,0 +1,197 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/javi11/altmount/internal/arrs/model"
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/httpclient"
+)
+
+const (
+	// repairVerifyInterval is how often triggered repairs are polled in their
+	// ARR; the health check cycle runs far more often than a search progresses.
+	repairVerifyInterval = 15 * time.Minute
+	// repairVerifyBatch bounds the repairs polled per pass.
+	repairVerifyBatch = 50
+	// repairNotifyTimeout bounds an escalation notification request.
+	repairNotifyTimeout = 10 * time.Second
+)
+
+// RepairEscalation is the payload POSTed to the notify URL for a stalled or
+// failed repair.
+type RepairEscalation struct {
+	Event       string `json:"event"`
+	FilePath    string `json:"file_path"`
+	ArrType     string `json:"arr_type,omitempty"`
+	ArrInstance string `json:"arr_instance,omitempty"`
+	State       string `json:"state"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// verifyRepairsIfDue polls triggered repairs at most every
+// repairVerifyInterval.
+func (hw *HealthWorker) verifyRepairsIfDue(ctx context.Context) {
+	cfg := hw.configGetter()
+	if !cfg.GetRepairEnabled() || !cfg.GetRepairVerifyCompletion() {
+		return
+	}
+	if time.Since(hw.lastRepairVerify) < repairVerifyInterval {
+		return
+	}
+	hw.lastRepairVerify = time.Now()
+
+	if err := hw.verifyRepairs(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to verify triggered repairs", "error", err)
+	}
+}
+
+// verifyRepairs follows up every triggered repair in its ARR: a grab of the
+// repaired media resolves it, a search that failed or found no releases fails
+// it and a repair still without a grab after the stall timeout stalls. Failed
+// and stalled repairs are escalated as configured.
+func (hw *HealthWorker) verifyRepairs(ctx context.Context) error {
+	triggers, err := hw.healthRepo.GetRepairsAwaitingVerification(ctx, repairVerifyBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, ev := range triggers {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if ev.Details == nil {
+			continue
+		}
+		var rescan model.RescanResult
+		if err := json.Unmarshal([]byte(*ev.Details), &rescan); err != nil || len(rescan.MediaIDs) == 0 {
+			continue
+		}
+
+		verification, err := hw.arrsService.VerifyRepair(ctx, &rescan, ev.CreatedAt)
+		if errors.Is(err, model.ErrVerificationUnsupported) {
+			continue
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to poll ARR for repair progress",
+				"file_path", ev.FilePath,
+				"instance", rescan.InstanceName,
+				"error", err)
+			continue
+		}
+
+		fh, err := hw.healthRepo.GetFileHealth(ctx, ev.FilePath)
+		if err != nil {
+			return err
+		}
+		if fh == nil || fh.RepairState != database.RepairStateTriggered {
+			continue
+		}
+
+		hw.applyRepairVerification(ctx, fh, &rescan, ev.CreatedAt, verification)
+	}
+
+	return nil
+}
+
+// applyRepairVerification records the outcome of polling a repair triggered
+// at triggeredAt and escalates a failed or stalled one.
+func (hw *HealthWorker) applyRepairVerification(ctx context.Context, fh *database.FileHealth, rescan *model.RescanResult, triggeredAt time.Time, v *model.RepairVerification) {
+	var to database.RepairState
+	reason := v.Message
+	switch v.Progress {
+	case model.RepairProgressGrabbed:
+		to = database.RepairStateResolved
+	case model.RepairProgressNoReleases:
+		to = database.RepairStateFailed
+		reason = "no releases found: " + v.Message
+	case model.RepairProgressFailed:
+		to = database.RepairStateFailed
+	default:
+		stall := hw.configGetter().GetRepairStallTimeout()
+		if time.Since(triggeredAt) < stall {
+			return
+		}
+		to = database.RepairStateStalled
+		reason = fmt.Sprintf("nothing grabbed within %s", stall)
+	}
+
+	ev := newRepairEvent(fh, to, RepairActionPoll, rescan)
+	if reason != "" {
+		ev.Result = &reason
+	}
+	recordRepairEvent(ctx, hw.healthRepo, fh, ev)
+	if fh.RepairState != to {
+		return
+	}
+
+	slog.InfoContext(ctx, "Verified triggered repair",
+		"file_path", fh.FilePath,
+		"instance", rescan.InstanceName,
+		"state", to,
+		"reason", reason)
+
+	if to != database.RepairStateResolved {
+		hw.escalateRepair(ctx, fh, rescan, reason)
+	}
+}
+
+// escalateRepair hands a stalled or failed repair to the configured
+// escalation: an alternate release search on Prowlarr or a notification.
+func (hw *HealthWorker) escalateRepair(ctx context.Context, fh *database.FileHealth, rescan *model.RescanResult, reason string) {
+	cfg := hw.configGetter()
+	switch cfg.GetRepairEscalation() {
+	case config.RepairEscalationAlternateRelease:
+		outcome, err := hw.repairFromAlternateRelease(ctx, fh)
+		if err != nil {
+			slog.WarnContext(ctx, "Alternate release escalation failed", "file_path", fh.FilePath, "error", err)
+		}
+		RecordRepair(ctx, hw.healthRepo, fh, outcome.repairState(), RepairActionAlternateRelease, nil, err)
+
+	case config.RepairEscalationNotify:
+		payload := RepairEscalation{
+			Event:       "repair." + string(fh.RepairState),
+			FilePath:    fh.FilePath,
+			ArrType:     rescan.InstanceType,
+			ArrInstance: rescan.InstanceName,
+			State:       string(fh.RepairState),
+			Reason:      reason,
+		}
+		if err := notifyRepairEscalation(ctx, cfg.Health.Repair.NotifyURL, payload); err != nil {
+			slog.WarnContext(ctx, "Repair escalation notification failed", "file_path", fh.FilePath, "error", err)
+		}
+	}
+}
+
+// notifyRepairEscalation POSTs payload as JSON to url.
+func notifyRepairEscalation(ctx context.Context, url string, payload RepairEscalation) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpclient.New(httpclient.WithTimeout(repairNotifyTimeout)).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/arrs/model"
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// triggerTestRepair inserts a health record and records a triggered repair
+// for it, as triggerFileRepair would.
+func triggerTestRepair(t *testing.T, env *repairTestEnv, filePath string) *database.FileHealth {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := env.db.Exec(`INSERT INTO file_health (file_path, status) VALUES (?, 'repair_triggered')`, filePath)
+	require.NoError(t, err)
+	fh, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	require.NotNil(t, fh)
+
+	rescan := &model.RescanResult{InstanceType: "radarr", InstanceName: "radarr-main", MediaIDs: []int64{7}, SearchCommandIDs: []int64{42}}
+	RecordRepair(ctx, env.healthRepo, fh, database.RepairStateTriggered, RepairActionTrigger, rescan, nil)
+	require.Equal(t, database.RepairStateTriggered, fh.RepairState)
+	return fh
+}
+
+func TestVerifyRepairs_Grabbed(t *testing.T) {
+	ctx := context.Background()
+	env := newRepairTestEnv(t, t.TempDir(), nil)
+	filePath := "movies/verify-grabbed.mkv"
+	triggerTestRepair(t, env, filePath)
+
+	env.mockARRs.verification = &model.RepairVerification{Progress: model.RepairProgressGrabbed, Message: "Movie.2020.1080p"}
+	require.NoError(t, env.hw.verifyRepairs(ctx))
+
+	stored, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, database.RepairStateResolved, stored.RepairState)
+
+	events, err := env.healthRepo.GetRepairEvents(ctx, filePath)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, RepairActionPoll, events[1].Action)
+	require.NotNil(t, events[1].Result)
+	assert.Equal(t, "Movie.2020.1080p", *events[1].Result)
+	require.NotNil(t, events[1].ArrInstance)
+	assert.Equal(t, "radarr-main", *events[1].ArrInstance)
+}
+
+func TestVerifyRepairs_NoReleasesNotifies(t *testing.T) {
+	ctx := context.Background()
+	received := make(chan RepairEscalation, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload RepairEscalation
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		received <- payload
+	}))
+	defer srv.Close()
+
+	env := newRepairTestEnv(t, t.TempDir(), nil, func(cfg *config.Config) {
+		cfg.Health.Repair.Escalation = config.RepairEscalationNotify
+		cfg.Health.Repair.NotifyURL = srv.URL
+	})
+	filePath := "movies/verify-no-releases.mkv"
+	triggerTestRepair(t, env, filePath)
+
+	env.mockARRs.verification = &model.RepairVerification{Progress: model.RepairProgressNoReleases, Message: "0 reports downloaded."}
+	require.NoError(t, env.hw.verifyRepairs(ctx))
+
+	stored, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, database.RepairStateFailed, stored.RepairState)
+
+	select {
+	case payload := <-received:
+		assert.Equal(t, "repair.failed", payload.Event)
+		assert.Equal(t, filePath, payload.FilePath)
+		assert.Equal(t, "radarr-main", payload.ArrInstance)
+		assert.Contains(t, payload.Reason, "no releases found")
+	default:
+		t.Fatal("escalation notification was not sent")
+	}
+}
+
+func TestVerifyRepairs_Stalled(t *testing.T) {
+	ctx := context.Background()
+	env := newRepairTestEnv(t, t.TempDir(), nil)
+	filePath := "movies/verify-stalled.mkv"
+	triggerTestRepair(t, env, filePath)
+
+	// Still searching and within the stall timeout: nothing changes.
+	require.NoError(t, env.hw.verifyRepairs(ctx))
+	stored, err := env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, database.RepairStateTriggered, stored.RepairState)
+
+	old := time.Now().UTC().Add(-25 * time.Hour).Format("2006-01-02 15:04:05")
+	_, err = env.db.Exec(`UPDATE repair_events SET created_at = ? WHERE file_path = ?`, old, filePath)
+	require.NoError(t, err)
+
+	require.NoError(t, env.hw.verifyRepairs(ctx))
+	stored, err = env.healthRepo.GetFileHealth(ctx, filePath)
+	require.NoError(t, err)
+	assert.Equal(t, database.RepairStateStalled, stored.RepairState)
+
+	events, err := env.healthRepo.GetRepairEvents(ctx, filePath)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.NotNil(t, events[1].Result)
+	assert.Contains(t, *events[1].Result, "nothing grabbed within")
+}
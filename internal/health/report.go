@@ -0,0 +1,375 @@
+package health
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javi11/altmount/internal/arrs/model"
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/importer/releasename"
+	"github.com/javi11/altmount/internal/metadata"
+)
+
+// ReportDimension is a way the library health report groups files.
+type ReportDimension string
+
+const (
+	ReportByCategory     ReportDimension = "category"
+	ReportByArr          ReportDimension = "arr"
+	ReportByReleaseGroup ReportDimension = "release_group"
+	ReportByIndexer      ReportDimension = "indexer"
+	ReportByProvider     ReportDimension = "provider"
+)
+
+// ReportDimensions lists every report dimension in report order.
+var ReportDimensions = []ReportDimension{
+	ReportByCategory,
+	ReportByArr,
+	ReportByReleaseGroup,
+	ReportByIndexer,
+	ReportByProvider,
+}
+
+// ParseReportDimensions parses a comma-separated list of dimensions; an
+// empty list selects all of them.
+func ParseReportDimensions(s string) ([]ReportDimension, error) {
+	if strings.TrimSpace(s) == "" {
+		return ReportDimensions, nil
+	}
+	var dims []ReportDimension
+	for name := range strings.SplitSeq(s, ",") {
+		dim := ReportDimension(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(ReportDimensions, dim) {
+			return nil, fmt.Errorf("unknown report dimension %q", name)
+		}
+		if !slices.Contains(dims, dim) {
+			dims = append(dims, dim)
+		}
+	}
+	return dims, nil
+}
+
+// reportUnknownKey groups the files a dimension has no value for.
+const reportUnknownKey = "unknown"
+
+// reportStatuses are the per-status counts of a report group, in CSV order.
+var reportStatuses = []database.HealthStatus{
+	database.HealthStatusHealthy,
+	database.HealthStatusDegraded,
+	database.HealthStatusPending,
+	database.HealthStatusChecking,
+	database.HealthStatusRepairTriggered,
+	database.HealthStatusCorrupted,
+}
+
+// atRisk reports whether a file with status is missing segments.
+func atRisk(status database.HealthStatus) bool {
+	switch status {
+	case database.HealthStatusCorrupted, database.HealthStatusRepairTriggered, database.HealthStatusDegraded:
+		return true
+	}
+	return false
+}
+
+// ReportGroup is the health of the files sharing one value of a dimension.
+type ReportGroup struct {
+	Dimension ReportDimension               `json:"dimension"`
+	Key       string                        `json:"key"`
+	Files     int                           `json:"files"`
+	Statuses  map[database.HealthStatus]int `json:"statuses"`
+	// BytesAtRisk is the size of the files missing segments.
+	BytesAtRisk int64 `json:"bytes_at_risk"`
+	// OldestUnchecked is the last check of the least recently checked file
+	// (when it was added, if never checked).
+	OldestUnchecked *time.Time `json:"oldest_unchecked,omitempty"`
+	// SegmentLosses counts the releases that lost segments, including those
+	// already replaced by a repair; LossesPer100Files relates them to the
+	// files the group holds today, so it can exceed 100.
+	SegmentLosses     int      `json:"segment_losses"`
+	LossesPer100Files *float64 `json:"losses_per_100_files,omitempty"`
+	// MedianDaysToLoss is the median release age at the first loss.
+	MedianDaysToLoss *float64 `json:"median_days_to_loss,omitempty"`
+	// Repair outcomes of the lost releases, judged by the record now at the
+	// release's path: healthy is a success, still corrupted a failure.
+	RepairsSucceeded  int      `json:"repairs_succeeded"`
+	RepairsFailed     int      `json:"repairs_failed"`
+	RepairsPending    int      `json:"repairs_pending"`
+	RepairSuccessRate *float64 `json:"repair_success_rate,omitempty"`
+	// Import outcomes from the indexer import log (indexer groups only).
+	ImportsTotal      int      `json:"imports_total,omitempty"`
+	ImportSuccessRate *float64 `json:"import_success_rate,omitempty"`
+
+	lossAges []time.Duration
+}
+
+// LibraryReport is the library-wide health report.
+type LibraryReport struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Groups      []*ReportGroup `json:"groups"`
+}
+
+// ReportSource is the database side of the library health report.
+type ReportSource interface {
+	GetReportFiles(ctx context.Context) ([]database.ReportFile, error)
+	GetReportLosses(ctx context.Context) ([]database.ReportLoss, error)
+	GetIndexerHealthStats(ctx context.Context) ([]*database.IndexerAggregatedHealth, error)
+}
+
+// FileSizer reads file sizes from the metadata store.
+type FileSizer interface {
+	ReadFileMetadataLite(virtualPath string) (*metadata.FileMetadataLite, error)
+}
+
+// BuildLibraryReport groups the whole library by each of dims. Only the
+// files missing segments have their size read, through sizer's lite
+// metadata projection.
+func BuildLibraryReport(ctx context.Context, src ReportSource, sizer FileSizer, cfg *config.Config, dims []ReportDimension) (*LibraryReport, error) {
+	files, err := src.GetReportFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	losses, err := src.GetReportLosses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	imports, err := src.GetIndexerHealthStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indexer import stats: %w", err)
+	}
+
+	groups := make(map[ReportDimension]map[string]*ReportGroup, len(dims))
+	group := func(dim ReportDimension, key string) *ReportGroup {
+		if key == "" {
+			key = reportUnknownKey
+		}
+		if groups[dim] == nil {
+			groups[dim] = make(map[string]*ReportGroup)
+		}
+		g, ok := groups[dim][key]
+		if !ok {
+			g = &ReportGroup{Dimension: dim, Key: key, Statuses: make(map[database.HealthStatus]int)}
+			groups[dim][key] = g
+		}
+		return g
+	}
+
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var size int64
+		if atRisk(f.Status) && sizer != nil {
+			if meta, err := sizer.ReadFileMetadataLite(f.FilePath); err == nil && meta != nil {
+				size = meta.FileSize
+			}
+		}
+		keys := reportKeys(cfg, f.FilePath, f.SourceNzbPath, f.Indexer, f.Provider, f.Metadata)
+		for _, dim := range dims {
+			g := group(dim, keys[dim])
+			g.Files++
+			g.Statuses[f.Status]++
+			g.BytesAtRisk += size
+			if g.OldestUnchecked == nil || f.CheckedAt.Before(*g.OldestUnchecked) {
+				checked := f.CheckedAt
+				g.OldestUnchecked = &checked
+			}
+		}
+	}
+
+	for _, l := range losses {
+		keys := reportKeys(cfg, l.FilePath, "", l.Indexer, l.Provider, l.Metadata)
+		for _, dim := range dims {
+			g := group(dim, keys[dim])
+			g.SegmentLosses++
+			g.lossAges = append(g.lossAges, l.Age)
+			switch l.Status {
+			case database.HealthStatusHealthy:
+				g.RepairsSucceeded++
+			case database.HealthStatusCorrupted:
+				g.RepairsFailed++
+			case database.HealthStatusRepairTriggered, database.HealthStatusPending, database.HealthStatusChecking:
+				g.RepairsPending++
+			}
+		}
+	}
+
+	if slices.Contains(dims, ReportByIndexer) {
+		for _, stat := range imports {
+			g := group(ReportByIndexer, stat.Indexer)
+			g.ImportsTotal = stat.TotalImports
+			rate := stat.SuccessRate
+			g.ImportSuccessRate = &rate
+		}
+	}
+
+	report := &LibraryReport{GeneratedAt: time.Now().UTC()}
+	for _, dim := range dims {
+		var dimGroups []*ReportGroup
+		for _, g := range groups[dim] {
+			g.finish()
+			dimGroups = append(dimGroups, g)
+		}
+		// Fastest-rotting groups first.
+		sort.Slice(dimGroups, func(i, j int) bool {
+			a, b := dimGroups[i], dimGroups[j]
+			if ra, rb := rateOrNegative(a.LossesPer100Files), rateOrNegative(b.LossesPer100Files); ra != rb {
+				return ra > rb
+			}
+			if a.Files != b.Files {
+				return a.Files > b.Files
+			}
+			return a.Key < b.Key
+		})
+		report.Groups = append(report.Groups, dimGroups...)
+	}
+	return report, nil
+}
+
+// finish derives a group's rates from its counts.
+func (g *ReportGroup) finish() {
+	if g.Files > 0 {
+		rate := float64(g.SegmentLosses) * 100 / float64(g.Files)
+		g.LossesPer100Files = &rate
+	}
+	if len(g.lossAges) > 0 {
+		slices.Sort(g.lossAges)
+		days := g.lossAges[len(g.lossAges)/2].Hours() / 24
+		g.MedianDaysToLoss = &days
+	}
+	if finished := g.RepairsSucceeded + g.RepairsFailed; finished > 0 {
+		rate := float64(g.RepairsSucceeded) * 100 / float64(finished)
+		g.RepairSuccessRate = &rate
+	}
+}
+
+func rateOrNegative(rate *float64) float64 {
+	if rate == nil {
+		return -1
+	}
+	return *rate
+}
+
+// reportKeys returns a file's value for every report dimension.
+func reportKeys(cfg *config.Config, filePath, sourceNzbPath, indexer, provider string, meta *string) map[ReportDimension]string {
+	group := releasename.Group(sourceNzbPath)
+	if group == "" {
+		group = releasename.Group(filePath)
+	}
+	return map[ReportDimension]string{
+		ReportByCategory:     reportCategory(cfg, filePath),
+		ReportByArr:          reportArrInstance(meta),
+		ReportByReleaseGroup: group,
+		ReportByIndexer:      indexer,
+		ReportByProvider:     provider,
+	}
+}
+
+// reportCategory maps a mount-relative file path back to the SABnzbd
+// category whose directory holds it, or to its top-level directory for files
+// outside every category (library-sync discoveries, NzbDAV imports).
+func reportCategory(cfg *config.Config, filePath string) string {
+	p := strings.Trim(filepath.ToSlash(filePath), "/")
+	completeDir := strings.Trim(filepath.ToSlash(cfg.SABnzbd.CompleteDir), "/")
+	if completeDir != "" {
+		if rest, ok := strings.CutPrefix(p, completeDir+"/"); ok {
+			p = rest
+		}
+	}
+
+	names := []string{config.DefaultCategoryName}
+	for _, cat := range cfg.SABnzbd.Categories {
+		names = append(names, cat.Name)
+	}
+	lower := strings.ToLower(p)
+	best, bestLen := "", 0
+	for _, name := range names {
+		dir := strings.ToLower(strings.Trim(filepath.ToSlash(resolveCategoryDir(cfg, name)), "/"))
+		if dir != "" && len(dir) > bestLen && (lower == dir || strings.HasPrefix(lower, dir+"/")) {
+			best, bestLen = name, len(dir)
+		}
+	}
+	if best != "" {
+		return best
+	}
+
+	if dir, _, ok := strings.Cut(p, "/"); ok {
+		return dir
+	}
+	return ""
+}
+
+// reportArrInstance returns the arr instance named in a record's metadata.
+func reportArrInstance(meta *string) string {
+	if meta == nil || *meta == "" {
+		return ""
+	}
+	var m model.WebhookMetadata
+	if err := json.Unmarshal([]byte(*meta), &m); err != nil {
+		return ""
+	}
+	return m.InstanceName
+}
+
+// WriteCSV writes the report as CSV, one row per group.
+func (r *LibraryReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"dimension", "key", "files"}
+	for _, status := range reportStatuses {
+		header = append(header, string(status))
+	}
+	header = append(header,
+		"bytes_at_risk", "oldest_unchecked", "segment_losses", "losses_per_100_files",
+		"median_days_to_loss", "repairs_succeeded", "repairs_failed", "repairs_pending",
+		"repair_success_rate", "imports_total", "import_success_rate",
+	)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, g := range r.Groups {
+		row := []string{string(g.Dimension), g.Key, strconv.Itoa(g.Files)}
+		for _, status := range reportStatuses {
+			row = append(row, strconv.Itoa(g.Statuses[status]))
+		}
+		oldest := ""
+		if g.OldestUnchecked != nil {
+			oldest = g.OldestUnchecked.UTC().Format(time.RFC3339)
+		}
+		row = append(row,
+			strconv.FormatInt(g.BytesAtRisk, 10),
+			oldest,
+			strconv.Itoa(g.SegmentLosses),
+			formatReportRate(g.LossesPer100Files),
+			formatReportRate(g.MedianDaysToLoss),
+			strconv.Itoa(g.RepairsSucceeded),
+			strconv.Itoa(g.RepairsFailed),
+			strconv.Itoa(g.RepairsPending),
+			formatReportRate(g.RepairSuccessRate),
+			strconv.Itoa(g.ImportsTotal),
+			formatReportRate(g.ImportSuccessRate),
+		)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatReportRate(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 1, 64)
+}
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReportSource struct {
+	files   []database.ReportFile
+	losses  []database.ReportLoss
+	imports []*database.IndexerAggregatedHealth
+}
+
+func (s *fakeReportSource) GetReportFiles(context.Context) ([]database.ReportFile, error) {
+	return s.files, nil
+}
+
+func (s *fakeReportSource) GetReportLosses(context.Context) ([]database.ReportLoss, error) {
+	return s.losses, nil
+}
+
+func (s *fakeReportSource) GetIndexerHealthStats(context.Context) ([]*database.IndexerAggregatedHealth, error) {
+	return s.imports, nil
+}
+
+type fakeSizer map[string]int64
+
+func (s fakeSizer) ReadFileMetadataLite(path string) (*metadata.FileMetadataLite, error) {
+	return &metadata.FileMetadataLite{FileSize: s[path]}, nil
+}
+
+func TestBuildLibraryReport(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	sonarr := `{"instanceName":"sonarr-4k"}`
+	cfg := &config.Config{}
+	cfg.SABnzbd.CompleteDir = "/complete"
+	cfg.SABnzbd.Categories = []config.SABnzbdCategory{{Name: "tv", Dir: "shows"}}
+
+	src := &fakeReportSource{
+		files: []database.ReportFile{
+			{FilePath: "complete/shows/A.S01E01.1080p.WEB-ROT.mkv", Status: database.HealthStatusCorrupted, CheckedAt: now.Add(-48 * time.Hour), Indexer: "bad", Metadata: &sonarr},
+			{FilePath: "complete/shows/A.S01E02.1080p.WEB-ROT.mkv", Status: database.HealthStatusHealthy, CheckedAt: now, Indexer: "bad", Metadata: &sonarr},
+			{FilePath: "complete/movies/B.2020.1080p.BluRay-GOOD.mkv", Status: database.HealthStatusHealthy, CheckedAt: now, Indexer: "good", Provider: "p1"},
+		},
+		losses: []database.ReportLoss{
+			{FilePath: "complete/shows/A.S01E01.1080p.WEB-ROT.mkv", Indexer: "bad", Age: 2 * 24 * time.Hour, Status: database.HealthStatusCorrupted},
+			{FilePath: "complete/shows/A.S01E03.1080p.WEB-ROT.mkv", Indexer: "bad", Age: 4 * 24 * time.Hour, Status: database.HealthStatusHealthy},
+		},
+		imports: []*database.IndexerAggregatedHealth{{Indexer: "bad", TotalImports: 10, SuccessRate: 80}},
+	}
+	sizer := fakeSizer{"complete/shows/A.S01E01.1080p.WEB-ROT.mkv": 1 << 30}
+
+	report, err := BuildLibraryReport(context.Background(), src, sizer, cfg, ReportDimensions)
+	require.NoError(t, err)
+
+	find := func(dim ReportDimension, key string) *ReportGroup {
+		for _, g := range report.Groups {
+			if g.Dimension == dim && g.Key == key {
+				return g
+			}
+		}
+		t.Fatalf("no %s group %q", dim, key)
+		return nil
+	}
+
+	bad := find(ReportByIndexer, "bad")
+	assert.Equal(t, 2, bad.Files)
+	assert.Equal(t, 1, bad.Statuses[database.HealthStatusCorrupted])
+	assert.Equal(t, int64(1<<30), bad.BytesAtRisk)
+	require.NotNil(t, bad.OldestUnchecked)
+	assert.True(t, bad.OldestUnchecked.Equal(now.Add(-48*time.Hour)))
+	assert.Equal(t, 2, bad.SegmentLosses)
+	assert.InDelta(t, 100.0, *bad.LossesPer100Files, 1e-9)
+	assert.InDelta(t, 4.0, *bad.MedianDaysToLoss, 1e-9)
+	assert.Equal(t, 1, bad.RepairsSucceeded)
+	assert.Equal(t, 1, bad.RepairsFailed)
+	assert.InDelta(t, 50.0, *bad.RepairSuccessRate, 1e-9)
+	assert.Equal(t, 10, bad.ImportsTotal)
+	assert.Nil(t, find(ReportByIndexer, "good").RepairSuccessRate)
+
+	assert.Equal(t, 2, find(ReportByCategory, "tv").Files)
+	assert.Equal(t, 1, find(ReportByCategory, "movies").Files)
+	assert.Equal(t, 2, find(ReportByArr, "sonarr-4k").Files)
+	assert.Equal(t, 1, find(ReportByArr, reportUnknownKey).Files)
+	assert.Equal(t, 2, find(ReportByReleaseGroup, "ROT").Files)
+	assert.Equal(t, 1, find(ReportByProvider, "p1").Files)
+
+	// The fastest-rotting indexer comes first.
+	var indexers []string
+	for _, g := range report.Groups {
+		if g.Dimension == ReportByIndexer {
+			indexers = append(indexers, g.Key)
+		}
+	}
+	assert.Equal(t, []string{"bad", "good"}, indexers)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, len(report.Groups)+1)
+	assert.Equal(t, []string{"dimension", "key", "files", "healthy"}, records[0][:4])
+}
+
+func TestParseReportDimensions(t *testing.T) {
+	dims, err := ParseReportDimensions("")
+	require.NoError(t, err)
+	assert.Equal(t, ReportDimensions, dims)
+
+	dims, err = ParseReportDimensions("Indexer, provider,indexer")
+	require.NoError(t, err)
+	assert.Equal(t, []ReportDimension{ReportByIndexer, ReportByProvider}, dims)
+
+	_, err = ParseReportDimensions("indexer,tracker")
+	assert.Error(t, err)
+}
@@ -48,7 +48,19 @@ func newResilienceDB(t *testing.T) *sql.DB {
 			streaming_failure_count INTEGER DEFAULT 0,
 			is_masked BOOLEAN DEFAULT FALSE,
 			indexer TEXT DEFAULT NULL,
-			download_id TEXT DEFAULT NULL
+			download_id TEXT DEFAULT NULL,
+			provider_completeness TEXT DEFAULT NULL,
+			newsgroup TEXT DEFAULT NULL,
+			poster TEXT DEFAULT NULL,
+			first_loss_at DATETIME DEFAULT NULL,
+			expiry_risk REAL DEFAULT NULL,
+			next_check_reason TEXT DEFAULT NULL,
+			last_streamed_at DATETIME DEFAULT NULL,
+			stream_count INTEGER NOT NULL DEFAULT 0,
+			continue_watching BOOLEAN NOT NULL DEFAULT FALSE,
+			sample_confidence TEXT DEFAULT NULL,
+			repair_state TEXT NOT NULL DEFAULT '',
+			repairability TEXT DEFAULT NULL
 		);
 
 		CREATE TABLE IF NOT EXISTS system_state (
@@ -126,7 +138,7 @@ func TestCleanupZombieRecord_DeletesLibrarySymlink(t *testing.T) {
 	assert.True(t, os.IsNotExist(err), "library file should be deleted by zombie cleanup")
 
 	// Health record should be deleted
-	count, err := healthRepo.CountHealthItems(ctx, nil, nil, "")
+	count, err := healthRepo.CountHealthItems(ctx, nil, nil, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, 0, count, "health record should be deleted")
 
@@ -177,7 +189,7 @@ func TestCleanupZombieRecord_NoLibraryPath(t *testing.T) {
 	hw.cleanupZombieRecord(ctx, item)
 
 	// Health record should be deleted
-	count, err := healthRepo.CountHealthItems(ctx, nil, nil, "")
+	count, err := healthRepo.CountHealthItems(ctx, nil, nil, nil, "")
 	require.NoError(t, err)
 	assert.Equal(t, 0, count)
 }
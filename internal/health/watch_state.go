@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/javi11/altmount/internal/database"
+)
+
+// watchStateSyncInterval is how often media servers are asked what their
+// users watched; watch state changes far slower than the check cycle runs.
+const watchStateSyncInterval = 15 * time.Minute
+
+// syncWatchStateIfDue records media server watch state at most every
+// watchStateSyncInterval.
+func (hw *HealthWorker) syncWatchStateIfDue(ctx context.Context) {
+	if time.Since(hw.lastWatchStateSync) < watchStateSyncInterval {
+		return
+	}
+	hw.lastWatchStateSync = time.Now()
+	hw.syncWatchState(ctx)
+}
+
+// syncWatchState records the files every enabled media server's users played
+// or are part way through as playbacks, so they are checked and repaired
+// first like streamed files. Files the servers see outside the mount are
+// skipped.
+func (hw *HealthWorker) syncWatchState(ctx context.Context) {
+	for _, server := range hw.mediaServers(hw.configGetter()) {
+		watched, err := server.MountWatchState(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to read media server watch state", "server", server.Name, "error", err)
+			continue
+		}
+		for _, w := range watched {
+			if err := hw.healthRepo.RecordPlayback(ctx, database.Playback{
+				FilePath:   w.Path,
+				At:         w.LastPlayed,
+				InProgress: w.InProgress,
+			}); err != nil {
+				slog.WarnContext(ctx, "Failed to record media server playback", "server", server.Name, "file_path", w.Path, "error", err)
+			}
+		}
+		slog.DebugContext(ctx, "Synced media server watch state", "server", server.Name, "files", len(watched))
+	}
+}
@@ -0,0 +1,51 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/mediaserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncWatchState_RecordsMediaServerPlayback(t *testing.T) {
+	ctx := context.Background()
+	env := newRepairTestEnv(t, t.TempDir(), nil)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Users":
+			_ = json.NewEncoder(w).Encode([]any{map[string]any{"Id": "u1"}})
+		case "/Users/u1/Items":
+			var items []any
+			if r.URL.Query().Get("Filters") == "IsResumable" {
+				items = []any{map[string]any{"Path": "/mnt/altmount/tv/S01E02.mkv", "UserData": map[string]any{"LastPlayedDate": "2024-05-02T20:00:00Z"}}}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"TotalRecordCount": len(items), "Items": items})
+		}
+	}))
+	defer srv.Close()
+	server, err := mediaserver.New(config.MediaServerConfig{Name: "jellyfin", Type: config.MediaServerTypeJellyfin, URL: srv.URL}, "/mnt/altmount", srv.Client())
+	require.NoError(t, err)
+	env.hw.mediaServers = func(*config.Config) []*mediaserver.Server { return []*mediaserver.Server{server} }
+
+	insertFileHealth(t, env.db, "tv/S01E02.mkv", "tv/S01E02.mkv", 0, 3)
+	insertFileHealth(t, env.db, "tv/S01E03.mkv", "tv/S01E03.mkv", 0, 3)
+
+	env.hw.syncWatchStateIfDue(ctx)
+
+	watched, err := env.healthRepo.GetFileHealth(ctx, "tv/S01E02.mkv")
+	require.NoError(t, err)
+	require.NotNil(t, watched.LastStreamedAt)
+	assert.Equal(t, 1, watched.StreamCount)
+	assert.True(t, watched.ContinueWatching)
+
+	unwatched, err := env.healthRepo.GetFileHealth(ctx, "tv/S01E03.mkv")
+	require.NoError(t, err)
+	assert.Nil(t, unwatched.LastStreamedAt)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/javi11/altmount/internal/database"
 	"github.com/javi11/altmount/internal/holes"
 	"github.com/javi11/altmount/internal/importer"
+	"github.com/javi11/altmount/internal/mediaserver"
 	"github.com/javi11/altmount/internal/metadata"
 	metapb "github.com/javi11/altmount/internal/metadata/proto"
 	"github.com/javi11/altmount/internal/progress"
@@ -28,7 +29,8 @@ import (
 
 // ARRsRepairService abstracts the ARR repair operations needed by HealthWorker.
 type ARRsRepairService interface {
-	TriggerFileRescan(ctx context.Context, pathForRescan string, relativePath string, metadataStr *string) error
+	TriggerFileRescan(ctx context.Context, pathForRescan string, relativePath string, metadataStr *string) (*model.RescanResult, error)
+	VerifyRepair(ctx context.Context, rescan *model.RescanResult, since time.Time) (*model.RepairVerification, error)
 	DiscoverFileMetadata(ctx context.Context, filePath, relativePath, nzbName, libraryPath string) (*model.WebhookMetadata, error)
 }
 
@@ -66,6 +68,8 @@ type HealthWorker struct {
 	importerService     importer.ImportService
 	configGetter        config.ConfigGetter
 	progressBroadcaster *progress.ProgressBroadcaster // optional, may be nil
+	releaseSearcher     func(*config.Config) ReleaseSearcher
+	mediaServers        func(*config.Config) []*mediaserver.Server
 
 	// Worker state
 	status       WorkerStatus
@@ -83,8 +87,18 @@ type HealthWorker struct {
 	stats   WorkerStats
 	statsMu sync.RWMutex
 
+	// When triggered repairs were last polled in their ARR
+	lastRepairVerify time.Time
+	// When media server watch state was last synced
+	lastWatchStateSync time.Time
+
 	// Singleflight for metadata discovery
 	discoverySF singleflight.Group
+
+	// Article-expiry model, rebuilt from the database every expiryModelTTL
+	expiryMu      sync.Mutex
+	expiryModel   *ExpiryModel
+	expiryBuiltAt time.Time
 }
 
 // NewHealthWorker creates a new health worker
@@ -105,6 +119,8 @@ func NewHealthWorker(
 		importerService:     importerService,
 		configGetter:        configGetter,
 		progressBroadcaster: broadcaster,
+		releaseSearcher:     newProwlarrSearcher,
+		mediaServers:        mediaserver.FromConfig,
 		status:              WorkerStatusStopped,
 		stopChan:            make(chan struct{}),
 		activeChecks:        make(map[string]context.CancelFunc),
@@ -385,7 +401,27 @@ func (hw *HealthWorker) PerformBackgroundCheck(ctx context.Context, filePath str
 }
 
 // prepareUpdateForResult decides what DB update and side effects are needed based on the check result.
+//
+// A loss found by a check that will still be retried may be transient; only a settled
+// outcome (retries spent, repair, delete, degraded) records the first loss of the
+// release for the article-expiry model.
 func (hw *HealthWorker) prepareUpdateForResult(ctx context.Context, fh *database.FileHealth, event HealthEvent) (*database.HealthStatusUpdate, func() error) {
+	update, sideEffect := hw.prepareCheckOutcome(ctx, fh, event)
+	if event.Type != EventTypeFileCorrupted || fh.FirstLossAt != nil || update.Type == database.UpdateTypeRetry {
+		return update, sideEffect
+	}
+	return update, func() error {
+		hw.recordSegmentLoss(ctx, fh)
+		if sideEffect == nil {
+			return nil
+		}
+		return sideEffect()
+	}
+}
+
+// prepareCheckOutcome builds the update and side effect for a check result (see
+// prepareUpdateForResult).
+func (hw *HealthWorker) prepareCheckOutcome(ctx context.Context, fh *database.FileHealth, event HealthEvent) (*database.HealthStatusUpdate, func() error) {
 	update := &database.HealthStatusUpdate{
 		FilePath: fh.FilePath,
 	}
@@ -401,21 +437,25 @@ func (hw *HealthWorker) prepareUpdateForResult(ctx context.Context, fh *database
 		return update, sideEffect
 	}
 
+	update.SampleConfidence = event.Confidence.Marshal()
+	update.Repairability = database.MarshalRepairability(event.Repairability)
+
 	if event.Type == EventTypeFileHealthy {
 		// File is now healthy
-		releaseDate := fh.ReleaseDate
-		if releaseDate == nil {
-			releaseDate = &fh.CreatedAt
-		}
-
-		nextCheck := CalculateNextCheck(releaseDate.UTC(), time.Now().UTC())
+		schedule := hw.scheduleNextCheck(ctx, fh)
 		update.Type = database.UpdateTypeHealthy
 		update.Status = database.HealthStatusHealthy
-		update.ScheduledCheckAt = nextCheck
+		update.ScheduledCheckAt = schedule.NextCheck
+		update.ExpiryRisk = &schedule.Risk
+		update.NextCheckReason = &schedule.Reason
 
 		sideEffect = func() error {
 			slog.InfoContext(ctx, "File is healthy", "file_path", fh.FilePath)
 
+			if fh.RepairState.IsOpen() || fh.RepairState == database.RepairStateExhausted {
+				RecordRepair(ctx, hw.healthRepo, fh, database.RepairStateResolved, RepairActionVerify, nil, nil)
+			}
+
 			return hw.metadataService.UpdateFileStatus(fh.FilePath, metapb.FileStatus_FILE_STATUS_HEALTHY)
 		}
 
@@ -438,15 +478,14 @@ func (hw *HealthWorker) prepareUpdateForResult(ctx context.Context, fh *database
 	if event.Classification != nil &&
 		event.Classification.Verdict == holes.VerdictDegraded &&
 		fh.Status != database.HealthStatusRepairTriggered {
-		releaseDate := fh.ReleaseDate
-		if releaseDate == nil {
-			releaseDate = &fh.CreatedAt
-		}
-		nextCheck := CalculateNextCheck(releaseDate.UTC(), time.Now().UTC())
+		schedule := hw.scheduleNextCheck(ctx, fh)
+		nextCheck := schedule.NextCheck
 
 		update.Type = database.UpdateTypeDegraded
 		update.Status = database.HealthStatusDegraded
 		update.ScheduledCheckAt = nextCheck
+		update.ExpiryRisk = &schedule.Risk
+		update.NextCheckReason = &schedule.Reason
 
 		sideEffect = func() error {
 			slog.InfoContext(ctx, "File degraded: missing segments are within padding caps, skipping repair",
@@ -465,9 +504,13 @@ func (hw *HealthWorker) prepareUpdateForResult(ctx context.Context, fh *database
 	// metadata safety-folder move. Regular health-check retries below are left intact.
 	// When delete-on-corruption is enabled, confirmed corruption is handled by removing
 	// the file entirely instead of ever entering the repair flow below (this covers both
-	// freshly-exhausted checks and files already sitting in repair_triggered).
+	// freshly-exhausted checks and files already sitting in repair_triggered) — unless
+	// the release's PAR2 volumes cover the damage (see keepRecoverableFile).
 	if hw.configGetter().GetHealthDeleteOnCorruption() {
-		return hw.deleteCorruptedFile(ctx, fh)
+		if !event.Repairability.Repairable() {
+			return hw.deleteCorruptedFile(ctx, fh)
+		}
+		return hw.keepRecoverableFile(ctx, fh, update, event.Repairability)
 	}
 
 	repairEnabled := hw.configGetter().GetRepairEnabled()
@@ -588,6 +631,10 @@ func (hw *HealthWorker) markCorruptedRepairExhausted(ctx context.Context, fh *da
 	return func() error {
 		slog.ErrorContext(ctx, "File permanently marked as corrupted after repair retries exhausted", "file_path", fh.FilePath)
 
+		if fh.RepairState != database.RepairStateExhausted {
+			RecordRepair(ctx, hw.healthRepo, fh, database.RepairStateExhausted, RepairActionExhaust, nil, nil)
+		}
+
 		// Ensure metadata is hidden in the safety folder
 		hw.moveMetadataToSafetyFolder(ctx, fh)
 
@@ -624,10 +671,28 @@ func (hw *HealthWorker) deleteCorruptedFile(ctx context.Context, fh *database.Fi
 			slog.ErrorContext(ctx, "Failed to delete corrupted file", "file_path", fh.FilePath, "error", err)
 			return err
 		}
+		RecordRepair(ctx, hw.healthRepo, fh, database.RepairStateRemoved, RepairActionDelete, nil, nil)
 		if err := hw.healthRepo.DeleteHealthRecord(ctx, fh.FilePath); err != nil {
 			slog.ErrorContext(ctx, "Failed to delete health record for deleted corrupted file", "file_path", fh.FilePath, "error", err)
 		}
 		slog.WarnContext(ctx, "Deleted corrupted file instead of triggering repair", "file_path", fh.FilePath)
+		hw.refreshMediaServers(ctx, fh.FilePath)
+		return nil
+	}
+}
+
+// keepRecoverableFile finalizes a corrupted file as corrupted under
+// delete-on-corruption when its release's PAR2 recovery data covers the damage
+// (holes.Recoverable). AltMount streams without running PAR2, so the file stays
+// unplayable and no repair is triggered, but its NZB still holds every byte: it
+// is kept for a PAR2-capable download client rather than deleted.
+func (hw *HealthWorker) keepRecoverableFile(ctx context.Context, fh *database.FileHealth, update *database.HealthStatusUpdate, repairability *database.Repairability) (*database.HealthStatusUpdate, func() error) {
+	update.Type = database.UpdateTypeCorrupted
+	update.Status = database.HealthStatusCorrupted
+	return update, func() error {
+		slog.InfoContext(ctx, "Corrupted file is recoverable with its release's PAR2 data, keeping it instead of deleting",
+			"file_path", fh.FilePath,
+			"repairability_score", repairability.Score)
 		return nil
 	}
 }
@@ -636,10 +701,6 @@ func (hw *HealthWorker) deleteCorruptedFile(ctx context.Context, fh *database.Fi
 // repair_triggered state. It re-triggers ARR directly without calling CheckFile, since the
 // metadata has already been moved to the corrupted folder.
 func (hw *HealthWorker) prepareRepairNotificationUpdate(ctx context.Context, fh *database.FileHealth) (*database.HealthStatusUpdate, func() error) {
-	if hw.configGetter().GetHealthDeleteOnCorruption() {
-		return hw.deleteCorruptedFile(ctx, fh)
-	}
-
 	// Default to the existing diagnosis so a successful re-trigger (which never
 	// sets these itself) doesn't null out the reason the file was flagged in
 	// the first place; applyRepairOutcome combines onto this if repair fails.
@@ -649,6 +710,14 @@ func (hw *HealthWorker) prepareRepairNotificationUpdate(ctx context.Context, fh
 		ErrorDetails: fh.ErrorDetails,
 	}
 
+	if hw.configGetter().GetHealthDeleteOnCorruption() {
+		repairability := database.ParseRepairability(fh.Repairability)
+		if !repairability.Repairable() {
+			return hw.deleteCorruptedFile(ctx, fh)
+		}
+		return hw.keepRecoverableFile(ctx, fh, update, repairability)
+	}
+
 	if fh.RepairRetryCount >= hw.configGetter().GetMaxRepairRetries() {
 		// Retries exhausted — give up and mark corrupted. Deliberately no metadata
 		// move here: unlike the failed-check path, this sweep has not re-validated
@@ -660,6 +729,9 @@ func (hw *HealthWorker) prepareRepairNotificationUpdate(ctx context.Context, fh
 			slog.ErrorContext(ctx, "File permanently marked as corrupted after repair retries exhausted",
 				"file_path", fh.FilePath,
 				"repair_retry_count", fh.RepairRetryCount)
+			if fh.RepairState != database.RepairStateExhausted {
+				RecordRepair(ctx, hw.healthRepo, fh, database.RepairStateExhausted, RepairActionExhaust, nil, nil)
+			}
 			return nil
 		}
 		return update, sideEffect
@@ -791,6 +863,12 @@ func (hw *HealthWorker) runHealthCheckCycle(ctx context.Context) error {
 		s.CurrentRunFilesChecked = 0
 	})
 
+	// Follow up triggered repairs in their ARR (throttled; cycles run often)
+	hw.verifyRepairsIfDue(ctx)
+
+	// Pick up what media server users watched, so it is checked first (throttled)
+	hw.syncWatchStateIfDue(ctx)
+
 	maxJobs := hw.getMaxConcurrentJobs()
 	cfg := hw.configGetter()
 	strategy := string(cfg.Import.ImportStrategy)
@@ -1030,7 +1108,7 @@ func (hw *HealthWorker) getMaxConcurrentJobs() int {
 type repairOutcome int
 
 const (
-	repairOutcomeTriggered   repairOutcome = iota // ARR accepted the repair; metadata moved to corrupted folder
+	repairOutcomeTriggered   repairOutcome = iota // ARR accepted the repair (metadata moved to corrupted folder) or an alternate release was queued
 	repairOutcomeCorrupted                        // ARR failed with a generic error; mark file corrupted
 	repairOutcomeDeleted                          // Health record and/or metadata were deleted (zombie)
 	repairOutcomeRegenerated                      // Metadata was successfully regenerated from NZB
@@ -1122,9 +1200,16 @@ func (hw *HealthWorker) cleanupZombieRecord(ctx context.Context, item *database.
 // triggerFileRepair handles the business logic for triggering repair of a corrupted file.
 // It contacts ARR APIs and moves metadata, but does NOT write health status to the DB directly.
 // Callers must apply the returned outcome to the HealthStatusUpdate before the bulk DB write.
-func (hw *HealthWorker) triggerFileRepair(ctx context.Context, item *database.FileHealth, errorMsg *string, errorDetails *string) (repairOutcome, error) {
+// The attempt is recorded in the repair audit log.
+func (hw *HealthWorker) triggerFileRepair(ctx context.Context, item *database.FileHealth, errorMsg *string, errorDetails *string) (outcome repairOutcome, err error) {
 	filePath := item.FilePath
 
+	action := RepairActionTrigger
+	var rescan *model.RescanResult
+	defer func() {
+		RecordRepair(ctx, hw.healthRepo, item, outcome.repairState(), action, rescan, err)
+	}()
+
 	// Check if file metadata still exists. If not, the file is gone (likely upgraded/deleted by Sonarr already)
 	// and this health record is a zombie.
 	var metadataErr error
@@ -1164,7 +1249,7 @@ func (hw *HealthWorker) triggerFileRepair(ctx context.Context, item *database.Fi
 	pathForRescan := hw.resolvePathForRescan(item)
 	metadataStr := hw.ensureMetadata(ctx, item)
 
-	err := hw.arrsService.TriggerFileRescan(ctx, pathForRescan, filePath, metadataStr)
+	rescan, err = hw.arrsService.TriggerFileRescan(ctx, pathForRescan, filePath, metadataStr)
 	if err != nil {
 		// ErrEpisodeAlreadySatisfied is an ID-based confirmation from the ARR (Smart Repair
 		// Guard) that this title was upgraded/replaced by a *different* file, so the AltMount
@@ -1189,6 +1274,13 @@ func (hw *HealthWorker) triggerFileRepair(ctx context.Context, item *database.Fi
 			return repairOutcomeCorrupted, err
 		}
 
+		// No ARR manages the file (manual import, Stremio, NzbDav migration): with
+		// alternate-release repair enabled, look for another release on Prowlarr.
+		if errors.Is(err, arrs.ErrInstanceNotFound) && hw.configGetter().GetRepairAlternateRelease() {
+			action = RepairActionAlternateRelease
+			return hw.repairFromAlternateRelease(ctx, item)
+		}
+
 		// A temporarily unreachable ARR (network/transport error or 5xx) must NOT condemn
 		// the file. Defer: keep it repair-pending (no retry-count bump, no metadata move)
 		// so it self-heals on the next cycle once the ARR returns.
@@ -1226,15 +1318,22 @@ func (hw *HealthWorker) triggerFileRepair(ctx context.Context, item *database.Fi
 // retriggerFileRepair re-triggers the ARR rescan for a file already in repair_triggered state.
 // Unlike triggerFileRepair it does NOT write to the DB.
 // Callers must apply the returned outcome to the HealthStatusUpdate before the bulk DB write.
-func (hw *HealthWorker) retriggerFileRepair(ctx context.Context, item *database.FileHealth) (repairOutcome, error) {
+// The attempt is recorded in the repair audit log.
+func (hw *HealthWorker) retriggerFileRepair(ctx context.Context, item *database.FileHealth) (outcome repairOutcome, err error) {
 	filePath := item.FilePath
 
+	action := RepairActionRetrigger
+	var rescan *model.RescanResult
+	defer func() {
+		RecordRepair(ctx, hw.healthRepo, item, outcome.repairState(), action, rescan, err)
+	}()
+
 	pathForRescan := hw.resolvePathForRescan(item)
 	metadataStr := hw.ensureMetadata(ctx, item)
 
 	slog.InfoContext(ctx, "Re-triggering ARR rescan for file in repair", "file_path", filePath, "path_for_rescan", pathForRescan)
 
-	err := hw.arrsService.TriggerFileRescan(ctx, pathForRescan, filePath, metadataStr)
+	rescan, err = hw.arrsService.TriggerFileRescan(ctx, pathForRescan, filePath, metadataStr)
 	if err != nil {
 		// See triggerFileRepair: only an ID-confirmed replacement (ErrEpisodeAlreadySatisfied)
 		// justifies deleting the AltMount copy. ErrPathMatchFailed is an ambiguous path miss
@@ -1252,6 +1351,13 @@ func (hw *HealthWorker) retriggerFileRepair(ctx context.Context, item *database.
 			return repairOutcomeCorrupted, err
 		}
 
+		// No ARR manages the file: search for another alternate release, e.g. after
+		// the previous replacement import failed (see triggerFileRepair).
+		if errors.Is(err, arrs.ErrInstanceNotFound) && hw.configGetter().GetRepairAlternateRelease() {
+			action = RepairActionAlternateRelease
+			return hw.repairFromAlternateRelease(ctx, item)
+		}
+
 		// Temporarily unreachable ARR: defer instead of condemning. Note the metadata move
 		// happens only on the success path below, so a deferred outcome leaves the file
 		// visible and untouched until the ARR comes back.
@@ -1357,5 +1463,17 @@ func (hw *HealthWorker) moveMetadataToSafetyFolder(ctx context.Context, item *da
 	slog.InfoContext(ctx, "Moving metadata file for corrupted item to safety folder to trigger replacement", "file_path", item.FilePath)
 	if moveErr := hw.metadataService.MoveToCorrupted(ctx, relativePath); moveErr != nil {
 		slog.WarnContext(ctx, "Failed to move corrupted metadata file", "error", moveErr)
+		return
+	}
+	hw.refreshMediaServers(ctx, relativePath)
+}
+
+// refreshMediaServers asks the media servers to rescan the directory of a
+// file a repair removed from the mount, so they drop it.
+func (hw *HealthWorker) refreshMediaServers(ctx context.Context, filePath string) {
+	servers := hw.mediaServers(hw.configGetter())
+	if len(servers) == 0 {
+		return
 	}
+	mediaserver.RefreshAll(ctx, servers, []string{filePath})
 }
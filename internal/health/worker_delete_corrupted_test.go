@@ -145,3 +145,42 @@ func TestPrepareUpdateForResultDeleteOnCorruption(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, got, "degraded file must not be deleted")
 }
+
+// TestPrepareUpdateForResultDeleteOnCorruptionRepairable verifies that a file whose
+// release's PAR2 volumes cover the damage is kept as corrupted, without a repair,
+// instead of being deleted when corruption_action is "delete".
+func TestPrepareUpdateForResultDeleteOnCorruptionRepairable(t *testing.T) {
+	tempDir := t.TempDir()
+	env := newRepairTestEnv(t, tempDir, nil, func(c *config.Config) {
+		c.Health.CorruptionAction = "delete"
+	})
+
+	filePath := "/movies/movie.mkv"
+	meta := validSegmentMeta(env.metadataService, 1024)
+	require.NoError(t, env.metadataService.WriteFileMetadata(filePath, meta))
+
+	fh := database.FileHealth{
+		FilePath:   filePath,
+		Status:     database.HealthStatusPending,
+		RetryCount: 99,
+	}
+	event := HealthEvent{
+		Type:           EventTypeFileCorrupted,
+		FilePath:       filePath,
+		Status:         database.HealthStatusCorrupted,
+		Classification: &holes.Impact{Verdict: holes.VerdictFailed, TotalMissing: 40, LongestRun: 20},
+		Repairability:  &database.Repairability{RecoveryBlocks: 200, UsableBlocks: 200, NeededBlocks: 60, Score: 200.0 / 60},
+	}
+
+	update, _ := env.hw.prepareUpdateForResult(context.Background(), &fh, event)
+	assert.False(t, update.Skip, "a recoverable file must not be deleted")
+	assert.Equal(t, database.UpdateTypeCorrupted, update.Type)
+	assert.Equal(t, database.HealthStatusCorrupted, update.Status)
+	require.NotNil(t, update.Repairability)
+	assert.Contains(t, *update.Repairability, `"score"`)
+
+	// Without recovery data the same damage is deleted.
+	event.Repairability = &database.Repairability{NeededBlocks: 60}
+	update, _ = env.hw.prepareUpdateForResult(context.Background(), &fh, event)
+	assert.True(t, update.Skip, "an unrepairable file is deleted")
+}
@@ -45,7 +45,30 @@ func setupWorkerTestDB(t *testing.T) (*database.HealthRepository, *sql.DB) {
 			streaming_failure_count INTEGER DEFAULT 0,
 			is_masked BOOLEAN DEFAULT FALSE,
 			indexer TEXT DEFAULT NULL,
-			download_id TEXT DEFAULT NULL
+			download_id TEXT DEFAULT NULL,
+			provider_completeness TEXT DEFAULT NULL,
+			newsgroup TEXT DEFAULT NULL,
+			poster TEXT DEFAULT NULL,
+			first_loss_at DATETIME DEFAULT NULL,
+			expiry_risk REAL DEFAULT NULL,
+			next_check_reason TEXT DEFAULT NULL,
+			last_streamed_at DATETIME DEFAULT NULL,
+			stream_count INTEGER NOT NULL DEFAULT 0,
+			continue_watching BOOLEAN NOT NULL DEFAULT FALSE,
+			sample_confidence TEXT DEFAULT NULL,
+			repair_state TEXT NOT NULL DEFAULT '',
+			repairability TEXT DEFAULT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS import_queue (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			status TEXT NOT NULL DEFAULT 'pending'
+		);
+
+		CREATE TABLE IF NOT EXISTS replacement_imports (
+			queue_id INTEGER PRIMARY KEY,
+			file_path TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT (datetime('now'))
 		);
 	`)
 	require.NoError(t, err)
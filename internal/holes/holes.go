@@ -6,7 +6,9 @@
 //     (fail the import / import as degraded / keep checking),
 //   - at HEALTH CHECK, sampled or full sweeps classify accumulated damage, and
 //   - at PLAYBACK, the hole hooks decide per miss whether to zero-fill and
-//     keep streaming or to kill the stream and fail the file.
+//     keep streaming or to kill the stream and fail the file, and
+//   - at CORRUPTION, the release's PAR2 repairability score decides whether
+//     a failed file is still recoverable from its own NZB (see Repair).
 //
 // Ported from AIOStreams' holes.ts. This package is imported by usenet,
 // importer, health and nzbfilesystem layers; it must stay dependency-free.
@@ -86,6 +88,10 @@ type Impact struct {
 	TotalSegments int `json:"total_segments,omitempty"`
 	// PaddedRatio is missing bytes / file bytes, when the file size is known.
 	PaddedRatio float64 `json:"padded_ratio,omitempty"`
+	// RecoveryScore is the release's PAR2 repairability score (see
+	// Repair.Score); nil when it is unknown. It never changes the verdict:
+	// streaming does not run PAR2.
+	RecoveryScore *float64 `json:"recovery_score,omitempty"`
 }
 
 // padEligibleExtensions are the video containers whose payload tolerates
@@ -0,0 +1,105 @@
+package holes
+
+import "math"
+
+// MinRecoveryScore is the repairability score (usable PAR2 recovery blocks
+// over the blocks needed to rebuild the missing data) from which the
+// release's recovery data covers the damage.
+const MinRecoveryScore = 1.0
+
+// Recoverable reports whether a repairability score covers the damage.
+// Streaming never runs PAR2, so a recoverable file plays no better; the score
+// only says its NZB still holds every byte for a PAR2-capable download client.
+func Recoverable(score float64) bool {
+	return score >= MinRecoveryScore
+}
+
+// RecoveryVolume is one checked PAR2 file of a release.
+type RecoveryVolume struct {
+	// Blocks is the number of recovery blocks the volume declares in its
+	// name; 0 for the index file.
+	Blocks int
+	Bytes  int64
+	// Checked and Missing count the volume's sampled segments that got a
+	// definitive answer and the missing ones among them.
+	Checked int
+	Missing int
+}
+
+// Damage is the data a check found missing from one file.
+type Damage struct {
+	// MissingRate is the share of the file's segments that is missing,
+	// projected from the sample when the check was not full.
+	MissingRate float64
+	Bytes       int64
+	Segments    int
+}
+
+// Repair estimates whether a release's PAR2 recovery volumes could rebuild
+// the missing data. Block counts come from the volume names and the block
+// size from the volumes' size, so no PAR2 data is downloaded.
+type Repair struct {
+	Par2Files      int `json:"par2_files"`
+	RecoveryBlocks int `json:"recovery_blocks"`
+	// UsableBlocks discounts the recovery blocks lost to the volumes' own
+	// missing segments.
+	UsableBlocks int   `json:"usable_blocks"`
+	BlockSize    int64 `json:"block_size,omitempty"`
+	// Par2SegmentsChecked and Par2SegmentsMissing count the recovery
+	// volumes' segments the check probed.
+	Par2SegmentsChecked int   `json:"par2_segments_checked,omitempty"`
+	Par2SegmentsMissing int   `json:"par2_segments_missing,omitempty"`
+	MissingBytes        int64 `json:"missing_bytes"`
+	NeededBlocks        int   `json:"needed_blocks"`
+	// Score is UsableBlocks / NeededBlocks: MinRecoveryScore or more means
+	// the recovery data covers the damage, 0 that there is none to repair
+	// with. It is 0 when nothing is missing.
+	Score float64 `json:"score"`
+}
+
+// Repairable reports whether there is damage and the usable recovery blocks
+// cover it.
+func (r *Repair) Repairable() bool {
+	return r != nil && r.NeededBlocks > 0 && Recoverable(r.Score)
+}
+
+// EstimateRepair compares the damage against the usable recovery blocks of
+// the release's PAR2 files. Each missing segment may straddle one more block
+// boundary than its size suggests, so one block per missing segment is added
+// on top of the byte count, which errs on the side of "not repairable".
+func EstimateRepair(volumes []RecoveryVolume, damage []Damage) Repair {
+	rep := Repair{Par2Files: len(volumes)}
+	var recoveryBytes int64
+	for _, v := range volumes {
+		if v.Blocks <= 0 {
+			continue
+		}
+		rep.RecoveryBlocks += v.Blocks
+		recoveryBytes += v.Bytes
+		rep.Par2SegmentsChecked += v.Checked
+		rep.Par2SegmentsMissing += v.Missing
+		usable := v.Blocks
+		if v.Checked > 0 {
+			usable = v.Blocks * (v.Checked - v.Missing) / v.Checked
+		}
+		rep.UsableBlocks += usable
+	}
+
+	for _, d := range damage {
+		if d.MissingRate <= 0 {
+			continue
+		}
+		rep.MissingBytes += int64(math.Ceil(d.MissingRate * float64(d.Bytes)))
+		rep.NeededBlocks += int(math.Ceil(d.MissingRate * float64(d.Segments)))
+	}
+	if rep.RecoveryBlocks > 0 {
+		rep.BlockSize = recoveryBytes / int64(rep.RecoveryBlocks)
+	}
+	if rep.BlockSize > 0 {
+		rep.NeededBlocks += int((rep.MissingBytes + rep.BlockSize - 1) / rep.BlockSize)
+	}
+	if rep.NeededBlocks > 0 {
+		rep.Score = float64(rep.UsableBlocks) / float64(rep.NeededBlocks)
+	}
+	return rep
+}
@@ -0,0 +1,56 @@
+package holes
+
+import "testing"
+
+func TestEstimateRepair(t *testing.T) {
+	// A 1 GB file in 1000 segments missing 1%, against 10% of recovery data
+	// in 100 blocks of 1 MB.
+	const fileSize = 1000 << 20
+	damage := []Damage{{MissingRate: 0.01, Bytes: fileSize, Segments: 1000}}
+	index := RecoveryVolume{Bytes: 1024}
+	volume := RecoveryVolume{Blocks: 100, Bytes: 100 << 20, Checked: 10}
+
+	rep := EstimateRepair([]RecoveryVolume{index, volume}, damage)
+	if rep.Par2Files != 2 || rep.RecoveryBlocks != 100 || rep.UsableBlocks != 100 {
+		t.Errorf("volumes = %d files, %d blocks, %d usable; want 2, 100, 100", rep.Par2Files, rep.RecoveryBlocks, rep.UsableBlocks)
+	}
+	if rep.BlockSize != 1<<20 {
+		t.Errorf("BlockSize = %d, want %d", rep.BlockSize, 1<<20)
+	}
+	if rep.NeededBlocks != 20 {
+		t.Errorf("NeededBlocks = %d, want 20 (10 blocks of data plus one per missing segment)", rep.NeededBlocks)
+	}
+	if rep.Score < 4.999 || rep.Score > 5.001 || !rep.Repairable() {
+		t.Errorf("Score = %.3f, Repairable = %v; want 5, true", rep.Score, rep.Repairable())
+	}
+
+	// The same damage without recovery data.
+	rep = EstimateRepair(nil, damage)
+	if rep.NeededBlocks != 10 || rep.Score != 0 || rep.Repairable() {
+		t.Errorf("no recovery: NeededBlocks = %d, Score = %v, Repairable = %v; want 10, 0, false", rep.NeededBlocks, rep.Score, rep.Repairable())
+	}
+
+	// Recovery blocks lost with the volume's own segments do not count.
+	volume.Missing = 9
+	rep = EstimateRepair([]RecoveryVolume{volume}, damage)
+	if rep.UsableBlocks != 10 || rep.Repairable() {
+		t.Errorf("damaged volume: UsableBlocks = %d, Repairable = %v; want 10, false", rep.UsableBlocks, rep.Repairable())
+	}
+
+	// Nothing missing: nothing to repair.
+	rep = EstimateRepair([]RecoveryVolume{volume}, []Damage{{Bytes: fileSize, Segments: 1000}})
+	if rep.NeededBlocks != 0 || rep.Repairable() {
+		t.Errorf("intact: NeededBlocks = %d, Repairable = %v; want 0, false", rep.NeededBlocks, rep.Repairable())
+	}
+}
+
+func TestRecoverable(t *testing.T) {
+	for _, tc := range []struct {
+		score float64
+		want  bool
+	}{{0, false}, {0.5, false}, {MinRecoveryScore, true}, {3, true}} {
+		if got := Recoverable(tc.score); got != tc.want {
+			t.Errorf("Recoverable(%v) = %v, want %v", tc.score, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package archive
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+)
+
+// chapterSidecarSuffix replaces the video extension to name the chapter
+// sidecar: "Movie.m2ts" → "Movie.chapters.xml".
+const chapterSidecarSuffix = ".chapters.xml"
+
+// ChapterSidecarPath returns the virtual path of the chapter sidecar that
+// belongs next to videoPath.
+func ChapterSidecarPath(videoPath string) string {
+	return strings.TrimSuffix(videoPath, path.Ext(videoPath)) + chapterSidecarSuffix
+}
+
+// NewChapterSidecarMetadata builds the metadata for a Matroska chapters XML
+// sidecar from chapter starts in 45 kHz ticks. The XML is stored inline, so
+// the sidecar needs no segments. Returns nil when there are fewer than two
+// chapters, which is not worth a sidecar.
+func NewChapterSidecarMetadata(chapterTicks []int64, sourceNzbPath string, releaseDate int64) *metapb.FileMetadata {
+	if len(chapterTicks) < 2 {
+		return nil
+	}
+	data := MatroskaChaptersXML(chapterTicks)
+	now := time.Now().Unix()
+	return &metapb.FileMetadata{
+		FileSize:      int64(len(data)),
+		SourceNzbPath: sourceNzbPath,
+		Status:        metapb.FileStatus_FILE_STATUS_HEALTHY,
+		CreatedAt:     now,
+		ModifiedAt:    now,
+		ReleaseDate:   releaseDate,
+		InlineData:    data,
+	}
+}
+
+// MatroskaChaptersXML renders chapter starts (45 kHz ticks) as a Matroska
+// chapters XML document, the format mkvmerge and most chapter-aware tools
+// import. Chapters are named "Chapter NN" like a disc menu would.
+func MatroskaChaptersXML(chapterTicks []int64) []byte {
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	b.WriteString("<!DOCTYPE Chapters SYSTEM \"matroskachapters.dtd\">\n")
+	b.WriteString("<Chapters>\n  <EditionEntry>\n")
+	for i, t := range chapterTicks {
+		ns := t * int64(time.Second) / 45000
+		fmt.Fprintf(&b, "    <ChapterAtom>\n"+
+			"      <ChapterTimeStart>%02d:%02d:%02d.%09d</ChapterTimeStart>\n"+
+			"      <ChapterDisplay>\n"+
+			"        <ChapterString>Chapter %02d</ChapterString>\n"+
+			"        <ChapterLanguage>eng</ChapterLanguage>\n"+
+			"      </ChapterDisplay>\n"+
+			"    </ChapterAtom>\n",
+			ns/int64(time.Hour), ns/int64(time.Minute)%60, ns/int64(time.Second)%60, ns%int64(time.Second), i+1)
+	}
+	b.WriteString("  </EditionEntry>\n</Chapters>\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,41 @@
+package archive
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChapterSidecarPath(t *testing.T) {
+	t.Parallel()
+
+	if got := ChapterSidecarPath("movies/Movie (1999)/Movie (1999).m2ts"); got != "movies/Movie (1999)/Movie (1999).chapters.xml" {
+		t.Errorf("ChapterSidecarPath = %q", got)
+	}
+}
+
+func TestNewChapterSidecarMetadata(t *testing.T) {
+	t.Parallel()
+
+	if meta := NewChapterSidecarMetadata([]int64{0}, "a.nzb", 0); meta != nil {
+		t.Error("a single chapter should not produce a sidecar")
+	}
+
+	meta := NewChapterSidecarMetadata([]int64{0, 45000*3723 + 22500}, "a.nzb", 42)
+	if meta == nil {
+		t.Fatal("expected sidecar metadata")
+	}
+	if meta.FileSize != int64(len(meta.InlineData)) || len(meta.SegmentData) != 0 || meta.ReleaseDate != 42 {
+		t.Errorf("sidecar metadata = size %d, %d inline bytes, %d segments", meta.FileSize, len(meta.InlineData), len(meta.SegmentData))
+	}
+
+	xml := string(meta.InlineData)
+	for _, want := range []string{
+		"<ChapterTimeStart>00:00:00.000000000</ChapterTimeStart>",
+		"<ChapterTimeStart>01:02:03.500000000</ChapterTimeStart>",
+		"<ChapterString>Chapter 02</ChapterString>",
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("chapters XML missing %q:\n%s", want, xml)
+		}
+	}
+}
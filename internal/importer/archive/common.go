@@ -57,25 +57,40 @@ type Content struct {
 	AesIV         []byte                `json:"aes_iv,omitempty"`         // AES initialization vector (if encrypted)
 	NzbdavID      string                `json:"nzbdav_id,omitempty"`      // Original ID from nzbdav
 	NestedSources []NestedSource        `json:"nested_sources,omitempty"` // Nested archive sources (encrypted outer)
-	// ISOExpansionIndex is non-zero for files expanded from an ISO archive.
+	// ISOExpansionIndex is non-zero for files expanded from an ISO archive
+	// or built from a DVD VIDEO_TS folder.
 	// It is the 1-based position of this file when all ISO files in the archive
 	// are sorted by size descending (1 = largest / main feature).
 	// Zero means this Content did not come from an ISO.
 	ISOExpansionIndex int `json:"iso_expansion_index,omitempty"`
 	// ClipBoundaries is the per-clip timeline table for a byte-concatenated
-	// multi-clip Blu-ray main feature. Empty for everything else. At read
-	// time a TS filter adds each clip's Delta90k to the timestamps inside
-	// its byte range to build one continuous timeline.
+	// multi-clip Blu-ray main feature or a stitched multi-part MPEG-TS movie.
+	// Empty for everything else. At read time a TS filter adds each clip's
+	// Delta90k to the timestamps inside its byte range to build one
+	// continuous timeline.
 	ClipBoundaries []ClipBoundary `json:"clip_boundaries,omitempty"`
+	// DVDTitleSet is the title-set number of a DVD main title built from
+	// VTS IFOs (see ExpandDVDFolders). Zero for everything else.
+	DVDTitleSet int `json:"dvd_title_set,omitempty"`
+	// ChapterTicks are the chapter starts of a Blu-ray playlist file in
+	// 45 kHz ticks from the start of the file. Writers emit a chapter
+	// sidecar next to files that have them (see NewChapterSidecarMetadata).
+	ChapterTicks []int64 `json:"chapter_ticks,omitempty"`
+	// ExtraPlaylist is the source playlist of a Blu-ray extra exposed next
+	// to the main feature (e.g. "BDMV/PLAYLIST/00801.mpls"). Extras keep
+	// their InternalPath under ExtrasDir instead of taking the release name.
+	ExtraPlaylist string `json:"extra_playlist,omitempty"`
 }
 
 // ClipBoundary mirrors metapb.ClipBoundary at the archive layer: one clip in a
-// concatenated multi-clip BD main feature. ByteLen is the clip's size in the
-// virtual file; Delta90k is the signed 90 kHz timeline offset for packets
-// inside this clip's byte range.
+// concatenated multi-clip BD main feature or stitched multi-part MPEG-TS
+// movie. ByteLen is the clip's size in the virtual file; Delta90k is the
+// signed 90 kHz timeline offset for packets inside this clip's byte range;
+// PacketSize is 188 for plain TS clips and 0 or 192 for BDAV.
 type ClipBoundary struct {
-	ByteLen  int64 `json:"byte_len"`
-	Delta90k int64 `json:"delta_90k"`
+	ByteLen    int64 `json:"byte_len"`
+	Delta90k   int64 `json:"delta_90k"`
+	PacketSize int   `json:"packet_size,omitempty"`
 }
 
 // GetContentSegments returns all segments for a Content,
@@ -44,13 +44,14 @@ func NewFileMetadataFromContent(
 		meta.AesIv = content.AesIV
 	}
 
-	// Carry the per-clip timeline table for multi-clip BD main features.
-	// Empty for everything else, which keeps the read-path remux filter
-	// disabled for all other files.
+	// Carry the per-clip timeline table for multi-clip BD main features and
+	// stitched multi-part MPEG-TS movies. Empty for everything else, which
+	// keeps the read-path remux filter disabled for all other files.
 	for _, cb := range content.ClipBoundaries {
 		meta.ClipBoundaries = append(meta.ClipBoundaries, &metapb.ClipBoundary{
-			ByteLen:   cb.ByteLen,
-			Delta_90K: cb.Delta90k,
+			ByteLen:    cb.ByteLen,
+			Delta_90K:  cb.Delta90k,
+			PacketSize: int32(cb.PacketSize),
 		})
 	}
 
@@ -0,0 +1,244 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/javi11/altmount/internal/importer/archive/iso"
+	"github.com/javi11/altmount/internal/importer/filesystem"
+	"github.com/javi11/altmount/internal/pool"
+)
+
+// DVD-Video main-title extraction.
+//
+// A DVD release is a VIDEO_TS folder: per title set, one VTS_NN_0.IFO with
+// the navigation data and up to nine 1 GB VTS_NN_1..9.VOB chunks. The main
+// movie is the longest program chain in any IFO; its cells are sector
+// ranges within the title set's VOBs, and concatenating them yields one
+// plain MPEG-PS stream most players handle without DVD navigation support.
+// ExpandDVDFolders does this for VIDEO_TS folders found in archives or
+// posted as bare files; ISO images take the same route inside
+// ExpandISOContents.
+
+// dvdTitleExt is the extension of the virtual main-title file. Media
+// servers treat .vob as a DVD fragment; .mpg is scanned as a movie.
+const dvdTitleExt = ".mpg"
+
+// maxIFOSize bounds how much of a VTS IFO is read. Real IFOs are a few
+// hundred KiB at most.
+const maxIFOSize = 4 * 1024 * 1024
+
+// DVDFileReader returns the full contents of a small file (a VTS IFO).
+type DVDFileReader func(ctx context.Context, c Content) ([]byte, error)
+
+// ExpandDVDFolders appends one virtual MPEG-PS file per VIDEO_TS folder in
+// contents whose IFOs resolve a main title. The folder's files are kept. The
+// new Content carries DVDTitleSet and an ISOExpansionIndex so aggregators
+// rename it after the release, as they do for ISO main features.
+// Folders whose IFO cannot be read, or whose VOBs are compressed or
+// incomplete, are left alone.
+func ExpandDVDFolders(ctx context.Context, enabled bool, contents []Content, read DVDFileReader) []Content {
+	if !enabled || read == nil {
+		return contents
+	}
+
+	type folder struct {
+		ifos map[int]Content
+		vobs map[int]map[int]Content
+	}
+	var (
+		folders = make(map[string]*folder)
+		dirs    []string
+		index   int
+	)
+	for _, c := range contents {
+		index = max(index, c.ISOExpansionIndex)
+		if c.IsDirectory {
+			continue
+		}
+		set, vob, ok := iso.DVDTitleSetFile(contentPath(c))
+		if !ok {
+			continue
+		}
+		dir := path.Dir(strings.ReplaceAll(contentPath(c), "\\", "/"))
+		f, exists := folders[dir]
+		if !exists {
+			f = &folder{ifos: make(map[int]Content), vobs: make(map[int]map[int]Content)}
+			folders[dir] = f
+			dirs = append(dirs, dir)
+		}
+		if vob == 0 {
+			f.ifos[set] = c
+			continue
+		}
+		if f.vobs[set] == nil {
+			f.vobs[set] = make(map[int]Content)
+		}
+		f.vobs[set][vob] = c
+	}
+
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		f := folders[dir]
+		if len(f.ifos) == 0 {
+			continue
+		}
+
+		sets := make(map[int]*iso.VTSInfo, len(f.ifos))
+		for set, c := range f.ifos {
+			data, err := read(ctx, c)
+			if err != nil {
+				slog.DebugContext(ctx, "Skipping unreadable DVD IFO", "path", contentPath(c), "error", err)
+				continue
+			}
+			info, err := iso.ParseVTSIFO(data)
+			if err != nil {
+				slog.DebugContext(ctx, "Skipping unparseable DVD IFO", "path", contentPath(c), "error", err)
+				continue
+			}
+			sets[set] = info
+		}
+
+		titleVOBs := func(set int) []Content {
+			var out []Content
+			for n := 1; n <= 9; n++ {
+				c, ok := f.vobs[set][n]
+				if !ok || !stitchable(c) {
+					break
+				}
+				out = append(out, c)
+			}
+			return out
+		}
+		plan, ok := iso.PlanDVDMainTitle(sets, func(set int) []int64 {
+			var sizes []int64
+			for _, c := range titleVOBs(set) {
+				sizes = append(sizes, c.Size)
+			}
+			return sizes
+		})
+		if !ok {
+			continue
+		}
+
+		vobs := titleVOBs(plan.TitleSet)
+		var (
+			sources []NestedSource
+			size    int64
+		)
+		for _, s := range plan.Slices {
+			sources = append(sources, sliceNestedSources(partNestedSources(vobs[s.VOB]), s.Offset, s.Length)...)
+			size += s.Length
+		}
+		if len(sources) == 0 {
+			continue
+		}
+
+		index++
+		filename := dvdTitleFilename(dir, plan.TitleSet)
+		slog.InfoContext(ctx, "Built DVD main-title virtual file",
+			"folder", dir,
+			"title_set", plan.TitleSet,
+			"duration", plan.Duration,
+			"vobs", len(vobs),
+			"size_bytes", size,
+			"filename", filename,
+		)
+		contents = append(contents, Content{
+			InternalPath:      path.Join(dvdReleaseDir(dir), filename),
+			Filename:          filename,
+			Size:              size,
+			PackedSize:        size,
+			NzbdavID:          vobs[0].NzbdavID,
+			NestedSources:     sources,
+			ISOExpansionIndex: index,
+			DVDTitleSet:       plan.TitleSet,
+		})
+	}
+	return contents
+}
+
+// sliceNestedSources returns the sources covering [off, off+length) of the
+// byte stream formed by concatenating srcs. Every nested reader addresses
+// its data by InnerOffset, so a sub-range only shifts the offset.
+func sliceNestedSources(srcs []NestedSource, off, length int64) []NestedSource {
+	var (
+		out  []NestedSource
+		base int64
+	)
+	for _, s := range srcs {
+		if length <= 0 {
+			break
+		}
+		if off >= base+s.InnerLength {
+			base += s.InnerLength
+			continue
+		}
+		within := off - base
+		n := min(s.InnerLength-within, length)
+		sub := s
+		sub.InnerOffset += within
+		sub.InnerLength = n
+		out = append(out, sub)
+		off += n
+		length -= n
+		base += s.InnerLength
+	}
+	return out
+}
+
+// dvdReleaseDir is the folder holding VIDEO_TS, where the main title is
+// placed; "." when VIDEO_TS is at the archive root.
+func dvdReleaseDir(dir string) string {
+	if strings.EqualFold(path.Base(dir), "VIDEO_TS") {
+		return path.Dir(dir)
+	}
+	return dir
+}
+
+// dvdTitleFilename names the main title after the release folder, falling
+// back to the title set when the DVD sits at the archive root.
+func dvdTitleFilename(dir string, titleSet int) string {
+	if name := path.Base(dvdReleaseDir(dir)); name != "." && name != "/" && name != "" {
+		return name + dvdTitleExt
+	}
+	return fmt.Sprintf("VTS_%02d%s", titleSet, dvdTitleExt)
+}
+
+// NewDVDFileReader returns a DVDFileReader that fetches a stored (not
+// compressed) file over NNTP.
+func NewDVDFileReader(poolManager pool.Manager, maxPrefetch int, readTimeout time.Duration) DVDFileReader {
+	return func(ctx context.Context, c Content) ([]byte, error) {
+		if !stitchable(c) || len(c.NestedSources) > 0 {
+			return nil, errors.New("file is not directly addressable")
+		}
+		if c.Size > maxIFOSize {
+			return nil, fmt.Errorf("file too large for an IFO: %d bytes", c.Size)
+		}
+		fsys := filesystem.NewDecryptingFileSystem(ctx, poolManager, []filesystem.DecryptingFileEntry{{
+			Filename:      c.Filename,
+			Segments:      c.Segments,
+			DecryptedSize: c.Size,
+			AesKey:        c.AesKey,
+			AesIV:         c.AesIV,
+		}}, maxPrefetch, readTimeout)
+		f, err := fsys.Open(c.Filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		data := make([]byte, c.Size)
+		if _, err := io.ReadFull(f, data); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", c.Filename, err)
+		}
+		return data, nil
+	}
+}
@@ -0,0 +1,92 @@
+package archive
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// dvdTestIFO builds a VTS IFO with one title PGC of the given BCD duration
+// whose cells are the given inclusive sector ranges.
+func dvdTestIFO(hours byte, cells ...[2]uint32) []byte {
+	const (
+		pgcit = 2048
+		pgc   = 16 // relative to PGCIT: header + one search pointer
+	)
+	data := make([]byte, pgcit+pgc+0xEA+len(cells)*24)
+	copy(data, "DVDVIDEO-VTS")
+	binary.BigEndian.PutUint32(data[0xCC:], 1)
+	binary.BigEndian.PutUint16(data[pgcit:], 1)
+	data[pgcit+8] = 0x81
+	binary.BigEndian.PutUint32(data[pgcit+12:], pgc)
+
+	p := data[pgcit+pgc:]
+	p[0x03] = byte(len(cells))
+	p[0x04] = hours
+	binary.BigEndian.PutUint16(p[0xE8:], 0xEA)
+	for i, c := range cells {
+		cell := p[0xEA+i*24:]
+		binary.BigEndian.PutUint32(cell[8:], c[0])
+		binary.BigEndian.PutUint32(cell[20:], c[1])
+	}
+	return data
+}
+
+func TestExpandDVDFolders(t *testing.T) {
+	t.Parallel()
+
+	const sector = 2048
+	ifos := map[string][]byte{
+		"Movie/VIDEO_TS/VTS_01_0.IFO": dvdTestIFO(0x00, [2]uint32{0, 9}),
+		// Cells 5..14 cross from VTS_02_1.VOB into VTS_02_2.VOB.
+		"Movie/VIDEO_TS/VTS_02_0.IFO": dvdTestIFO(0x01, [2]uint32{5, 14}),
+	}
+	read := func(_ context.Context, c Content) ([]byte, error) {
+		if b, ok := ifos[c.InternalPath]; ok {
+			return b, nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	in := []Content{
+		stitchTestContent("Movie/VIDEO_TS/VTS_01_0.IFO", 4096),
+		stitchTestContent("Movie/VIDEO_TS/VTS_01_1.VOB", 10*sector),
+		stitchTestContent("Movie/VIDEO_TS/VTS_02_0.IFO", 4096),
+		stitchTestContent("Movie/VIDEO_TS/VTS_02_1.VOB", 10*sector),
+		stitchTestContent("Movie/VIDEO_TS/VTS_02_2.VOB", 10*sector),
+	}
+	out := ExpandDVDFolders(context.Background(), true, in, read)
+	if len(out) != len(in)+1 {
+		t.Fatalf("got %d contents, want %d", len(out), len(in)+1)
+	}
+
+	got := out[len(in)]
+	if got.InternalPath != "Movie/Movie.mpg" || got.DVDTitleSet != 2 || got.ISOExpansionIndex != 1 {
+		t.Errorf("title = %q set %d index %d", got.InternalPath, got.DVDTitleSet, got.ISOExpansionIndex)
+	}
+	if got.Size != 10*sector || len(got.NestedSources) != 2 {
+		t.Fatalf("title size %d with %d sources, want %d with 2", got.Size, len(got.NestedSources), 10*sector)
+	}
+	first, second := got.NestedSources[0], got.NestedSources[1]
+	if first.InnerOffset != 5*sector || first.InnerLength != 5*sector || first.Segments[0].Id != "Movie/VIDEO_TS/VTS_02_1.VOB" {
+		t.Errorf("first source = %+v", first)
+	}
+	if second.InnerOffset != 0 || second.InnerLength != 5*sector || second.Segments[0].Id != "Movie/VIDEO_TS/VTS_02_2.VOB" {
+		t.Errorf("second source = %+v", second)
+	}
+
+	// The title set's VOB chunks must not also be stitched.
+	stitched := StitchMultiPartContents(context.Background(), true, out, nil)
+	if len(stitched) != len(out) {
+		t.Errorf("VOBs of an extracted DVD title were stitched again")
+	}
+
+	if out := ExpandDVDFolders(context.Background(), false, in, read); len(out) != len(in) {
+		t.Error("disabled expansion must return contents unchanged")
+	}
+	failing := func(context.Context, Content) ([]byte, error) { return nil, errors.New("boom") }
+	if out := ExpandDVDFolders(context.Background(), true, in, failing); len(out) != len(in) {
+		t.Error("unreadable IFOs must leave the folder alone")
+	}
+}
@@ -2,6 +2,7 @@ package iso
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
 	"sort"
@@ -26,8 +27,22 @@ type MainFeaturePlaylist struct {
 	// drive the continuous-timeline remux of the concatenated clips.
 	ClipInTimes   []int64
 	ClipDurations []int64
+	// ChapterTicks are the playlist's chapter starts from its entry marks,
+	// in 45 kHz ticks from the start of the playlist. See
+	// MPLSPlayList.ChapterTicks.
+	ChapterTicks []int64
 }
 
+// maxExtraPlaylists caps how many additional playlists ResolvePlaylists
+// returns. Discs with obfuscated playlist sets can carry hundreds.
+const maxExtraPlaylists = 32
+
+// extraDurationSlack is how close (as a fraction of the main feature's
+// duration) an extra may come to the main feature before it is treated as
+// another copy of it. Obfuscated discs ship dozens of near-identical main
+// playlists; real alternate cuts differ by minutes.
+const extraDurationSlack = 0.01
+
 // ResolveMainFeature inspects the entries returned by ListISOFiles for a
 // Blu-ray (BDMV) structure and returns the playlist that represents the
 // main movie. Returns nil if the disc is not BDMV, has no .mpls, or no
@@ -41,6 +56,16 @@ type MainFeaturePlaylist struct {
 // keep evaluating the rest, mirroring how every Blu-ray player tolerates
 // malformed entries in BDMV/PLAYLIST/.
 func ResolveMainFeature(ctx context.Context, rs io.ReadSeeker, files []isoFileEntry, progressTracker *progress.Tracker) *MainFeaturePlaylist {
+	best, _ := ResolvePlaylists(ctx, rs, files, 0, progressTracker)
+	return best
+}
+
+// ResolvePlaylists is ResolveMainFeature plus the disc's other playlists
+// lasting at least minExtraTicks (45 kHz): extended cuts, bonus features
+// and the individual episodes of a TV disc. Extras exclude the main
+// feature, copies of it, and duplicates of each other, and are returned in
+// playlist-name order. minExtraTicks <= 0 returns no extras.
+func ResolvePlaylists(ctx context.Context, rs io.ReadSeeker, files []isoFileEntry, minExtraTicks int64, progressTracker *progress.Tracker) (*MainFeaturePlaylist, []*MainFeaturePlaylist) {
 	// Build per-clip indexes. M2TS streams live at BDMV/STREAM/<NNNNN>.M2TS
 	// and carry the 2D version (or the only version on a 2D disc). SSIF
 	// streams live at BDMV/STREAM/SSIF/<NNNNN>.SSIF and carry the
@@ -66,7 +91,7 @@ func ResolveMainFeature(ctx context.Context, rs io.ReadSeeker, files []isoFileEn
 		}
 	}
 	if len(playlistEntries) == 0 || (len(m2tsByClip) == 0 && len(ssifByClip) == 0) {
-		return nil
+		return nil, nil
 	}
 
 	// Deterministic order: shorter filenames (and lexicographic ties) win
@@ -84,7 +109,10 @@ func ResolveMainFeature(ctx context.Context, rs io.ReadSeeker, files []isoFileEn
 	// segments once per file. See readPlaylistsCoalesced.
 	playlistData := readPlaylistsCoalesced(rs, playlistEntries)
 
-	var best *MainFeaturePlaylist
+	var (
+		best       *MainFeaturePlaylist
+		candidates []*MainFeaturePlaylist
+	)
 	for idx, pe := range playlistEntries {
 		// Report progress per playlist examined — the granular signal that
 		// keeps the queue item's bar moving during BD analysis. Network I/O
@@ -120,6 +148,7 @@ func ResolveMainFeature(ctx context.Context, rs io.ReadSeeker, files []isoFileEn
 			UniqueClipCount: resolved.uniqueClipCount,
 			ClipInTimes:     resolved.inTimes,
 			ClipDurations:   resolved.durations,
+			ChapterTicks:    pl.ChapterTicks(),
 		}
 		slog.DebugContext(ctx, "Blu-ray playlist candidate",
 			"playlist", pe.path,
@@ -130,6 +159,7 @@ func ResolveMainFeature(ctx context.Context, rs io.ReadSeeker, files []isoFileEn
 			"unique_clip_bytes", cand.UniqueClipBytes,
 			"duration_seconds", cand.DurationTicks/45000,
 		)
+		candidates = append(candidates, cand)
 		if best == nil || isBetterPlaylist(cand, best) {
 			best = cand
 		}
@@ -144,7 +174,47 @@ func ResolveMainFeature(ctx context.Context, rs io.ReadSeeker, files []isoFileEn
 			"duration_seconds", best.DurationTicks/45000,
 		)
 	}
-	return best
+	return best, selectExtraPlaylists(best, candidates, minExtraTicks)
+}
+
+// selectExtraPlaylists picks the candidates worth exposing next to best.
+// candidates are in playlist-name order.
+func selectExtraPlaylists(best *MainFeaturePlaylist, candidates []*MainFeaturePlaylist, minExtraTicks int64) []*MainFeaturePlaylist {
+	if best == nil || minExtraTicks <= 0 {
+		return nil
+	}
+	slack := int64(float64(best.DurationTicks) * extraDurationSlack)
+	seen := map[string]bool{playlistSignature(best): true}
+
+	var extras []*MainFeaturePlaylist
+	for _, c := range candidates {
+		if len(extras) == maxExtraPlaylists {
+			break
+		}
+		if c == best || c.DurationTicks < minExtraTicks {
+			continue
+		}
+		if d := c.DurationTicks - best.DurationTicks; d >= -slack && d <= slack {
+			continue
+		}
+		sig := playlistSignature(c)
+		if seen[sig] {
+			continue
+		}
+		seen[sig] = true
+		extras = append(extras, c)
+	}
+	return extras
+}
+
+// playlistSignature identifies a playlist by its clip sequence and IN/OUT
+// windows, so byte-identical copies under different names collapse.
+func playlistSignature(p *MainFeaturePlaylist) string {
+	var b strings.Builder
+	for i, e := range p.Streams {
+		fmt.Fprintf(&b, "%s:%d:%d;", e.path, p.ClipInTimes[i], p.ClipDurations[i])
+	}
+	return b.String()
 }
 
 type resolvedPlaylistStreams struct {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/javi11/altmount/internal/progress"
@@ -678,3 +679,41 @@ func TestReadPlaylistsCoalesced(t *testing.T) {
 		}
 	})
 }
+
+func TestSelectExtraPlaylists(t *testing.T) {
+	t.Parallel()
+
+	pl := func(name string, clip string, dur int64) *MainFeaturePlaylist {
+		return &MainFeaturePlaylist{
+			PlaylistName:  name,
+			DurationTicks: dur,
+			Streams:       []isoFileEntry{{path: clip}},
+			ClipInTimes:   []int64{0},
+			ClipDurations: []int64{dur},
+		}
+	}
+	const minute = 45000 * 60
+
+	main := pl("00800.mpls", "00001.m2ts", 120*minute)
+	candidates := []*MainFeaturePlaylist{
+		pl("00001.mpls", "00002.m2ts", 45*minute), // episode
+		pl("00002.mpls", "00002.m2ts", 45*minute), // byte-identical copy of 00001
+		pl("00003.mpls", "00003.m2ts", 5*minute),  // trailer: below threshold
+		main,
+		pl("00801.mpls", "00004.m2ts", 120*minute+minute/2), // obfuscated main copy
+		pl("00900.mpls", "00005.m2ts", 135*minute),          // extended cut
+	}
+
+	got := selectExtraPlaylists(main, candidates, 20*minute)
+	var names []string
+	for _, p := range got {
+		names = append(names, p.PlaylistName)
+	}
+	if strings.Join(names, ",") != "00001.mpls,00900.mpls" {
+		t.Errorf("extras = %v, want [00001.mpls 00900.mpls]", names)
+	}
+
+	if got := selectExtraPlaylists(main, candidates, 0); got != nil {
+		t.Errorf("threshold 0 must disable extras, got %d", len(got))
+	}
+}
@@ -0,0 +1,156 @@
+package iso
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// DVDTitlePlan is the result of resolving a DVD-Video main title: the title
+// set it lives in and the byte slices of that set's title VOBs which,
+// concatenated in order, form one continuous MPEG-PS stream.
+type DVDTitlePlan struct {
+	TitleSet int
+	Duration time.Duration
+	Slices   []DVDVOBSlice
+}
+
+// PlanDVDMainTitle selects the main title from the parsed VTS IFOs (keyed by
+// title-set number) and maps it onto the title set's VOB files. vobSizes
+// returns the sizes of VTS_NN_1.VOB, VTS_NN_2.VOB, ... for a title set, in
+// order and stopping at the first missing VOB. ok is false when no title
+// resolves to at least one byte of VOB data.
+func PlanDVDMainTitle(sets map[int]*VTSInfo, vobSizes func(titleSet int) []int64) (DVDTitlePlan, bool) {
+	title, ok := SelectDVDMainTitle(sets)
+	if !ok {
+		return DVDTitlePlan{}, false
+	}
+	slices := SliceDVDRanges(title.Ranges, vobSizes(title.TitleSet))
+	if len(slices) == 0 {
+		return DVDTitlePlan{}, false
+	}
+	return DVDTitlePlan{TitleSet: title.TitleSet, Duration: title.Duration, Slices: slices}, true
+}
+
+// ResolveDVDMainTitle inspects the entries returned by ListISOFiles for a
+// DVD-Video (VIDEO_TS) structure and returns a synthetic file entry whose
+// extents cover the main title's cells across VTS_NN_1..N.VOB. Returns
+// false when the disc is not DVD-Video or no IFO parses.
+//
+// As with Blu-ray playlists, individual unreadable IFOs are skipped rather
+// than failing the whole disc.
+func ResolveDVDMainTitle(ctx context.Context, rs io.ReadSeeker, files []isoFileEntry) (isoFileEntry, DVDTitlePlan, bool) {
+	ifos := make(map[int]isoFileEntry)
+	vobs := make(map[int]map[int]isoFileEntry)
+	for _, f := range files {
+		if !strings.HasPrefix(strings.ToUpper(f.path), "VIDEO_TS/") {
+			continue
+		}
+		set, vob, ok := DVDTitleSetFile(f.path)
+		if !ok {
+			continue
+		}
+		if vob == 0 {
+			ifos[set] = f
+			continue
+		}
+		if vobs[set] == nil {
+			vobs[set] = make(map[int]isoFileEntry)
+		}
+		vobs[set][vob] = f
+	}
+	if len(ifos) == 0 {
+		return isoFileEntry{}, DVDTitlePlan{}, false
+	}
+
+	sets := make(map[int]*VTSInfo, len(ifos))
+	for set, e := range ifos {
+		if ctx.Err() != nil {
+			return isoFileEntry{}, DVDTitlePlan{}, false
+		}
+		data, err := readISOFile(rs, e)
+		if err != nil {
+			slog.DebugContext(ctx, "Skipping unreadable DVD IFO", "path", e.path, "error", err)
+			continue
+		}
+		info, err := ParseVTSIFO(data)
+		if err != nil {
+			slog.DebugContext(ctx, "Skipping unparseable DVD IFO", "path", e.path, "error", err)
+			continue
+		}
+		sets[set] = info
+	}
+
+	titleVOBs := func(set int) []isoFileEntry {
+		var out []isoFileEntry
+		for n := 1; n <= 9; n++ {
+			e, ok := vobs[set][n]
+			if !ok {
+				break
+			}
+			out = append(out, e)
+		}
+		return out
+	}
+	plan, ok := PlanDVDMainTitle(sets, func(set int) []int64 {
+		var sizes []int64
+		for _, e := range titleVOBs(set) {
+			sizes = append(sizes, int64(e.size))
+		}
+		return sizes
+	})
+	if !ok {
+		return isoFileEntry{}, DVDTitlePlan{}, false
+	}
+
+	entries := titleVOBs(plan.TitleSet)
+	title := isoFileEntry{path: fmt.Sprintf("VIDEO_TS/VTS_%02d_TITLE.VOB", plan.TitleSet)}
+	for _, s := range plan.Slices {
+		exts, ok := sliceExtents(entries[s.VOB].extents, s.Offset, s.Length)
+		if !ok {
+			slog.DebugContext(ctx, "DVD title cell is not sector aligned",
+				"path", entries[s.VOB].path, "offset", s.Offset)
+			return isoFileEntry{}, DVDTitlePlan{}, false
+		}
+		title.extents = append(title.extents, exts...)
+		title.size += uint64(s.Length)
+	}
+	title.extents = coalesceExtents(title.extents)
+	return title, plan, true
+}
+
+// sliceExtents returns the on-disc extents covering [off, off+length) of a
+// file. Every extent boundary inside the range must fall on a sector, which
+// holds for DVD cells (they are addressed in sectors).
+func sliceExtents(extents []isoExtent, off, length int64) ([]isoExtent, bool) {
+	var (
+		out  []isoExtent
+		base int64
+	)
+	for _, ext := range extents {
+		extLen := int64(ext.length)
+		if length <= 0 {
+			break
+		}
+		if off >= base+extLen {
+			base += extLen
+			continue
+		}
+		within := off - base
+		if within%iso9660SectorSize != 0 {
+			return nil, false
+		}
+		n := min(extLen-within, length)
+		out = append(out, isoExtent{
+			lba:    ext.lba + uint32(within/iso9660SectorSize),
+			length: uint64(n),
+		})
+		off += n
+		length -= n
+		base += extLen
+	}
+	return out, length == 0
+}
@@ -0,0 +1,262 @@
+package iso
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VTS IFO (Video Title Set information) is the fixed binary format DVD
+// players use to navigate a title set. We only parse what is needed to find
+// the main title and its on-disc extent: the program chains (PGCs) in
+// VTS_PGCIT, their playback time, and the sector range of every cell.
+// All multi-byte integers are big-endian.
+
+const (
+	dvdSectorSize = 2048
+
+	vtsMagic        = "DVDVIDEO-VTS"
+	vtsPGCITPointer = 0x0CC // sector of VTS_PGCIT within the IFO
+	pgcTimeOffset   = 0x04  // dvd_time_t playback time
+	pgcCellsCount   = 0x03
+	pgcCellPlayback = 0xE8 // offset of the cell playback table within the PGC
+	cellPlaybackLen = 24
+	pgcSearchPtrLen = 8
+	pgcitHeaderLen  = 8
+	pgcEntryFlag    = 0x80 // entry_id bit marking a title entry PGC
+	cellAngleBlock  = 0x01 // block_type: angle block
+	cellBlockFirst  = 0x01 // block_mode: first cell in block
+)
+
+// DVDCell is one cell of a program chain: an inclusive sector range relative
+// to the start of the title set's title VOBs (VTS_NN_1.VOB).
+type DVDCell struct {
+	FirstSector uint32
+	LastSector  uint32
+}
+
+// DVDProgramChain is one title PGC of a title set.
+type DVDProgramChain struct {
+	Duration time.Duration
+	Cells    []DVDCell
+}
+
+// VTSInfo is the parsed view of a VTS_NN_0.IFO file.
+type VTSInfo struct {
+	ProgramChains []DVDProgramChain
+}
+
+// ParseVTSIFO decodes the title program chains of a VTS IFO. Menu PGCs and
+// navigation commands are ignored. For multi-angle blocks only the first
+// angle's cells are kept.
+func ParseVTSIFO(data []byte) (*VTSInfo, error) {
+	if len(data) < vtsPGCITPointer+4 {
+		return nil, errors.New("ifo: truncated header")
+	}
+	if string(data[:len(vtsMagic)]) != vtsMagic {
+		return nil, fmt.Errorf("ifo: bad magic %q", data[:len(vtsMagic)])
+	}
+
+	pgcit := int(binary.BigEndian.Uint32(data[vtsPGCITPointer:])) * dvdSectorSize
+	if pgcit == 0 || pgcit+pgcitHeaderLen > len(data) {
+		return nil, errors.New("ifo: VTS_PGCIT out of range")
+	}
+	count := int(binary.BigEndian.Uint16(data[pgcit:]))
+
+	info := &VTSInfo{}
+	for i := range count {
+		srp := pgcit + pgcitHeaderLen + i*pgcSearchPtrLen
+		if srp+pgcSearchPtrLen > len(data) {
+			return nil, errors.New("ifo: PGC search pointer out of range")
+		}
+		if data[srp]&pgcEntryFlag == 0 {
+			continue
+		}
+		pgc := pgcit + int(binary.BigEndian.Uint32(data[srp+4:]))
+		chain, err := parsePGC(data, pgc)
+		if err != nil {
+			return nil, fmt.Errorf("ifo: PGC %d: %w", i+1, err)
+		}
+		info.ProgramChains = append(info.ProgramChains, chain)
+	}
+	return info, nil
+}
+
+func parsePGC(data []byte, pgc int) (DVDProgramChain, error) {
+	if pgc+pgcCellPlayback+2 > len(data) {
+		return DVDProgramChain{}, errors.New("truncated")
+	}
+	chain := DVDProgramChain{Duration: dvdTime(data[pgc+pgcTimeOffset:])}
+
+	cells := int(data[pgc+pgcCellsCount])
+	table := pgc + int(binary.BigEndian.Uint16(data[pgc+pgcCellPlayback:]))
+	if cells > 0 && table+cells*cellPlaybackLen > len(data) {
+		return DVDProgramChain{}, errors.New("cell playback table out of range")
+	}
+	for c := range cells {
+		cell := data[table+c*cellPlaybackLen:]
+		blockMode := cell[0] >> 6
+		blockType := (cell[0] >> 4) & 0x03
+		if blockType == cellAngleBlock && blockMode != cellBlockFirst {
+			continue
+		}
+		first := binary.BigEndian.Uint32(cell[8:])
+		last := binary.BigEndian.Uint32(cell[20:])
+		if last < first {
+			return DVDProgramChain{}, fmt.Errorf("cell %d has inverted sector range", c+1)
+		}
+		chain.Cells = append(chain.Cells, DVDCell{FirstSector: first, LastSector: last})
+	}
+	return chain, nil
+}
+
+// dvdTime decodes a 4-byte BCD dvd_time_t (hours, minutes, seconds, frames
+// with the frame rate in the top two bits of the last byte).
+func dvdTime(b []byte) time.Duration {
+	bcd := func(v byte) int { return int(v>>4)*10 + int(v&0x0F) }
+	d := time.Duration(bcd(b[0]))*time.Hour +
+		time.Duration(bcd(b[1]))*time.Minute +
+		time.Duration(bcd(b[2]))*time.Second
+	fps := 0
+	switch b[3] >> 6 {
+	case 0x01:
+		fps = 25
+	case 0x03:
+		fps = 30
+	}
+	if fps > 0 {
+		d += time.Duration(bcd(b[3]&0x3F)) * time.Second / time.Duration(fps)
+	}
+	return d
+}
+
+// DVDByteRange is a byte range within a title set's concatenated title VOBs.
+type DVDByteRange struct {
+	Offset int64
+	Length int64
+}
+
+// DVDMainTitle is the longest title across every title set of a DVD.
+type DVDMainTitle struct {
+	TitleSet int
+	Duration time.Duration
+	// Ranges are the main title's cells in playback order, merged where
+	// adjacent, as byte ranges of VTS_NN_1.VOB..VTS_NN_9.VOB concatenated.
+	Ranges []DVDByteRange
+}
+
+// SelectDVDMainTitle picks the longest title PGC across the parsed title
+// sets (keyed by title-set number). Ties go to the title covering more
+// bytes. ok is false when no title has any cells.
+func SelectDVDMainTitle(sets map[int]*VTSInfo) (DVDMainTitle, bool) {
+	nums := make([]int, 0, len(sets))
+	for n := range sets {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+
+	var (
+		best      DVDMainTitle
+		bestBytes int64
+		found     bool
+	)
+	for _, n := range nums {
+		for _, pgc := range sets[n].ProgramChains {
+			ranges := cellRanges(pgc.Cells)
+			if len(ranges) == 0 {
+				continue
+			}
+			var bytes int64
+			for _, r := range ranges {
+				bytes += r.Length
+			}
+			if found && (pgc.Duration < best.Duration || (pgc.Duration == best.Duration && bytes <= bestBytes)) {
+				continue
+			}
+			best = DVDMainTitle{TitleSet: n, Duration: pgc.Duration, Ranges: ranges}
+			bestBytes = bytes
+			found = true
+		}
+	}
+	return best, found
+}
+
+// cellRanges converts cells to byte ranges, merging cells that continue
+// exactly where the previous one ended.
+func cellRanges(cells []DVDCell) []DVDByteRange {
+	var out []DVDByteRange
+	for _, c := range cells {
+		r := DVDByteRange{
+			Offset: int64(c.FirstSector) * dvdSectorSize,
+			Length: (int64(c.LastSector) - int64(c.FirstSector) + 1) * dvdSectorSize,
+		}
+		if n := len(out); n > 0 && out[n-1].Offset+out[n-1].Length == r.Offset {
+			out[n-1].Length += r.Length
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// DVDVOBSlice is a byte range of one title VOB file.
+type DVDVOBSlice struct {
+	VOB    int // index into the vobSizes passed to SliceDVDRanges
+	Offset int64
+	Length int64
+}
+
+// SliceDVDRanges maps title ranges onto the individual title VOB files
+// (VTS_NN_1.VOB.. in order, with the given sizes), splitting ranges that
+// cross a VOB boundary. Ranges past the last VOB are truncated.
+func SliceDVDRanges(ranges []DVDByteRange, vobSizes []int64) []DVDVOBSlice {
+	var out []DVDVOBSlice
+	for _, r := range ranges {
+		off, remaining := r.Offset, r.Length
+		var base int64
+		for i, size := range vobSizes {
+			if remaining <= 0 {
+				break
+			}
+			if off >= base+size {
+				base += size
+				continue
+			}
+			within := off - base
+			n := min(size-within, remaining)
+			out = append(out, DVDVOBSlice{VOB: i, Offset: within, Length: n})
+			off += n
+			remaining -= n
+			base += size
+		}
+	}
+	return out
+}
+
+var (
+	vtsIFOPattern = regexp.MustCompile(`(?i)^VTS_(\d{2})_0\.IFO$`)
+	vtsVOBPattern = regexp.MustCompile(`(?i)^VTS_(\d{2})_([1-9])\.VOB$`)
+)
+
+// DVDTitleSetFile classifies a path inside a VIDEO_TS folder. It returns
+// the title-set number and, for title VOBs, the 1-based VOB number (0 for
+// the VTS_NN_0.IFO). ok is false for every other file, including menu VOBs.
+func DVDTitleSetFile(p string) (titleSet, vob int, ok bool) {
+	name := path.Base(strings.ReplaceAll(p, "\\", "/"))
+	if m := vtsIFOPattern.FindStringSubmatch(name); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return n, 0, true
+	}
+	if m := vtsVOBPattern.FindStringSubmatch(name); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		v, _ := strconv.Atoi(m[2])
+		return n, v, true
+	}
+	return 0, 0, false
+}
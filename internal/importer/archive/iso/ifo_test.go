@@ -0,0 +1,188 @@
+package iso
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// testCell is one cell of a synthetic PGC: an inclusive sector range and
+// its block flags.
+type testCell struct {
+	first, last uint32
+	flags       byte // block_mode<<6 | block_type<<4
+}
+
+// testPGC is one synthetic title PGC.
+type testPGC struct {
+	time  [4]byte // BCD dvd_time_t
+	entry bool
+	cells []testCell
+}
+
+// buildVTSIFO assembles a minimal VTS IFO: the header with the VTS_PGCIT
+// pointer at sector 1, then the PGCIT with one search pointer and PGC per
+// chain.
+func buildVTSIFO(pgcs []testPGC) []byte {
+	const pgcitStart = dvdSectorSize
+	pgcLen := func(p testPGC) int { return pgcCellPlayback + 2 + len(p.cells)*cellPlaybackLen }
+
+	size := pgcitStart + pgcitHeaderLen + len(pgcs)*pgcSearchPtrLen
+	for _, p := range pgcs {
+		size += pgcLen(p)
+	}
+	data := make([]byte, size)
+	copy(data, vtsMagic)
+	binary.BigEndian.PutUint32(data[vtsPGCITPointer:], 1)
+	binary.BigEndian.PutUint16(data[pgcitStart:], uint16(len(pgcs)))
+
+	off := pgcitHeaderLen + len(pgcs)*pgcSearchPtrLen // relative to PGCIT
+	for i, p := range pgcs {
+		srp := pgcitStart + pgcitHeaderLen + i*pgcSearchPtrLen
+		if p.entry {
+			data[srp] = pgcEntryFlag | byte(i+1)
+		}
+		binary.BigEndian.PutUint32(data[srp+4:], uint32(off))
+
+		pgc := pgcitStart + off
+		data[pgc+pgcCellsCount] = byte(len(p.cells))
+		copy(data[pgc+pgcTimeOffset:], p.time[:])
+		binary.BigEndian.PutUint16(data[pgc+pgcCellPlayback:], pgcCellPlayback+2)
+		for c, cell := range p.cells {
+			b := data[pgc+pgcCellPlayback+2+c*cellPlaybackLen:]
+			b[0] = cell.flags
+			binary.BigEndian.PutUint32(b[8:], cell.first)
+			binary.BigEndian.PutUint32(b[20:], cell.last)
+		}
+		off += pgcLen(p)
+	}
+	return data
+}
+
+func TestParseVTSIFO(t *testing.T) {
+	t.Parallel()
+
+	data := buildVTSIFO([]testPGC{
+		{time: [4]byte{0x00, 0x02, 0x30, 0x00}, entry: true, cells: []testCell{{0, 99, 0}}},
+		{time: [4]byte{0x01, 0x45, 0x10, 0x40 | 0x12}, entry: true, cells: []testCell{
+			{100, 199, 0},
+			{200, 299, 0x50}, // angle block, first angle
+			{300, 399, 0xD0}, // angle block, last angle: skipped
+			{400, 499, 0},
+		}},
+		{time: [4]byte{0x02, 0x00, 0x00, 0x00}, entry: false, cells: []testCell{{0, 9, 0}}},
+	})
+
+	info, err := ParseVTSIFO(data)
+	if err != nil {
+		t.Fatalf("ParseVTSIFO: %v", err)
+	}
+	if len(info.ProgramChains) != 2 {
+		t.Fatalf("got %d program chains, want 2 (non-entry PGC skipped)", len(info.ProgramChains))
+	}
+
+	pgc := info.ProgramChains[1]
+	want := time.Hour + 45*time.Minute + 10*time.Second + 12*time.Second/25
+	if pgc.Duration != want {
+		t.Errorf("duration = %v, want %v", pgc.Duration, want)
+	}
+	if len(pgc.Cells) != 3 || pgc.Cells[2].FirstSector != 400 {
+		t.Errorf("cells = %+v, want the non-first angle cell dropped", pgc.Cells)
+	}
+
+	if _, err := ParseVTSIFO([]byte("DVDVIDEO-VMG")); err == nil {
+		t.Error("expected error for truncated data")
+	}
+	bad := append([]byte(nil), data...)
+	copy(bad, "DVDVIDEO-VMG")
+	if _, err := ParseVTSIFO(bad); err == nil {
+		t.Error("expected error for a VMG IFO")
+	}
+}
+
+func TestSelectDVDMainTitle(t *testing.T) {
+	t.Parallel()
+
+	sets := map[int]*VTSInfo{
+		1: {ProgramChains: []DVDProgramChain{
+			{Duration: 2 * time.Minute, Cells: []DVDCell{{0, 9}}},
+		}},
+		2: {ProgramChains: []DVDProgramChain{
+			{Duration: 100 * time.Minute, Cells: []DVDCell{{0, 9}, {10, 19}, {40, 49}}},
+			{Duration: 0, Cells: nil},
+		}},
+	}
+	title, ok := SelectDVDMainTitle(sets)
+	if !ok {
+		t.Fatal("expected a main title")
+	}
+	if title.TitleSet != 2 || title.Duration != 100*time.Minute {
+		t.Errorf("picked set %d (%v), want set 2", title.TitleSet, title.Duration)
+	}
+	want := []DVDByteRange{{0, 20 * dvdSectorSize}, {40 * dvdSectorSize, 10 * dvdSectorSize}}
+	if len(title.Ranges) != len(want) || title.Ranges[0] != want[0] || title.Ranges[1] != want[1] {
+		t.Errorf("ranges = %+v, want %+v", title.Ranges, want)
+	}
+
+	if _, ok := SelectDVDMainTitle(map[int]*VTSInfo{1: {}}); ok {
+		t.Error("expected no title without cells")
+	}
+}
+
+func TestSliceDVDRanges(t *testing.T) {
+	t.Parallel()
+
+	got := SliceDVDRanges([]DVDByteRange{{Offset: 80, Length: 50}, {Offset: 290, Length: 100}}, []int64{100, 100, 100})
+	want := []DVDVOBSlice{
+		{VOB: 0, Offset: 80, Length: 20},
+		{VOB: 1, Offset: 0, Length: 30},
+		{VOB: 2, Offset: 90, Length: 10}, // truncated at the last VOB
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("slice %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSliceExtents(t *testing.T) {
+	t.Parallel()
+
+	extents := []isoExtent{{lba: 100, length: 4 * dvdSectorSize}, {lba: 500, length: 4 * dvdSectorSize}}
+	got, ok := sliceExtents(extents, 2*dvdSectorSize, 4*dvdSectorSize)
+	if !ok || len(got) != 2 {
+		t.Fatalf("sliceExtents = %+v, %t", got, ok)
+	}
+	if got[0] != (isoExtent{lba: 102, length: 2 * dvdSectorSize}) || got[1] != (isoExtent{lba: 500, length: 2 * dvdSectorSize}) {
+		t.Errorf("sliceExtents = %+v", got)
+	}
+	if _, ok := sliceExtents(extents, 100, dvdSectorSize); ok {
+		t.Error("expected unaligned range to be rejected")
+	}
+}
+
+func TestDVDTitleSetFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path     string
+		set, vob int
+		ok       bool
+	}{
+		{"VIDEO_TS/VTS_01_0.IFO", 1, 0, true},
+		{"Movie/VIDEO_TS/vts_03_2.vob", 3, 2, true},
+		{"VIDEO_TS/VTS_01_0.VOB", 0, 0, false}, // menu
+		{"VIDEO_TS/VIDEO_TS.IFO", 0, 0, false},
+		{"VIDEO_TS/VTS_01_0.BUP", 0, 0, false},
+	}
+	for _, tt := range tests {
+		set, vob, ok := DVDTitleSetFile(tt.path)
+		if set != tt.set || vob != tt.vob || ok != tt.ok {
+			t.Errorf("DVDTitleSetFile(%q) = (%d, %d, %t); want (%d, %d, %t)",
+				tt.path, set, vob, ok, tt.set, tt.vob, tt.ok)
+		}
+	}
+}
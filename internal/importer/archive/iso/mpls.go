@@ -9,7 +9,8 @@ import (
 // MPLS (Blu-ray PlayList) is a fixed binary format defined by the BDA spec.
 // We only parse the fields needed to identify the main feature playlist and
 // its ordered list of M2TS clips: the clip_information_file_name for each
-// PlayItem and the IN/OUT presentation times used to estimate duration.
+// PlayItem and the IN/OUT presentation times used to estimate duration, plus
+// the PlayListMark entries that define the disc's chapters.
 
 // mplsHeaderSize is the fixed prefix length: 4 magic + 4 version +
 // 4 PlayList offset + 4 PlayListMark offset + 4 ExtensionData offset.
@@ -26,10 +27,27 @@ type MPLSPlayItem struct {
 	OutTime uint32
 }
 
+// mplsMarkSize is the fixed size of one PlayListMark entry.
+const mplsMarkSize = 14
+
+// mplsMarkEntry is the mark_type of an entry mark (a chapter start).
+const mplsMarkEntry = 1
+
+// MPLSMark is one PlayListMark. Entry marks are the chapters a player
+// offers; link points (type 2) are skipped by ChapterTicks.
+type MPLSMark struct {
+	Type     byte
+	PlayItem int // index into PlayItems
+	// Timestamp is a 45 kHz presentation time on the referenced PlayItem's
+	// own timeline (between its InTime and OutTime).
+	Timestamp uint32
+}
+
 // MPLSPlayList is the parsed view of a single .mpls file.
 type MPLSPlayList struct {
 	Version   string // e.g. "0100", "0200", "0300"
 	PlayItems []MPLSPlayItem
+	Marks     []MPLSMark
 }
 
 // DurationTicks returns the sum of (OutTime-InTime) across PlayItems in
@@ -45,6 +63,38 @@ func (p *MPLSPlayList) DurationTicks() int64 {
 	return total
 }
 
+// ChapterTicks returns the start of every chapter on the playlist's
+// continuous timeline (PlayItems laid end to end from 0), in 45 kHz ticks,
+// ascending and without duplicates. Marks that reference a missing PlayItem
+// or fall outside its IN/OUT window are ignored.
+func (p *MPLSPlayList) ChapterTicks() []int64 {
+	starts := make([]int64, len(p.PlayItems))
+	var cum int64
+	for i, it := range p.PlayItems {
+		starts[i] = cum
+		if it.OutTime > it.InTime {
+			cum += int64(it.OutTime - it.InTime)
+		}
+	}
+
+	var out []int64
+	for _, m := range p.Marks {
+		if m.Type != mplsMarkEntry || m.PlayItem < 0 || m.PlayItem >= len(p.PlayItems) {
+			continue
+		}
+		it := p.PlayItems[m.PlayItem]
+		if m.Timestamp < it.InTime || m.Timestamp > it.OutTime {
+			continue
+		}
+		t := starts[m.PlayItem] + int64(m.Timestamp-it.InTime)
+		if n := len(out); n > 0 && t <= out[n-1] {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
 // ParseMPLS decodes a .mpls file. All multi-byte integers are big-endian
 // per the BDA spec. Sub-paths, the STN table, and per-angle alternates
 // are skipped — we use each PlayItem's leading length field to advance
@@ -104,5 +154,36 @@ func ParseMPLS(data []byte) (*MPLSPlayList, error) {
 		cursor = itemEnd
 	}
 
-	return &MPLSPlayList{Version: version, PlayItems: items}, nil
+	return &MPLSPlayList{
+		Version:   version,
+		PlayItems: items,
+		Marks:     parseMPLSMarks(data, binary.BigEndian.Uint32(data[12:16])),
+	}, nil
+}
+
+// parseMPLSMarks decodes the PlayListMark section at off. Marks only feed
+// chapter sidecars, so a missing or malformed section yields no marks
+// rather than failing the playlist.
+func parseMPLSMarks(data []byte, off uint32) []MPLSMark {
+	// PlayListMark header: length(4) + number_of_PlayList_marks(2)
+	if int(off) < mplsHeaderSize || int(off)+6 > len(data) {
+		return nil
+	}
+	n := int(binary.BigEndian.Uint16(data[off+4 : off+6]))
+	body := data[off+6:]
+	if n*mplsMarkSize > len(body) {
+		return nil
+	}
+	marks := make([]MPLSMark, 0, n)
+	for i := range n {
+		// +0 reserved, +1 mark_type, +2 ref_to_PlayItem_id(2),
+		// +4 mark_time_stamp(4), +8 entry_ES_PID(2), +10 duration(4)
+		m := body[i*mplsMarkSize : (i+1)*mplsMarkSize]
+		marks = append(marks, MPLSMark{
+			Type:      m[1],
+			PlayItem:  int(binary.BigEndian.Uint16(m[2:4])),
+			Timestamp: binary.BigEndian.Uint32(m[4:8]),
+		})
+	}
+	return marks
 }
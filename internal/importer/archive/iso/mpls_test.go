@@ -165,3 +165,61 @@ func TestParseMPLS(t *testing.T) {
 		})
 	}
 }
+
+// withMarks appends a PlayListMark section to an MPLS built by buildMPLS
+// and points the header at it.
+func withMarks(data []byte, marks []MPLSMark) []byte {
+	off := len(data)
+	section := make([]byte, 6+len(marks)*mplsMarkSize)
+	binary.BigEndian.PutUint32(section[0:4], uint32(2+len(marks)*mplsMarkSize))
+	binary.BigEndian.PutUint16(section[4:6], uint16(len(marks)))
+	for i, m := range marks {
+		e := section[6+i*mplsMarkSize:]
+		e[1] = m.Type
+		binary.BigEndian.PutUint16(e[2:4], uint16(m.PlayItem))
+		binary.BigEndian.PutUint32(e[4:8], m.Timestamp)
+	}
+	out := append(data, section...)
+	binary.BigEndian.PutUint32(out[12:16], uint32(off))
+	return out
+}
+
+func TestMPLSChapterTicks(t *testing.T) {
+	t.Parallel()
+
+	data := withMarks(buildMPLS(t, "0200", []MPLSPlayItem{
+		{ClipName: "00001", InTime: 1000, OutTime: 91000},
+		{ClipName: "00002", InTime: 5000, OutTime: 50000},
+	}, nil), []MPLSMark{
+		{Type: mplsMarkEntry, PlayItem: 0, Timestamp: 1000},
+		{Type: mplsMarkEntry, PlayItem: 0, Timestamp: 46000},
+		{Type: 2, PlayItem: 0, Timestamp: 50000}, // link point: not a chapter
+		{Type: mplsMarkEntry, PlayItem: 1, Timestamp: 5000},
+		{Type: mplsMarkEntry, PlayItem: 1, Timestamp: 99999}, // outside IN/OUT
+		{Type: mplsMarkEntry, PlayItem: 7, Timestamp: 0},     // no such PlayItem
+	})
+
+	pl, err := ParseMPLS(data)
+	if err != nil {
+		t.Fatalf("ParseMPLS: %v", err)
+	}
+	if len(pl.Marks) != 6 {
+		t.Fatalf("got %d marks, want 6", len(pl.Marks))
+	}
+	got := pl.ChapterTicks()
+	want := []int64{0, 45000, 90000}
+	if len(got) != len(want) {
+		t.Fatalf("ChapterTicks = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("chapter %d = %d, want %d", i, got[i], want[i])
+		}
+	}
+
+	// A playlist without a mark section still parses.
+	plain, err := ParseMPLS(buildMPLS(t, "0200", []MPLSPlayItem{{ClipName: "00001", InTime: 0, OutTime: 1}}, nil))
+	if err != nil || len(plain.Marks) != 0 {
+		t.Errorf("plain playlist: marks %v, err %v", plain.Marks, err)
+	}
+}
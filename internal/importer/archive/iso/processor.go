@@ -17,7 +17,10 @@ import (
 //   - the volume label (for multi-disc grouping),
 //   - the filtered list of inner files (Files),
 //   - the ordered MainFeature M2TS list when the ISO is a Blu-ray with a
-//     resolvable playlist (nil otherwise).
+//     resolvable playlist (nil otherwise),
+//   - the other Blu-ray playlists lasting at least minExtraPlaylist
+//     (none when minExtraPlaylist <= 0),
+//   - otherwise the DVD-Video main title when VIDEO_TS IFOs resolve one.
 //
 // allowedExtensions only filters Files. MainFeature is always returned for
 // BDMV discs regardless of the extension list — its existence is the
@@ -29,6 +32,7 @@ func AnalyzeISO(
 	maxPrefetch int,
 	readTimeout time.Duration,
 	analyzeTimeout time.Duration,
+	minExtraPlaylist time.Duration,
 	allowedExtensions []string,
 	progressTracker *progress.Tracker,
 ) (*AnalyzedISO, error) {
@@ -68,18 +72,23 @@ func AnalyzeISO(
 		out.Files = append(out.Files, buildFileContent(src, e))
 	}
 
-	if mf := ResolveMainFeature(ctx, rs, entries, progressTracker); mf != nil {
+	minExtraTicks := int64(minExtraPlaylist.Seconds() * 45000)
+	if mf, extras := ResolvePlaylists(ctx, rs, entries, minExtraTicks, progressTracker); mf != nil {
 		out.DurationTicks = mf.DurationTicks
-		for i, e := range mf.Streams {
-			fc := buildFileContent(src, e)
-			// Carry per-clip MPLS timing (45 kHz) for the continuous-timeline
-			// remux. ClipInTimes/ClipDurations are parallel to Streams.
-			if i < len(mf.ClipInTimes) {
-				fc.InTimeTicks = mf.ClipInTimes[i]
-				fc.DurationTicks = mf.ClipDurations[i]
-			}
-			out.MainFeature = append(out.MainFeature, fc)
+		out.ChapterTicks = mf.ChapterTicks
+		out.MainFeature = playlistClips(src, mf)
+		for _, x := range extras {
+			out.ExtraPlaylists = append(out.ExtraPlaylists, AnalyzedPlaylist{
+				Name:          x.PlaylistName,
+				Clips:         playlistClips(src, x),
+				DurationTicks: x.DurationTicks,
+				ChapterTicks:  x.ChapterTicks,
+			})
 		}
+	} else if entry, plan, ok := ResolveDVDMainTitle(ctx, rs, entries); ok {
+		fc := buildFileContent(src, entry)
+		out.DVDTitle = &fc
+		out.DVDTitleSet = plan.TitleSet
 	}
 
 	// Single completion log: raw entry count, filtered file count, BD clip
@@ -92,12 +101,30 @@ func AnalyzeISO(
 		"entries", len(entries),
 		"files", len(out.Files),
 		"main_feature_clips", len(out.MainFeature),
+		"extra_playlists", len(out.ExtraPlaylists),
+		"dvd_title_set", out.DVDTitleSet,
 		"duration_seconds", time.Since(start).Seconds(),
 	)
 
 	return out, nil
 }
 
+// playlistClips converts a resolved playlist's streams into file contents
+// carrying per-clip MPLS timing (45 kHz) for the continuous-timeline remux.
+// ClipInTimes/ClipDurations are parallel to Streams.
+func playlistClips(src ISOSource, pl *MainFeaturePlaylist) []ISOFileContent {
+	clips := make([]ISOFileContent, 0, len(pl.Streams))
+	for i, e := range pl.Streams {
+		fc := buildFileContent(src, e)
+		if i < len(pl.ClipInTimes) {
+			fc.InTimeTicks = pl.ClipInTimes[i]
+			fc.DurationTicks = pl.ClipDurations[i]
+		}
+		clips = append(clips, fc)
+	}
+	return clips
+}
+
 // buildFileContent turns one ISO directory entry into an ISOFileContent,
 // emitting one ISONestedSource per on-disc extent. Concatenating the
 // sources' byte ranges yields the complete file. This is the path that
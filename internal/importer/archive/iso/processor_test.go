@@ -29,6 +29,7 @@ func TestAnalyzeISO_HonorsTimeout(t *testing.T) {
 		0,
 		0,
 		1*time.Nanosecond, // analyzeTimeout
+		0,                 // minExtraPlaylist
 		nil,
 		nil, // progressTracker
 	)
@@ -65,6 +66,7 @@ func TestAnalyzeISO_HonorsTimeout_PreCanceled(t *testing.T) {
 		0,
 		0,
 		0, // analyzeTimeout=0 → cap disabled, parent ctx still canceled
+		0, // minExtraPlaylist
 		nil,
 		nil, // progressTracker
 	)
@@ -53,10 +53,26 @@ type ISONestedSource struct {
 // filtering applied). MainFeature, when non-nil, is the ordered M2TS list
 // that forms the Blu-ray main feature according to BDMV/PLAYLIST/*.mpls —
 // this is the slice callers should concatenate to produce a single playable
-// virtual file.
+// virtual file. DVDTitle is the DVD-Video equivalent: the longest title
+// (per VIDEO_TS/VTS_NN_0.IFO) as one MPEG-PS file spanning its VOB cells.
 type AnalyzedISO struct {
 	VolumeLabel   string
 	Files         []ISOFileContent
 	MainFeature   []ISOFileContent // nil for non-BDMV / unparseable playlists
 	DurationTicks int64            // sum of (OUT-IN) of MainFeature at 45 kHz
+	ChapterTicks  []int64          // MainFeature chapter starts at 45 kHz
+	DVDTitle      *ISOFileContent  // nil for non-DVD / unparseable IFOs or when MainFeature is set
+	DVDTitleSet   int              // title-set number of DVDTitle
+	// ExtraPlaylists are the other Blu-ray playlists above the caller's
+	// duration threshold (extended cuts, bonus features, TV episodes).
+	ExtraPlaylists []AnalyzedPlaylist
+}
+
+// AnalyzedPlaylist is one additional Blu-ray playlist. Clips has the same
+// shape as AnalyzedISO.MainFeature.
+type AnalyzedPlaylist struct {
+	Name          string // e.g. "BDMV/PLAYLIST/00801.mpls"
+	Clips         []ISOFileContent
+	DurationTicks int64
+	ChapterTicks  []int64
 }
@@ -36,6 +36,14 @@ type analyzedISO struct {
 //     in one NZB), discs sharing a stripped volume label are merged so
 //     the cross-disc movie also plays as one file.
 //
+// Other Blu-ray playlists lasting at least minExtraPlaylist (extended cuts,
+// bonus features, TV episodes) become separate continuous files under
+// ExtrasDir, and every playlist file carries its .mpls chapter marks.
+//
+// DVD-Video ISOs get the same treatment: the longest title from the
+// VIDEO_TS IFOs becomes one MPEG-PS file spanning its VOB cells, merged
+// across discs of the same group.
+//
 // Non-ISO entries pass through unchanged. Per-ISO errors are non-fatal:
 // on failure the original .iso Content is kept so downstream still has
 // something to work with.
@@ -47,6 +55,7 @@ func ExpandISOContents(
 	maxPrefetch int,
 	readTimeout time.Duration,
 	analyzeTimeout time.Duration,
+	minExtraPlaylist time.Duration,
 	allowedExtensions []string,
 	progressTracker *progress.Tracker,
 ) ([]Content, error) {
@@ -87,7 +96,7 @@ func ExpandISOContents(
 		// updates inside AnalyzeISO stay within [isoIdx, isoIdx+1] of the
 		// band; bump the parent to the slice boundary once it completes so
 		// even non-BDMV ISOs (no playlist loop) advance the bar.
-		a, err := iso.AnalyzeISO(ctx, src, poolManager, maxPrefetch, readTimeout, analyzeTimeout, allowedExtensions, progressTracker.Slice(isoIdx, numISOs))
+		a, err := iso.AnalyzeISO(ctx, src, poolManager, maxPrefetch, readTimeout, analyzeTimeout, minExtraPlaylist, allowedExtensions, progressTracker.Slice(isoIdx, numISOs))
 		isoIdx++
 		progressTracker.Update(isoIdx, numISOs)
 		if err != nil {
@@ -96,7 +105,7 @@ func ExpandISOContents(
 			result = append(result, c)
 			continue
 		}
-		if len(a.Files) == 0 && len(a.MainFeature) == 0 {
+		if len(a.Files) == 0 && len(a.MainFeature) == 0 && a.DVDTitle == nil {
 			result = append(result, c)
 			continue
 		}
@@ -128,6 +137,28 @@ func ExpandISOContents(
 		if allHaveMainFeature {
 			merged, ok := buildMainFeatureContent(ctx, key, g)
 			if ok {
+				result = append(result, merged)
+				for _, e := range g {
+					for _, pl := range e.analyzed.ExtraPlaylists {
+						if extra, ok := buildExtraPlaylistContent(ctx, key, e, pl); ok {
+							result = append(result, extra)
+						}
+					}
+				}
+				continue
+			}
+		}
+
+		// Same rule for DVD-Video discs.
+		allHaveDVDTitle := true
+		for _, e := range g {
+			if e.analyzed.DVDTitle == nil {
+				allHaveDVDTitle = false
+				break
+			}
+		}
+		if allHaveDVDTitle {
+			if merged, ok := buildDVDTitleContent(ctx, key, g); ok {
 				result = append(result, merged)
 				continue
 			}
@@ -149,14 +180,92 @@ func ExpandISOContents(
 
 // buildMainFeatureContent concatenates every member's MainFeature into a
 // single Content whose NestedSources chain spans every M2TS in disc and
-// playlist order. Returns (zero, false) when, after conversion, the chain
-// is empty.
+// playlist order. Chapters from each disc are shifted by the running
+// duration of the discs before it. Returns (zero, false) when, after
+// conversion, the chain is empty.
 func buildMainFeatureContent(ctx context.Context, groupKey string, g []analyzedISO) (Content, bool) {
 	var (
-		sources      []NestedSource
-		totalSize    int64
-		firstISOName string
-		nzbdavID     string
+		clips    []iso.ISOFileContent
+		chapters []int64
+		cum45k   int64
+	)
+	for _, e := range g {
+		clips = append(clips, e.analyzed.MainFeature...)
+		for _, t := range e.analyzed.ChapterTicks {
+			chapters = append(chapters, cum45k+t)
+		}
+		cum45k += e.analyzed.DurationTicks
+	}
+
+	c, timeline90k, ok := buildPlaylistContent(ctx, groupKey, clips)
+	if !ok {
+		return Content{}, false
+	}
+
+	filename := mainFeatureFilename(groupKey, g[0].src.Filename)
+	slog.InfoContext(ctx, "Built Blu-ray main-feature virtual file",
+		"group", groupKey,
+		"discs", len(g),
+		"clips", len(c.ClipBoundaries),
+		"extents", len(c.NestedSources),
+		"size_bytes", c.Size,
+		"timeline_seconds", timeline90k/90000,
+		"chapters", len(chapters),
+		"filename", filename,
+	)
+
+	c.InternalPath = filename
+	c.Filename = filename
+	c.NzbdavID = g[0].src.NzbdavID
+	c.ISOExpansionIndex = 1
+	c.ChapterTicks = chapters
+	return c, true
+}
+
+// ExtrasDir is the release subfolder that holds Blu-ray extra playlists.
+// Plex and Jellyfin both pick up an "Extras" folder next to a movie.
+const ExtrasDir = "Extras"
+
+// buildExtraPlaylistContent turns one extra playlist of a disc into its own
+// continuous virtual file under ExtrasDir, named after the group, disc and
+// playlist ("AVATAR - Disc 2 - 00801.m2ts").
+func buildExtraPlaylistContent(ctx context.Context, groupKey string, e analyzedISO, pl iso.AnalyzedPlaylist) (Content, bool) {
+	c, timeline90k, ok := buildPlaylistContent(ctx, groupKey, pl.Clips)
+	if !ok {
+		return Content{}, false
+	}
+
+	stem := strings.TrimSuffix(mainFeatureFilename(groupKey, e.src.Filename), ".m2ts")
+	if e.discNum > 0 {
+		stem = fmt.Sprintf("%s - Disc %d", stem, e.discNum)
+	}
+	playlist := filepath.Base(pl.Name)
+	filename := fmt.Sprintf("%s - %s.m2ts", stem, strings.TrimSuffix(playlist, filepath.Ext(playlist)))
+	slog.InfoContext(ctx, "Built Blu-ray extra playlist virtual file",
+		"group", groupKey,
+		"playlist", pl.Name,
+		"clips", len(c.ClipBoundaries),
+		"size_bytes", c.Size,
+		"timeline_seconds", timeline90k/90000,
+		"filename", filename,
+	)
+
+	c.InternalPath = ExtrasDir + "/" + filename
+	c.Filename = filename
+	c.NzbdavID = e.src.NzbdavID
+	c.ChapterTicks = pl.ChapterTicks
+	c.ExtraPlaylist = pl.Name
+	return c, true
+}
+
+// buildPlaylistContent concatenates playlist clips into one Content whose
+// NestedSources chain spans every M2TS in order, with the per-clip timeline
+// table for the continuous-timeline remux. It also returns the total
+// timeline length in 90 kHz ticks. Returns false when the chain is empty.
+func buildPlaylistContent(ctx context.Context, groupKey string, clips []iso.ISOFileContent) (Content, int64, bool) {
+	var (
+		sources   []NestedSource
+		totalSize int64
 	)
 	// Per-clip timeline table for the continuous-timeline remux. We walk
 	// clips in output order across every disc, building a running 90 kHz
@@ -172,44 +281,38 @@ func buildMainFeatureContent(ctx context.Context, groupKey string, g []analyzedI
 		anyTiming      bool
 		byteMismatch   bool
 	)
-	for _, e := range g {
-		if firstISOName == "" {
-			firstISOName = e.src.Filename
-			nzbdavID = e.src.NzbdavID
-		}
-		for _, fc := range e.analyzed.MainFeature {
-			var clipByteLen int64
-			for _, ns := range isoFileContentToNestedSources(fc) {
-				if ns.InnerLength <= 0 {
-					continue
-				}
-				sources = append(sources, ns)
-				totalSize += ns.InnerLength
-				clipByteLen += ns.InnerLength
-			}
-			if clipByteLen == 0 {
+	for _, fc := range clips {
+		var clipByteLen int64
+		for _, ns := range isoFileContentToNestedSources(fc) {
+			if ns.InnerLength <= 0 {
 				continue
 			}
-			if fc.Size != 0 && fc.Size != clipByteLen {
-				byteMismatch = true
-			}
-			inBase90k := fc.InTimeTicks * 2
-			if base0_90k < 0 {
-				base0_90k = inBase90k
-			}
-			timelineStart90k := base0_90k + cum90k
-			clipBoundaries = append(clipBoundaries, ClipBoundary{
-				ByteLen:  clipByteLen,
-				Delta90k: timelineStart90k - inBase90k,
-			})
-			if fc.InTimeTicks != 0 || fc.DurationTicks != 0 {
-				anyTiming = true
-			}
-			cum90k += fc.DurationTicks * 2
+			sources = append(sources, ns)
+			totalSize += ns.InnerLength
+			clipByteLen += ns.InnerLength
+		}
+		if clipByteLen == 0 {
+			continue
+		}
+		if fc.Size != 0 && fc.Size != clipByteLen {
+			byteMismatch = true
+		}
+		inBase90k := fc.InTimeTicks * 2
+		if base0_90k < 0 {
+			base0_90k = inBase90k
+		}
+		timelineStart90k := base0_90k + cum90k
+		clipBoundaries = append(clipBoundaries, ClipBoundary{
+			ByteLen:  clipByteLen,
+			Delta90k: timelineStart90k - inBase90k,
+		})
+		if fc.InTimeTicks != 0 || fc.DurationTicks != 0 {
+			anyTiming = true
 		}
+		cum90k += fc.DurationTicks * 2
 	}
 	if len(sources) == 0 {
-		return Content{}, false
+		return Content{}, 0, false
 	}
 	// Only attach the timeline table when we actually have MPLS timing;
 	// without it the remux filter must stay disabled (empty → bypassed).
@@ -230,14 +333,42 @@ func buildMainFeatureContent(ctx context.Context, groupKey string, g []analyzedI
 		}
 	}
 
-	filename := mainFeatureFilename(groupKey, firstISOName)
-	slog.InfoContext(ctx, "Built Blu-ray main-feature virtual file",
+	return Content{
+		Size:           totalSize,
+		PackedSize:     totalSize,
+		NestedSources:  sources,
+		ClipBoundaries: clipBoundaries,
+	}, cum90k, true
+}
+
+// buildDVDTitleContent concatenates every member's DVD main title into a
+// single MPEG-PS Content in disc order. MPEG-PS needs no timeline table:
+// demuxers resync on the SCR discontinuity between discs.
+func buildDVDTitleContent(ctx context.Context, groupKey string, g []analyzedISO) (Content, bool) {
+	var (
+		sources   []NestedSource
+		totalSize int64
+	)
+	for _, e := range g {
+		for _, ns := range isoFileContentToNestedSources(*e.analyzed.DVDTitle) {
+			if ns.InnerLength <= 0 {
+				continue
+			}
+			sources = append(sources, ns)
+			totalSize += ns.InnerLength
+		}
+	}
+	if len(sources) == 0 {
+		return Content{}, false
+	}
+
+	filename := strings.TrimSuffix(mainFeatureFilename(groupKey, g[0].src.Filename), ".m2ts") + dvdTitleExt
+	slog.InfoContext(ctx, "Built DVD main-title virtual file",
 		"group", groupKey,
 		"discs", len(g),
-		"clips", len(clipBoundaries),
+		"title_set", g[0].analyzed.DVDTitleSet,
 		"extents", len(sources),
 		"size_bytes", totalSize,
-		"timeline_seconds", cum90k/90000,
 		"filename", filename,
 	)
 
@@ -246,10 +377,10 @@ func buildMainFeatureContent(ctx context.Context, groupKey string, g []analyzedI
 		Filename:          filename,
 		Size:              totalSize,
 		PackedSize:        totalSize,
-		NzbdavID:          nzbdavID,
+		NzbdavID:          g[0].src.NzbdavID,
 		NestedSources:     sources,
 		ISOExpansionIndex: 1,
-		ClipBoundaries:    clipBoundaries,
+		DVDTitleSet:       g[0].analyzed.DVDTitleSet,
 	}, true
 }
 
@@ -405,3 +405,73 @@ func TestBuildLargestFileContent(t *testing.T) {
 		t.Errorf("NzbdavID = %q, want id-1", got.NzbdavID)
 	}
 }
+
+// TestBuildMainFeatureContent_Chapters: each disc's chapter marks are lifted
+// by the authored duration of the discs before it.
+func TestBuildMainFeatureContent_Chapters(t *testing.T) {
+	t.Parallel()
+
+	clip := func(name string) iso.ISOFileContent {
+		return iso.ISOFileContent{
+			Filename: name, Size: 100,
+			Sources: []iso.ISONestedSource{{
+				Segments:    []*metapb.SegmentData{{Id: name, EndOffset: 99, SegmentSize: 100}},
+				InnerLength: 100,
+			}},
+		}
+	}
+	g := []analyzedISO{
+		{src: Content{Filename: "M_DISC_1.iso"}, discNum: 1, analyzed: &iso.AnalyzedISO{
+			MainFeature: []iso.ISOFileContent{clip("00001.m2ts")}, DurationTicks: 45000 * 60, ChapterTicks: []int64{0, 45000 * 30},
+		}},
+		{src: Content{Filename: "M_DISC_2.iso"}, discNum: 2, analyzed: &iso.AnalyzedISO{
+			MainFeature: []iso.ISOFileContent{clip("00002.m2ts")}, DurationTicks: 45000 * 60, ChapterTicks: []int64{0, 45000 * 10},
+		}},
+	}
+
+	got, ok := buildMainFeatureContent(context.Background(), "M", g)
+	if !ok {
+		t.Fatal("ok=false")
+	}
+	want := []int64{0, 45000 * 30, 45000 * 60, 45000 * 70}
+	if len(got.ChapterTicks) != len(want) {
+		t.Fatalf("ChapterTicks = %v, want %v", got.ChapterTicks, want)
+	}
+	for i := range want {
+		if got.ChapterTicks[i] != want[i] {
+			t.Errorf("chapter %d = %d, want %d", i, got.ChapterTicks[i], want[i])
+		}
+	}
+}
+
+func TestBuildExtraPlaylistContent(t *testing.T) {
+	t.Parallel()
+
+	e := analyzedISO{src: Content{Filename: "SHOW_DISC_2.iso", NzbdavID: "nzb-2"}, discNum: 2, groupKey: "SHOW"}
+	pl := iso.AnalyzedPlaylist{
+		Name: "BDMV/PLAYLIST/00801.mpls",
+		Clips: []iso.ISOFileContent{{
+			Filename: "00010.m2ts", Size: 100,
+			Sources: []iso.ISONestedSource{{
+				Segments:    []*metapb.SegmentData{{Id: "a", EndOffset: 99, SegmentSize: 100}},
+				InnerLength: 100,
+			}},
+			InTimeTicks: 1000, DurationTicks: 45000,
+		}},
+		ChapterTicks: []int64{0, 22500},
+	}
+
+	got, ok := buildExtraPlaylistContent(context.Background(), "SHOW", e, pl)
+	if !ok {
+		t.Fatal("ok=false")
+	}
+	if got.InternalPath != "Extras/SHOW - Disc 2 - 00801.m2ts" || got.Filename != "SHOW - Disc 2 - 00801.m2ts" {
+		t.Errorf("path = %q (%q)", got.InternalPath, got.Filename)
+	}
+	if got.ISOExpansionIndex != 0 || got.ExtraPlaylist != pl.Name || got.NzbdavID != "nzb-2" {
+		t.Errorf("extra = index %d, playlist %q, nzbdav %q", got.ISOExpansionIndex, got.ExtraPlaylist, got.NzbdavID)
+	}
+	if len(got.ClipBoundaries) != 1 || len(got.ChapterTicks) != 2 {
+		t.Errorf("extra lost its timeline (%d boundaries) or chapters (%v)", len(got.ClipBoundaries), got.ChapterTicks)
+	}
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/javi11/altmount/internal/encryption/aes"
 	"github.com/javi11/altmount/internal/importer/archive"
+	"github.com/javi11/altmount/internal/importer/checkpoint"
 	"github.com/javi11/altmount/internal/importer/filesystem"
 	"github.com/javi11/altmount/internal/importer/parser"
 	"github.com/javi11/altmount/internal/importer/utils"
@@ -100,6 +101,8 @@ type ProcessArchiveOptions struct {
 	ReadTimeout            time.Duration
 	IsoAnalyzeTimeout      time.Duration
 	ExpandBlurayIso        bool
+	BlurayExtraPlaylistMin time.Duration
+	StitchMultiPart        bool
 	FilterSamples          bool
 	RenameToNzbName        bool
 	// SegmentIndex + StoreRef enable direct v3 store-backed metadata writes. When
@@ -165,7 +168,17 @@ func ProcessArchive(ctx context.Context, opts ProcessArchiveOptions) error {
 		wg.Add(1)
 		go func(idx int, g []parser.ParsedFile) {
 			defer wg.Done()
+			// A previous attempt of the import may have analyzed this set already.
+			stage := checkpoint.ArchiveStage("rar", g[0].Filename)
+			checkpoints := checkpoint.FromContext(ctx)
+			if groupContents, ok := checkpoints.LoadContents(ctx, stage); ok {
+				groupResults[idx] = groupResult{contents: groupContents, firstName: g[0].Filename}
+				return
+			}
 			groupContents, err := rarProcessor.AnalyzeRarContentFromNzb(ctx, g, password, archiveProgressTracker)
+			if err == nil {
+				checkpoints.SaveContents(ctx, stage, groupContents)
+			}
 			groupResults[idx] = groupResult{contents: groupContents, err: err, firstName: g[0].Filename}
 		}(i, group)
 	}
@@ -212,11 +225,23 @@ func ProcessArchive(ctx context.Context, opts ProcessArchiveOptions) error {
 	if archiveProgressTracker != nil {
 		isoProgressTracker = archiveProgressTracker.Slice(0, 1).WithStage("Analyzing ISO")
 	}
-	rarContents, err := archive.ExpandISOContents(ctx, expandBlurayIso, rarContents, poolManager, maxPrefetch, readTimeout, analyzeTimeout, allowedFileExtensions, isoProgressTracker)
+	rarContents, err := archive.ExpandISOContents(ctx, expandBlurayIso, rarContents, poolManager, maxPrefetch, readTimeout, analyzeTimeout, opts.BlurayExtraPlaylistMin, allowedFileExtensions, isoProgressTracker)
 	if err != nil {
 		slog.WarnContext(ctx, "ISO expansion failed, proceeding without ISO contents", "error", err)
 	}
 
+	// DVD releases shipped as a VIDEO_TS folder get the same main-title
+	// treatment as DVD ISOs: one MPEG-PS file for the longest title. Only
+	// the small VTS IFOs are read over NNTP.
+	rarContents = archive.ExpandDVDFolders(ctx, expandBlurayIso, rarContents,
+		archive.NewDVDFileReader(poolManager, maxPrefetch, readTimeout))
+
+	// Offer multi-part movies (CD1/CD2, VOB title sets) as one concatenated
+	// virtual file next to their parts. Only MPEG-TS groups touch NNTP, to
+	// read each part's first and last timestamps.
+	rarContents = archive.StitchMultiPartContents(ctx, opts.StitchMultiPart, rarContents,
+		archive.NewTSTimelineProbe(poolManager, maxPrefetch, readTimeout))
+
 	// Validate file extensions before processing
 	if !hasAllowedFiles(rarContents, allowedFileExtensions, filterSamples) {
 		err := newErrNoAllowedFiles(rarContents, allowedFileExtensions)
@@ -402,6 +427,12 @@ func ProcessArchive(ctx context.Context, opts ProcessArchiveOptions) error {
 			if err := metadataService.WriteFileMetadataAuto(ctx, item.virtualFilePath, fileMeta, opts.SegmentIndex, opts.StoreRef); err != nil {
 				return fmt.Errorf("failed to write metadata for RAR file %s: %w", item.content.Filename, err)
 			}
+			if sidecar := archive.NewChapterSidecarMetadata(item.content.ChapterTicks, nzbPath, releaseDate); sidecar != nil {
+				sidecarPath := archive.ChapterSidecarPath(item.virtualFilePath)
+				if err := metadataService.WriteFileMetadata(sidecarPath, sidecar); err != nil {
+					slog.WarnContext(ctx, "Failed to write chapter sidecar", "virtual_path", sidecarPath, "error", err)
+				}
+			}
 
 			slog.InfoContext(ctx, "Created metadata for RAR extracted file",
 				"file", item.baseFilename,
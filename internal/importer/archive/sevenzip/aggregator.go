@@ -13,6 +13,7 @@ import (
 	concpool "github.com/sourcegraph/conc/pool"
 
 	"github.com/javi11/altmount/internal/importer/archive"
+	"github.com/javi11/altmount/internal/importer/checkpoint"
 	"github.com/javi11/altmount/internal/importer/filesystem"
 	"github.com/javi11/altmount/internal/importer/parser"
 	"github.com/javi11/altmount/internal/importer/utils"
@@ -96,6 +97,8 @@ type ProcessArchiveOptions struct {
 	ReadTimeout            time.Duration
 	IsoAnalyzeTimeout      time.Duration
 	ExpandBlurayIso        bool
+	BlurayExtraPlaylistMin time.Duration
+	StitchMultiPart        bool
 	FilterSamples          bool
 	RenameToNzbName        bool
 	// SegmentIndex + StoreRef enable direct v3 store-backed metadata writes. When
@@ -135,10 +138,18 @@ func ProcessArchive(ctx context.Context, opts ProcessArchiveOptions) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
-	sevenZipContents, err := sevenZipProcessor.AnalyzeSevenZipContentFromNzb(ctx, archiveFiles, password, archiveProgressTracker)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to analyze 7zip archive content", "error", err)
-		return err
+	// A previous attempt of the import may have analyzed the archive already.
+	stage := checkpoint.ArchiveStage("7z", archiveFiles[0].Filename)
+	checkpoints := checkpoint.FromContext(ctx)
+	sevenZipContents, resumed := checkpoints.LoadContents(ctx, stage)
+	var err error
+	if !resumed {
+		sevenZipContents, err = sevenZipProcessor.AnalyzeSevenZipContentFromNzb(ctx, archiveFiles, password, archiveProgressTracker)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to analyze 7zip archive content", "error", err)
+			return err
+		}
+		checkpoints.SaveContents(ctx, stage, sevenZipContents)
 	}
 
 	slog.InfoContext(ctx, "Successfully analyzed 7zip archive content", "files_in_archive", len(sevenZipContents))
@@ -154,11 +165,23 @@ func ProcessArchive(ctx context.Context, opts ProcessArchiveOptions) error {
 	if archiveProgressTracker != nil {
 		isoProgressTracker = archiveProgressTracker.Slice(0, 1).WithStage("Analyzing ISO")
 	}
-	sevenZipContents, err = archive.ExpandISOContents(ctx, expandBlurayIso, sevenZipContents, poolManager, maxPrefetch, readTimeout, analyzeTimeout, allowedFileExtensions, isoProgressTracker)
+	sevenZipContents, err = archive.ExpandISOContents(ctx, expandBlurayIso, sevenZipContents, poolManager, maxPrefetch, readTimeout, analyzeTimeout, opts.BlurayExtraPlaylistMin, allowedFileExtensions, isoProgressTracker)
 	if err != nil {
 		slog.WarnContext(ctx, "ISO expansion failed, proceeding without ISO contents", "error", err)
 	}
 
+	// DVD releases shipped as a VIDEO_TS folder get the same main-title
+	// treatment as DVD ISOs: one MPEG-PS file for the longest title. Only
+	// the small VTS IFOs are read over NNTP.
+	sevenZipContents = archive.ExpandDVDFolders(ctx, expandBlurayIso, sevenZipContents,
+		archive.NewDVDFileReader(poolManager, maxPrefetch, readTimeout))
+
+	// Offer multi-part movies (CD1/CD2, VOB title sets) as one concatenated
+	// virtual file next to their parts. Only MPEG-TS groups touch NNTP, to
+	// read each part's first and last timestamps.
+	sevenZipContents = archive.StitchMultiPartContents(ctx, opts.StitchMultiPart, sevenZipContents,
+		archive.NewTSTimelineProbe(poolManager, maxPrefetch, readTimeout))
+
 	// Validate file extensions before processing
 	if !hasAllowedFiles(sevenZipContents, allowedFileExtensions, filterSamples) {
 		err := newErrNoAllowedFiles(sevenZipContents, allowedFileExtensions)
@@ -337,6 +360,12 @@ func ProcessArchive(ctx context.Context, opts ProcessArchiveOptions) error {
 			if err := metadataService.WriteFileMetadataAuto(ctx, item.virtualFilePath, fileMeta, opts.SegmentIndex, opts.StoreRef); err != nil {
 				return fmt.Errorf("failed to write metadata for 7zip file %s: %w", item.content.Filename, err)
 			}
+			if sidecar := archive.NewChapterSidecarMetadata(item.content.ChapterTicks, nzbPath, releaseDate); sidecar != nil {
+				sidecarPath := archive.ChapterSidecarPath(item.virtualFilePath)
+				if err := metadataService.WriteFileMetadata(sidecarPath, sidecar); err != nil {
+					slog.WarnContext(ctx, "Failed to write chapter sidecar", "virtual_path", sidecarPath, "error", err)
+				}
+			}
 
 			slog.InfoContext(ctx, "Created metadata for 7zip extracted file",
 				"file", item.baseFilename,
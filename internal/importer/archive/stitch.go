@@ -0,0 +1,460 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javi11/altmount/internal/importer/filesystem"
+	"github.com/javi11/altmount/internal/pool"
+)
+
+// Multi-part movie stitching.
+//
+// Older movie releases are often split into CD1/CD2 or part1/part2 files, and
+// DVD title sets are split into 1 GB VTS_NN_1..N.VOB chunks. Media servers
+// handle these poorly, so StitchMultiPartContents adds one virtual file per
+// group that byte-concatenates the parts (via NestedSources) while keeping
+// the parts themselves.
+//
+// Only formats whose demuxers resync on a new header in the middle of the
+// stream are stitched:
+//   - MPEG-TS (.ts/.m2ts/.mts): each part restarts its own PTS/PCR timeline,
+//     so the parts' ClipBoundaries lift every later part onto one continuous
+//     timeline with the same read-time remux used for Blu-ray main features.
+//   - MPEG-PS (.vob/.mpg/.mpeg): plain concatenation. A DVD title set is
+//     already one continuous stream; CD1/CD2 MPEG files resync on the SCR
+//     discontinuity.
+//   - AVI (.avi/.divx), DV (.dv) and Ogg (.ogm/.ogv): plain concatenation.
+//     DV is a run of self-contained frames and Ogg streams chain by design.
+//     ffmpeg-based players (Plex, Jellyfin, mpv) resync on the later AVI
+//     parts' chunk headers and play through, but the first part's header and
+//     index still describe only that part, so the reported duration and
+//     seeking cover part 1 alone.
+//
+// MKV and MP4 keep their sizes and index in a header the player trusts, so
+// they cannot be concatenated byte-wise and are left as separate parts.
+
+// partGap90k is the gap inserted between the last frame of one stitched TS
+// part and the first frame of the next (one frame at 25 fps).
+const partGap90k = 3600
+
+// tsProbeWindow is how many bytes the timeline probe reads from the head and
+// the tail of each MPEG-TS part to find its first and last PTS.
+const tsProbeWindow = 512 * 1024
+
+var (
+	// vobPartPattern matches DVD title-set video chunks; VTS_NN_0.VOB is the
+	// title-set menu and is never part of the feature.
+	vobPartPattern = regexp.MustCompile(`(?i)^(VTS_\d{2})_([1-9])$`)
+	// multiPartPattern matches a trailing CD/part marker on a file stem:
+	// "Movie.1999.CD1", "Movie (Part 2)", "Movie-pt1of2".
+	multiPartPattern = regexp.MustCompile(`(?i)^(.*?)[ ._\-\[(]*(?:cd|disc|disk|part|pt)[ ._\-]?([1-9])(?:[ ._\-]*of[ ._\-]*[1-9])?[\])]?$`)
+	// episodePattern rejects TV episodes; "Part 1/Part 2" episodes are
+	// separate episodes, not one split movie.
+	episodePattern = regexp.MustCompile(`(?i)(^|[^a-z0-9])(s\d{1,2}e\d{1,3}|\d{1,2}x\d{2})([^a-z0-9]|$)`)
+)
+
+var (
+	stitchTSExtensions = map[string]bool{".ts": true, ".m2ts": true, ".mts": true}
+	stitchPSExtensions = map[string]bool{".vob": true, ".mpg": true, ".mpeg": true}
+	// stitchConcatExtensions are the other containers players accept as a
+	// plain byte concatenation of their parts.
+	stitchConcatExtensions = map[string]bool{".avi": true, ".divx": true, ".dv": true, ".ogm": true, ".ogv": true}
+)
+
+// PartTimeline is the presentation time span of one MPEG-TS part.
+type PartTimeline struct {
+	// PacketSize is 188 (plain TS) or 192 (BDAV M2TS).
+	PacketSize int
+	// FirstPTS and LastPTS are the lowest PTS near the start and the highest
+	// PTS near the end of the part, in 90 kHz ticks.
+	FirstPTS int64
+	LastPTS  int64
+}
+
+// TimelineProbe reads the timeline of an MPEG-TS part. An error disables
+// timestamp continuity for the part's group; the parts are still stitched.
+type TimelineProbe func(ctx context.Context, c Content) (PartTimeline, error)
+
+// stitchPart is one member of a multi-part group.
+type stitchPart struct {
+	content Content
+	num     int
+}
+
+// multiPartKey returns the grouping key and 1-based part number for a
+// stitchable multi-part file, or ok=false when name is not one. The key
+// includes the directory and extension so only siblings of the same format
+// group together. base is the release stem without the part marker.
+func multiPartKey(internalPath string) (key, base string, num int, ok bool) {
+	p := strings.ReplaceAll(internalPath, "\\", "/")
+	dir, file := path.Split(p)
+	ext := strings.ToLower(path.Ext(file))
+	if !stitchTSExtensions[ext] && !stitchPSExtensions[ext] && !stitchConcatExtensions[ext] {
+		return "", "", 0, false
+	}
+	stem := file[:len(file)-len(ext)]
+
+	var m []string
+	if ext == ".vob" {
+		m = vobPartPattern.FindStringSubmatch(stem)
+	}
+	if m == nil {
+		if episodePattern.MatchString(stem) {
+			return "", "", 0, false
+		}
+		m = multiPartPattern.FindStringSubmatch(stem)
+	}
+	if m == nil {
+		return "", "", 0, false
+	}
+
+	base = strings.TrimRight(m[1], " ._-[(")
+	num, _ = strconv.Atoi(m[2])
+	key = strings.ToLower(dir) + "\x00" + strings.ToLower(base) + "\x00" + ext
+	return key, base, num, true
+}
+
+// stitchable reports whether c's bytes can be addressed directly, which
+// concatenation needs. Compressed 7z members (packed size differs from the
+// unpacked size) and Blu-ray main features are excluded.
+func stitchable(c Content) bool {
+	if c.IsDirectory || c.Size <= 0 || len(c.ClipBoundaries) > 0 {
+		return false
+	}
+	if len(c.NestedSources) > 0 {
+		return true
+	}
+	return len(c.Segments) > 0 && (c.PackedSize == 0 || c.PackedSize == c.Size)
+}
+
+// StitchMultiPartContents appends one concatenated Content for every group
+// of multi-part movie files in contents (CD1/CD2, part1/part2, VTS_NN_N.VOB).
+// The parts are kept unchanged. A group needs at least two parts numbered
+// contiguously from 1, sharing directory, stem and extension. probe may be
+// nil, in which case MPEG-TS parts are concatenated without timestamp
+// continuity. VOB chunks of a VIDEO_TS folder that already produced a DVD
+// main title (see ExpandDVDFolders) are not stitched again. When enabled is
+// false contents are returned unchanged.
+func StitchMultiPartContents(ctx context.Context, enabled bool, contents []Content, probe TimelineProbe) []Content {
+	if !enabled {
+		return contents
+	}
+
+	var (
+		groups = make(map[string][]stitchPart)
+		bases  = make(map[string]string)
+		keys   []string
+		taken  = make(map[string]bool, len(contents))
+		dvds   = make(map[string]bool)
+	)
+	for _, c := range contents {
+		if c.DVDTitleSet > 0 {
+			dvds[path.Dir(contentPath(c))] = true
+		}
+	}
+	for _, c := range contents {
+		taken[strings.ToLower(contentPath(c))] = true
+		if !stitchable(c) {
+			continue
+		}
+		key, base, num, ok := multiPartKey(contentPath(c))
+		if !ok {
+			continue
+		}
+		if p := strings.ReplaceAll(contentPath(c), "\\", "/"); strings.EqualFold(path.Ext(p), ".vob") && dvds[dvdReleaseDir(path.Dir(p))] {
+			continue
+		}
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+			bases[key] = base
+		}
+		groups[key] = append(groups[key], stitchPart{content: c, num: num})
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts := groups[key]
+		if !contiguousParts(parts) {
+			continue
+		}
+		stitched, ok := buildStitchedContent(ctx, bases[key], parts, probe)
+		if !ok || taken[strings.ToLower(contentPath(stitched))] {
+			continue
+		}
+		taken[strings.ToLower(contentPath(stitched))] = true
+		contents = append(contents, stitched)
+	}
+	return contents
+}
+
+// contiguousParts sorts parts by number and reports whether they form the
+// sequence 1..N with N >= 2.
+func contiguousParts(parts []stitchPart) bool {
+	if len(parts) < 2 {
+		return false
+	}
+	sort.SliceStable(parts, func(i, j int) bool { return parts[i].num < parts[j].num })
+	for i, p := range parts {
+		if p.num != i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// buildStitchedContent concatenates the parts into a single Content whose
+// NestedSources chain covers every part in order.
+func buildStitchedContent(ctx context.Context, base string, parts []stitchPart, probe TimelineProbe) (Content, bool) {
+	first := parts[0].content
+	firstPath := contentPath(first)
+	ext := path.Ext(firstPath)
+
+	var (
+		sources   []NestedSource
+		totalSize int64
+	)
+	for _, p := range parts {
+		sources = append(sources, partNestedSources(p.content)...)
+		totalSize += p.content.Size
+	}
+
+	var clipBoundaries []ClipBoundary
+	if stitchTSExtensions[strings.ToLower(ext)] && probe != nil {
+		clipBoundaries = stitchedTimeline(ctx, parts, probe)
+	}
+
+	if base == "" {
+		base = "movie"
+	}
+	filename := base + ext
+	internalPath := path.Join(path.Dir(strings.ReplaceAll(firstPath, "\\", "/")), filename)
+
+	slog.InfoContext(ctx, "Stitched multi-part movie into one virtual file",
+		"file", internalPath,
+		"parts", len(parts),
+		"size_bytes", totalSize,
+		"timeline_continuity", len(clipBoundaries) > 0)
+
+	return Content{
+		InternalPath:   internalPath,
+		Filename:       filename,
+		Size:           totalSize,
+		PackedSize:     totalSize,
+		NzbdavID:       first.NzbdavID,
+		NestedSources:  sources,
+		ClipBoundaries: clipBoundaries,
+	}, true
+}
+
+// stitchedTimeline builds the per-part timeline table for MPEG-TS parts:
+// part 0 keeps its native timeline and every later part is lifted to start
+// one frame after the previous part ends. Returns nil when any part cannot
+// be probed or the parts disagree on packet size.
+func stitchedTimeline(ctx context.Context, parts []stitchPart, probe TimelineProbe) []ClipBoundary {
+	var (
+		boundaries []ClipBoundary
+		timeline   int64
+		packetSize int
+	)
+	for i, p := range parts {
+		tl, err := probe(ctx, p.content)
+		if err != nil {
+			slog.WarnContext(ctx, "Stitching MPEG-TS parts without timeline continuity",
+				"file", p.content.Filename, "error", err)
+			return nil
+		}
+		if i == 0 {
+			timeline = tl.FirstPTS
+			packetSize = tl.PacketSize
+		} else if tl.PacketSize != packetSize {
+			slog.WarnContext(ctx, "Stitching MPEG-TS parts without timeline continuity",
+				"file", p.content.Filename, "error", "packet size differs between parts")
+			return nil
+		}
+		boundaries = append(boundaries, ClipBoundary{
+			ByteLen:    p.content.Size,
+			Delta90k:   timeline - tl.FirstPTS,
+			PacketSize: tl.PacketSize,
+		})
+		timeline += ptsSpan(tl.FirstPTS, tl.LastPTS) + partGap90k
+	}
+	return boundaries
+}
+
+// partNestedSources returns the sources that yield c's bytes: its own
+// NestedSources, or a single source over its segments.
+func partNestedSources(c Content) []NestedSource {
+	if len(c.NestedSources) > 0 {
+		return c.NestedSources
+	}
+	return []NestedSource{{
+		Segments:        c.Segments,
+		AesKey:          c.AesKey,
+		AesIV:           c.AesIV,
+		InnerLength:     c.Size,
+		InnerVolumeSize: c.Size,
+	}}
+}
+
+// contentPath is the archive-relative path of c, falling back to Filename
+// for contents built from bare NZB files.
+func contentPath(c Content) string {
+	if c.InternalPath != "" {
+		return c.InternalPath
+	}
+	return c.Filename
+}
+
+// NewTSTimelineProbe returns a TimelineProbe that reads the head and tail of
+// a part over NNTP. Parts backed by nested sources are not probed.
+func NewTSTimelineProbe(poolManager pool.Manager, maxPrefetch int, readTimeout time.Duration) TimelineProbe {
+	return func(ctx context.Context, c Content) (PartTimeline, error) {
+		if len(c.NestedSources) > 0 {
+			return PartTimeline{}, errors.New("timeline probe does not support nested sources")
+		}
+		fsys := filesystem.NewDecryptingFileSystem(ctx, poolManager, []filesystem.DecryptingFileEntry{{
+			Filename:      c.Filename,
+			Segments:      c.Segments,
+			DecryptedSize: c.Size,
+			AesKey:        c.AesKey,
+			AesIV:         c.AesIV,
+		}}, maxPrefetch, readTimeout)
+		f, err := fsys.Open(c.Filename)
+		if err != nil {
+			return PartTimeline{}, err
+		}
+		defer f.Close()
+
+		rs, ok := f.(io.ReadSeeker)
+		if !ok {
+			return PartTimeline{}, errors.New("part does not support seeking")
+		}
+
+		window := min(int64(tsProbeWindow), c.Size)
+		head := make([]byte, window)
+		if _, err := io.ReadFull(rs, head); err != nil {
+			return PartTimeline{}, fmt.Errorf("reading head: %w", err)
+		}
+		tailStart := c.Size - window
+		if _, err := rs.Seek(tailStart, io.SeekStart); err != nil {
+			return PartTimeline{}, fmt.Errorf("seeking to tail: %w", err)
+		}
+		tail := make([]byte, window)
+		if _, err := io.ReadFull(rs, tail); err != nil {
+			return PartTimeline{}, fmt.Errorf("reading tail: %w", err)
+		}
+
+		return scanTSTimeline(head, tail, tailStart)
+	}
+}
+
+// scanTSTimeline detects the packet size from head (which starts at the
+// part's first byte) and returns the lowest PTS in head and the highest PTS
+// in tail. tailStart is the part offset of tail's first byte, used to find
+// the packet grid inside it.
+func scanTSTimeline(head, tail []byte, tailStart int64) (PartTimeline, error) {
+	packetSize := detectTSPacketSize(head)
+	if packetSize == 0 {
+		return PartTimeline{}, errors.New("not an MPEG transport stream")
+	}
+
+	first, ok := ptsExtreme(head, packetSize, false)
+	if !ok {
+		return PartTimeline{}, errors.New("no PTS found at start of part")
+	}
+	skip := 0
+	if r := int(tailStart % int64(packetSize)); r != 0 {
+		skip = packetSize - r
+	}
+	if skip > len(tail) {
+		skip = len(tail)
+	}
+	last, ok := ptsExtreme(tail[skip:], packetSize, true)
+	if !ok {
+		return PartTimeline{}, errors.New("no PTS found at end of part")
+	}
+	return PartTimeline{PacketSize: packetSize, FirstPTS: first, LastPTS: last}, nil
+}
+
+// detectTSPacketSize checks the sync-byte grid at the start of b for BDAV
+// (192) and plain (188) packets. Returns 0 when neither matches.
+func detectTSPacketSize(b []byte) int {
+	for _, size := range []int{192, 188} {
+		off := size - 188
+		if len(b) < off+3*size {
+			continue
+		}
+		if b[off] == 0x47 && b[off+size] == 0x47 && b[off+2*size] == 0x47 {
+			return size
+		}
+	}
+	return 0
+}
+
+// ptsExtreme returns the lowest (or, with highest, the highest) PES PTS
+// carried by the packets in b. b must start on a packet boundary.
+func ptsExtreme(b []byte, packetSize int, highest bool) (int64, bool) {
+	var (
+		best  int64
+		found bool
+	)
+	for off := 0; off+packetSize <= len(b); off += packetSize {
+		pts, ok := packetPTS(b[off+packetSize-188 : off+packetSize])
+		if !ok {
+			continue
+		}
+		if !found || (highest && pts > best) || (!highest && pts < best) {
+			best, found = pts, true
+		}
+	}
+	return best, found
+}
+
+// packetPTS extracts the PTS from the PES header carried by a 188-byte TS
+// packet, if the packet starts a PES with a PTS.
+func packetPTS(ts []byte) (int64, bool) {
+	if ts[0] != 0x47 || ts[1]&0x40 == 0 {
+		return 0, false
+	}
+	afc := (ts[3] >> 4) & 0x03
+	if afc != 0x01 && afc != 0x03 {
+		return 0, false
+	}
+	payload := 4
+	if afc == 0x03 {
+		payload = 5 + int(ts[4])
+	}
+	if payload+14 > len(ts) {
+		return 0, false
+	}
+	p := ts[payload:]
+	if p[0] != 0x00 || p[1] != 0x00 || p[2] != 0x01 || p[6]&0xC0 != 0x80 || p[7]&0x80 == 0 {
+		return 0, false
+	}
+	pts := (int64(p[9]&0x0E) << 29) |
+		(int64(p[10]) << 22) |
+		(int64(p[11]&0xFE) << 14) |
+		(int64(p[12]) << 7) |
+		(int64(p[13]) >> 1)
+	return pts, true
+}
+
+// ptsSpan returns last-first in the 33-bit PTS space, accounting for a wrap.
+func ptsSpan(first, last int64) int64 {
+	const modulus = int64(1) << 33
+	d := (last - first) % modulus
+	if d < 0 {
+		d += modulus
+	}
+	return d
+}
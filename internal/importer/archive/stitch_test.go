@@ -0,0 +1,199 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+)
+
+func TestMultiPartKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path     string
+		wantBase string
+		wantNum  int
+		wantOK   bool
+	}{
+		{"Movie.1999.DVDRip.CD1.mpg", "Movie.1999.DVDRip", 1, true},
+		{"Movie.1999.DVDRip-cd2.mpg", "Movie.1999.DVDRip", 2, true},
+		{"Movie (Part 2).ts", "Movie", 2, true},
+		{"Movie.pt1of2.m2ts", "Movie", 1, true},
+		{"VIDEO_TS/VTS_01_3.VOB", "VTS_01", 3, true},
+		{"VIDEO_TS/VTS_01_0.VOB", "", 0, false}, // title-set menu
+		{"Movie.1999.CD1.avi", "Movie.1999", 1, true},
+		{"Movie.1999.CD1.mkv", "", 0, false},    // indexed container
+		{"Show.S01E01.Part.1.ts", "", 0, false}, // TV episode
+		{"Movie.1999.mpg", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			_, base, num, ok := multiPartKey(tt.path)
+			if ok != tt.wantOK || base != tt.wantBase || num != tt.wantNum {
+				t.Errorf("multiPartKey(%q) = (%q, %d, %t); want (%q, %d, %t)",
+					tt.path, base, num, ok, tt.wantBase, tt.wantNum, tt.wantOK)
+			}
+		})
+	}
+}
+
+func stitchTestContent(path string, size int64) Content {
+	return Content{
+		InternalPath: path,
+		Filename:     path,
+		Size:         size,
+		PackedSize:   size,
+		Segments:     []*metapb.SegmentData{{Id: path, SegmentSize: size, StartOffset: 0, EndOffset: size - 1}},
+	}
+}
+
+func TestStitchMultiPartContents_ProgramStream(t *testing.T) {
+	t.Parallel()
+
+	in := []Content{
+		stitchTestContent("Movie/Movie.1999.CD2.mpg", 200),
+		stitchTestContent("Movie/Movie.1999.CD1.mpg", 100),
+		stitchTestContent("Movie/Movie.1999.nfo", 1),
+	}
+	out := StitchMultiPartContents(context.Background(), true, in, nil)
+	if len(out) != len(in)+1 {
+		t.Fatalf("got %d contents, want %d", len(out), len(in)+1)
+	}
+
+	got := out[len(in)]
+	if got.InternalPath != "Movie/Movie.1999.mpg" || got.Filename != "Movie.1999.mpg" {
+		t.Errorf("stitched path = %q (%q)", got.InternalPath, got.Filename)
+	}
+	if got.Size != 300 || len(got.NestedSources) != 2 {
+		t.Fatalf("stitched size %d with %d sources, want 300 with 2", got.Size, len(got.NestedSources))
+	}
+	if got.NestedSources[0].InnerLength != 100 || got.NestedSources[1].InnerLength != 200 {
+		t.Errorf("parts not concatenated in order: %+v", got.NestedSources)
+	}
+	if len(got.ClipBoundaries) != 0 {
+		t.Errorf("program streams must not carry a timeline table")
+	}
+}
+
+func TestStitchMultiPartContents_ConcatenatesAVI(t *testing.T) {
+	t.Parallel()
+
+	in := []Content{
+		stitchTestContent("Movie.1999.XviD.CD1.avi", 100),
+		stitchTestContent("Movie.1999.XviD.CD2.avi", 150),
+		stitchTestContent("Other.1999.CD1.mkv", 100),
+		stitchTestContent("Other.1999.CD2.mkv", 100),
+	}
+	out := StitchMultiPartContents(context.Background(), true, in, nil)
+	if len(out) != len(in)+1 {
+		t.Fatalf("got %d contents, want %d (AVI stitched, MKV left alone)", len(out), len(in)+1)
+	}
+	got := out[len(in)]
+	if got.Filename != "Movie.1999.XviD.avi" || got.Size != 250 || len(got.NestedSources) != 2 {
+		t.Errorf("stitched %q of %d bytes from %d sources, want Movie.1999.XviD.avi of 250 from 2",
+			got.Filename, got.Size, len(got.NestedSources))
+	}
+}
+
+func TestStitchMultiPartContents_SkipsIncompleteGroups(t *testing.T) {
+	t.Parallel()
+
+	compressed := stitchTestContent("Other.CD2.mpg", 100)
+	compressed.PackedSize = 60
+
+	in := []Content{
+		stitchTestContent("Movie.CD1.mpg", 100),
+		stitchTestContent("Movie.CD3.mpg", 100), // CD2 missing
+		stitchTestContent("Other.CD1.mpg", 100),
+		compressed, // not byte-addressable
+		stitchTestContent("Single.CD1.ts", 100),
+	}
+	if out := StitchMultiPartContents(context.Background(), true, in, nil); len(out) != len(in) {
+		t.Errorf("expected no stitched content, got %d extra", len(out)-len(in))
+	}
+
+	pair := []Content{stitchTestContent("A.CD1.mpg", 1), stitchTestContent("A.CD2.mpg", 1)}
+	if out := StitchMultiPartContents(context.Background(), false, pair, nil); len(out) != len(pair) {
+		t.Errorf("disabled stitching must return contents unchanged")
+	}
+}
+
+func TestStitchMultiPartContents_TransportStreamTimeline(t *testing.T) {
+	t.Parallel()
+
+	timelines := map[string]PartTimeline{
+		"Movie.part1.ts": {PacketSize: 188, FirstPTS: 90000, LastPTS: 90000 + 600*90000},
+		"Movie.part2.ts": {PacketSize: 188, FirstPTS: 126000, LastPTS: 126000 + 300*90000},
+	}
+	probe := func(_ context.Context, c Content) (PartTimeline, error) {
+		return timelines[c.Filename], nil
+	}
+
+	in := []Content{stitchTestContent("Movie.part1.ts", 188*10), stitchTestContent("Movie.part2.ts", 188*5)}
+	out := StitchMultiPartContents(context.Background(), true, in, probe)
+	if len(out) != 3 {
+		t.Fatalf("got %d contents, want 3", len(out))
+	}
+
+	cb := out[2].ClipBoundaries
+	if len(cb) != 2 {
+		t.Fatalf("got %d clip boundaries, want 2", len(cb))
+	}
+	if cb[0].Delta90k != 0 || cb[0].ByteLen != 188*10 || cb[0].PacketSize != 188 {
+		t.Errorf("part 1 boundary = %+v", cb[0])
+	}
+	wantStart := int64(90000 + 600*90000 + partGap90k)
+	if cb[1].Delta90k != wantStart-126000 {
+		t.Errorf("part 2 delta = %d, want %d", cb[1].Delta90k, wantStart-126000)
+	}
+
+	failing := func(context.Context, Content) (PartTimeline, error) { return PartTimeline{}, errors.New("boom") }
+	out = StitchMultiPartContents(context.Background(), true, in, failing)
+	if len(out) != 3 || len(out[2].ClipBoundaries) != 0 {
+		t.Errorf("probe failure should stitch without a timeline table")
+	}
+}
+
+// tsPESPacket builds a 188-byte TS packet starting a PES with the given PTS.
+func tsPESPacket(pts int64) []byte {
+	p := make([]byte, 188)
+	p[0], p[1], p[2], p[3] = 0x47, 0x41, 0x00, 0x10
+	pl := p[4:]
+	pl[2] = 0x01
+	pl[3] = 0xE0
+	pl[6] = 0x80
+	pl[7] = 0x80
+	pl[8] = 0x05
+	pl[9] = 0x21 | byte((pts>>29)&0x0E)
+	pl[10] = byte(pts >> 22)
+	pl[11] = 0x01 | byte((pts>>14)&0xFE)
+	pl[12] = byte(pts >> 7)
+	pl[13] = 0x01 | byte((pts<<1)&0xFE)
+	return p
+}
+
+func TestScanTSTimeline(t *testing.T) {
+	t.Parallel()
+
+	var stream []byte
+	for _, pts := range []int64{1000, 900, 4000, 3000, 8000, 7000} {
+		stream = append(stream, tsPESPacket(pts)...)
+	}
+
+	// Tail starts mid-packet; the grid is recovered from its file offset.
+	tailStart := int64(2*188 + 50)
+	tl, err := scanTSTimeline(stream, stream[tailStart:], tailStart)
+	if err != nil {
+		t.Fatalf("scanTSTimeline: %v", err)
+	}
+	if tl.PacketSize != 188 || tl.FirstPTS != 900 || tl.LastPTS != 8000 {
+		t.Errorf("timeline = %+v, want packet 188, first 900, last 8000", tl)
+	}
+
+	if _, err := scanTSTimeline(make([]byte, 1024), nil, 0); err == nil {
+		t.Error("expected error for non-TS data")
+	}
+}
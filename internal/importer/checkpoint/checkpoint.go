@@ -0,0 +1,211 @@
+// Package checkpoint persists the expensive intermediate results of an NZB
+// import — the parsed NZB with the sizes read from every file's first-segment
+// yEnc headers, and the listing of each archive set — keyed by queue item, so
+// an import interrupted by a crash, a restart or a retry resumes from the last
+// completed stage instead of re-reading thousands of segment headers.
+// Checkpoints carry a fingerprint of the NZB and are ignored once it changes.
+//
+// Checkpoints are an optimization: every failure to save or load one is
+// logged and the stage simply runs again.
+package checkpoint
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/importer/archive"
+	"github.com/javi11/altmount/internal/importer/parser"
+	"github.com/javi11/altmount/internal/importer/report"
+	"github.com/javi11/nzbparser"
+)
+
+// StageParsed is the checkpoint of the parsed NZB.
+const StageParsed = "parsed"
+
+// ArchiveStage names the checkpoint of one archive set's listing, keyed by
+// the archive format and the set's first volume.
+func ArchiveStage(format, firstVolume string) string {
+	return "archive:" + format + ":" + firstVolume
+}
+
+// Store persists checkpoints. *database.QueueRepository implements it.
+type Store interface {
+	SaveImportCheckpoint(ctx context.Context, queueID int64, stage, fingerprint string, data []byte) error
+	GetImportCheckpoint(ctx context.Context, queueID int64, stage string) (*database.ImportCheckpoint, error)
+}
+
+// Checkpoints saves and loads the stage checkpoints of one import, recording
+// every stage it restores in the import report carried by the context. All
+// methods are safe on a nil *Checkpoints, which never saves and never finds a
+// checkpoint, so imports without a queue item (metadata regeneration, tests)
+// need no checks.
+type Checkpoints struct {
+	store       Store
+	queueID     int64
+	fingerprint string
+}
+
+// New returns the checkpoints of queue item queueID for the NZB with the
+// given fingerprint, or nil when there is no store or no queue item.
+func New(store Store, queueID int64, fingerprint string) *Checkpoints {
+	if store == nil || queueID <= 0 {
+		return nil
+	}
+	return &Checkpoints{store: store, queueID: queueID, fingerprint: fingerprint}
+}
+
+// Fingerprint identifies an NZB by its files and segments, so a checkpoint
+// is never applied to a different NZB queued under a reused item.
+func Fingerprint(n *nzbparser.Nzb) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", n.Meta["password"])
+	for _, f := range n.Files {
+		fmt.Fprintf(h, "%s\x00%d\x00", f.Subject, len(f.Segments))
+		for _, s := range f.Segments {
+			fmt.Fprintf(h, "%s\x00%d\x00%d\x00", s.ID, s.Number, s.Bytes)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// parsedSnapshot is the persisted form of a parser.ParsedNzb. The store and
+// segment index are rebuilt from the NZB (parser.BuildStore needs no
+// network). Files keep their FirstSegmentBytes (at most 16 KB each): release
+// name recovery reads the first inner name of an obfuscated archive from
+// them.
+type parsedSnapshot struct {
+	Type          parser.NzbType             `json:"type"`
+	TotalSize     int64                      `json:"total_size"`
+	SegmentsCount int                        `json:"segments_count"`
+	Password      string                     `json:"password,omitempty"`
+	Title         string                     `json:"title,omitempty"`
+	Files         []parser.ParsedFile        `json:"files"`
+	Par2Files     []parser.ExtractedFileInfo `json:"par2_files,omitempty"`
+}
+
+// SaveParsed checkpoints the parsed NZB.
+func (c *Checkpoints) SaveParsed(ctx context.Context, p *parser.ParsedNzb) {
+	if c == nil || p == nil {
+		return
+	}
+	c.save(ctx, StageParsed, parsedSnapshot{
+		Type:          p.Type,
+		TotalSize:     p.TotalSize,
+		SegmentsCount: p.SegmentsCount,
+		Password:      p.GetPassword(),
+		Title:         p.Title,
+		Files:         p.Files,
+		Par2Files:     p.Par2Files,
+	})
+}
+
+// LoadParsed restores the parsed NZB checkpointed for n, read from nzbPath.
+func (c *Checkpoints) LoadParsed(ctx context.Context, n *nzbparser.Nzb, nzbPath string) (*parser.ParsedNzb, bool) {
+	var snap parsedSnapshot
+	if !c.load(ctx, StageParsed, &snap) {
+		return nil, false
+	}
+	p := &parser.ParsedNzb{
+		Path:          nzbPath,
+		Filename:      filepath.Base(nzbPath),
+		TotalSize:     snap.TotalSize,
+		Type:          snap.Type,
+		Files:         snap.Files,
+		SegmentsCount: snap.SegmentsCount,
+		Title:         snap.Title,
+		Par2Files:     snap.Par2Files,
+	}
+	p.SetPassword(snap.Password)
+	p.Store, p.SegmentIndex = parser.BuildStore(n)
+	return p, true
+}
+
+// SaveContents checkpoints the listing of an archive set.
+func (c *Checkpoints) SaveContents(ctx context.Context, stage string, contents []archive.Content) {
+	if c == nil {
+		return
+	}
+	c.save(ctx, stage, contents)
+}
+
+// LoadContents restores the checkpointed listing of an archive set.
+func (c *Checkpoints) LoadContents(ctx context.Context, stage string) ([]archive.Content, bool) {
+	var contents []archive.Content
+	if !c.load(ctx, stage, &contents) {
+		return nil, false
+	}
+	return contents, true
+}
+
+func (c *Checkpoints) save(ctx context.Context, stage string, v any) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	err := json.NewEncoder(zw).Encode(v)
+	if err == nil {
+		err = zw.Close()
+	}
+	if err == nil {
+		err = c.store.SaveImportCheckpoint(ctx, c.queueID, stage, c.fingerprint, buf.Bytes())
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to save import checkpoint", "queue_id", c.queueID, "stage", stage, "error", err)
+	}
+}
+
+func (c *Checkpoints) load(ctx context.Context, stage string, v any) bool {
+	if c == nil {
+		return false
+	}
+	cp, err := c.store.GetImportCheckpoint(ctx, c.queueID, stage)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to load import checkpoint", "queue_id", c.queueID, "stage", stage, "error", err)
+		return false
+	}
+	if cp == nil || cp.Fingerprint != c.fingerprint {
+		return false
+	}
+	if err := decode(cp.Data, v); err != nil {
+		slog.WarnContext(ctx, "Ignoring unreadable import checkpoint", "queue_id", c.queueID, "stage", stage, "error", err)
+		return false
+	}
+	report.FromContext(ctx).Resumed(stage)
+	return true
+}
+
+func decode(data []byte, v any) error {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("decompress checkpoint: %w", err)
+	}
+	defer zr.Close()
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("decompress checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("decode checkpoint: %w", err)
+	}
+	return nil
+}
+
+type checkpointsKey struct{}
+
+// WithCheckpoints returns a context carrying c.
+func WithCheckpoints(ctx context.Context, c *Checkpoints) context.Context {
+	return context.WithValue(ctx, checkpointsKey{}, c)
+}
+
+// FromContext returns the Checkpoints carried by ctx, or nil.
+func FromContext(ctx context.Context) *Checkpoints {
+	c, _ := ctx.Value(checkpointsKey{}).(*Checkpoints)
+	return c
+}
@@ -0,0 +1,107 @@
+package checkpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/importer/archive"
+	"github.com/javi11/altmount/internal/importer/parser"
+	"github.com/javi11/altmount/internal/importer/report"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/javi11/altmount/internal/testsupport/nzbbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore keeps checkpoints in memory, keyed by queue item and stage.
+type memStore map[string]database.ImportCheckpoint
+
+func (m memStore) SaveImportCheckpoint(_ context.Context, queueID int64, stage, fingerprint string, data []byte) error {
+	m[stage] = database.ImportCheckpoint{QueueID: queueID, Stage: stage, Fingerprint: fingerprint, Data: data}
+	return nil
+}
+
+func (m memStore) GetImportCheckpoint(_ context.Context, _ int64, stage string) (*database.ImportCheckpoint, error) {
+	cp, ok := m[stage]
+	if !ok {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+func TestParsed_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	n := nzbbuild.Build(nzbbuild.File{
+		Subject:  `"movie.mkv" yEnc (1/2)`,
+		Segments: []nzbbuild.Segment{{ID: "a@x", Bytes: 700000}, {ID: "b@x", Bytes: 500000}},
+	})
+	store, index := parser.BuildStore(n)
+	parsed := &parser.ParsedNzb{
+		Path:          "/queue/movie.nzb",
+		Filename:      "movie.nzb",
+		Type:          parser.NzbTypeSingleFile,
+		TotalSize:     1100000,
+		SegmentsCount: 2,
+		Title:         "Movie",
+		Files: []parser.ParsedFile{{
+			Filename:          "movie.mkv",
+			Size:              1100000,
+			Segments:          []*metapb.SegmentData{{Id: "a@x", StartOffset: 0, EndOffset: 649999, SegmentSize: 650000}},
+			FirstSegmentBytes: []byte("Rar!\x1a\x07\x01\x00"),
+		}},
+		Store:        store,
+		SegmentIndex: index,
+	}
+	parsed.SetPassword("secret")
+
+	mem := memStore{}
+	New(mem, 7, Fingerprint(n)).SaveParsed(ctx, parsed)
+	require.Contains(t, mem, StageParsed)
+
+	rec := report.NewRecorder(7, "/queue/movie.nzb")
+	ctx = report.WithRecorder(ctx, rec)
+	got, ok := New(mem, 7, Fingerprint(n)).LoadParsed(ctx, n, "/queue/movie.nzb")
+	require.True(t, ok)
+	assert.Equal(t, parsed.Type, got.Type)
+	assert.Equal(t, parsed.TotalSize, got.TotalSize)
+	assert.Equal(t, "Movie", got.Title)
+	assert.Equal(t, "secret", got.GetPassword())
+	require.Len(t, got.Files, 1)
+	assert.Equal(t, "movie.mkv", got.Files[0].Filename)
+	assert.Equal(t, int64(649999), got.Files[0].Segments[0].EndOffset)
+	assert.Equal(t, []byte("Rar!\x1a\x07\x01\x00"), got.Files[0].FirstSegmentBytes, "first-segment bytes survive for release name recovery")
+	assert.Len(t, got.Store.Files, 1, "store rebuilt from the NZB")
+	assert.Equal(t, index, got.SegmentIndex)
+	assert.Equal(t, []string{StageParsed}, rec.Finish(nil).ResumedStages)
+}
+
+func TestLoad_IgnoresOtherNzb(t *testing.T) {
+	ctx := context.Background()
+	original := nzbbuild.Build(nzbbuild.File{Subject: "a.mkv", Segments: []nzbbuild.Segment{{ID: "a@x", Bytes: 100}}})
+	repost := nzbbuild.Build(nzbbuild.File{Subject: "a.mkv", Segments: []nzbbuild.Segment{{ID: "b@x", Bytes: 100}}})
+	require.NotEqual(t, Fingerprint(original), Fingerprint(repost))
+
+	mem := memStore{}
+	stage := ArchiveStage("rar", "a.part01.rar")
+	New(mem, 1, Fingerprint(original)).SaveContents(ctx, stage, []archive.Content{{Filename: "a.mkv", Size: 100}})
+
+	_, ok := New(mem, 1, Fingerprint(repost)).LoadContents(ctx, stage)
+	assert.False(t, ok, "a checkpoint of another NZB is ignored")
+
+	contents, ok := New(mem, 1, Fingerprint(original)).LoadContents(ctx, stage)
+	require.True(t, ok)
+	assert.Equal(t, []archive.Content{{Filename: "a.mkv", Size: 100}}, contents)
+}
+
+func TestNil_NeverCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, New(memStore{}, 0, "fp"), "no queue item")
+	assert.Nil(t, New(nil, 1, "fp"), "no store")
+
+	var c *Checkpoints
+	c.SaveContents(ctx, "stage", nil)
+	_, ok := c.LoadContents(ctx, "stage")
+	assert.False(t, ok)
+	assert.Nil(t, FromContext(ctx))
+}
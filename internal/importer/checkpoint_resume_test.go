@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/importer/checkpoint"
+	"github.com/javi11/altmount/internal/importer/report"
+	"github.com/javi11/altmount/internal/testsupport/nzbbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memCheckpointStore keeps import checkpoints in memory, keyed by stage.
+type memCheckpointStore map[string]database.ImportCheckpoint
+
+func (m memCheckpointStore) SaveImportCheckpoint(_ context.Context, queueID int64, stage, fingerprint string, data []byte) error {
+	m[stage] = database.ImportCheckpoint{QueueID: queueID, Stage: stage, Fingerprint: fingerprint, Data: data}
+	return nil
+}
+
+func (m memCheckpointStore) GetImportCheckpoint(_ context.Context, _ int64, stage string) (*database.ImportCheckpoint, error) {
+	cp, ok := m[stage]
+	if !ok {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+// TestProcessNzbFile_ResumesFromParsedCheckpoint verifies that a retry of a
+// queue item skips fast-fail and parsing when the first attempt checkpointed
+// the parsed NZB, and still writes the same metadata.
+func TestProcessNzbFile_ResumesFromParsedCheckpoint(t *testing.T) {
+	env := newBatteryEnv(t)
+	store := memCheckpointStore{}
+	env.proc.SetCheckpointStore(store)
+
+	content := bytes.Repeat([]byte("A"), 30_000)
+	segs := env.registerContent("resume", content, 10_000, 1.0, nil)
+	nzb := nzbbuild.Build(nzbbuild.File{Subject: "Movie.2024.mkv", Segments: segs})
+
+	run := func() (*report.Report, []string) {
+		nzbPath := nzbbuild.WriteTemp(t, nzb, "Movie.2024.mkv")
+		rec := report.NewRecorder(5, nzbPath)
+		ctx := report.WithRecorder(context.Background(), rec)
+		_, written, err := env.proc.ProcessNzbFile(ctx, nzbPath, filepath.Dir(nzbPath), 5, nil, nil, nil, nil, nil, nil)
+		require.NoError(t, err)
+		return rec.Finish(nil), filePaths(written)
+	}
+
+	first, _ := run()
+	assert.Empty(t, first.ResumedStages)
+	require.Contains(t, store, checkpoint.StageParsed)
+
+	env.client.ResetCounters()
+	second, written := run()
+	assert.Equal(t, []string{checkpoint.StageParsed}, second.ResumedStages)
+	assert.Nil(t, second.FastFail, "fast-fail skipped on resume")
+	assert.Zero(t, env.client.PerMessageCalls(segs[0].ID), "first segment not fetched again")
+
+	require.Len(t, written, 1)
+	meta := env.readMeta(written[0])
+	assert.Equal(t, int64(len(content)), meta.FileSize)
+	assert.Len(t, meta.SegmentData, 3)
+}
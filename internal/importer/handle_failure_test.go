@@ -58,6 +58,8 @@ func newMoveToFailedTestService(t *testing.T) *Service {
 			skip_arr_notification BOOLEAN NOT NULL DEFAULT FALSE,
 			skip_post_import_links BOOLEAN NOT NULL DEFAULT FALSE,
 			indexer TEXT DEFAULT NULL,
+			hold_until DATETIME DEFAULT NULL,
+			deprioritized BOOLEAN NOT NULL DEFAULT FALSE,
 			UNIQUE(nzb_path)
 		);
 		CREATE INDEX IF NOT EXISTS idx_queue_nzb_path ON import_queue(nzb_path);
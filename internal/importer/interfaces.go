@@ -2,6 +2,7 @@ package importer
 
 import (
 	"context"
+	"time"
 
 	"github.com/javi11/altmount/internal/database"
 )
@@ -78,3 +79,9 @@ type HistoryRecorder interface {
 	// AddImportHistory records a successful file import
 	AddImportHistory(ctx context.Context, history *database.ImportHistory) error
 }
+
+// SamplingObserver is notified when an item's fast-fail sampling was slow
+type SamplingObserver interface {
+	// OnSlowSampling reports that sampling for queueID took elapsed, above the configured threshold
+	OnSlowSampling(ctx context.Context, queueID int, elapsed time.Duration)
+}
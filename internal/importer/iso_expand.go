@@ -116,9 +116,18 @@ func expandBareISOFiles(
 		pl.Go(func(ctx context.Context) error {
 			meta := archive.NewFileMetadataFromContent(c, sourceNzbPath, releaseDate, c.NzbdavID)
 			virtualPath := path.Join(virtualDir, c.Filename)
+			if c.ExtraPlaylist != "" {
+				virtualPath = path.Join(virtualDir, c.InternalPath)
+			}
 			if err := deps.writeMetadata(virtualPath, meta); err != nil {
 				return fmt.Errorf("write metadata %q: %w", virtualPath, err)
 			}
+			if sidecar := archive.NewChapterSidecarMetadata(c.ChapterTicks, sourceNzbPath, releaseDate); sidecar != nil {
+				sidecarPath := archive.ChapterSidecarPath(virtualPath)
+				if err := deps.writeMetadata(sidecarPath, sidecar); err != nil {
+					slog.WarnContext(ctx, "Failed to write chapter sidecar", "virtual_path", sidecarPath, "error", err)
+				}
+			}
 			writtenMu.Lock()
 			written = append(written, virtualPath)
 			writtenMu.Unlock()
@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/javi11/altmount/internal/importer/archive"
+	"github.com/javi11/altmount/internal/importer/filesystem"
+	"github.com/javi11/altmount/internal/importer/parser"
+	"github.com/javi11/altmount/internal/importer/validation"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+)
+
+// stitchRegularFiles writes one concatenated virtual file for every
+// multi-part movie among regularFiles (CD1/CD2, part1/part2, VOB title sets;
+// see archive.StitchMultiPartContents) next to its parts under baseDir, and
+// one main-title file for every VIDEO_TS folder (see
+// archive.ExpandDVDFolders). The parts themselves are written by the normal
+// multi-file path. Failures are logged and skipped: the parts stay playable
+// either way.
+func (proc *Processor) stitchRegularFiles(
+	ctx context.Context,
+	baseDir string,
+	regularFiles []parser.ParsedFile,
+	nzbPath string,
+	storeIndex map[string]int64,
+	storeRef string,
+) []string {
+	importCfg := proc.configGetter().Import
+	stitch := true
+	if importCfg.StitchMultiPart != nil {
+		stitch = *importCfg.StitchMultiPart
+	}
+	expandDVD := true
+	if importCfg.ExpandBlurayIso != nil {
+		expandDVD = *importCfg.ExpandBlurayIso
+	}
+	if !stitch && !expandDVD {
+		return nil
+	}
+
+	var in []archive.Content
+	for _, f := range regularFiles {
+		// Rclone-encrypted files cannot be addressed as raw byte ranges.
+		if f.Encryption != metapb.Encryption_NONE {
+			continue
+		}
+		if err := validation.ValidateSegmentsForFile(f.Filename, f.Size, f.Segments, f.Encryption); err != nil {
+			continue
+		}
+		// Bare files map to archive.Content the same way bare ISOs do.
+		c := parsedFileToISOContent(f)
+		c.InternalPath = strings.ReplaceAll(f.Filename, "\\", "/")
+		in = append(in, c)
+	}
+	if len(in) < 2 {
+		return nil
+	}
+
+	readTimeout := time.Duration(importCfg.ReadTimeoutSeconds) * time.Second
+	if readTimeout == 0 {
+		readTimeout = 5 * time.Minute
+	}
+	out := archive.ExpandDVDFolders(ctx, expandDVD, in,
+		archive.NewDVDFileReader(proc.poolManager, importCfg.MaxDownloadPrefetch, readTimeout))
+	out = archive.StitchMultiPartContents(ctx, stitch, out,
+		archive.NewTSTimelineProbe(proc.poolManager, importCfg.MaxDownloadPrefetch, readTimeout))
+
+	var written []string
+	for _, c := range out[len(in):] {
+		// DVD main titles sit at the release root; stitched parts go next
+		// to their siblings.
+		parentPath := baseDir
+		for _, f := range regularFiles {
+			if c.DVDTitleSet > 0 {
+				break
+			}
+			if path.Dir(strings.ReplaceAll(f.Filename, "\\", "/")) == path.Dir(c.InternalPath) {
+				parentPath, _ = filesystem.DetermineFileLocation(f, baseDir)
+				break
+			}
+		}
+		virtualPath := path.Join(parentPath, c.Filename)
+
+		meta := archive.NewFileMetadataFromContent(c, nzbPath, regularFiles[0].ReleaseDate.Unix(), c.NzbdavID)
+		if err := proc.metadataService.WriteFileMetadataAuto(ctx, virtualPath, meta, storeIndex, storeRef); err != nil {
+			proc.log.WarnContext(ctx, "Failed to write stitched multi-part file",
+				"virtual_path", virtualPath, "error", err)
+			continue
+		}
+		written = append(written, virtualPath)
+	}
+	return written
+}
@@ -142,6 +142,10 @@ func (p *Parser) ParseNzb(ctx context.Context, n *nzbparser.Nzb, nzbPath string,
 		if pwd, ok := n.Meta["password"]; ok && pwd != "" {
 			parsed.SetPassword(pwd)
 		}
+		parsed.Title = strings.TrimSpace(n.Meta["title"])
+		if parsed.Title == "" {
+			parsed.Title = strings.TrimSpace(n.Meta["name"])
+		}
 	}
 
 	// Fetch first segment data for all files in parallel
@@ -246,6 +250,9 @@ func (p *Parser) ParseNzb(ctx context.Context, n *nzbparser.Nzb, nzbPath string,
 		}
 		p.log.WarnContext(ctx, "Failed to extract PAR2 file descriptors", "error", par2Err)
 	}
+	for _, desc := range par2Descriptors {
+		parsed.Par2Files = append(parsed.Par2Files, ExtractedFileInfo{Name: desc.Name, Size: int64(desc.Length)})
+	}
 
 	// For files whose first segment we intentionally skipped, fill in the first-segment
 	// decoded size from the NZB-wide representative middle-segment PartSize. In a uniform
@@ -45,8 +45,10 @@ type ParsedNzb struct {
 	SegmentsCount  int
 	password       string // Private field - use GetPassword() to access
 	ExtractedFiles []ExtractedFileInfo
-	Store          *metapb.NzbStore // NzbStore for this release (built at parse time)
-	SegmentIndex   map[string]int64 // message-id → flat store index
+	Store          *metapb.NzbStore    // NzbStore for this release (built at parse time)
+	SegmentIndex   map[string]int64    // message-id → flat store index
+	Title          string              // <meta type="title"> (or "name") from the NZB head, if any
+	Par2Files      []ExtractedFileInfo // files named by the PAR2 index, when it was read
 }
 
 // GetPassword returns the password for this NZB
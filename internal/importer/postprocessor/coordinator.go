@@ -1,6 +1,6 @@
 // Package postprocessor handles all post-import processing steps including
 // symlink creation, STRM file generation, VFS notifications, health check
-// scheduling, and ARR notifications.
+// scheduling, ARR notifications and media server refreshes.
 package postprocessor
 
 import (
@@ -15,6 +15,7 @@ import (
 	"github.com/javi11/altmount/internal/config"
 	"github.com/javi11/altmount/internal/database"
 	"github.com/javi11/altmount/internal/errors"
+	"github.com/javi11/altmount/internal/mediaserver"
 	"github.com/javi11/altmount/internal/metadata"
 	"github.com/javi11/altmount/pkg/rclonecli"
 )
@@ -28,6 +29,9 @@ type Coordinator struct {
 	healthRepo      *database.HealthRepository
 	arrsService     *arrs.Service
 	userRepo        *database.UserRepository
+	fileIndex       *database.FileIndexRepository
+	fileOpener      VirtualFileOpener
+	mediaServers    func(*config.Config) []*mediaserver.Server
 	log             *slog.Logger
 }
 
@@ -39,6 +43,9 @@ type Config struct {
 	HealthRepo      *database.HealthRepository
 	ArrsService     *arrs.Service
 	UserRepo        *database.UserRepository
+	// FileIndex, when set, records every symlink and STRM file created so
+	// library sync can find them without walking the library.
+	FileIndex *database.FileIndexRepository
 }
 
 // NewCoordinator creates a new post-processor coordinator
@@ -50,6 +57,8 @@ func NewCoordinator(cfg Config) *Coordinator {
 		healthRepo:      cfg.HealthRepo,
 		arrsService:     cfg.ArrsService,
 		userRepo:        cfg.UserRepo,
+		fileIndex:       cfg.FileIndex,
+		mediaServers:    mediaserver.FromConfig,
 		log:             slog.Default().With("component", "postprocessor"),
 	}
 }
@@ -68,14 +77,23 @@ func (c *Coordinator) SetArrsService(service *arrs.Service) {
 	c.arrsService = service
 }
 
+// SetVirtualFileOpener sets the virtual filesystem used to read files that
+// are materialized into the library (called once the filesystem exists)
+func (c *Coordinator) SetVirtualFileOpener(opener VirtualFileOpener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fileOpener = opener
+}
+
 // ProcessingResult holds the result of post-processing operations
 type ProcessingResult struct {
-	SymlinksCreated bool
-	StrmCreated     bool
-	VFSNotified     bool
-	HealthScheduled bool
-	ARRNotified     bool
-	Errors          []error
+	SymlinksCreated       bool
+	StrmCreated           bool
+	VFSNotified           bool
+	HealthScheduled       bool
+	ARRNotified           bool
+	MediaServersRefreshed bool
+	Errors                []error
 }
 
 // HandleSuccess performs all post-processing for successful imports.
@@ -139,7 +157,10 @@ func (c *Coordinator) HandleSuccess(ctx context.Context, item *database.ImportQu
 		result.HealthScheduled = true
 	}
 
-	// 5. Notify ARR applications
+	// 5. Retire the corrupted file this import replaces (alternate-release repair)
+	c.retireReplacedFile(ctx, item, writtenPaths)
+
+	// 6. Notify ARR applications
 	if shouldSkipARRNotification(item) {
 		c.log.DebugContext(ctx, "ARR notification skipped (requested by caller)",
 			"queue_id", item.ID,
@@ -153,6 +174,9 @@ func (c *Coordinator) HandleSuccess(ctx context.Context, item *database.ImportQu
 		result.ARRNotified = true
 	}
 
+	// 7. Refresh media server libraries
+	result.MediaServersRefreshed = c.RefreshMediaServers(ctx, resultingPath, writtenPaths)
+
 	return result, nil
 }
 
@@ -160,6 +184,10 @@ func (c *Coordinator) HandleSuccess(ctx context.Context, item *database.ImportQu
 func (c *Coordinator) HandleFailure(ctx context.Context, item *database.ImportQueueItem, processingErr error) error {
 	cfg := c.configGetter()
 
+	// An alternate release that failed to import leaves the corrupted file it
+	// was meant to replace in place.
+	c.failReplacement(ctx, item, processingErr)
+
 	// Attempt SABnzbd fallback if configured — the download is transferred to
 	// an external SABnzbd instance so we must NOT notify ARR of a failure here
 	// (the download is still in progress elsewhere).
@@ -10,6 +10,7 @@ import (
 	"github.com/javi11/altmount/internal/config"
 	"github.com/javi11/altmount/internal/database"
 	"github.com/javi11/altmount/internal/importer/parser/fileinfo"
+	"github.com/javi11/altmount/internal/importer/report"
 )
 
 // maxDirExpansionDepth bounds the recursive walk of "DIR:" written-path entries.
@@ -73,6 +74,14 @@ func (c *Coordinator) ScheduleHealthCheck(ctx context.Context, item *database.Im
 		downloadID = item.DownloadID
 	}
 
+	// Per-provider completeness of the import's fast-fail sample, when
+	// per-provider sampling ran. It is a release-wide estimate, so every file
+	// of the import carries the same value.
+	providerCompleteness := database.MarshalProviderCompleteness(report.FromContext(ctx).Providers())
+
+	// Posting of each release (by NZB store), for the article-expiry model.
+	postings := make(map[string]releasePosting)
+
 	var lastErr error
 	repairDirs := make(map[string]struct{})
 	records := make([]database.HealthCheckUpsert, 0, len(paths))
@@ -94,6 +103,11 @@ func (c *Coordinator) ScheduleHealthCheck(ctx context.Context, item *database.Im
 		// the batch outlive the loop and do not retain the proto message.
 		filePath := p
 		srcNzb := fileMeta.SourceNzbPath
+		posting, ok := postings[fileMeta.StoreRef]
+		if !ok && fileMeta.StoreRef != "" {
+			posting = c.readReleasePosting(ctx, fileMeta.StoreRef)
+			postings[fileMeta.StoreRef] = posting
+		}
 		records = append(records, database.HealthCheckUpsert{
 			FilePath:         filePath,
 			LibraryPath:      &filePath,
@@ -103,6 +117,10 @@ func (c *Coordinator) ScheduleHealthCheck(ctx context.Context, item *database.Im
 			MaxRetries:       cfg.GetMaxRetries(),
 			MaxRepairRetries: cfg.GetMaxRepairRetries(),
 			DownloadID:       downloadID,
+
+			ProviderCompleteness: providerCompleteness,
+			Newsgroup:            posting.newsgroup,
+			Poster:               posting.poster,
 		})
 		repairDirs[filepath.Dir(p)] = struct{}{}
 	}
@@ -151,6 +169,47 @@ var arrAudioBookExtensions = map[string]bool{
 	".epub": true, ".pdf": true, ".cbz": true, ".cbr": true, ".mobi": true, ".azw3": true,
 }
 
+// releasePosting is the newsgroup and poster a release was posted with.
+type releasePosting struct {
+	newsgroup *string
+	poster    *string
+}
+
+// readReleasePosting returns the most common newsgroup and poster among the
+// files of an NZB store. Failures only cost the expiry model some detail.
+func (c *Coordinator) readReleasePosting(ctx context.Context, storeRef string) releasePosting {
+	store, err := c.metadataService.Store().ReadStore(storeRef)
+	if err != nil || store == nil {
+		slog.DebugContext(ctx, "Failed to read NZB store for release posting", "store_ref", storeRef, "error", err)
+		return releasePosting{}
+	}
+	groups := make(map[string]int)
+	posters := make(map[string]int)
+	for _, f := range store.Files {
+		if len(f.Groups) > 0 && f.Groups[0] != "" {
+			groups[f.Groups[0]]++
+		}
+		if f.Poster != "" {
+			posters[f.Poster]++
+		}
+	}
+	return releasePosting{newsgroup: mostCommon(groups), poster: mostCommon(posters)}
+}
+
+// mostCommon returns the most frequent key (the smallest on ties), or nil.
+func mostCommon(counts map[string]int) *string {
+	var best string
+	for k, n := range counts {
+		if n > counts[best] || (n == counts[best] && k < best) {
+			best = k
+		}
+	}
+	if best == "" {
+		return nil
+	}
+	return &best
+}
+
 // isArrImportableMedia reports whether the virtual path is a media file an ARR could
 // import into the library — i.e. one that can ever be relinked to a real library path
 // by a Download webhook and so become eligible for health checks under SYMLINK/STRM.
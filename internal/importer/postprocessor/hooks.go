@@ -0,0 +1,373 @@
+package postprocessor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/errors"
+	"github.com/javi11/altmount/internal/httpclient"
+	"github.com/javi11/altmount/internal/importer/report"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+)
+
+// HookEventImportCompleted is the event name sent to post-import hooks.
+const HookEventImportCompleted = "import.completed"
+
+// maxHookResponseSize bounds how much of a hook's stdout / response body is read.
+const maxHookResponseSize = 1 << 20
+
+// HookPayload is the JSON document sent to every post-import hook.
+type HookPayload struct {
+	Event        string     `json:"event"`
+	QueueID      int64      `json:"queue_id"`
+	NzbPath      string     `json:"nzb_path"`
+	Category     string     `json:"category,omitempty"`
+	DownloadID   string     `json:"download_id,omitempty"`
+	Indexer      string     `json:"indexer,omitempty"`
+	ResultPath   string     `json:"result_path"`
+	TargetPath   string     `json:"target_path,omitempty"`
+	VirtualPaths []string   `json:"virtual_paths"`
+	Files        []HookFile `json:"files"`
+	// Health is the worst classification among Files.
+	Health string `json:"health"`
+}
+
+// HookFile describes one virtual file written by the import.
+type HookFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Health string `json:"health"`
+}
+
+// HookResponse is the optional JSON answer of a hook. An empty answer
+// continues the import unchanged.
+type HookResponse struct {
+	// Veto marks the import failed with Reason.
+	Veto   bool   `json:"veto,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// TargetPath overrides the symlink/STRM destination of the import.
+	TargetPath string `json:"target_path,omitempty"`
+	// Paths renames written virtual files, old path to new path.
+	Paths map[string]string `json:"paths,omitempty"`
+}
+
+// HookOutcome is the state of an import after all hooks ran.
+type HookOutcome struct {
+	ResultPath   string
+	WrittenPaths []string
+}
+
+// RunPostImportHooks calls the configured post-import hooks for item in
+// order. Rewrites are applied between hooks so each one sees the previous
+// hook's result; item.TargetPath is updated in place. A veto, or a failing
+// hook with FailImportOnError, returns a non-retryable error and skips the
+// remaining hooks.
+func (c *Coordinator) RunPostImportHooks(ctx context.Context, item *database.ImportQueueItem, resultingPath string, writtenPaths []string) (*HookOutcome, error) {
+	outcome := &HookOutcome{ResultPath: resultingPath, WrittenPaths: writtenPaths}
+
+	category := config.DefaultCategoryName
+	if item.Category != nil && *item.Category != "" {
+		category = *item.Category
+	}
+	hooks := c.configGetter().GetPostImportHooks(category)
+	if len(hooks) == 0 {
+		return outcome, nil
+	}
+
+	rec := report.FromContext(ctx)
+	for i, hook := range hooks {
+		name := hook.Name
+		if name == "" {
+			name = "hook-" + strconv.Itoa(i+1)
+		}
+		payload := c.buildHookPayload(item, category, outcome)
+
+		resp, err := c.callHookWithRetries(ctx, name, hook, payload)
+		if err != nil {
+			rec.Hook(report.Hook{Name: name, Error: err.Error()})
+			if hook.FailImportOnError {
+				return outcome, errors.NewNonRetryableError(fmt.Sprintf("post-import hook %q failed", name), err)
+			}
+			c.log.WarnContext(ctx, "Post-import hook failed, continuing",
+				"queue_id", item.ID,
+				"hook", name,
+				"error", err)
+			continue
+		}
+
+		if resp.Veto {
+			reason := resp.Reason
+			if reason == "" {
+				reason = "no reason given"
+			}
+			rec.Hook(report.Hook{Name: name, Veto: true, Error: reason})
+			c.log.InfoContext(ctx, "Post-import hook vetoed import",
+				"queue_id", item.ID,
+				"hook", name,
+				"reason", reason)
+			return outcome, errors.NewNonRetryableError(fmt.Sprintf("post-import hook %q vetoed import: %s", name, reason), nil)
+		}
+
+		if resp.TargetPath != "" {
+			target := resp.TargetPath
+			item.TargetPath = &target
+			c.log.InfoContext(ctx, "Post-import hook set target path",
+				"queue_id", item.ID,
+				"hook", name,
+				"target_path", target)
+		}
+		renamed := c.applyHookRenames(ctx, item.ID, name, resp.Paths, outcome)
+		rec.Hook(report.Hook{Name: name, TargetPath: resp.TargetPath, Renamed: renamed})
+	}
+
+	return outcome, nil
+}
+
+// buildHookPayload describes the import's current state for a hook.
+func (c *Coordinator) buildHookPayload(item *database.ImportQueueItem, category string, outcome *HookOutcome) HookPayload {
+	payload := HookPayload{
+		Event:      HookEventImportCompleted,
+		QueueID:    item.ID,
+		NzbPath:    item.NzbPath,
+		Category:   category,
+		ResultPath: outcome.ResultPath,
+		Health:     "healthy",
+	}
+	if item.DownloadID != nil {
+		payload.DownloadID = *item.DownloadID
+	}
+	if item.Indexer != nil {
+		payload.Indexer = *item.Indexer
+	}
+	if item.TargetPath != nil {
+		payload.TargetPath = *item.TargetPath
+	}
+
+	payload.VirtualPaths = c.expandWrittenPaths(outcome.WrittenPaths)
+	if len(payload.VirtualPaths) == 0 {
+		payload.VirtualPaths = []string{outcome.ResultPath}
+	}
+	for _, p := range payload.VirtualPaths {
+		f := HookFile{Path: p, Health: "unknown"}
+		if c.metadataService != nil {
+			if lite, err := c.metadataService.ReadFileMetadataLite(p); err == nil && lite != nil {
+				f.Size = lite.FileSize
+				f.Health = hookHealth(lite.Status)
+			}
+		}
+		if healthRank(f.Health) > healthRank(payload.Health) {
+			payload.Health = f.Health
+		}
+		payload.Files = append(payload.Files, f)
+	}
+	return payload
+}
+
+// hookHealth maps a metadata file status onto the classification sent to hooks.
+func hookHealth(status metapb.FileStatus) string {
+	switch status {
+	case metapb.FileStatus_FILE_STATUS_HEALTHY:
+		return "healthy"
+	case metapb.FileStatus_FILE_STATUS_DEGRADED:
+		return "degraded"
+	case metapb.FileStatus_FILE_STATUS_CORRUPTED:
+		return "corrupted"
+	default:
+		return "unknown"
+	}
+}
+
+// healthRank orders classifications from best to worst.
+func healthRank(health string) int {
+	switch health {
+	case "healthy":
+		return 0
+	case "unknown":
+		return 1
+	case "degraded":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// applyHookRenames moves the virtual files a hook renamed and keeps the
+// outcome's paths in step. Failed renames are logged and skipped. It returns
+// how many files were renamed.
+func (c *Coordinator) applyHookRenames(ctx context.Context, queueID int64, hook string, renames map[string]string, outcome *HookOutcome) int {
+	if len(renames) == 0 || c.metadataService == nil {
+		return 0
+	}
+	renamed := 0
+	for oldPath, newPath := range renames {
+		oldPath, newPath = path.Clean("/"+oldPath), path.Clean("/"+newPath)
+		if oldPath == "/" || newPath == "/" || oldPath == newPath {
+			continue
+		}
+		if err := c.metadataService.RenameFileMetadata(oldPath, newPath); err != nil {
+			c.log.WarnContext(ctx, "Post-import hook rename failed",
+				"queue_id", queueID,
+				"hook", hook,
+				"from", oldPath,
+				"to", newPath,
+				"error", err)
+			continue
+		}
+		c.log.InfoContext(ctx, "Post-import hook renamed file",
+			"queue_id", queueID,
+			"hook", hook,
+			"from", oldPath,
+			"to", newPath)
+
+		if outcome.ResultPath == oldPath {
+			outcome.ResultPath = newPath
+		}
+		outcome.WrittenPaths = renameWrittenPath(outcome.WrittenPaths, oldPath, newPath)
+		renamed++
+	}
+	return renamed
+}
+
+// renameWrittenPath replaces oldPath in writtenPaths. A file that lived under
+// a "DIR:" entry is only added explicitly when it moved out of every such
+// directory, so failure cleanup and health scheduling still find it.
+func renameWrittenPath(writtenPaths []string, oldPath, newPath string) []string {
+	for i, p := range writtenPaths {
+		if p == oldPath {
+			out := append([]string(nil), writtenPaths...)
+			out[i] = newPath
+			return out
+		}
+	}
+	for _, p := range writtenPaths {
+		if dir, ok := strings.CutPrefix(p, "DIR:"); ok && strings.HasPrefix(newPath, dir+"/") {
+			return writtenPaths
+		}
+	}
+	return append(append([]string(nil), writtenPaths...), newPath)
+}
+
+// callHookWithRetries calls hook until it succeeds or its retries run out,
+// waiting a little longer before each retry.
+func (c *Coordinator) callHookWithRetries(ctx context.Context, name string, hook config.PostImportHook, payload HookPayload) (*HookResponse, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode hook payload: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= hook.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * 2 * time.Second):
+			}
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, hook.Timeout())
+		var out []byte
+		if hook.Command != "" {
+			out, lastErr = runCommandHook(attemptCtx, hook, payload, body)
+		} else {
+			out, lastErr = runHTTPHook(attemptCtx, hook, body)
+		}
+		cancel()
+		if lastErr == nil {
+			return parseHookResponse(out)
+		}
+		c.log.DebugContext(ctx, "Post-import hook attempt failed",
+			"queue_id", payload.QueueID,
+			"hook", name,
+			"attempt", attempt+1,
+			"error", lastErr)
+	}
+	return nil, lastErr
+}
+
+// runCommandHook runs an executable hook with the payload on stdin and returns
+// its stdout. The queue ID and event are also exported as environment
+// variables for scripts that do not parse JSON.
+func runCommandHook(ctx context.Context, hook config.PostImportHook, payload HookPayload, body []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, hook.Command, hook.Args...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"ALTMOUNT_EVENT="+payload.Event,
+		"ALTMOUNT_QUEUE_ID="+strconv.FormatInt(payload.QueueID, 10),
+		"ALTMOUNT_RESULT_PATH="+payload.ResultPath,
+		"ALTMOUNT_CATEGORY="+payload.Category,
+		"ALTMOUNT_HEALTH="+payload.Health,
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("hook timed out after %s", hook.Timeout())
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > 512 {
+			msg = msg[len(msg)-512:]
+		}
+		if msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	if stdout.Len() > maxHookResponseSize {
+		return nil, fmt.Errorf("hook output exceeds %d bytes", maxHookResponseSize)
+	}
+	return stdout.Bytes(), nil
+}
+
+// runHTTPHook POSTs the payload to an HTTP hook and returns the response body.
+func runHTTPHook(ctx context.Context, hook config.PostImportHook, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range hook.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpclient.New(httpclient.WithTimeout(hook.Timeout())).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxHookResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hook response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("hook returned HTTP %d", resp.StatusCode)
+	}
+	return data, nil
+}
+
+// parseHookResponse decodes a hook's answer. Empty output means "continue".
+func parseHookResponse(out []byte) (*HookResponse, error) {
+	resp := &HookResponse{}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return resp, nil
+	}
+	if err := json.Unmarshal(out, resp); err != nil {
+		return nil, fmt.Errorf("invalid hook response: %w", err)
+	}
+	return resp, nil
+}
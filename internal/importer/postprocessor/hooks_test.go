@@ -0,0 +1,143 @@
+package postprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/javi11/altmount/internal/errors"
+	"github.com/javi11/altmount/internal/metadata"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+)
+
+func newHookCoordinator(t *testing.T, hooks ...config.PostImportHook) (*Coordinator, *metadata.MetadataService) {
+	t.Helper()
+	cfg := &config.Config{MountType: config.MountTypeNone}
+	cfg.Import.PostImportHooks = hooks
+	metaSvc := metadata.NewMetadataService(t.TempDir())
+	return NewCoordinator(Config{
+		ConfigGetter:    func() *config.Config { return cfg },
+		MetadataService: metaSvc,
+	}), metaSvc
+}
+
+func TestRunPostImportHooks_HTTPRewrite(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		payload HookPayload
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt fails so the retry path is exercised.
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if r.Header.Get("X-Token") != "secret" {
+			t.Errorf("missing configured header")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(HookResponse{
+			TargetPath: "/library/Movie (2020)/Movie.mkv",
+			Paths:      map[string]string{"/complete/movies/Movie/a.mkv": "/complete/movies/Movie/Movie.mkv"},
+		})
+	}))
+	defer srv.Close()
+
+	coord, metaSvc := newHookCoordinator(t, config.PostImportHook{
+		Name:    "indexer",
+		URL:     srv.URL,
+		Headers: map[string]string{"X-Token": "secret"},
+		Retries: 1,
+	})
+	if err := metaSvc.WriteFileMetadata("/complete/movies/Movie/a.mkv", &metapb.FileMetadata{
+		FileSize: 42,
+		Status:   metapb.FileStatus_FILE_STATUS_DEGRADED,
+	}); err != nil {
+		t.Fatalf("WriteFileMetadata: %v", err)
+	}
+
+	category, downloadID := "movies", "dl-1"
+	item := &database.ImportQueueItem{ID: 7, Category: &category, DownloadID: &downloadID}
+	outcome, err := coord.RunPostImportHooks(context.Background(), item, "/complete/movies/Movie/a.mkv", []string{"/complete/movies/Movie/a.mkv"})
+	if err != nil {
+		t.Fatalf("RunPostImportHooks: %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("hook called %d times, want 2", calls.Load())
+	}
+	if payload.QueueID != 7 || payload.Category != "movies" || payload.DownloadID != "dl-1" {
+		t.Errorf("payload = %+v", payload)
+	}
+	if payload.Health != "degraded" || len(payload.Files) != 1 || payload.Files[0].Size != 42 {
+		t.Errorf("payload files = %+v, health %q", payload.Files, payload.Health)
+	}
+
+	if item.TargetPath == nil || *item.TargetPath != "/library/Movie (2020)/Movie.mkv" {
+		t.Errorf("target path = %v", item.TargetPath)
+	}
+	if outcome.ResultPath != "/complete/movies/Movie/Movie.mkv" {
+		t.Errorf("result path = %q", outcome.ResultPath)
+	}
+	if len(outcome.WrittenPaths) != 1 || outcome.WrittenPaths[0] != "/complete/movies/Movie/Movie.mkv" {
+		t.Errorf("written paths = %v", outcome.WrittenPaths)
+	}
+	if !metaSvc.FileExists("/complete/movies/Movie/Movie.mkv") {
+		t.Error("renamed metadata file not found")
+	}
+}
+
+func TestRunPostImportHooks_CommandVeto(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "hook.sh")
+	body := "#!/bin/sh\ncat >/dev/null\necho '{\"veto\":true,\"reason\":\"no subtitles\"}'\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	coord, _ := newHookCoordinator(t,
+		config.PostImportHook{Name: "subs", Command: script},
+		config.PostImportHook{Name: "never", URL: "http://127.0.0.1:1/unreachable"},
+	)
+	_, err := coord.RunPostImportHooks(context.Background(), &database.ImportQueueItem{ID: 1}, "/complete/x.mkv", nil)
+	if err == nil {
+		t.Fatal("expected veto error")
+	}
+	if !errors.IsNonRetryable(err) {
+		t.Errorf("veto must be non-retryable: %v", err)
+	}
+}
+
+func TestRunPostImportHooks_FailureHandling(t *testing.T) {
+	failing := config.PostImportHook{Name: "broken", Command: "/nonexistent/hook"}
+
+	coord, _ := newHookCoordinator(t, failing)
+	if _, err := coord.RunPostImportHooks(context.Background(), &database.ImportQueueItem{ID: 1}, "/complete/x.mkv", nil); err != nil {
+		t.Errorf("a failing hook must be ignored by default: %v", err)
+	}
+
+	failing.FailImportOnError = true
+	coord, _ = newHookCoordinator(t, failing)
+	if _, err := coord.RunPostImportHooks(context.Background(), &database.ImportQueueItem{ID: 1}, "/complete/x.mkv", nil); err == nil {
+		t.Error("expected an error with fail_import_on_error")
+	}
+}
+
+func TestRenameWrittenPath(t *testing.T) {
+	written := []string{"DIR:/complete/Show"}
+	if got := renameWrittenPath(written, "/complete/Show/e1.mkv", "/complete/Show/S01E01.mkv"); len(got) != 1 {
+		t.Errorf("rename inside a DIR entry must not add a path: %v", got)
+	}
+	got := renameWrittenPath(written, "/complete/Show/e1.mkv", "/complete/Other/S01E01.mkv")
+	if len(got) != 2 || got[1] != "/complete/Other/S01E01.mkv" {
+		t.Errorf("rename out of a DIR entry = %v", got)
+	}
+}
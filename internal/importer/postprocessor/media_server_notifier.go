@@ -0,0 +1,26 @@
+package postprocessor
+
+import (
+	"context"
+
+	"github.com/javi11/altmount/internal/mediaserver"
+)
+
+// RefreshMediaServers asks every enabled media server to rescan the
+// directories of the imported files, so files they play straight from the
+// mount show up without waiting for a scheduled scan. writtenPaths lists the
+// imported files (nil falls back to resultingPath). Reports whether any
+// server was configured.
+func (c *Coordinator) RefreshMediaServers(ctx context.Context, resultingPath string, writtenPaths []string) bool {
+	servers := c.mediaServers(c.configGetter())
+	if len(servers) == 0 {
+		return false
+	}
+
+	paths := writtenPaths
+	if len(paths) == 0 {
+		paths = []string{resultingPath}
+	}
+	mediaserver.RefreshAll(ctx, servers, paths)
+	return true
+}
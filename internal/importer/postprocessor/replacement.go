@@ -0,0 +1,100 @@
+package postprocessor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/javi11/altmount/internal/database"
+)
+
+// repairActionAlternateRelease is health.RepairActionAlternateRelease (the
+// health package imports the importer, so it cannot be imported here).
+const repairActionAlternateRelease = "alternate_release"
+
+// retireReplacedFile retires the corrupted file an alternate-release import
+// replaces, now that the replacement is in: its metadata moves to the
+// corrupted folder, its health record is deleted and the repair is closed.
+// A replacement written over the corrupted file's own path already reset its
+// health record, so there is nothing left to retire.
+func (c *Coordinator) retireReplacedFile(ctx context.Context, item *database.ImportQueueItem, writtenPaths []string) {
+	if c.healthRepo == nil {
+		return
+	}
+	filePath, err := c.healthRepo.TakeReplacementImport(ctx, item.ID)
+	if err != nil {
+		c.log.WarnContext(ctx, "Failed to look up replaced file", "queue_id", item.ID, "error", err)
+		return
+	}
+	if filePath == "" {
+		return
+	}
+
+	relativePath := strings.TrimPrefix(strings.TrimPrefix(filePath, c.configGetter().MountPath), "/")
+	for _, p := range c.expandWrittenPaths(writtenPaths) {
+		if strings.TrimPrefix(p, "/") == relativePath {
+			c.log.InfoContext(ctx, "Alternate release replaced corrupted file in place",
+				"queue_id", item.ID, "file_path", filePath)
+			return
+		}
+	}
+
+	if err := c.metadataService.MoveToCorrupted(ctx, relativePath); err != nil {
+		c.log.WarnContext(ctx, "Failed to move replaced file metadata", "file_path", filePath, "error", err)
+	} else {
+		c.RefreshMediaServers(ctx, relativePath, nil)
+	}
+
+	// The import may already have resolved the record (resolve_repair_on_import).
+	c.recordReplacementTransition(ctx, filePath, database.RepairStateTriggered, database.RepairStateRemoved, nil)
+	fh, err := c.healthRepo.GetFileHealth(ctx, filePath)
+	if err != nil {
+		c.log.WarnContext(ctx, "Failed to read health record of replaced file", "file_path", filePath, "error", err)
+	} else if fh != nil {
+		if err := c.healthRepo.DeleteHealthRecord(ctx, filePath); err != nil {
+			c.log.ErrorContext(ctx, "Failed to delete health record of replaced file", "file_path", filePath, "error", err)
+		}
+	}
+
+	c.log.InfoContext(ctx, "Retired corrupted file replaced by alternate release",
+		"queue_id", item.ID, "file_path", filePath)
+}
+
+// failReplacement records that an alternate-release import failed. The
+// corrupted file keeps its health record, so the repair sweep searches for
+// another release; the link stays with the queue item so a manual retry
+// that succeeds still retires the file.
+func (c *Coordinator) failReplacement(ctx context.Context, item *database.ImportQueueItem, processingErr error) {
+	if c.healthRepo == nil {
+		return
+	}
+	filePath, err := c.healthRepo.GetReplacementImport(ctx, item.ID)
+	if err != nil {
+		c.log.WarnContext(ctx, "Failed to look up replaced file", "queue_id", item.ID, "error", err)
+		return
+	}
+	if filePath == "" {
+		return
+	}
+
+	result := fmt.Sprintf("replacement import failed: %v", processingErr)
+	c.recordReplacementTransition(ctx, filePath, database.RepairStateTriggered, database.RepairStateFailed, &result)
+	c.log.WarnContext(ctx, "Alternate release import failed; corrupted file kept",
+		"queue_id", item.ID, "file_path", filePath, "error", processingErr)
+}
+
+// recordReplacementTransition appends the outcome of a replacement import to
+// the repair audit log of the file it replaces.
+func (c *Coordinator) recordReplacementTransition(ctx context.Context, filePath string, from, to database.RepairState, result *string) {
+	ev := &database.RepairEvent{
+		FilePath:  filePath,
+		FromState: from,
+		ToState:   to,
+		Action:    repairActionAlternateRelease,
+		Result:    result,
+	}
+	if err := c.healthRepo.RecordRepairTransition(ctx, ev); err != nil {
+		c.log.WarnContext(ctx, "Failed to record repair transition",
+			"file_path", filePath, "to", to, "error", err)
+	}
+}
@@ -0,0 +1,122 @@
+package postprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/javi11/altmount/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupReplacementTest extends the scheduler fixture with the tables an
+// alternate-release replacement touches and links queue item queueID to a
+// corrupted file awaiting its replacement.
+func setupReplacementTest(t *testing.T, queueID int64, filePath string) (*Coordinator, *database.HealthRepository, func(string) bool) {
+	t.Helper()
+	coordinator, ms, repo, db := setupSchedulerTest(t)
+
+	_, err := db.Exec(`
+		CREATE TABLE replacement_imports (
+			queue_id INTEGER PRIMARY KEY,
+			file_path TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT (datetime('now'))
+		);
+		CREATE TABLE repair_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			file_path TEXT NOT NULL,
+			from_state TEXT NOT NULL DEFAULT '',
+			to_state TEXT NOT NULL,
+			action TEXT NOT NULL,
+			arr_type TEXT DEFAULT NULL,
+			arr_instance TEXT DEFAULT NULL,
+			details TEXT DEFAULT NULL,
+			result TEXT DEFAULT NULL,
+			created_at DATETIME NOT NULL DEFAULT (datetime('now'))
+		);
+	`)
+	require.NoError(t, err)
+
+	writeTestMetadata(t, ms, filePath)
+	_, err = db.Exec(`
+		INSERT INTO file_health (file_path, status, repair_state)
+		VALUES (?, 'repair_triggered', 'triggered')
+	`, filePath)
+	require.NoError(t, err)
+	require.NoError(t, repo.AddReplacementImport(context.Background(), queueID, filePath))
+
+	metadataExists := func(p string) bool {
+		meta, err := ms.ReadFileMetadata(p)
+		require.NoError(t, err)
+		return meta != nil
+	}
+	return coordinator, repo, metadataExists
+}
+
+func TestRetireReplacedFile_RetiresCorruptedFileOnSuccess(t *testing.T) {
+	ctx := context.Background()
+	const oldPath = "movies/Some.Movie.2019-GRP/movie.mkv"
+	coordinator, repo, metadataExists := setupReplacementTest(t, 7, oldPath)
+
+	coordinator.retireReplacedFile(ctx, &database.ImportQueueItem{ID: 7}, []string{"movies/Some.Movie.2019-GRP_1/movie.mkv"})
+
+	assert.False(t, metadataExists(oldPath), "corrupted metadata is retired")
+	fh, err := repo.GetFileHealth(ctx, oldPath)
+	require.NoError(t, err)
+	assert.Nil(t, fh, "health record is retired")
+
+	events, err := repo.GetRepairEvents(ctx, oldPath)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, database.RepairStateRemoved, events[0].ToState)
+	assert.Equal(t, repairActionAlternateRelease, events[0].Action)
+
+	linked, err := repo.TakeReplacementImport(ctx, 7)
+	require.NoError(t, err)
+	assert.Empty(t, linked, "the link is consumed")
+}
+
+func TestRetireReplacedFile_InPlaceReplacementKeepsFile(t *testing.T) {
+	ctx := context.Background()
+	const oldPath = "movies/Some.Movie.2019-GRP/movie.mkv"
+	coordinator, repo, metadataExists := setupReplacementTest(t, 7, oldPath)
+
+	coordinator.retireReplacedFile(ctx, &database.ImportQueueItem{ID: 7}, []string{"/" + oldPath})
+
+	assert.True(t, metadataExists(oldPath), "the replacement's metadata is not retired")
+	fh, err := repo.GetFileHealth(ctx, oldPath)
+	require.NoError(t, err)
+	assert.NotNil(t, fh)
+}
+
+func TestFailReplacement_KeepsCorruptedFile(t *testing.T) {
+	ctx := context.Background()
+	const oldPath = "movies/Some.Movie.2019-GRP/movie.mkv"
+	coordinator, repo, metadataExists := setupReplacementTest(t, 7, oldPath)
+
+	coordinator.failReplacement(ctx, &database.ImportQueueItem{ID: 7}, errors.New("missing articles"))
+
+	assert.True(t, metadataExists(oldPath), "corrupted metadata is kept")
+	fh, err := repo.GetFileHealth(ctx, oldPath)
+	require.NoError(t, err)
+	require.NotNil(t, fh, "health record is kept")
+	assert.Equal(t, database.RepairStateFailed, fh.RepairState)
+
+	events, err := repo.GetRepairEvents(ctx, oldPath)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.NotNil(t, events[0].Result)
+	assert.Contains(t, *events[0].Result, "missing articles")
+
+	// The link outlives the failure: a manual retry that succeeds still
+	// retires the corrupted file.
+	linked, err := repo.GetReplacementImport(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, oldPath, linked, "the link is kept after a failure")
+	coordinator.retireReplacedFile(ctx, &database.ImportQueueItem{ID: 7}, []string{"movies/Some.Movie.2019-GRP_1/movie.mkv"})
+	assert.False(t, metadataExists(oldPath), "a retried replacement retires the file")
+
+	// Imports that replace nothing are untouched.
+	coordinator.failReplacement(ctx, &database.ImportQueueItem{ID: 8}, errors.New("boom"))
+}
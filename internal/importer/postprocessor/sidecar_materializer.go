@@ -0,0 +1,89 @@
+package postprocessor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/javi11/altmount/internal/importer/utils"
+	"github.com/spf13/afero"
+)
+
+// maxMaterializedSize bounds a materialized sidecar. Text subtitles are a few
+// hundred KiB; PGS (.sup) tracks can reach tens of MiB.
+const maxMaterializedSize = 128 * 1024 * 1024
+
+// VirtualFileOpener opens files of the virtual (NZB-backed) filesystem.
+type VirtualFileOpener interface {
+	Open(ctx context.Context, name string) (afero.File, error)
+}
+
+// materializeSidecar writes the subtitle at virtualPath as a real file at
+// libraryRelPath under import_dir when MaterializeSubtitles is enabled.
+// handled is false for any other file, which the caller links as usual.
+func (c *Coordinator) materializeSidecar(ctx context.Context, virtualPath, libraryRelPath string) (handled bool, err error) {
+	cfg := c.configGetter()
+	if !cfg.GetMaterializeSubtitles() || !utils.IsSubtitleFile(virtualPath) {
+		return false, nil
+	}
+	if cfg.Import.ImportDir == nil || *cfg.Import.ImportDir == "" {
+		return false, nil
+	}
+
+	c.mu.RLock()
+	opener := c.fileOpener
+	c.mu.RUnlock()
+	if opener == nil {
+		// Without the virtual filesystem the sidecar is linked like any file.
+		return false, nil
+	}
+
+	dest := filepath.Join(*cfg.Import.ImportDir, strings.TrimPrefix(libraryRelPath, "/"))
+	if err := os.MkdirAll(filepath.Dir(dest), 0775); err != nil {
+		return true, fmt.Errorf("failed to create sidecar directory: %w", err)
+	}
+
+	src, err := opener.Open(ctx, "/"+strings.TrimPrefix(virtualPath, "/"))
+	if err != nil {
+		return true, fmt.Errorf("failed to open sidecar %s: %w", virtualPath, err)
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".sidecar-*")
+	if err != nil {
+		return true, fmt.Errorf("failed to create sidecar file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, io.LimitReader(src, maxMaterializedSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to copy sidecar %s: %w", virtualPath, err)
+	}
+	if n > maxMaterializedSize {
+		return true, fmt.Errorf("sidecar %s exceeds %d bytes", virtualPath, maxMaterializedSize)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return true, fmt.Errorf("failed to set sidecar permissions: %w", err)
+	}
+
+	// A symlink or .strm left by an earlier import of the same file would
+	// shadow the real file.
+	if _, err := os.Lstat(dest); err == nil {
+		if err := os.Remove(dest); err != nil {
+			return true, fmt.Errorf("failed to remove existing sidecar: %w", err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return true, fmt.Errorf("failed to write sidecar: %w", err)
+	}
+	_ = os.Remove(dest + ".strm")
+
+	c.log.DebugContext(ctx, "Materialized subtitle sidecar", "path", virtualPath, "dest", dest, "size", n)
+	return true, nil
+}
@@ -0,0 +1,79 @@
+package postprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memOpener serves virtual files from an in-memory filesystem.
+type memOpener struct{ fs afero.Fs }
+
+func (m memOpener) Open(_ context.Context, name string) (afero.File, error) {
+	return m.fs.Open(name)
+}
+
+func TestCreateSymlinks_MaterializesSubtitles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks not supported on Windows")
+	}
+	tmpDir := t.TempDir()
+	metadataDir := filepath.Join(tmpDir, "metadata")
+	importDir := filepath.Join(tmpDir, "import")
+	mountDir := filepath.Join(tmpDir, "mount")
+
+	metaDir := filepath.Join(metadataDir, "complete", "movies", "Movie")
+	require.NoError(t, os.MkdirAll(metaDir, 0755))
+	for _, name := range []string{"Movie.mkv.meta", "Movie.en.srt.meta"} {
+		require.NoError(t, os.WriteFile(filepath.Join(metaDir, name), []byte("meta"), 0644))
+	}
+
+	virtual := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(virtual, "/complete/movies/Movie/Movie.en.srt", []byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), 0644))
+
+	enabled := true
+	cfg := &config.Config{
+		Import: config.ImportConfig{
+			ImportStrategy:       config.ImportStrategySYMLINK,
+			ImportDir:            &importDir,
+			MaterializeSubtitles: &enabled,
+		},
+		Metadata:  config.MetadataConfig{RootPath: metadataDir},
+		SABnzbd:   config.SABnzbdConfig{CompleteDir: "/complete"},
+		MountPath: mountDir,
+	}
+	coord := NewCoordinator(Config{ConfigGetter: func() *config.Config { return cfg }})
+	coord.SetVirtualFileOpener(memOpener{fs: virtual})
+
+	category := "movies"
+	err := coord.CreateSymlinks(context.Background(), &database.ImportQueueItem{ID: 1, Category: &category}, "/complete/movies/Movie")
+	require.NoError(t, err)
+
+	libDir := filepath.Join(importDir, "complete", "movies", "Movie")
+	info, err := os.Lstat(filepath.Join(libDir, "Movie.en.srt"))
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular(), "subtitle must be a real file")
+	data, err := os.ReadFile(filepath.Join(libDir, "Movie.en.srt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Hi")
+
+	info, err = os.Lstat(filepath.Join(libDir, "Movie.mkv"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&os.ModeSymlink, "video must stay a symlink")
+
+	// Disabled: the subtitle is linked like any other file.
+	disabled := false
+	cfg.Import.MaterializeSubtitles = &disabled
+	require.NoError(t, coord.CreateSymlinks(context.Background(), &database.ImportQueueItem{ID: 1, Category: &category}, "/complete/movies/Movie"))
+	info, err = os.Lstat(filepath.Join(libDir, "Movie.en.srt"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&os.ModeSymlink)
+}
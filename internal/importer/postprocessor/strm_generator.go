@@ -54,12 +54,18 @@ func (c *Coordinator) CreateStrmFiles(ctx context.Context, item *database.Import
 	if err != nil {
 		metaFile := metadataPath + ".meta"
 		if _, metaErr := os.Stat(metaFile); metaErr == nil {
+			if handled, err := c.materializeSidecar(ctx, originalResultingPath, resultingPath); handled {
+				return err
+			}
 			return c.CreateSingleStrmFile(ctx, resultingPath, originalResultingPath, cfg.WebDAV.Port)
 		}
 		return fmt.Errorf("failed to stat metadata path: %w", err)
 	}
 
 	if !fileInfo.IsDir() {
+		if handled, err := c.materializeSidecar(ctx, originalResultingPath, resultingPath); handled {
+			return err
+		}
 		return c.CreateSingleStrmFile(ctx, resultingPath, originalResultingPath, cfg.WebDAV.Port)
 	}
 
@@ -110,6 +116,20 @@ func (c *Coordinator) CreateStrmFiles(ctx context.Context, item *database.Import
 		// double-prefix the category/CompleteDir on Windows (issue #585).
 		strmResultingPath := buildLibraryRelPath(relPath, cfg.SABnzbd.CompleteDir, category)
 
+		// Subtitle sidecars may be written as real files instead: a
+		// "Movie.en.srt.strm" is not recognised as a subtitle.
+		if handled, err := c.materializeSidecar(ctx, relPath, strmResultingPath); handled {
+			if err != nil {
+				c.log.ErrorContext(ctx, "Failed to materialize sidecar",
+					"path", relPath,
+					"error", err)
+				strmErrors = append(strmErrors, err)
+				return nil
+			}
+			strmCount++
+			return nil
+		}
+
 		if err := c.CreateSingleStrmFile(ctx, strmResultingPath, relPath, cfg.WebDAV.Port); err != nil {
 			c.log.ErrorContext(ctx, "Failed to create STRM file",
 				"path", relPath,
@@ -190,6 +210,7 @@ func (c *Coordinator) CreateSingleStrmFile(ctx context.Context, strmResultingPat
 	// Check if STRM file already exists with the same content
 	if existingContent, err := os.ReadFile(strmPath); err == nil {
 		if string(existingContent) == streamURL {
+			c.indexLink(ctx, strmPath, originalVirtualPath)
 			return nil // File exists with correct content
 		}
 	}
@@ -197,6 +218,7 @@ func (c *Coordinator) CreateSingleStrmFile(ctx context.Context, strmResultingPat
 	if err := os.WriteFile(strmPath, []byte(streamURL), 0644); err != nil {
 		return fmt.Errorf("failed to write STRM file: %w", err)
 	}
+	c.indexLink(ctx, strmPath, originalVirtualPath)
 
 	return nil
 }
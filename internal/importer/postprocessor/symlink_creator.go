@@ -20,7 +20,7 @@ func (c *Coordinator) CreateSymlinks(ctx context.Context, item *database.ImportQ
 	// regardless of the configured import strategy.
 	if item.TargetPath != nil && *item.TargetPath != "" {
 		actualPath := filepath.Join(cfg.MountPath, strings.TrimPrefix(resultingPath, "/"))
-		return c.createAbsoluteSymlink(actualPath, *item.TargetPath)
+		return c.createAbsoluteSymlink(ctx, actualPath, *item.TargetPath)
 	}
 
 	// Check if symlinks are enabled
@@ -63,13 +63,19 @@ func (c *Coordinator) CreateSymlinks(ctx context.Context, item *database.ImportQ
 	if err != nil {
 		metaFile := metadataPath + ".meta"
 		if _, metaErr := os.Stat(metaFile); metaErr == nil {
-			return c.createSingleSymlink(actualPath, resultingPath)
+			if handled, err := c.materializeSidecar(ctx, originalResultingPath, resultingPath); handled {
+				return err
+			}
+			return c.createSingleSymlink(ctx, actualPath, resultingPath)
 		}
 		return fmt.Errorf("failed to stat metadata path: %w", err)
 	}
 
 	if !fileInfo.IsDir() {
-		return c.createSingleSymlink(actualPath, resultingPath)
+		if handled, err := c.materializeSidecar(ctx, originalResultingPath, resultingPath); handled {
+			return err
+		}
+		return c.createSingleSymlink(ctx, actualPath, resultingPath)
 	}
 
 	// Directory - walk through and create symlinks for all files
@@ -122,7 +128,20 @@ func (c *Coordinator) CreateSymlinks(ctx context.Context, item *database.ImportQ
 		// double-prefix the category/CompleteDir on Windows (issue #585).
 		symlinkResultingPath := buildLibraryRelPath(relPath, cfg.SABnzbd.CompleteDir, category)
 
-		if err := c.createSingleSymlink(actualFilePath, symlinkResultingPath); err != nil {
+		// Subtitle sidecars may be written as real files instead.
+		if handled, err := c.materializeSidecar(ctx, relPath, symlinkResultingPath); handled {
+			if err != nil {
+				c.log.ErrorContext(ctx, "Failed to materialize sidecar",
+					"path", relPath,
+					"error", err)
+				symlinkErrors = append(symlinkErrors, err)
+				return nil
+			}
+			symlinkCount++
+			return nil
+		}
+
+		if err := c.createSingleSymlink(ctx, actualFilePath, symlinkResultingPath); err != nil {
 			c.log.ErrorContext(ctx, "Failed to create symlink",
 				"path", actualFilePath,
 				"error", err)
@@ -150,7 +169,7 @@ func (c *Coordinator) CreateSymlinks(ctx context.Context, item *database.ImportQ
 
 // createAbsoluteSymlink creates a symlink at an exact absolute destination path.
 // It creates any missing parent directories and removes an existing symlink at destPath.
-func (c *Coordinator) createAbsoluteSymlink(actualPath, destPath string) error {
+func (c *Coordinator) createAbsoluteSymlink(ctx context.Context, actualPath, destPath string) error {
 	if err := os.MkdirAll(filepath.Dir(destPath), 0775); err != nil {
 		return fmt.Errorf("failed to create parent directory for target symlink: %w", err)
 	}
@@ -165,12 +184,13 @@ func (c *Coordinator) createAbsoluteSymlink(actualPath, destPath string) error {
 	if err := os.Symlink(actualPath, destPath); err != nil {
 		return fmt.Errorf("failed to create symlink at target path: %w", err)
 	}
+	c.indexLink(ctx, destPath, actualPath)
 
 	return nil
 }
 
 // createSingleSymlink creates a symlink for a single file
-func (c *Coordinator) createSingleSymlink(actualPath, resultingPath string) error {
+func (c *Coordinator) createSingleSymlink(ctx context.Context, actualPath, resultingPath string) error {
 	cfg := c.configGetter()
 
 	baseDir := filepath.Join(*cfg.Import.ImportDir, filepath.Dir(strings.TrimPrefix(resultingPath, "/")))
@@ -192,6 +212,20 @@ func (c *Coordinator) createSingleSymlink(actualPath, resultingPath string) erro
 	if err := os.Symlink(actualPath, symlinkPath); err != nil {
 		return fmt.Errorf("failed to create symlink: %w", err)
 	}
+	c.indexLink(ctx, symlinkPath, actualPath)
 
 	return nil
 }
+
+// indexLink records a created symlink or STRM file in the file index, with
+// target made mount-relative. Indexing is best-effort: the next full library
+// sync picks up anything missed.
+func (c *Coordinator) indexLink(ctx context.Context, linkPath, target string) {
+	if c.fileIndex == nil {
+		return
+	}
+	rel := strings.TrimPrefix(filepath.ToSlash(target), filepath.ToSlash(c.configGetter().MountPath))
+	if err := c.fileIndex.IndexLink(ctx, linkPath, rel); err != nil {
+		c.log.WarnContext(ctx, "Failed to index library link", "path", linkPath, "error", err)
+	}
+}
@@ -20,10 +20,13 @@ import (
 	"github.com/javi11/altmount/internal/importer/archive"
 	"github.com/javi11/altmount/internal/importer/archive/rar"
 	"github.com/javi11/altmount/internal/importer/archive/sevenzip"
+	"github.com/javi11/altmount/internal/importer/checkpoint"
 	"github.com/javi11/altmount/internal/importer/filesystem"
 	"github.com/javi11/altmount/internal/importer/multifile"
 	"github.com/javi11/altmount/internal/importer/parser"
+	"github.com/javi11/altmount/internal/importer/report"
 	"github.com/javi11/altmount/internal/importer/singlefile"
+	"github.com/javi11/altmount/internal/importer/utils"
 	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
 	"github.com/javi11/altmount/internal/importer/validation"
 	"github.com/javi11/altmount/internal/metadata"
@@ -50,6 +53,8 @@ type Processor struct {
 	log               *slog.Logger
 	broadcaster       *progress.ProgressBroadcaster // WebSocket progress broadcaster
 	recorder          HistoryRecorder
+	samplingObserver  SamplingObserver
+	checkpointStore   checkpoint.Store
 
 	// Pre-compiled regex patterns for RAR file sorting
 	rarPartPattern  *regexp.Regexp // pattern.part###.rar
@@ -91,6 +96,17 @@ func (proc *Processor) SetRecorder(recorder HistoryRecorder) {
 	proc.recorder = recorder
 }
 
+// SetSamplingObserver registers the observer notified about slow fast-fail sampling.
+func (proc *Processor) SetSamplingObserver(observer SamplingObserver) {
+	proc.samplingObserver = observer
+}
+
+// SetCheckpointStore sets the store persisting stage checkpoints, letting an
+// interrupted import resume from its last completed stage.
+func (proc *Processor) SetCheckpointStore(store checkpoint.Store) {
+	proc.checkpointStore = store
+}
+
 func (proc *Processor) isCategoryFolder(path string, category *string) bool {
 	cfg := proc.configGetter()
 	normalizedPath := strings.Trim(filepath.ToSlash(path), "/")
@@ -154,7 +170,9 @@ func (proc *Processor) updateProgress(queueID int, percentage int) {
 }
 
 // updateProgressWithStage emits a progress update with a stage label if broadcaster is available
-func (proc *Processor) updateProgressWithStage(queueID int, percentage int, stage string) {
+// and opens the stage in the import report carried by ctx.
+func (proc *Processor) updateProgressWithStage(ctx context.Context, queueID int, percentage int, stage string) {
+	report.FromContext(ctx).Stage(stage)
 	if proc.broadcaster != nil {
 		proc.broadcaster.UpdateProgressWithStage(queueID, percentage, stage)
 	}
@@ -210,6 +228,10 @@ func (proc *Processor) preParseFastFail(ctx context.Context, n *nzbparser.Nzb, c
 	// pool's full connection capacity so multi-part releases don't crawl.
 	concurrency := fastFailConcurrency(cfg)
 
+	if cfg.GetPerProviderSampling() {
+		proc.sampleProviders(ctx, fastFailFiles, cfg, concurrency)
+	}
+
 	// Phase 1: cheap release-level probe. Sample the whole release once
 	// (≤55 Stats) and fail fast. Healthy releases — the common case — pay only
 	// this and skip the per-file sweep entirely, keeping the "Checking segment
@@ -226,6 +248,8 @@ func (proc *Processor) preParseFastFail(ctx context.Context, n *nzbparser.Nzb, c
 	if err != nil {
 		return nil, nil, err
 	}
+	rec := report.FromContext(ctx)
+	rec.FastFailProbe(missing, time.Since(probeStart))
 	if !missing {
 		if proc.log != nil {
 			proc.log.DebugContext(ctx, "Fast-fail release probe passed",
@@ -315,6 +339,14 @@ func (proc *Processor) preParseFastFail(ctx context.Context, n *nzbparser.Nzb, c
 		}
 	}
 
+	for i, result := range results {
+		if result.SampledCount == 0 && !result.Broken {
+			continue
+		}
+		_, excluded := brokenIdx[i]
+		rec.FastFailFile(fastFailSample(n.Files[i].Filename, len(n.Files[i].Segments), result, excluded))
+	}
+
 	// With set-level propagation, a broken set has all its parts in brokenIdx, so
 	// this equality is logical-unit accurate: it holds only when every RAR set and
 	// every standalone regular file is broken — nothing healthy remains to import.
@@ -342,6 +374,45 @@ func (proc *Processor) preParseFastFail(ctx context.Context, n *nzbparser.Nzb, c
 	return brokenIdx, missingIDs, nil
 }
 
+// fastFailSample converts a fast-fail sweep result into its import report
+// entry. excluded tells whether the file was dropped from the import.
+func fastFailSample(filename string, segments int, result validation.FastFailFileResult, excluded bool) report.FileSample {
+	sample := report.FileSample{
+		Filename:     filename,
+		Segments:     segments,
+		Sampled:      result.SampledCount,
+		MissingCount: len(result.MissingSegmentIDs),
+		Verdict:      "ok",
+	}
+	switch {
+	case excluded:
+		sample.Verdict = "broken"
+	case result.Broken:
+		sample.Verdict = "degraded"
+	}
+	for j, id := range result.MissingSegmentIDs {
+		if j == report.MaxMissingSegmentsPerFile {
+			break
+		}
+		miss := report.MissingSegment{ID: id}
+		if j < len(result.MissingErrors) {
+			miss.Response = result.MissingErrors[j]
+		}
+		sample.Missing = append(sample.Missing, miss)
+	}
+	return sample
+}
+
+// reportSamplingDuration notifies the sampling observer when the fast-fail
+// pass exceeded the configured slow-sampling threshold.
+func (proc *Processor) reportSamplingDuration(ctx context.Context, cfg *config.Config, queueID int, elapsed time.Duration) {
+	threshold := cfg.GetSlowSamplingThreshold()
+	if proc.samplingObserver == nil || threshold <= 0 || elapsed <= threshold {
+		return
+	}
+	proc.samplingObserver.OnSlowSampling(ctx, queueID, elapsed)
+}
+
 // longestSampledRun maps missing segment IDs back to their indices in the
 // file's segment list and returns the longest run of consecutive missing
 // indices among the sampled set. Because the fast-fail sample is sparse this
@@ -364,6 +435,47 @@ func longestSampledRun(segments []*metapb.SegmentData, missingIDs []string) int
 	return acc.LongestRun()
 }
 
+// sampleProviders Stats the fast-fail sample on every provider separately and
+// records each provider's completeness on the import report, from where it is
+// stored on the queue item and on the file_health rows of the import. It is
+// informational only: errors are logged and never fail the import.
+func (proc *Processor) sampleProviders(ctx context.Context, files []validation.FastFailFile, cfg *config.Config, concurrency int) {
+	start := time.Now()
+	samples, err := validation.FastFailProviderSample(
+		ctx,
+		files,
+		proc.poolManager,
+		cfg.Import.SegmentSamplePercentage,
+		concurrency,
+		proc.validationTimeout,
+	)
+	if err != nil {
+		if proc.log != nil {
+			proc.log.WarnContext(ctx, "Per-provider sampling failed", "error", err)
+		}
+		return
+	}
+	if len(samples) == 0 {
+		return
+	}
+
+	entries := make([]database.ProviderCompleteness, len(samples))
+	attrs := make([]any, 0, 2*len(samples)+2)
+	for i, s := range samples {
+		entries[i] = database.NewProviderCompleteness(s.Provider, s.Sampled, s.Missing)
+		if s.Err != nil {
+			entries[i].Error = s.Err.Error()
+		}
+		attrs = append(attrs, s.Provider, fmt.Sprintf("%.1f%% (%d/%d)", entries[i].Completeness*100, s.Sampled-s.Missing, s.Sampled))
+	}
+	report.FromContext(ctx).ProviderCompleteness(entries)
+
+	if proc.log != nil {
+		attrs = append(attrs, "duration", time.Since(start))
+		proc.log.InfoContext(ctx, "Per-provider completeness", attrs...)
+	}
+}
+
 // fastFailConcurrency returns the goroutine cap for the fast-fail Stat sweep.
 // Stats are cheap and queue on the pool's normal lane, so we use the pool's
 // full connection capacity, bounded to keep goroutine counts sane.
@@ -405,8 +517,14 @@ func (proc *Processor) ProcessNzbFile(ctx context.Context, filePath, relativePat
 	if allowedExtensionsOverride != nil {
 		allowedExtensions = *allowedExtensionsOverride
 	}
+	// Subtitle sidecars are kept whatever the extension filter says; they are
+	// renamed after their video once the import has written everything.
+	subtitleSidecars := cfg.GetSubtitleSidecars()
+	if subtitleSidecars {
+		allowedExtensions = utils.WithSubtitleExtensions(allowedExtensions)
+	}
 
-	proc.updateProgressWithStage(queueID, 0, "Parsing NZB")
+	proc.updateProgressWithStage(ctx, queueID, 0, "Parsing NZB")
 	file, err := nzbfile.Open(filePath)
 	if err != nil {
 		return "", nil, NewNonRetryableError("failed to open file", err)
@@ -439,22 +557,36 @@ func (proc *Processor) ProcessNzbFile(ctx context.Context, filePath, relativePat
 
 		parser.SanitizeNzbFilenames(n)
 
-		// Pre-parse Stat check — runs before any Body fetches.
-		proc.updateProgressWithStage(queueID, 0, "Checking segment availability")
-		var missingIDs map[string]struct{}
-		var fastFailErr error
-		brokenIdx, missingIDs, fastFailErr = proc.preParseFastFail(ctx, n, cfg, queueID)
-		if fastFailErr != nil {
-			return "", nil, NewNonRetryableError("fast-fail segment check failed", fastFailErr)
-		}
+		// A previous attempt of this queue item may have left checkpoints:
+		// resume from them rather than re-sampling and re-parsing.
+		checkpoints := checkpoint.New(proc.checkpointStore, int64(queueID), checkpoint.Fingerprint(n))
+		ctx = checkpoint.WithCheckpoints(ctx, checkpoints)
 
-		parseTracker := progress.NewTracker(proc.broadcaster, queueID, 2, 10)
-		parsed, err = proc.parser.ParseNzb(ctx, n, filePath, parseTracker, parser.ParseOptions{
-			BrokenFileIndexes:      brokenIdx,
-			KnownMissingSegmentIDs: missingIDs,
-		})
-		if err != nil {
-			return "", nil, NewNonRetryableError("failed to parse NZB file", err)
+		if resumed, ok := checkpoints.LoadParsed(ctx, n, filePath); ok {
+			proc.log.InfoContext(ctx, "Resuming import from parsed checkpoint",
+				"file_path", filePath, "queue_id", queueID)
+			parsed = resumed
+		} else {
+			// Pre-parse Stat check — runs before any Body fetches.
+			proc.updateProgressWithStage(ctx, queueID, 0, "Checking segment availability")
+			var missingIDs map[string]struct{}
+			var fastFailErr error
+			samplingStart := time.Now()
+			brokenIdx, missingIDs, fastFailErr = proc.preParseFastFail(ctx, n, cfg, queueID)
+			proc.reportSamplingDuration(ctx, cfg, queueID, time.Since(samplingStart))
+			if fastFailErr != nil {
+				return "", nil, NewNonRetryableError("fast-fail segment check failed", fastFailErr)
+			}
+
+			parseTracker := progress.NewTracker(proc.broadcaster, queueID, 2, 10)
+			parsed, err = proc.parser.ParseNzb(ctx, n, filePath, parseTracker, parser.ParseOptions{
+				BrokenFileIndexes:      brokenIdx,
+				KnownMissingSegmentIDs: missingIDs,
+			})
+			if err != nil {
+				return "", nil, NewNonRetryableError("failed to parse NZB file", err)
+			}
+			checkpoints.SaveParsed(ctx, parsed)
 		}
 
 		// Validate the parsed NZB
@@ -468,7 +600,7 @@ func (proc *Processor) ProcessNzbFile(ctx context.Context, filePath, relativePat
 		parsed.ExtractedFiles = extractedFiles
 	}
 	// Update progress: parsing complete, about to identify file type
-	proc.updateProgressWithStage(queueID, 10, "Identifying files")
+	proc.updateProgressWithStage(ctx, queueID, 10, "Identifying files")
 
 	// Check for cancellation after parsing
 	if err := proc.checkCancellation(ctx); err != nil {
@@ -498,6 +630,10 @@ func (proc *Processor) ProcessNzbFile(ctx context.Context, filePath, relativePat
 		}
 	}
 
+	// Pick the name for the release folder and history, replacing an
+	// obfuscated NZB filename with a recovered release name when possible.
+	names := proc.resolveReleaseNames(ctx, parsed, queueID)
+
 	// Step 2: Calculate virtual directory
 	virtualDir := ""
 	if virtualDirOverride != nil {
@@ -620,12 +756,12 @@ func (proc *Processor) ProcessNzbFile(ctx context.Context, filePath, relativePat
 			enabled: expandEnabled,
 			expand: func(ctx context.Context, enabled bool, contents []archive.Content) ([]archive.Content, error) {
 				return archive.ExpandISOContents(ctx, enabled, contents,
-					proc.poolManager, isoMaxPrefetch, isoReadTimeout, cfg.GetIsoAnalyzeTimeout(), allowedExtensions, isoTracker)
+					proc.poolManager, isoMaxPrefetch, isoReadTimeout, cfg.GetIsoAnalyzeTimeout(), cfg.GetBlurayExtraPlaylistMin(), allowedExtensions, isoTracker)
 			},
 			writeMetadata: func(virtualPath string, meta *metapb.FileMetadata) error {
 				return proc.metadataService.WriteFileMetadataAuto(ctx, virtualPath, meta, storeIndex, storeRef)
 			},
-		}, regularFiles, virtualDir, proc.getCleanNzbName(parsed.Path, queueID), parsed.Path, isoReleaseDate)
+		}, regularFiles, virtualDir, names.nzbName, parsed.Path, isoReleaseDate)
 		if isoErr != nil {
 			return "", writtenPaths, NewNonRetryableError("bare-ISO expansion failed", isoErr)
 		}
@@ -650,30 +786,34 @@ func (proc *Processor) ProcessNzbFile(ctx context.Context, filePath, relativePat
 	var dispatchPaths []string
 	switch parsed.Type {
 	case parser.NzbTypeSingleFile:
-		proc.updateProgressWithStage(queueID, 30, "Validating segments")
-		result, dispatchPaths, err = proc.processSingleFile(ctx, virtualDir, regularFiles, par2Files, parsed.Path, queueID, allowedExtensions, category, metadata, downloadID, storeIndex, storeRef)
+		proc.updateProgressWithStage(ctx, queueID, 30, "Validating segments")
+		result, dispatchPaths, err = proc.processSingleFile(ctx, virtualDir, regularFiles, par2Files, parsed.Path, queueID, names, allowedExtensions, category, metadata, downloadID, storeIndex, storeRef)
 
 	case parser.NzbTypeMultiFile:
-		proc.updateProgressWithStage(queueID, 30, "Writing metadata")
-		result, dispatchPaths, err = proc.processMultiFile(ctx, virtualDir, regularFiles, par2Files, parsed.Path, queueID, allowedExtensions, category, metadata, downloadID, storeIndex, storeRef)
+		proc.updateProgressWithStage(ctx, queueID, 30, "Writing metadata")
+		result, dispatchPaths, err = proc.processMultiFile(ctx, virtualDir, regularFiles, par2Files, parsed.Path, queueID, names, allowedExtensions, category, metadata, downloadID, storeIndex, storeRef)
 
 	case parser.NzbTypeRarArchive:
-		proc.updateProgressWithStage(queueID, 15, "Analyzing archive")
-		result, dispatchPaths, err = proc.processRarArchive(ctx, virtualDir, regularFiles, archiveFiles, parsed, queueID, allowedExtensions, parsed.ExtractedFiles, category, metadata, downloadID, storeIndex, storeRef)
+		proc.updateProgressWithStage(ctx, queueID, 15, "Analyzing archive")
+		result, dispatchPaths, err = proc.processRarArchive(ctx, virtualDir, regularFiles, archiveFiles, parsed, queueID, names, allowedExtensions, parsed.ExtractedFiles, category, metadata, downloadID, storeIndex, storeRef)
 
 	case parser.NzbType7zArchive:
-		proc.updateProgressWithStage(queueID, 15, "Analyzing archive")
-		result, dispatchPaths, err = proc.processSevenZipArchive(ctx, virtualDir, regularFiles, archiveFiles, parsed, queueID, allowedExtensions, parsed.ExtractedFiles, category, metadata, downloadID, storeIndex, storeRef)
+		proc.updateProgressWithStage(ctx, queueID, 15, "Analyzing archive")
+		result, dispatchPaths, err = proc.processSevenZipArchive(ctx, virtualDir, regularFiles, archiveFiles, parsed, queueID, names, allowedExtensions, parsed.ExtractedFiles, category, metadata, downloadID, storeIndex, storeRef)
 
 	case parser.NzbTypeStrm:
-		proc.updateProgressWithStage(queueID, 30, "Validating segments")
-		result, dispatchPaths, err = proc.processSingleFile(ctx, virtualDir, regularFiles, par2Files, parsed.Path, queueID, allowedExtensions, category, metadata, downloadID, storeIndex, storeRef)
+		proc.updateProgressWithStage(ctx, queueID, 30, "Validating segments")
+		result, dispatchPaths, err = proc.processSingleFile(ctx, virtualDir, regularFiles, par2Files, parsed.Path, queueID, names, allowedExtensions, category, metadata, downloadID, storeIndex, storeRef)
 
 	default:
 		return "", writtenPaths, NewNonRetryableError(fmt.Sprintf("unknown file type: %s", parsed.Type), nil)
 	}
 	writtenPaths = append(writtenPaths, dispatchPaths...)
 
+	if err == nil && subtitleSidecars {
+		writtenPaths = proc.organizeSubtitleSidecars(ctx, writtenPaths)
+	}
+
 	// Update progress: complete
 	if err == nil {
 		proc.updateProgress(queueID, 100)
@@ -692,6 +832,7 @@ func (proc *Processor) processSingleFile(
 	par2Files []parser.ParsedFile,
 	nzbPath string,
 	queueID int,
+	names releaseNames,
 	allowedExtensions []string,
 	category *string,
 	metadata *string,
@@ -715,7 +856,7 @@ func (proc *Processor) processSingleFile(
 
 	// Normalize virtualDir only for synthetic duplicate folders; skip if the NZB actually lives inside a
 	// real directory named like the release (e.g. .../Season 01/<file>/<file>.nzb).
-	nzbName := proc.getCleanNzbName(nzbPath, queueID)
+	nzbName := names.nzbName
 	releaseName := nzbtrim.TrimNzbExtension(nzbName)
 	nzbDirBase := filepath.Base(filepath.Dir(nzbPath))
 	fileDir := filepath.Dir(regularFiles[0].Filename)
@@ -780,15 +921,16 @@ func (proc *Processor) processSingleFile(
 	if proc.recorder != nil {
 		nzbID := int64(queueID)
 		if err := proc.recorder.AddImportHistory(ctx, &database.ImportHistory{
-			DownloadID:  downloadID,
-			NzbID:       &nzbID,
-			NzbName:     nzbName,
-			FileName:    finalName,
-			FileSize:    regularFiles[0].Size,
-			VirtualPath: result,
-			Category:    category,
-			Metadata:    metadata,
-			CompletedAt: time.Now(),
+			DownloadID:      downloadID,
+			NzbID:           &nzbID,
+			NzbName:         nzbName,
+			OriginalNzbName: names.original,
+			FileName:        finalName,
+			FileSize:        regularFiles[0].Size,
+			VirtualPath:     result,
+			Category:        category,
+			Metadata:        metadata,
+			CompletedAt:     time.Now(),
 		}); err != nil {
 			proc.log.ErrorContext(ctx, "Failed to add import history", "error", err, "nzb_name", nzbName)
 		}
@@ -805,6 +947,7 @@ func (proc *Processor) processMultiFile(
 	par2Files []parser.ParsedFile,
 	nzbPath string,
 	queueID int,
+	names releaseNames,
 	allowedExtensions []string,
 	category *string,
 	metadata *string,
@@ -823,7 +966,7 @@ func (proc *Processor) processMultiFile(
 		filterSampleFiles = *importCfg.FilterSampleFiles
 	}
 
-	nzbName := proc.getCleanNzbName(nzbPath, queueID)
+	nzbName := names.nzbName
 
 	// Create NZB folder for multi-file imports, even if early fast-fail filtering
 	// leaves only one regular file. The release still originated as a multi-file
@@ -869,6 +1012,7 @@ func (proc *Processor) processMultiFile(
 	if err != nil {
 		return "", writtenPaths, err
 	}
+	writtenPaths = append(writtenPaths, proc.stitchRegularFiles(ctx, targetBaseDir, regularFiles, nzbPath, storeIndex, storeRef)...)
 
 	// Record history
 	if proc.recorder != nil {
@@ -880,15 +1024,16 @@ func (proc *Processor) processMultiFile(
 		}
 
 		if err := proc.recorder.AddImportHistory(ctx, &database.ImportHistory{
-			DownloadID:  downloadID,
-			NzbID:       &nzbID,
-			NzbName:     nzbName,
-			FileName:    filepath.Base(targetBaseDir),
-			FileSize:    totalSize,
-			VirtualPath: targetBaseDir,
-			Category:    category,
-			Metadata:    metadata,
-			CompletedAt: time.Now(),
+			DownloadID:      downloadID,
+			NzbID:           &nzbID,
+			NzbName:         nzbName,
+			OriginalNzbName: names.original,
+			FileName:        filepath.Base(targetBaseDir),
+			FileSize:        totalSize,
+			VirtualPath:     targetBaseDir,
+			Category:        category,
+			Metadata:        metadata,
+			CompletedAt:     time.Now(),
 		}); err != nil {
 			proc.log.ErrorContext(ctx, "Failed to add import history", "error", err, "nzb_name", nzbName)
 		}
@@ -905,6 +1050,7 @@ func (proc *Processor) processRarArchive(
 	archiveFiles []parser.ParsedFile,
 	parsed *parser.ParsedNzb,
 	queueID int,
+	names releaseNames,
 	allowedExtensions []string,
 	extractedFiles []parser.ExtractedFileInfo,
 	category *string,
@@ -923,6 +1069,10 @@ func (proc *Processor) processRarArchive(
 	if importCfg.ExpandBlurayIso != nil {
 		expandBlurayIso = *importCfg.ExpandBlurayIso
 	}
+	stitchMultiPart := true
+	if importCfg.StitchMultiPart != nil {
+		stitchMultiPart = *importCfg.StitchMultiPart
+	}
 	filterSampleFiles := true
 	if importCfg.FilterSampleFiles != nil {
 		filterSampleFiles = *importCfg.FilterSampleFiles
@@ -933,7 +1083,7 @@ func (proc *Processor) processRarArchive(
 	}
 
 	// Create NZB folder
-	nzbName := proc.getCleanNzbName(parsed.Path, queueID)
+	nzbName := names.nzbName
 	nzbFolder, err := filesystem.CreateNzbFolder(virtualDir, nzbName, proc.metadataService)
 	if err != nil {
 		return nzbFolder, nil, err
@@ -991,12 +1141,15 @@ func (proc *Processor) processRarArchive(
 			MaxPrefetch:            maxPrefetch,
 			ReadTimeout:            readTimeout,
 			IsoAnalyzeTimeout:      proc.configGetter().GetIsoAnalyzeTimeout(),
+			BlurayExtraPlaylistMin: proc.configGetter().GetBlurayExtraPlaylistMin(),
 			ExpandBlurayIso:        expandBlurayIso,
+			StitchMultiPart:        stitchMultiPart,
 			FilterSamples:          filterSampleFiles,
 			RenameToNzbName:        renameToNzbName,
 			SegmentIndex:           storeIndex,
 			StoreRef:               storeRef,
 		})
+		recordArchive(ctx, "rar", archiveFiles, parsed.GetPassword(), err)
 		if err != nil {
 			return nzbFolder, writtenPaths, err
 		}
@@ -1013,15 +1166,16 @@ func (proc *Processor) processRarArchive(
 		}
 
 		if err := proc.recorder.AddImportHistory(ctx, &database.ImportHistory{
-			DownloadID:  downloadID,
-			NzbID:       &nzbID,
-			NzbName:     nzbName,
-			FileName:    filepath.Base(nzbFolder),
-			FileSize:    totalSize,
-			VirtualPath: nzbFolder,
-			Category:    category,
-			Metadata:    metadata,
-			CompletedAt: time.Now(),
+			DownloadID:      downloadID,
+			NzbID:           &nzbID,
+			NzbName:         nzbName,
+			OriginalNzbName: names.original,
+			FileName:        filepath.Base(nzbFolder),
+			FileSize:        totalSize,
+			VirtualPath:     nzbFolder,
+			Category:        category,
+			Metadata:        metadata,
+			CompletedAt:     time.Now(),
 		}); err != nil {
 			proc.log.ErrorContext(ctx, "Failed to add import history", "error", err, "nzb_name", nzbName)
 		}
@@ -1030,6 +1184,41 @@ func (proc *Processor) processRarArchive(
 	return nzbFolder, writtenPaths, nil
 }
 
+// recordArchive adds an archive set's layout, and the password tried against
+// it, to the import report carried by ctx.
+func recordArchive(ctx context.Context, format string, archiveFiles []parser.ParsedFile, password string, err error) {
+	rec := report.FromContext(ctx)
+	if rec == nil {
+		return
+	}
+	layout := report.Archive{
+		Format:   format,
+		Volumes:  len(archiveFiles),
+		Password: password != "",
+	}
+	sets := make(map[string]bool)
+	for _, f := range archiveFiles {
+		layout.Size += f.Size
+		layout.Files = append(layout.Files, f.Filename)
+		if key, ok := archive.SetKey(f.Filename); ok && !sets[key] {
+			sets[key] = true
+			layout.Sets = append(layout.Sets, key)
+		}
+	}
+	if err != nil {
+		layout.Error = err.Error()
+	}
+	rec.Archive(layout)
+
+	if password != "" {
+		attempt := report.PasswordAttempt{Source: "nzb", Format: format, Success: err == nil}
+		if err != nil {
+			attempt.Error = err.Error()
+		}
+		rec.PasswordAttempt(attempt)
+	}
+}
+
 // processSevenZipArchive handles 7zip archive imports
 func (proc *Processor) processSevenZipArchive(
 	ctx context.Context,
@@ -1038,6 +1227,7 @@ func (proc *Processor) processSevenZipArchive(
 	archiveFiles []parser.ParsedFile,
 	parsed *parser.ParsedNzb,
 	queueID int,
+	names releaseNames,
 	allowedExtensions []string,
 	extractedFiles []parser.ExtractedFileInfo,
 	category *string,
@@ -1056,6 +1246,10 @@ func (proc *Processor) processSevenZipArchive(
 	if importCfg.ExpandBlurayIso != nil {
 		expandBlurayIso = *importCfg.ExpandBlurayIso
 	}
+	stitchMultiPart := true
+	if importCfg.StitchMultiPart != nil {
+		stitchMultiPart = *importCfg.StitchMultiPart
+	}
 	filterSampleFiles := true
 	if importCfg.FilterSampleFiles != nil {
 		filterSampleFiles = *importCfg.FilterSampleFiles
@@ -1066,7 +1260,7 @@ func (proc *Processor) processSevenZipArchive(
 	}
 
 	// Create NZB folder
-	nzbName := proc.getCleanNzbName(parsed.Path, queueID)
+	nzbName := names.nzbName
 	nzbFolder, err := filesystem.CreateNzbFolder(virtualDir, nzbName, proc.metadataService)
 	if err != nil {
 		return nzbFolder, nil, err
@@ -1123,12 +1317,15 @@ func (proc *Processor) processSevenZipArchive(
 			MaxPrefetch:            maxPrefetch,
 			ReadTimeout:            readTimeout,
 			IsoAnalyzeTimeout:      proc.configGetter().GetIsoAnalyzeTimeout(),
+			BlurayExtraPlaylistMin: proc.configGetter().GetBlurayExtraPlaylistMin(),
 			ExpandBlurayIso:        expandBlurayIso,
+			StitchMultiPart:        stitchMultiPart,
 			FilterSamples:          filterSampleFiles,
 			RenameToNzbName:        renameToNzbName,
 			SegmentIndex:           storeIndex,
 			StoreRef:               storeRef,
 		})
+		recordArchive(ctx, "7z", archiveFiles, parsed.GetPassword(), err)
 		if err != nil {
 			return nzbFolder, writtenPaths, err
 		}
@@ -1145,15 +1342,16 @@ func (proc *Processor) processSevenZipArchive(
 		}
 
 		if err := proc.recorder.AddImportHistory(ctx, &database.ImportHistory{
-			DownloadID:  downloadID,
-			NzbID:       &nzbID,
-			NzbName:     nzbName,
-			FileName:    filepath.Base(nzbFolder),
-			FileSize:    totalSize,
-			VirtualPath: nzbFolder,
-			Category:    category,
-			Metadata:    metadata,
-			CompletedAt: time.Now(),
+			DownloadID:      downloadID,
+			NzbID:           &nzbID,
+			NzbName:         nzbName,
+			OriginalNzbName: names.original,
+			FileName:        filepath.Base(nzbFolder),
+			FileSize:        totalSize,
+			VirtualPath:     nzbFolder,
+			Category:        category,
+			Metadata:        metadata,
+			CompletedAt:     time.Now(),
 		}); err != nil {
 			proc.log.ErrorContext(ctx, "Failed to add import history", "error", err, "nzb_name", nzbName)
 		}
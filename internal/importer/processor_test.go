@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/javi11/altmount/internal/importer/filesystem"
 	"github.com/javi11/altmount/internal/importer/multifile"
 	"github.com/javi11/altmount/internal/importer/parser"
+	"github.com/javi11/altmount/internal/importer/report"
 	"github.com/javi11/altmount/internal/metadata"
 	metapb "github.com/javi11/altmount/internal/metadata/proto"
 	"github.com/javi11/altmount/internal/pool"
@@ -90,6 +92,43 @@ func TestPreParseFastFailSkipsOnlyMissingEpisode(t *testing.T) {
 	}
 }
 
+// TestPreParseFastFailRecordsReport verifies the fast-fail sweep lands in the
+// import report carried by ctx, with the provider response of each miss.
+func TestPreParseFastFailRecordsReport(t *testing.T) {
+	client := fakepool.New()
+	client.SetBehavior("missing-segment", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	proc := &Processor{
+		poolManager:       processorTestPoolManager{client: client},
+		validationTimeout: 100 * time.Millisecond,
+	}
+	n := buildTestNzb([]testNzbFile{
+		{name: "Show.S01E01.mkv", segID: "healthy-segment"},
+		{name: "Show.S01E02.mkv", segID: "missing-segment"},
+	})
+	cfg := config.DefaultConfig()
+	cfg.Import.SegmentSamplePercentage = 100
+
+	rec := report.NewRecorder(1, "show.nzb")
+	if _, _, err := proc.preParseFastFail(report.WithRecorder(context.Background(), rec), n, cfg, 1); err != nil {
+		t.Fatalf("preParseFastFail returned error: %v", err)
+	}
+
+	ff := rec.Finish(nil).FastFail
+	if ff == nil || !ff.ProbeMissing || len(ff.Files) != 2 {
+		t.Fatalf("fast-fail report = %+v", ff)
+	}
+	if ff.Files[0].Verdict != "ok" {
+		t.Errorf("healthy file verdict = %q, want ok", ff.Files[0].Verdict)
+	}
+	broken := ff.Files[1]
+	if broken.Verdict != "broken" || len(broken.Missing) != 1 || broken.Missing[0].ID != "missing-segment" {
+		t.Fatalf("broken file sample = %+v", broken)
+	}
+	if broken.Missing[0].Response == "" {
+		t.Error("missing segment has no provider response")
+	}
+}
+
 // TestPreParseFastFailDoesNotStatPar2 verifies PAR2 segments are skipped entirely
 // from the fast-fail Stat sweep: an unreachable PAR2 segment must neither be
 // Stat-checked nor mark the import broken.
@@ -304,6 +343,7 @@ func TestProcessMultiFilePreservesReleaseFolderWhenOnlyOneFileRemains(t *testing
 		nil,
 		"Show.S01.nzb",
 		1,
+		releaseNames{nzbName: "Show.S01.nzb"},
 		[]string{".mkv"},
 		nil,
 		nil,
@@ -327,6 +367,61 @@ func TestProcessMultiFilePreservesReleaseFolderWhenOnlyOneFileRemains(t *testing
 	}
 }
 
+func TestProcessMultiFileStitchesMultiPartMovie(t *testing.T) {
+	client := fakepool.New()
+	metaRoot := t.TempDir()
+	cfg := config.DefaultConfig()
+	proc := &Processor{
+		metadataService:   metadata.NewMetadataService(metaRoot),
+		poolManager:       processorTestPoolManager{client: client},
+		configGetter:      func() *config.Config { return cfg },
+		validationTimeout: 100 * time.Millisecond,
+	}
+
+	_, writtenPaths, err := proc.processMultiFile(
+		context.Background(),
+		"movies",
+		[]parser.ParsedFile{
+			processorTestParsedFile("Movie.1999.CD1.mpg", "cd1-segment"),
+			processorTestParsedFile("Movie.1999.CD2.mpg", "cd2-segment"),
+		},
+		nil,
+		"Movie.1999.nzb",
+		1,
+		releaseNames{nzbName: "Movie.1999.nzb"},
+		[]string{".mpg"},
+		nil,
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	if err != nil {
+		t.Fatalf("processMultiFile returned error: %v", err)
+	}
+
+	wantPath := "movies/Movie.1999/Movie.1999.mpg"
+	if !slices.Contains(writtenPaths, wantPath) || len(writtenPaths) != 3 {
+		t.Fatalf("writtenPaths = %v, want both parts plus %q", writtenPaths, wantPath)
+	}
+	meta, err := proc.metadataService.ReadFileMetadata(wantPath)
+	if err != nil {
+		t.Fatalf("reading stitched metadata: %v", err)
+	}
+	if meta.FileSize != 200 || len(meta.NestedSources) != 2 {
+		t.Fatalf("stitched file size %d with %d sources, want 200 with 2", meta.FileSize, len(meta.NestedSources))
+	}
+
+	disabled := false
+	cfg.Import.StitchMultiPart = &disabled
+	if got := proc.stitchRegularFiles(context.Background(), "movies/Other", []parser.ParsedFile{
+		processorTestParsedFile("Other.CD1.mpg", "a"),
+		processorTestParsedFile("Other.CD2.mpg", "b"),
+	}, "Other.nzb", nil, ""); len(got) != 0 {
+		t.Fatalf("stitch_multi_part=false still wrote %v", got)
+	}
+}
+
 func processorTestParsedFile(filename, segmentID string) parser.ParsedFile {
 	return parser.ParsedFile{
 		Filename: filename,
@@ -15,20 +15,24 @@ import (
 
 // QueueRepository defines the interface for queue database operations
 type QueueRepository interface {
-	ClaimNextQueueItem(ctx context.Context) (*database.ImportQueueItem, error)
+	ClaimNextQueueItemWithRules(ctx context.Context, rules database.ClaimRules) (*database.ImportQueueItem, error)
 }
 
 // Claimer handles claiming queue items with retry logic
 type Claimer struct {
-	repo QueueRepository
-	log  *slog.Logger
+	repo  QueueRepository
+	rules func() database.ClaimRules
+	log   *slog.Logger
 }
 
-// NewClaimer creates a new Claimer
-func NewClaimer(repo QueueRepository) *Claimer {
+// NewClaimer creates a new Claimer. rules is evaluated before every claim so
+// scheduling windows open and close without restarting workers; nil applies
+// no category restrictions.
+func NewClaimer(repo QueueRepository, rules func() database.ClaimRules) *Claimer {
 	return &Claimer{
-		repo: repo,
-		log:  slog.Default().With("component", "queue-claimer"),
+		repo:  repo,
+		rules: rules,
+		log:   slog.Default().With("component", "queue-claimer"),
 	}
 }
 
@@ -36,9 +40,14 @@ func NewClaimer(repo QueueRepository) *Claimer {
 func (c *Claimer) ClaimWithRetry(ctx context.Context, workerID int) (*database.ImportQueueItem, error) {
 	var item *database.ImportQueueItem
 
+	var rules database.ClaimRules
+	if c.rules != nil {
+		rules = c.rules()
+	}
+
 	err := retry.Do(
 		func() error {
-			claimedItem, err := c.repo.ClaimNextQueueItem(ctx)
+			claimedItem, err := c.repo.ClaimNextQueueItemWithRules(ctx, rules)
 			if err != nil {
 				return err
 			}
@@ -74,7 +74,7 @@ func NewManager(cfg ManagerConfig, repository *database.QueueRepository, process
 	return &Manager{
 		config:       cfg,
 		repository:   repository,
-		claimer:      NewClaimer(repository),
+		claimer:      NewClaimer(repository, claimRulesFunc(cfg.ConfigGetter)),
 		processor:    processor,
 		listener:     listener,
 		configGetter: cfg.ConfigGetter,
@@ -0,0 +1,42 @@
+package queue
+
+import (
+	"time"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/database"
+)
+
+// ClaimRulesFromConfig builds the claim restrictions implied by the SABnzbd
+// category settings at time now: categories outside their scheduling window
+// are blocked and per-category concurrency caps are passed through.
+func ClaimRulesFromConfig(cfg *config.Config, now time.Time) database.ClaimRules {
+	rules := database.ClaimRules{DefaultCategory: config.DefaultCategoryName}
+	if cfg == nil {
+		return rules
+	}
+
+	for _, cat := range cfg.SABnzbd.Categories {
+		if !cat.InScheduleWindow(now) {
+			rules.BlockedCategories = append(rules.BlockedCategories, cat.Name)
+		}
+		if cat.MaxConcurrent > 0 {
+			if rules.CategoryLimits == nil {
+				rules.CategoryLimits = make(map[string]int)
+			}
+			rules.CategoryLimits[cat.Name] = cat.MaxConcurrent
+		}
+	}
+
+	return rules
+}
+
+// claimRulesFunc adapts a config getter into the Claimer's rules callback.
+func claimRulesFunc(getter config.ConfigGetter) func() database.ClaimRules {
+	if getter == nil {
+		return nil
+	}
+	return func() database.ClaimRules {
+		return ClaimRulesFromConfig(getter(), time.Now())
+	}
+}
@@ -0,0 +1,211 @@
+package rarname
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+)
+
+var (
+	rar4Signature = []byte("Rar!\x1a\x07\x00")
+	rar5Signature = []byte("Rar!\x1a\x07\x01\x00")
+)
+
+// FirstEntryName returns the name of the first file stored in a RAR archive,
+// read from the leading bytes of its first volume (the parser keeps up to 16KB
+// of every first segment). It understands RAR4 and RAR5 headers. ok is false
+// when b is not a RAR volume, the headers are encrypted, or the first file
+// header is not fully contained in b. Directory entries are skipped.
+func FirstEntryName(b []byte) (string, bool) {
+	switch {
+	case bytes.HasPrefix(b, rar5Signature):
+		return firstEntryNameRar5(b[len(rar5Signature):])
+	case bytes.HasPrefix(b, rar4Signature):
+		return firstEntryNameRar4(b[len(rar4Signature):])
+	default:
+		return "", false
+	}
+}
+
+// firstEntryNameRar5 walks RAR5 blocks: CRC32, header size (vint), then the
+// header body starting with type and flags.
+func firstEntryNameRar5(b []byte) (string, bool) {
+	const (
+		headerFile       = 2
+		headerEncryption = 4
+
+		flagExtraArea = 0x01
+		flagDataArea  = 0x02
+
+		fileFlagDirectory = 0x01
+		fileFlagMtime     = 0x02
+		fileFlagCRC       = 0x04
+	)
+
+	pos := 0
+	for pos+4 < len(b) {
+		r := vintReader{b: b, pos: pos + 4}
+		size, ok := r.next()
+		if !ok || size == 0 {
+			return "", false
+		}
+		bodyStart := r.pos
+		end := bodyStart + int(size)
+		if end > len(b) || end < bodyStart {
+			return "", false
+		}
+
+		typ, ok1 := r.next()
+		flags, ok2 := r.next()
+		if !ok1 || !ok2 {
+			return "", false
+		}
+		if flags&flagExtraArea != 0 {
+			if _, ok := r.next(); !ok {
+				return "", false
+			}
+		}
+		var dataSize uint64
+		if flags&flagDataArea != 0 {
+			if dataSize, ok = r.next(); !ok {
+				return "", false
+			}
+		}
+
+		switch typ {
+		case headerEncryption:
+			return "", false
+		case headerFile:
+			fileFlags, ok := r.next()
+			if !ok {
+				return "", false
+			}
+			if _, ok := r.next(); !ok { // unpacked size
+				return "", false
+			}
+			if _, ok := r.next(); !ok { // attributes
+				return "", false
+			}
+			if fileFlags&fileFlagMtime != 0 {
+				r.pos += 4
+			}
+			if fileFlags&fileFlagCRC != 0 {
+				r.pos += 4
+			}
+			if _, ok := r.next(); !ok { // compression info
+				return "", false
+			}
+			if _, ok := r.next(); !ok { // host OS
+				return "", false
+			}
+			nameLen, ok := r.next()
+			if !ok || r.pos+int(nameLen) > end {
+				return "", false
+			}
+			if fileFlags&fileFlagDirectory == 0 && nameLen > 0 {
+				return string(b[r.pos : r.pos+int(nameLen)]), true
+			}
+		}
+
+		next := uint64(end) + dataSize
+		if next > uint64(len(b)) {
+			return "", false
+		}
+		pos = int(next)
+	}
+	return "", false
+}
+
+// firstEntryNameRar4 walks RAR 1.5–4.x blocks: CRC16, type, flags, size.
+func firstEntryNameRar4(b []byte) (string, bool) {
+	const (
+		blockMain = 0x73
+		blockFile = 0x74
+
+		flagLongBlock     = 0x8000
+		mainFlagPassword  = 0x0080
+		fileFlagLarge     = 0x0100
+		fileFlagUnicode   = 0x0200
+		fileFlagDirectory = 0x00e0
+	)
+
+	pos := 0
+	for pos+7 <= len(b) {
+		typ := b[pos+2]
+		flags := binary.LittleEndian.Uint16(b[pos+3:])
+		size := int(binary.LittleEndian.Uint16(b[pos+5:]))
+		if size < 7 || pos+size > len(b) {
+			return "", false
+		}
+		block := b[pos : pos+size]
+
+		switch typ {
+		case blockMain:
+			if flags&mainFlagPassword != 0 {
+				return "", false
+			}
+		case blockFile:
+			nameOff := 32
+			if flags&fileFlagLarge != 0 {
+				nameOff += 8
+			}
+			if len(block) < 28 {
+				return "", false
+			}
+			nameLen := int(binary.LittleEndian.Uint16(block[26:]))
+			if nameOff+nameLen > len(block) {
+				return "", false
+			}
+			if flags&fileFlagDirectory == fileFlagDirectory {
+				break
+			}
+			name := block[nameOff : nameOff+nameLen]
+			if flags&fileFlagUnicode != 0 {
+				// The ASCII form precedes a NUL and the packed Unicode form.
+				if i := bytes.IndexByte(name, 0); i >= 0 {
+					name = name[:i]
+				}
+			}
+			if len(name) == 0 {
+				return "", false
+			}
+			return strings.ReplaceAll(string(name), `\`, "/"), true
+		}
+
+		next := pos + size
+		if flags&flagLongBlock != 0 {
+			if size < 11 {
+				return "", false
+			}
+			next += int(binary.LittleEndian.Uint32(block[7:]))
+		}
+		if next <= pos {
+			return "", false
+		}
+		pos = next
+	}
+	return "", false
+}
+
+// vintReader decodes RAR5 variable-length integers (7 bits per byte, high
+// bit set on every byte but the last).
+type vintReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *vintReader) next() (uint64, bool) {
+	var v uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		if r.pos >= len(r.b) {
+			return 0, false
+		}
+		c := r.b[r.pos]
+		r.pos++
+		v |= uint64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			return v, true
+		}
+	}
+	return 0, false
+}
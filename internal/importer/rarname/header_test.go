@@ -0,0 +1,120 @@
+package rarname
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// rar5Block builds a RAR5 block with a zero CRC and a one-byte size vint.
+func rar5Block(body ...byte) []byte {
+	return append([]byte{0, 0, 0, 0, byte(len(body))}, body...)
+}
+
+func rar5FileHeader(name string, dir bool) []byte {
+	fileFlags := byte(0)
+	if dir {
+		fileFlags = 0x01
+	}
+	body := []byte{
+		2,         // header type: file
+		0,         // header flags
+		fileFlags, // file flags
+		0,         // unpacked size
+		0,         // attributes
+		0,         // compression info
+		0,         // host OS
+		byte(len(name)),
+	}
+	return rar5Block(append(body, name...)...)
+}
+
+func rar4FileHeader(name string, flags uint16) []byte {
+	block := make([]byte, 32, 32+len(name))
+	block[2] = 0x74
+	binary.LittleEndian.PutUint16(block[3:], flags)
+	binary.LittleEndian.PutUint16(block[5:], uint16(32+len(name)))
+	binary.LittleEndian.PutUint16(block[26:], uint16(len(name)))
+	return append(block, name...)
+}
+
+func rar4MainHeader(flags uint16) []byte {
+	block := make([]byte, 13)
+	block[2] = 0x73
+	binary.LittleEndian.PutUint16(block[3:], flags)
+	binary.LittleEndian.PutUint16(block[5:], 13)
+	return block
+}
+
+func TestFirstEntryName(t *testing.T) {
+	rar5 := func(blocks ...[]byte) []byte {
+		b := append([]byte(nil), rar5Signature...)
+		for _, blk := range blocks {
+			b = append(b, blk...)
+		}
+		return b
+	}
+	rar4 := func(blocks ...[]byte) []byte {
+		b := append([]byte(nil), rar4Signature...)
+		for _, blk := range blocks {
+			b = append(b, blk...)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "rar5 first file",
+			data:   rar5(rar5Block(1, 0, 0), rar5FileHeader("Movie.2020.1080p.mkv", false)),
+			want:   "Movie.2020.1080p.mkv",
+			wantOK: true,
+		},
+		{
+			name:   "rar5 skips directory",
+			data:   rar5(rar5Block(1, 0, 0), rar5FileHeader("Movie", true), rar5FileHeader("Movie/Movie.mkv", false)),
+			want:   "Movie/Movie.mkv",
+			wantOK: true,
+		},
+		{
+			name: "rar5 encrypted headers",
+			data: rar5(rar5Block(4, 0, 0, 0)),
+		},
+		{
+			name:   "rar4 first file",
+			data:   rar4(rar4MainHeader(0), rar4FileHeader(`Show\Show.S01E01.mkv`, 0)),
+			want:   "Show/Show.S01E01.mkv",
+			wantOK: true,
+		},
+		{
+			name:   "rar4 unicode name keeps ascii form",
+			data:   rar4(rar4MainHeader(0), rar4FileHeader("Show.S01E02.mkv\x00\x01\x02", 0x0200)),
+			want:   "Show.S01E02.mkv",
+			wantOK: true,
+		},
+		{
+			name: "rar4 password protected",
+			data: rar4(rar4MainHeader(0x0080), rar4FileHeader("Movie.mkv", 0)),
+		},
+		{
+			name: "truncated header",
+			data: rar4(rar4MainHeader(0), rar4FileHeader("Movie.mkv", 0)[:20]),
+		},
+		{
+			name: "not a rar volume",
+			data: []byte("7z\xbc\xaf\x27\x1c"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FirstEntryName(tt.data)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("FirstEntryName() = (%q, %t); want (%q, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/javi11/altmount/internal/importer/parser"
+	"github.com/javi11/altmount/internal/importer/rarname"
+	"github.com/javi11/altmount/internal/importer/releasename"
+	"github.com/javi11/altmount/internal/nzbfile"
+)
+
+// releaseNames carries the NZB name used for the release folder and history,
+// plus the original NZB filename when an obfuscated one was replaced.
+type releaseNames struct {
+	nzbName  string
+	original *string
+}
+
+// resolveReleaseNames picks the NZB name for the release folder and history.
+// When the NZB filename is obfuscated and recover_obfuscated_names is enabled
+// (the default), a readable name recovered from the NZB title, the PAR2 index
+// or the archive contents replaces it and the original is kept as an alias.
+func (proc *Processor) resolveReleaseNames(ctx context.Context, parsed *parser.ParsedNzb, queueID int) releaseNames {
+	names := releaseNames{nzbName: proc.getCleanNzbName(parsed.Path, queueID)}
+
+	if parsed.Type == parser.NzbTypeStrm {
+		return names
+	}
+	if enabled := proc.configGetter().Import.RecoverObfuscatedNames; enabled != nil && !*enabled {
+		return names
+	}
+
+	best, ok := releasename.Recover(names.nzbName, releaseNameCandidates(parsed))
+	if !ok {
+		return names
+	}
+
+	original := names.nzbName
+	names.original = &original
+	names.nzbName = best.Name + nzbfile.PlainExtension
+
+	proc.log.InfoContext(ctx, "Recovered release name for obfuscated NZB",
+		"queue_id", queueID,
+		"nzb_name", original,
+		"release_name", best.Name,
+		"source", best.Source)
+
+	return names
+}
+
+// releaseNameCandidates collects every name the parsed NZB offers for
+// release-name recovery.
+func releaseNameCandidates(parsed *parser.ParsedNzb) []releasename.Candidate {
+	var candidates []releasename.Candidate
+	if parsed.Title != "" {
+		candidates = append(candidates, releasename.Candidate{Name: parsed.Title, Source: releasename.SourceNzbMeta})
+	}
+	for _, f := range parsed.Par2Files {
+		candidates = append(candidates, releasename.Candidate{Name: f.Name, Source: releasename.SourcePar2, Size: f.Size})
+	}
+	for _, f := range parsed.ExtractedFiles {
+		candidates = append(candidates, releasename.Candidate{Name: f.Name, Source: releasename.SourceArchive, Size: f.Size})
+	}
+
+	var archiveSize int64
+	for _, f := range parsed.Files {
+		if f.IsRarArchive || f.Is7zArchive {
+			archiveSize += f.Size
+		}
+	}
+
+	for _, f := range parsed.Files {
+		switch {
+		case f.IsPar2Archive:
+		case f.IsRarArchive || f.Is7zArchive:
+			candidates = append(candidates, releasename.Candidate{Name: f.Filename, Source: releasename.SourceArchive, Size: f.Size})
+			// The first volume's leading bytes hold the first inner file header;
+			// rank it with the whole set's size so it beats individual volumes.
+			if inner, ok := rarname.FirstEntryName(f.FirstSegmentBytes); ok {
+				candidates = append(candidates, releasename.Candidate{Name: inner, Source: releasename.SourceArchive, Size: archiveSize})
+			}
+		default:
+			candidates = append(candidates, releasename.Candidate{Name: f.Filename, Source: releasename.SourceFile, Size: f.Size})
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,190 @@
+// Package releasename recovers a human-readable release name for NZBs whose
+// own filename is obfuscated (e.g. a random hash from a search-by-ID grab),
+// using names the import already has at hand: the NZB <meta> title, PAR2 file
+// descriptors and the names of archive volumes and their first inner file.
+package releasename
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/javi11/altmount/internal/importer/parser/fileinfo"
+	"github.com/javi11/altmount/internal/importer/rarname"
+	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
+)
+
+// Source identifies where a candidate name came from. Sources are ranked in
+// the order declared: an explicit NZB title beats PAR2 descriptors, which beat
+// archive names, which beat the parsed file names.
+type Source string
+
+const (
+	SourceNzbMeta Source = "nzb_meta"
+	SourcePar2    Source = "par2"
+	SourceArchive Source = "archive"
+	SourceFile    Source = "file"
+)
+
+var sourceRank = map[Source]int{
+	SourceNzbMeta: 0,
+	SourcePar2:    1,
+	SourceArchive: 2,
+	SourceFile:    3,
+}
+
+var (
+	par2VolumePattern = regexp.MustCompile(`(?i)\.vol\d+\+\d+$`)
+	shortExtPattern   = regexp.MustCompile(`^\.[A-Za-z0-9]{2,4}$`)
+	samplePattern     = regexp.MustCompile(`(?i)(^|[\W_])sample([\W_]|$)`)
+	groupPattern      = regexp.MustCompile(`-([A-Za-z0-9]+)$`)
+	taggedPattern     = regexp.MustCompile(`\s*[\[(][^\[\]()]*[\])]$`)
+)
+
+// notGroups are trailing dash-suffixed tokens that belong to the source tag,
+// not a release group (Movie.2020.WEB-DL).
+var notGroups = map[string]bool{"dl": true, "rip": true}
+
+// Candidate is a possible release name. Size breaks ties within a source so
+// the main feature wins over extras.
+type Candidate struct {
+	Name   string
+	Source Source
+	Size   int64
+}
+
+// Clean reduces a filename to a release-name stem: directories, archive
+// volume suffixes (.partNN.rar, .rNN, .7z.NNN), PAR2 volume markers and a
+// short trailing extension are removed. Case is preserved.
+func Clean(name string) string {
+	base := path.Base(filepath.ToSlash(strings.TrimSpace(name)))
+	if base == "." || base == "/" {
+		return ""
+	}
+
+	if key, ok := rarname.SetKey(base); ok && isVolumeName(base) && len(key) <= len(base) {
+		base = base[:len(key)]
+		if strings.EqualFold(filepath.Ext(base), ".7z") {
+			base = base[:len(base)-3]
+		}
+	} else {
+		if strings.EqualFold(filepath.Ext(base), ".par2") {
+			base = base[:len(base)-len(".par2")]
+			base = par2VolumePattern.ReplaceAllString(base, "")
+		} else if ext := filepath.Ext(base); shortExtPattern.MatchString(ext) && !isAllDigits(ext[1:]) {
+			base = base[:len(base)-len(ext)]
+		}
+	}
+
+	return strings.Trim(nzbtrim.TrimSurroundingQuotes(base), " .-_")
+}
+
+// IsObfuscated reports whether a release-name stem looks obfuscated.
+func IsObfuscated(stem string) bool {
+	if strings.TrimSpace(stem) == "" {
+		return true
+	}
+	// IsProbablyObfuscated strips the last extension, so give it one to strip.
+	return fileinfo.IsProbablyObfuscated(stem + ".nzb")
+}
+
+// Recover returns the best readable release name for an NZB. ok is false when
+// nzbName (the NZB filename) is not obfuscated or no candidate is readable, in
+// which case the NZB name should be kept.
+func Recover(nzbName string, candidates []Candidate) (Candidate, bool) {
+	if !IsObfuscated(nzbtrim.TrimNzbExtension(filepath.Base(nzbName))) {
+		return Candidate{}, false
+	}
+
+	ranked := make([]Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		stem := c.Name
+		if c.Source != SourceNzbMeta {
+			stem = Clean(stem)
+		} else {
+			stem = strings.TrimSpace(stem)
+		}
+		if IsObfuscated(stem) || samplePattern.MatchString(stem) {
+			continue
+		}
+		c.Name = sanitize(stem)
+		if c.Name == "" {
+			continue
+		}
+		ranked = append(ranked, c)
+	}
+	if len(ranked) == 0 {
+		return Candidate{}, false
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		ri, rj := sourceRank[ranked[i].Source], sourceRank[ranked[j].Source]
+		if ri != rj {
+			return ri < rj
+		}
+		return ranked[i].Size > ranked[j].Size
+	})
+	return ranked[0], true
+}
+
+// Group returns the release group of a scene-style release name
+// (Show.S01E01.1080p.WEB-GRP), ignoring a trailing indexer tag such as
+// [rartv]. It returns "" for obfuscated names and names without a group.
+func Group(name string) string {
+	stem := Clean(name)
+	for taggedPattern.MatchString(stem) {
+		stem = taggedPattern.ReplaceAllString(stem, "")
+	}
+	if IsObfuscated(stem) {
+		return ""
+	}
+	m := groupPattern.FindStringSubmatch(stem)
+	if m == nil || notGroups[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
+
+// sanitize makes a name safe for use as a single virtual path component.
+func sanitize(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		if r < 0x20 {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Trim(strings.TrimSpace(name), ".")
+	if len(name) > 200 {
+		name = name[:200]
+	}
+	return name
+}
+
+// isVolumeName reports whether base carries an archive volume suffix. Plain
+// numeric extensions only count with exactly three digits (.001), so a
+// trailing year such as Movie.2020 is not mistaken for a split volume.
+func isVolumeName(base string) bool {
+	scheme, _, ok := rarname.VolumeNumber(base)
+	if !ok {
+		return false
+	}
+	if scheme == rarname.SchemeNumeric {
+		return len(filepath.Ext(base)) == 4
+	}
+	return true
+}
+
+func isAllDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,99 @@
+package releasename
+
+import "testing"
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Movie.2020.1080p.BluRay-GRP.part01.rar", "Movie.2020.1080p.BluRay-GRP"},
+		{"Movie.2020.1080p.BluRay-GRP.r00", "Movie.2020.1080p.BluRay-GRP"},
+		{"Movie.2020.1080p.BluRay-GRP.7z.001", "Movie.2020.1080p.BluRay-GRP"},
+		{"Movie.2020.1080p.BluRay-GRP.vol03+04.par2", "Movie.2020.1080p.BluRay-GRP"},
+		{"Movie.2020.1080p.BluRay-GRP.par2", "Movie.2020.1080p.BluRay-GRP"},
+		{"folder/Movie.2020.1080p.BluRay-GRP.mkv", "Movie.2020.1080p.BluRay-GRP"},
+		{"Movie.2020", "Movie.2020"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Clean(tt.in); got != tt.want {
+				t.Errorf("Clean(%q) = %q; want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Movie.2020.1080p.BluRay-GRP.nzb", "GRP"},
+		{"tv/Show.S01E01.1080p.WEB-h264-GRP.mkv", "GRP"},
+		{"Show.S01E01.1080p.WEB-GRP[rartv].nzb", "GRP"},
+		{"Movie.2020.1080p.WEB-DL.mkv", ""},
+		{"Movie.2020.1080p.mkv", ""},
+		{"a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6.nzb", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Group(tt.in); got != tt.want {
+				t.Errorf("Group(%q) = %q; want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	const obfuscated = "a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6.nzb"
+
+	t.Run("keeps readable nzb name", func(t *testing.T) {
+		_, ok := Recover("Movie.2020.1080p.BluRay-GRP.nzb", []Candidate{
+			{Name: "Other.Name.2021.mkv", Source: SourceFile},
+		})
+		if ok {
+			t.Fatal("expected no recovery for a readable NZB name")
+		}
+	})
+
+	t.Run("prefers higher ranked source", func(t *testing.T) {
+		got, ok := Recover(obfuscated, []Candidate{
+			{Name: "Movie.2020.1080p.BluRay-GRP.mkv", Source: SourceFile, Size: 10},
+			{Name: "Movie.2020.1080p.BluRay-GRP.part01.rar", Source: SourceArchive, Size: 5},
+			{Name: "Movie.2020.1080p.BluRay-GRP.vol00+01.par2", Source: SourcePar2},
+		})
+		if !ok || got.Source != SourcePar2 || got.Name != "Movie.2020.1080p.BluRay-GRP" {
+			t.Errorf("Recover() = (%+v, %t); want par2 candidate", got, ok)
+		}
+	})
+
+	t.Run("skips obfuscated and sample candidates", func(t *testing.T) {
+		got, ok := Recover(obfuscated, []Candidate{
+			{Name: "9f8e7d6c5b4a39281706f5e4d3c2b1a0.par2", Source: SourcePar2},
+			{Name: "Movie.2020.1080p.BluRay-GRP.sample.mkv", Source: SourceFile, Size: 100},
+			{Name: "Movie.2020.1080p.BluRay-GRP.mkv", Source: SourceFile, Size: 50},
+		})
+		if !ok || got.Name != "Movie.2020.1080p.BluRay-GRP" {
+			t.Errorf("Recover() = (%+v, %t); want main feature", got, ok)
+		}
+	})
+
+	t.Run("larger file wins within a source", func(t *testing.T) {
+		got, ok := Recover(obfuscated, []Candidate{
+			{Name: "Show.S01E01.Extras.mkv", Source: SourceFile, Size: 10},
+			{Name: "Show.S01E01.1080p.WEB-GRP.mkv", Source: SourceFile, Size: 1000},
+		})
+		if !ok || got.Name != "Show.S01E01.1080p.WEB-GRP" {
+			t.Errorf("Recover() = (%+v, %t); want larger file", got, ok)
+		}
+	})
+
+	t.Run("nothing readable", func(t *testing.T) {
+		if _, ok := Recover(obfuscated, []Candidate{{Name: "0123456789abcdef0123456789abcdef.mkv", Source: SourceFile}}); ok {
+			t.Fatal("expected no recovery")
+		}
+	})
+}
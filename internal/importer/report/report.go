@@ -0,0 +1,358 @@
+// Package report collects a structured, per-queue-item import report: stage
+// timings, fast-fail sampling results, archive layout, password attempts, the
+// virtual paths an import produced and the post-processing outcome. Reports
+// are built by a Recorder carried on the import's context and persisted as
+// gzip-compressed JSON.
+package report
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/javi11/altmount/internal/database"
+)
+
+// MaxMissingSegmentsPerFile caps how many missing segments are kept per file
+// so a badly damaged release does not produce a multi-megabyte report. The
+// total count is always kept in FileSample.MissingCount.
+const MaxMissingSegmentsPerFile = 50
+
+// Report outcome values.
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Report is the import report of a single queue item.
+type Report struct {
+	QueueID    int64     `json:"queue_id"`
+	NzbPath    string    `json:"nzb_path"`
+	Status     string    `json:"status,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitzero"`
+	DurationMs int64     `json:"duration_ms"`
+
+	Stages []Stage `json:"stages"`
+	// ResumedStages lists the checkpointed stages the import skipped because
+	// an earlier attempt had completed them.
+	ResumedStages []string  `json:"resumed_stages,omitempty"`
+	FastFail      *FastFail `json:"fast_fail,omitempty"`
+	// Providers is the per-provider completeness of the fast-fail sample,
+	// set when per-provider sampling is enabled.
+	Providers      []database.ProviderCompleteness `json:"provider_completeness,omitempty"`
+	Archives       []Archive                       `json:"archives,omitempty"`
+	Passwords      []PasswordAttempt               `json:"password_attempts,omitempty"`
+	ResultPath     string                          `json:"result_path,omitempty"`
+	VirtualPaths   []string                        `json:"virtual_paths,omitempty"`
+	Hooks          []Hook                          `json:"hooks,omitempty"`
+	PostProcessing *PostProcessing                 `json:"post_processing,omitempty"`
+}
+
+// Stage is one progress stage ("Parsing NZB", "Analyzing archive", ...) and
+// how long the import spent in it.
+type Stage struct {
+	Name       string    `json:"name"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// FastFail holds the pre-parse segment reachability results.
+type FastFail struct {
+	// ProbeMissing is true when the release-wide probe hit an unreachable
+	// segment and the per-file sweep ran.
+	ProbeMissing    bool         `json:"probe_missing"`
+	ProbeDurationMs int64        `json:"probe_duration_ms"`
+	Files           []FileSample `json:"files,omitempty"`
+}
+
+// FileSample is the per-file outcome of the fast-fail sweep.
+type FileSample struct {
+	Filename     string           `json:"filename"`
+	Segments     int              `json:"segments"`
+	Sampled      int              `json:"sampled"`
+	MissingCount int              `json:"missing_count"`
+	Missing      []MissingSegment `json:"missing,omitempty"`
+	// Verdict is "ok", "broken" (excluded from the import) or "degraded"
+	// (imported despite missing segments under the tolerant damage policy).
+	Verdict string `json:"verdict"`
+}
+
+// MissingSegment is a sampled segment that could not be reached, with the
+// provider response that reported it.
+type MissingSegment struct {
+	ID       string `json:"id"`
+	Response string `json:"response,omitempty"`
+}
+
+// Archive describes the layout of an archive set found in the release.
+type Archive struct {
+	Format   string   `json:"format"`
+	Volumes  int      `json:"volumes"`
+	Sets     []string `json:"sets,omitempty"`
+	Size     int64    `json:"size"`
+	Files    []string `json:"files,omitempty"`
+	Password bool     `json:"password"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// PasswordAttempt records a password tried against an archive. The password
+// itself is never stored.
+type PasswordAttempt struct {
+	Source  string `json:"source"`
+	Format  string `json:"format"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Hook is the outcome of one post-import hook call.
+type Hook struct {
+	Name       string `json:"name"`
+	Veto       bool   `json:"veto,omitempty"`
+	TargetPath string `json:"target_path,omitempty"`
+	Renamed    int    `json:"renamed,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// PostProcessing is the outcome of the post-import steps.
+type PostProcessing struct {
+	SymlinksCreated bool     `json:"symlinks_created"`
+	StrmCreated     bool     `json:"strm_created"`
+	VFSNotified     bool     `json:"vfs_notified"`
+	HealthScheduled bool     `json:"health_scheduled"`
+	ARRNotified     bool     `json:"arr_notified"`
+	Errors          []string `json:"errors,omitempty"`
+}
+
+// Recorder accumulates a Report while an import runs. All methods are safe on
+// a nil *Recorder, so code paths without a report (metadata regeneration,
+// tests) need no checks.
+type Recorder struct {
+	mu     sync.Mutex
+	report Report
+	stage  int // index of the open stage in report.Stages, -1 when none
+	now    func() time.Time
+}
+
+// NewRecorder starts a report for a queue item.
+func NewRecorder(queueID int64, nzbPath string) *Recorder {
+	r := &Recorder{stage: -1, now: time.Now}
+	r.report = Report{QueueID: queueID, NzbPath: nzbPath, StartedAt: r.now()}
+	return r
+}
+
+// Stage closes the open stage and opens name. Repeating the open stage's
+// name is a no-op.
+func (r *Recorder) Stage(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stage >= 0 && r.report.Stages[r.stage].Name == name {
+		return
+	}
+	now := r.now()
+	r.closeStage(now)
+	r.report.Stages = append(r.report.Stages, Stage{Name: name, StartedAt: now})
+	r.stage = len(r.report.Stages) - 1
+}
+
+// closeStage must be called with r.mu held.
+func (r *Recorder) closeStage(now time.Time) {
+	if r.stage < 0 {
+		return
+	}
+	s := &r.report.Stages[r.stage]
+	s.DurationMs = now.Sub(s.StartedAt).Milliseconds()
+	r.stage = -1
+}
+
+// FastFailProbe records the release-wide probe outcome.
+func (r *Recorder) FastFailProbe(missing bool, elapsed time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.report.FastFail == nil {
+		r.report.FastFail = &FastFail{}
+	}
+	r.report.FastFail.ProbeMissing = missing
+	r.report.FastFail.ProbeDurationMs = elapsed.Milliseconds()
+}
+
+// FastFailFile records the per-file sweep result of one file. Missing
+// segments beyond MaxMissingSegmentsPerFile are counted but not listed.
+func (r *Recorder) FastFailFile(sample FileSample) {
+	if r == nil {
+		return
+	}
+	if sample.MissingCount < len(sample.Missing) {
+		sample.MissingCount = len(sample.Missing)
+	}
+	if len(sample.Missing) > MaxMissingSegmentsPerFile {
+		sample.Missing = sample.Missing[:MaxMissingSegmentsPerFile]
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.report.FastFail == nil {
+		r.report.FastFail = &FastFail{}
+	}
+	r.report.FastFail.Files = append(r.report.FastFail.Files, sample)
+}
+
+// ProviderCompleteness records the per-provider sample results.
+func (r *Recorder) ProviderCompleteness(entries []database.ProviderCompleteness) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Providers = append([]database.ProviderCompleteness(nil), entries...)
+}
+
+// Providers returns the recorded per-provider sample results.
+func (r *Recorder) Providers() []database.ProviderCompleteness {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]database.ProviderCompleteness(nil), r.report.Providers...)
+}
+
+// Resumed records a stage restored from a checkpoint instead of run.
+func (r *Recorder) Resumed(stage string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.ResumedStages = append(r.report.ResumedStages, stage)
+}
+
+// Archive records the layout of an archive set.
+func (r *Recorder) Archive(a Archive) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Archives = append(r.report.Archives, a)
+}
+
+// PasswordAttempt records a password tried against an archive.
+func (r *Recorder) PasswordAttempt(a PasswordAttempt) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Passwords = append(r.report.Passwords, a)
+}
+
+// Hook records the outcome of a post-import hook.
+func (r *Recorder) Hook(h Hook) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Hooks = append(r.report.Hooks, h)
+}
+
+// Paths records the result path and the virtual paths the import produced.
+// Later calls replace earlier ones, so the report ends with the final paths
+// after hook renames.
+func (r *Recorder) Paths(resultPath string, virtualPaths []string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.ResultPath = resultPath
+	r.report.VirtualPaths = append([]string(nil), virtualPaths...)
+}
+
+// PostProcessing records the outcome of the post-import steps.
+func (r *Recorder) PostProcessing(p PostProcessing) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.PostProcessing = &p
+}
+
+// Finish closes the open stage, sets the outcome and returns a copy of the
+// report. A nil err marks the import completed.
+func (r *Recorder) Finish(err error) *Report {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.closeStage(now)
+	r.report.FinishedAt = now
+	r.report.DurationMs = now.Sub(r.report.StartedAt).Milliseconds()
+	r.report.Status = StatusCompleted
+	r.report.Error = ""
+	if err != nil {
+		r.report.Status = StatusFailed
+		r.report.Error = err.Error()
+	}
+	out := r.report
+	return &out
+}
+
+// Marshal encodes the report as gzip-compressed JSON.
+func (rep *Report) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(rep); err != nil {
+		return nil, fmt.Errorf("encode import report: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress import report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes a report produced by Report.Marshal.
+func Unmarshal(data []byte) (*Report, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompress import report: %w", err)
+	}
+	defer zr.Close()
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress import report: %w", err)
+	}
+	var rep Report
+	if err := json.Unmarshal(raw, &rep); err != nil {
+		return nil, fmt.Errorf("decode import report: %w", err)
+	}
+	return &rep, nil
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context carrying rec.
+func WithRecorder(ctx context.Context, rec *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, rec)
+}
+
+// FromContext returns the Recorder carried by ctx, or nil.
+func FromContext(ctx context.Context) *Recorder {
+	rec, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return rec
+}
@@ -0,0 +1,93 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a clock that advances by step on every call.
+func fakeClock(step time.Duration) func() time.Time {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestRecorder_StagesAndFinish(t *testing.T) {
+	rec := NewRecorder(7, "/nzbs/movie.nzb")
+	rec.now = fakeClock(time.Second)
+
+	rec.Stage("Parsing NZB")
+	rec.Stage("Parsing NZB") // repeated stage updates stay one stage
+	rec.Stage("Analyzing archive")
+	rep := rec.Finish(errors.New("boom"))
+
+	require.Len(t, rep.Stages, 2)
+	assert.Equal(t, "Parsing NZB", rep.Stages[0].Name)
+	assert.Equal(t, int64(1000), rep.Stages[0].DurationMs)
+	assert.Equal(t, int64(1000), rep.Stages[1].DurationMs)
+	assert.Equal(t, StatusFailed, rep.Status)
+	assert.Equal(t, "boom", rep.Error)
+	assert.Equal(t, int64(7), rep.QueueID)
+}
+
+func TestRecorder_CapsMissingSegments(t *testing.T) {
+	rec := NewRecorder(1, "")
+	sample := FileSample{Filename: "a.mkv", Verdict: "broken"}
+	for i := range MaxMissingSegmentsPerFile + 10 {
+		sample.Missing = append(sample.Missing, MissingSegment{ID: fmt.Sprintf("seg-%d", i), Response: "430 no such article"})
+	}
+	rec.FastFailFile(sample)
+
+	rep := rec.Finish(nil)
+	require.NotNil(t, rep.FastFail)
+	require.Len(t, rep.FastFail.Files, 1)
+	assert.Len(t, rep.FastFail.Files[0].Missing, MaxMissingSegmentsPerFile)
+	assert.Equal(t, MaxMissingSegmentsPerFile+10, rep.FastFail.Files[0].MissingCount)
+}
+
+func TestReport_MarshalRoundTrip(t *testing.T) {
+	rec := NewRecorder(3, "/nzbs/show.nzb")
+	rec.Archive(Archive{Format: "rar", Volumes: 12, Sets: []string{"show"}, Password: true})
+	rec.PasswordAttempt(PasswordAttempt{Source: "nzb", Format: "rar", Success: true})
+	rec.Paths("/complete/tv/Show", []string{"DIR:/complete/tv/Show"})
+	rec.PostProcessing(PostProcessing{SymlinksCreated: true, Errors: []string{"arr unreachable"}})
+	rep := rec.Finish(nil)
+
+	data, err := rep.Marshal()
+	require.NoError(t, err)
+	got, err := Unmarshal(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusCompleted, got.Status)
+	assert.Equal(t, rep.Archives, got.Archives)
+	assert.Equal(t, rep.Passwords, got.Passwords)
+	assert.Equal(t, "/complete/tv/Show", got.ResultPath)
+	assert.Equal(t, rep.PostProcessing, got.PostProcessing)
+}
+
+func TestNilRecorder(t *testing.T) {
+	rec := FromContext(context.Background())
+	assert.Nil(t, rec)
+
+	// Every method must be safe on a nil recorder.
+	rec.Stage("Parsing NZB")
+	rec.FastFailProbe(true, time.Second)
+	rec.FastFailFile(FileSample{})
+	rec.Archive(Archive{})
+	rec.PasswordAttempt(PasswordAttempt{})
+	rec.Hook(Hook{})
+	rec.Paths("", nil)
+	rec.PostProcessing(PostProcessing{})
+	assert.Nil(t, rec.Finish(nil))
+
+	ctx := WithRecorder(context.Background(), NewRecorder(1, ""))
+	assert.NotNil(t, FromContext(ctx))
+}
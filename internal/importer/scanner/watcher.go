@@ -2,6 +2,7 @@ package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/javi11/altmount/internal/config"
 	"github.com/javi11/altmount/internal/database"
 	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
+	"github.com/javi11/altmount/internal/nzbfile"
 )
 
 // WatchQueueAdder interface for adding items to the import queue from directory watcher
@@ -21,6 +23,9 @@ type WatchQueueAdder interface {
 }
 
 
+// errFileChanging is returned by processNzb for a file still being written.
+var errFileChanging = errors.New("file is still changing")
+
 // Watcher handles monitoring a directory for new NZB files
 type Watcher struct {
 	queueAdder   WatchQueueAdder
@@ -55,13 +60,26 @@ func (w *Watcher) Start(ctx context.Context) error {
 		interval = time.Duration(*cfg.Import.WatchIntervalSeconds) * time.Second
 	}
 
-	w.log.InfoContext(ctx, "Starting directory watcher", "dir", watchDir, "interval", interval)
+	useEvents := false
+	switch cfg.GetWatchMode() {
+	case config.WatchModeEvents:
+		useEvents = true
+	case config.WatchModeAuto:
+		// NFS/SMB and FUSE mounts do not report changes made by other hosts.
+		useEvents = !isNetworkFilesystem(watchDir)
+	}
+
+	w.log.InfoContext(ctx, "Starting directory watcher", "dir", watchDir, "interval", interval, "events", useEvents)
 
 	// Create cancellable context
 	watchCtx, cancel := context.WithCancel(ctx)
 	w.cancel = cancel
 
-	go w.watchLoop(watchCtx, watchDir, interval)
+	if useEvents {
+		go w.eventWatchLoop(watchCtx, watchDir, interval)
+	} else {
+		go w.watchLoop(watchCtx, watchDir, interval)
+	}
 
 	return nil
 }
@@ -114,12 +132,12 @@ func (w *Watcher) scanDirectory(ctx context.Context, watchDir string) {
 		}
 
 		// Check extension
-		if !nzbtrim.HasNzbExtension(d.Name()) {
+		if !isWatchedFile(d.Name()) {
 			return nil
 		}
 
-		// Process NZB file
-		if err := w.processNzb(ctx, watchDir, path); err != nil {
+		// Process NZB file; one still being written is picked up next scan
+		if err := w.processNzb(ctx, watchDir, path); err != nil && !errors.Is(err, errFileChanging) {
 			w.log.ErrorContext(ctx, "Failed to process watched file", "file", path, "error", err)
 		}
 
@@ -131,6 +149,12 @@ func (w *Watcher) scanDirectory(ctx context.Context, watchDir string) {
 	}
 }
 
+// isWatchedFile reports whether the watcher imports files with this name:
+// NZBs (plain or compressed), NZB bundles and STRM files.
+func isWatchedFile(name string) bool {
+	return nzbtrim.HasNzbExtension(name) || nzbfile.IsExpandable(name) || strings.HasSuffix(strings.ToLower(name), ".strm")
+}
+
 // getCategoryFromPath detects the category from the file's relative path by matching against
 // configured category directories. The path must START with the category's Dir to match.
 // For example, if a category has Dir="filmes/download/mov", then:
@@ -234,7 +258,7 @@ func (w *Watcher) processNzb(ctx context.Context, watchRoot, filePath string) er
 
 	if info1.Size() != info2.Size() || info1.ModTime() != info2.ModTime() {
 		w.log.DebugContext(ctx, "File is changing, skipping for now", "file", filePath)
-		return nil
+		return errFileChanging
 	}
 
 	// Check if already in queue to avoid duplicates/resets
@@ -290,6 +314,9 @@ func (w *Watcher) processNzb(ctx context.Context, watchRoot, filePath string) er
 
 	// Add to queue
 	priority := database.QueuePriorityNormal
+	if nzbfile.IsExpandable(filePath) {
+		return w.queueBundle(ctx, filePath, relativePath, category, &priority)
+	}
 	item, err := w.queueAdder.AddToQueue(ctx, filePath, relativePath, category, &priority, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to add to queue: %w", err)
@@ -307,3 +334,51 @@ func (w *Watcher) processNzb(ctx context.Context, watchRoot, filePath string) er
 
 	return nil
 }
+
+// queueBundle expands a bundle or bzip2/xz-compressed NZB and queues every
+// contained NZB with the bundle's category and priority. The bundle is removed
+// from the watch directory once all entries are queued; when some entries fail
+// it is renamed with a .failed suffix so the next scan does not expand it again.
+func (w *Watcher) queueBundle(ctx context.Context, filePath string, relativePath, category *string, priority *database.QueuePriority) error {
+	expandDir := filepath.Join(os.TempDir(), "altmount-uploads", "bundles", fmt.Sprintf("watch-%d", time.Now().UnixNano()))
+	defer os.RemoveAll(expandDir)
+
+	entries, expandErr := nzbfile.Expand(filePath, expandDir)
+
+	failed := expandErr != nil || len(entries) == 0
+	queued := 0
+	for _, entry := range entries {
+		if entry.Err != nil {
+			failed = true
+			w.log.ErrorContext(ctx, "Failed to extract NZB from bundle", "bundle", filePath, "entry", entry.Name, "error", entry.Err)
+			continue
+		}
+
+		item, err := w.queueAdder.AddToQueue(ctx, entry.Path, relativePath, category, priority, nil, nil, nil)
+		if err != nil {
+			failed = true
+			w.log.ErrorContext(ctx, "Failed to add bundled NZB to queue", "bundle", filePath, "entry", entry.Name, "error", err)
+			continue
+		}
+		queued++
+		w.log.InfoContext(ctx, "Added bundled NZB to queue", "bundle", filePath, "entry", entry.Name, "queue_id", item.ID)
+	}
+
+	if failed {
+		if err := os.Rename(filePath, filePath+".failed"); err != nil {
+			w.log.WarnContext(ctx, "Failed to mark bundle as failed", "file", filePath, "error", err)
+		}
+		if expandErr != nil {
+			return fmt.Errorf("failed to expand bundle (%d NZBs queued): %w", queued, expandErr)
+		}
+		if queued == 0 {
+			return fmt.Errorf("no NZBs could be queued from bundle")
+		}
+		return nil
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		w.log.WarnContext(ctx, "Failed to remove expanded bundle", "file", filePath, "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long a watched file must stay quiet (no further
+// create/write events) before it is handed to processNzb. Downloaders and
+// indexer tools often write an NZB in several chunks; acting on the first
+// event would race the writer.
+const watchDebounce = 2 * time.Second
+
+// watchRescanInterval is how often event mode walks the whole tree anyway, as a
+// backstop for events the watcher never saw (e.g. a directory moved in before
+// its watch was registered).
+const watchRescanInterval = 15 * time.Minute
+
+// eventWatchLoop reacts to filesystem events instead of re-walking the whole
+// tree every interval. Every non-hidden directory under watchDir is watched;
+// directories created later are added as they appear and scanned once so files
+// moved in together with their directory are not missed. Files still being
+// written when their debounce expires are re-queued, and the whole tree is
+// rescanned every watchRescanInterval. If the event watcher cannot be created
+// (e.g. inotify watch limit reached) it falls back to polling.
+func (w *Watcher) eventWatchLoop(ctx context.Context, watchDir string, interval time.Duration) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		w.log.WarnContext(ctx, "Filesystem events unavailable, falling back to polling", "dir", watchDir, "error", err)
+		w.watchLoop(ctx, watchDir, interval)
+		return
+	}
+	defer fsw.Close()
+
+	if err := w.addWatchTree(fsw, watchDir); err != nil {
+		w.log.WarnContext(ctx, "Failed to watch directory tree, falling back to polling", "dir", watchDir, "error", err)
+		fsw.Close()
+		w.watchLoop(ctx, watchDir, interval)
+		return
+	}
+
+	// Pick up whatever was dropped while we were not running.
+	w.scanDirectory(ctx, watchDir)
+
+	// pending maps a file path to the time of its latest event; a file is
+	// processed once it has been quiet for watchDebounce.
+	pending := make(map[string]time.Time)
+	ticker := time.NewTicker(watchDebounce / 2)
+	defer ticker.Stop()
+	rescan := time.NewTicker(watchRescanInterval)
+	defer rescan.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-fsw.Events:
+			if !ok {
+				return
+			}
+			w.handleWatchEvent(ctx, fsw, watchDir, event, pending)
+
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// The kernel dropped events; a full walk is the only way to
+				// know what we missed.
+				w.log.WarnContext(ctx, "Filesystem event queue overflowed, rescanning watch directory", "dir", watchDir)
+				w.scanDirectory(ctx, watchDir)
+				continue
+			}
+			w.log.WarnContext(ctx, "Filesystem watcher error", "dir", watchDir, "error", err)
+
+		case <-rescan.C:
+			w.scanDirectory(ctx, watchDir)
+
+		case now := <-ticker.C:
+			w.processPending(ctx, watchDir, pending, now)
+		}
+	}
+}
+
+// processPending processes every pending file that has been quiet for
+// watchDebounce. A file still changing is re-queued for another debounce: no
+// further event may arrive once its writer is done.
+func (w *Watcher) processPending(ctx context.Context, watchDir string, pending map[string]time.Time, now time.Time) {
+	for path, last := range pending {
+		if now.Sub(last) < watchDebounce {
+			continue
+		}
+		delete(pending, path)
+		err := w.processNzb(ctx, watchDir, path)
+		switch {
+		case errors.Is(err, errFileChanging):
+			pending[path] = time.Now()
+		case err != nil:
+			w.log.ErrorContext(ctx, "Failed to process watched file", "file", path, "error", err)
+		}
+	}
+}
+
+// handleWatchEvent updates the debounce set for a single filesystem event and
+// starts watching newly created directories.
+func (w *Watcher) handleWatchEvent(ctx context.Context, fsw *fsnotify.Watcher, watchDir string, event fsnotify.Event, pending map[string]time.Time) {
+	name := filepath.Base(event.Name)
+	if strings.HasPrefix(name, ".") {
+		return
+	}
+
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		// Rename is reported on the old name; the new name arrives as Create.
+		delete(pending, event.Name)
+		return
+	}
+
+	if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+		return
+	}
+
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			if err := w.addWatchTree(fsw, event.Name); err != nil {
+				w.log.WarnContext(ctx, "Failed to watch new directory", "dir", event.Name, "error", err)
+			}
+			// Files moved in along with the directory produce no events of their own.
+			w.queueExistingFiles(event.Name, pending)
+			return
+		}
+	}
+
+	if isWatchedFile(name) {
+		pending[event.Name] = time.Now()
+	}
+}
+
+// addWatchTree registers root and every non-hidden directory below it.
+func (w *Watcher) addWatchTree(fsw *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return fsw.Add(path)
+	})
+}
+
+// queueExistingFiles marks every watched file below dir as pending.
+func (w *Watcher) queueExistingFiles(dir string, pending map[string]time.Time) {
+	now := time.Now()
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() && path != dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && isWatchedFile(d.Name()) {
+			pending[path] = now
+		}
+		return nil
+	})
+}
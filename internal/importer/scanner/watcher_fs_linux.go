@@ -0,0 +1,38 @@
+//go:build linux
+
+package scanner
+
+import "syscall"
+
+// Filesystem magic numbers (see statfs(2)) for network and FUSE filesystems,
+// which do not deliver inotify events for changes made by other hosts.
+const (
+	nfsSuperMagic    = 0x6969
+	smbSuperMagic    = 0x517B
+	cifsSuperMagic   = 0xFF534D42
+	smb2SuperMagic   = 0xFE534D42
+	fuseSuperMagic   = 0x65735546
+	cephSuperMagic   = 0x00C36400
+	afsSuperMagic    = 0x5346414F
+	codaSuperMagic   = 0x73757245
+	ncpSuperMagic    = 0x564C
+	v9fsSuperMagic   = 0x01021997
+	gfsSuperMagic    = 0x01161970
+	lustreSuperMagic = 0x0BD00BD0
+)
+
+// isNetworkFilesystem reports whether path lives on a filesystem that is known
+// not to deliver change events reliably, so the watcher must poll instead.
+func isNetworkFilesystem(path string) bool {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false
+	}
+	switch uint32(stat.Type) {
+	case nfsSuperMagic, smbSuperMagic, cifsSuperMagic, smb2SuperMagic, fuseSuperMagic,
+		cephSuperMagic, afsSuperMagic, codaSuperMagic, ncpSuperMagic, v9fsSuperMagic,
+		gfsSuperMagic, lustreSuperMagic:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package scanner
+
+// isNetworkFilesystem reports whether path lives on a filesystem that is known
+// not to deliver change events reliably. Detection is only implemented on
+// Linux; elsewhere a watch_mode of "poll" must be configured explicitly.
+func isNetworkFilesystem(string) bool {
+	return false
+}
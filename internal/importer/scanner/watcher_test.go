@@ -1,12 +1,14 @@
 package scanner
 
 import (
+	"archive/zip"
 	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/javi11/altmount/internal/config"
 	"github.com/javi11/altmount/internal/database"
@@ -91,3 +93,162 @@ func TestProcessNzb_RelativePathHasNoBackslashes(t *testing.T) {
 		t.Errorf("relativePath = %q, want %q", got, "sub/nested")
 	}
 }
+
+func (s *stubQueueAdder) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addToQueueCalls
+}
+
+// TestEventWatcher_PicksUpFilesInNewSubdirectory verifies the event-driven
+// watcher queues NZBs written after start, including ones inside a directory
+// created after the watcher registered its initial tree, and ignores files
+// with unrelated extensions.
+func TestEventWatcher_PicksUpFilesInNewSubdirectory(t *testing.T) {
+	watchRoot := t.TempDir()
+	cfg := &config.Config{Import: config.ImportConfig{WatchDir: &watchRoot, WatchMode: config.WatchModeEvents}}
+	stub := &stubQueueAdder{}
+	w := NewWatcher(stub, func() *config.Config { return cfg })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer w.Stop()
+
+	// Give the watcher a moment to register the initial tree.
+	time.Sleep(200 * time.Millisecond)
+
+	subDir := filepath.Join(watchRoot, "tv", "incoming")
+	if err := os.MkdirAll(subDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(subDir, "release.nzb"), []byte("<nzb/>"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(subDir, "notes.txt"), []byte("ignore me"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for stub.calls() == 0 && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if got := stub.calls(); got != 1 {
+		t.Fatalf("AddToQueue calls = %d, want 1", got)
+	}
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	if stub.lastRelativePath == nil || *stub.lastRelativePath != "tv/incoming" {
+		t.Errorf("relativePath = %v, want %q", stub.lastRelativePath, "tv/incoming")
+	}
+}
+
+func TestIsWatchedFile(t *testing.T) {
+	for name, want := range map[string]bool{
+		"release.nzb":    true,
+		"release.NZB.gz": true,
+		"movie.strm":     true,
+		"release.nzb.xz": true,
+		"bundle.zip":     true,
+		"notes.txt":      false,
+		"archive.rar":    false,
+	} {
+		if got := isWatchedFile(name); got != want {
+			t.Errorf("isWatchedFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+// TestProcessNzb_ExpandsBundle verifies a zip of NZBs dropped in the watch
+// directory queues each contained NZB under the bundle's relative path and is
+// consumed afterwards.
+func TestProcessNzb_ExpandsBundle(t *testing.T) {
+	watchRoot := t.TempDir()
+	subDir := filepath.Join(watchRoot, "movies")
+	if err := os.MkdirAll(subDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	bundlePath := filepath.Join(subDir, "export.zip")
+	f, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for name, body := range map[string]string{"a.nzb": "<nzb/>", "b.nzb": "<nzb/>", "readme.txt": "hi"} {
+		fw, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create: %v", err)
+		}
+		if _, err := fw.Write([]byte(body)); err != nil {
+			t.Fatalf("zip write: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	f.Close()
+
+	stub := &stubQueueAdder{}
+	w := NewWatcher(stub, func() *config.Config { return &config.Config{} })
+
+	if err := w.processNzb(context.Background(), watchRoot, bundlePath); err != nil {
+		t.Fatalf("processNzb: %v", err)
+	}
+
+	if got := stub.calls(); got != 2 {
+		t.Fatalf("AddToQueue calls = %d, want 2", got)
+	}
+	if stub.lastRelativePath == nil || *stub.lastRelativePath != "movies" {
+		t.Errorf("relativePath = %v, want %q", stub.lastRelativePath, "movies")
+	}
+	if _, err := os.Stat(bundlePath); !os.IsNotExist(err) {
+		t.Errorf("bundle should be removed after expansion, stat err = %v", err)
+	}
+}
+
+// TestProcessPending_RequeuesChangingFile verifies a file still being written
+// when its debounce expires stays pending instead of being dropped, and is
+// queued once it settles.
+func TestProcessPending_RequeuesChangingFile(t *testing.T) {
+	watchRoot := t.TempDir()
+	nzbPath := filepath.Join(watchRoot, "release.nzb")
+	f, err := os.Create(nzbPath)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	stub := &stubQueueAdder{}
+	w := NewWatcher(stub, func() *config.Config { return &config.Config{} })
+	pending := map[string]time.Time{nzbPath: time.Now().Add(-watchDebounce)}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 20 {
+			_, _ = f.WriteString("<segment/>")
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+	w.processPending(context.Background(), watchRoot, pending, time.Now())
+	<-done
+	f.Close()
+
+	if stub.calls() != 0 {
+		t.Fatalf("AddToQueue calls = %d, want 0 while the file changes", stub.calls())
+	}
+	if _, ok := pending[nzbPath]; !ok {
+		t.Fatal("changing file was dropped from pending")
+	}
+
+	w.processPending(context.Background(), watchRoot, pending, time.Now().Add(watchDebounce))
+	if stub.calls() != 1 {
+		t.Fatalf("AddToQueue calls = %d, want 1 once the file settled", stub.calls())
+	}
+	if len(pending) != 0 {
+		t.Errorf("pending = %v, want empty", pending)
+	}
+}
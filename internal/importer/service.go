@@ -24,6 +24,7 @@ import (
 	"github.com/javi11/altmount/internal/importer/parser"
 	"github.com/javi11/altmount/internal/importer/postprocessor"
 	"github.com/javi11/altmount/internal/importer/queue"
+	"github.com/javi11/altmount/internal/importer/report"
 	"github.com/javi11/altmount/internal/importer/scanner"
 	"github.com/javi11/altmount/internal/importer/utils/nzbtrim"
 	"github.com/javi11/altmount/internal/metadata"
@@ -210,6 +211,11 @@ type Service struct {
 	// Keys are item.ID (int64), values are []string.
 	writtenPathsCache sync.Map
 	grabbedIndexers   sync.Map
+
+	// importReports holds the report recorder of each item from processing
+	// until its success or failure handling persists it.
+	// Keys are item.ID (int64), values are *report.Recorder.
+	importReports sync.Map
 }
 
 // NewService creates a new NZB import service with manual scanning and queue processing capabilities
@@ -230,13 +236,22 @@ func NewService(config ServiceConfig, metadataService *metadata.MetadataService,
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Create post-processor coordinator
-	postProc := postprocessor.NewCoordinator(postprocessor.Config{
+	postProcConfig := postprocessor.Config{
 		ConfigGetter:    configGetter,
 		MetadataService: metadataService,
 		RcloneClient:    rcloneClient,
 		HealthRepo:      healthRepo,
 		UserRepo:        userRepo,
-	})
+	}
+
+	// Wire the file index so metadata and library link changes keep it current
+	// for incremental library sync.
+	if database != nil && database.FileIndexRepo != nil {
+		metadataService.SetFileIndex(database.FileIndexRepo)
+		postProcConfig.FileIndex = database.FileIndexRepo
+	}
+
+	postProc := postprocessor.NewCoordinator(postProcConfig)
 
 	service := &Service{
 		config:          config,
@@ -268,6 +283,10 @@ func NewService(config ServiceConfig, metadataService *metadata.MetadataService,
 
 	// Set recorder for processor
 	processor.SetRecorder(service)
+	processor.SetSamplingObserver(service)
+	if database != nil && database.Repository != nil {
+		processor.SetCheckpointStore(database.Repository)
+	}
 
 	// Create scanner adapter for directory scanning
 	scannerAdapter := &queueAdapterForScanner{
@@ -853,9 +872,52 @@ func (s *Service) processNzbItem(ctx context.Context, item *database.ImportQueue
 		}
 	}
 
+	rec := report.NewRecorder(item.ID, item.NzbPath)
+	s.importReports.Store(item.ID, rec)
+	ctx = report.WithRecorder(ctx, rec)
+
 	return s.processor.ProcessNzbFile(ctx, item.NzbPath, basePath, int(item.ID), allowedExtensionsOverride, &virtualDir, extractedFiles, item.Category, item.Metadata, item.DownloadID)
 }
 
+// importReport returns the report recorder of an item being handled, or nil.
+func (s *Service) importReport(itemID int64) *report.Recorder {
+	if rec, ok := s.importReports.Load(itemID); ok {
+		return rec.(*report.Recorder)
+	}
+	return nil
+}
+
+// saveImportReport finishes the item's import report with processingErr as
+// its outcome and persists it. Reports are diagnostics, so failures are only
+// logged. Calling it again for the same item is a no-op. A completed import
+// also drops its stage checkpoints; a failed one keeps them for the retry.
+func (s *Service) saveImportReport(ctx context.Context, itemID int64, processingErr error) {
+	if processingErr == nil {
+		if err := s.database.Repository.DeleteImportCheckpoints(ctx, itemID); err != nil {
+			s.log.WarnContext(ctx, "Failed to delete import checkpoints", "queue_id", itemID, "error", err)
+		}
+	}
+	v, ok := s.importReports.LoadAndDelete(itemID)
+	if !ok {
+		return
+	}
+	rec := v.(*report.Recorder)
+	if providers := rec.Providers(); len(providers) > 0 {
+		if err := s.database.Repository.SetQueueItemProviderCompleteness(ctx, itemID, providers); err != nil {
+			s.log.WarnContext(ctx, "Failed to save provider completeness", "queue_id", itemID, "error", err)
+		}
+	}
+	rep := rec.Finish(processingErr)
+	data, err := rep.Marshal()
+	if err != nil {
+		s.log.WarnContext(ctx, "Failed to encode import report", "queue_id", itemID, "error", err)
+		return
+	}
+	if err := s.database.Repository.SaveImportReport(ctx, itemID, rep.Status, data); err != nil {
+		s.log.WarnContext(ctx, "Failed to save import report", "queue_id", itemID, "error", err)
+	}
+}
+
 func (s *Service) calculateProcessVirtualDir(item *database.ImportQueueItem, basePath *string) string {
 	// Calculate initial virtual directory from physical/relative path
 	virtualDir := filesystem.CalculateVirtualDirectory(item.NzbPath, *basePath)
@@ -1214,7 +1276,24 @@ func (s *Service) resolveIndexerFromArrs(ctx context.Context, downloadID string)
 // handleProcessingSuccess handles all steps after successful NZB processing.
 // writtenPaths lists every virtual file the import wrote (may be nil for legacy
 // callers); multi-file imports use it to health-check each file individually.
-func (s *Service) handleProcessingSuccess(ctx context.Context, item *database.ImportQueueItem, resultingPath string, writtenPaths []string) error {
+func (s *Service) handleProcessingSuccess(ctx context.Context, item *database.ImportQueueItem, resultingPath string, writtenPaths []string) (err error) {
+	rec := s.importReport(item.ID)
+	defer func() { s.saveImportReport(ctx, item.ID, err) }()
+	rec.Stage("Post-processing")
+	ctx = report.WithRecorder(ctx, rec)
+
+	// Post-import hooks run first: a veto turns the import into a failure
+	// before anything (indexer stats, storage path, streamable signal) records
+	// it as a success, and rewritten paths apply to every later step.
+	outcome, err := s.postProcessor.RunPostImportHooks(ctx, item, resultingPath, writtenPaths)
+	if err != nil {
+		s.cleanupWrittenPaths(ctx, item.ID, outcome.WrittenPaths)
+		s.handleProcessingFailure(ctx, item, err)
+		return err
+	}
+	resultingPath, writtenPaths = outcome.ResultPath, outcome.WrittenPaths
+	rec.Paths(resultingPath, writtenPaths)
+
 	// Log persistent indexer statistic
 	indexerName := database.IndexerUnknown
 	if item.Indexer != nil && *item.Indexer != "" {
@@ -1267,6 +1346,18 @@ func (s *Service) handleProcessingSuccess(ctx context.Context, item *database.Im
 		return err
 	}
 
+	postReport := report.PostProcessing{
+		SymlinksCreated: result.SymlinksCreated,
+		StrmCreated:     result.StrmCreated,
+		VFSNotified:     result.VFSNotified,
+		HealthScheduled: result.HealthScheduled,
+		ARRNotified:     result.ARRNotified,
+	}
+	for _, postErr := range result.Errors {
+		postReport.Errors = append(postReport.Errors, postErr.Error())
+	}
+	rec.PostProcessing(postReport)
+
 	// Log any non-fatal errors from post-processing
 	if len(result.Errors) > 0 {
 		for _, postErr := range result.Errors {
@@ -1310,6 +1401,20 @@ func (s *Service) handleProcessingSuccess(ctx context.Context, item *database.Im
 	return nil
 }
 
+// OnSlowSampling deprioritizes a queue item whose fast-fail sampling was slow so
+// that, if it is retried, healthier releases of the same priority go first.
+func (s *Service) OnSlowSampling(ctx context.Context, queueID int, elapsed time.Duration) {
+	s.log.InfoContext(ctx, "Fast-fail sampling was slow, deprioritizing queue item",
+		"queue_id", queueID,
+		"duration", elapsed)
+	if s.database == nil {
+		return
+	}
+	if err := s.database.Repository.SetQueueItemDeprioritized(ctx, int64(queueID), true); err != nil {
+		s.log.WarnContext(ctx, "Failed to deprioritize queue item", "queue_id", queueID, "error", err)
+	}
+}
+
 // OnItemClaimed implements queue.QueueEventListener. It broadcasts a queue-changed
 // notification whenever a worker claims a pending item (pending → processing transition).
 func (s *Service) OnItemClaimed(ctx context.Context, item *database.ImportQueueItem) {
@@ -1387,6 +1492,7 @@ func (s *Service) cleanupHealthRecords(ctx context.Context, itemID int64, virtua
 // handleProcessingFailure handles when processing fails
 func (s *Service) handleProcessingFailure(ctx context.Context, item *database.ImportQueueItem, processingErr error) {
 	errorMessage := processingErr.Error()
+	s.saveImportReport(ctx, item.ID, processingErr)
 
 	// Log persistent indexer statistic
 	indexerName := database.IndexerUnknown
@@ -1551,7 +1657,8 @@ func (s *Service) cleanupFailedItems(ctx context.Context) {
 		"retention_hours", retentionHours)
 }
 
-// cleanupOldHistory deletes import_history records older than the configured retention period.
+// cleanupOldHistory deletes import_history records and import reports older
+// than the configured retention period.
 func (s *Service) cleanupOldHistory(ctx context.Context) {
 	cfg := s.configGetter()
 	if cfg.Import.HistoryRetentionDays == nil || *cfg.Import.HistoryRetentionDays <= 0 {
@@ -1562,6 +1669,9 @@ func (s *Service) cleanupOldHistory(ctx context.Context) {
 	if err := s.database.Repository.DeleteImportHistoryOlderThan(ctx, cutoff); err != nil {
 		s.log.ErrorContext(ctx, "Failed to clean up old import history", "error", err)
 	}
+	if _, err := s.database.Repository.DeleteImportReportsOlderThan(ctx, cutoff); err != nil {
+		s.log.ErrorContext(ctx, "Failed to clean up old import reports", "error", err)
+	}
 }
 
 // CancelProcessing cancels a processing queue item by cancelling its context
@@ -0,0 +1,89 @@
+package importer
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/javi11/altmount/internal/importer/utils"
+)
+
+// maxSidecarDirDepth bounds the walk of "DIR:" written-path entries when
+// looking for subtitles and videos.
+const maxSidecarDirDepth = 10
+
+// organizeSubtitleSidecars renames the subtitle files an import wrote so media
+// servers pair them with their video: "<video stem>.<lang>.<ext>" next to the
+// video (see utils.PlanSubtitleSidecars). It returns writtenPaths with renamed
+// explicit entries updated; files under a "DIR:" entry stay covered by it.
+// Failed renames are logged and the subtitle keeps its original name.
+func (proc *Processor) organizeSubtitleSidecars(ctx context.Context, writtenPaths []string) []string {
+	var files []utils.SidecarFile
+	seen := make(map[string]bool)
+	add := func(p string) {
+		if seen[p] {
+			return
+		}
+		seen[p] = true
+		lite, err := proc.metadataService.ReadFileMetadataLite(p)
+		if err != nil || lite == nil {
+			return
+		}
+		files = append(files, utils.SidecarFile{Path: p, Size: lite.FileSize})
+	}
+	for _, p := range writtenPaths {
+		if dir, ok := strings.CutPrefix(p, "DIR:"); ok {
+			for _, f := range proc.listMetadataFiles(dir, 0) {
+				add(f)
+			}
+			continue
+		}
+		add(p)
+	}
+
+	renames := utils.PlanSubtitleSidecars(files)
+	if len(renames) == 0 {
+		return writtenPaths
+	}
+
+	out := append([]string(nil), writtenPaths...)
+	for oldPath, newPath := range renames {
+		if proc.metadataService.FileExists(newPath) {
+			continue
+		}
+		if err := proc.metadataService.RenameFileMetadata(oldPath, newPath); err != nil {
+			proc.log.WarnContext(ctx, "Failed to rename subtitle sidecar",
+				"from", oldPath,
+				"to", newPath,
+				"error", err)
+			continue
+		}
+		proc.log.DebugContext(ctx, "Renamed subtitle sidecar", "from", oldPath, "to", newPath)
+		for i, p := range out {
+			if p == oldPath {
+				out[i] = newPath
+			}
+		}
+	}
+	return out
+}
+
+// listMetadataFiles returns the virtual paths of all metadata files under
+// virtualDir, recursing up to maxSidecarDirDepth levels.
+func (proc *Processor) listMetadataFiles(virtualDir string, depth int) []string {
+	if depth > maxSidecarDirDepth {
+		return nil
+	}
+	dirs, files, err := proc.metadataService.ListDirectoryAll(virtualDir)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, f := range files {
+		out = append(out, path.Join(virtualDir, f))
+	}
+	for _, d := range dirs {
+		out = append(out, proc.listMetadataFiles(path.Join(virtualDir, d.Name()), depth+1)...)
+	}
+	return out
+}
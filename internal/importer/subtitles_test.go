@@ -0,0 +1,38 @@
+package importer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/metadata"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+)
+
+func TestOrganizeSubtitleSidecars(t *testing.T) {
+	svc := metadata.NewMetadataService(t.TempDir())
+	proc := NewProcessor(svc, nil, nil, func() *config.Config { return &config.Config{} }, nil)
+
+	write := func(p string, size int64) {
+		if err := svc.WriteFileMetadata(p, &metapb.FileMetadata{FileSize: size}); err != nil {
+			t.Fatalf("WriteFileMetadata(%s): %v", p, err)
+		}
+	}
+	write("/complete/movies/Movie/Movie.mkv", 1000)
+	write("/complete/movies/Movie/Subs/English.srt", 10)
+	write("/complete/movies/Extra.fr.srt", 10)
+
+	written := proc.organizeSubtitleSidecars(context.Background(), []string{
+		"DIR:/complete/movies/Movie",
+		"/complete/movies/Extra.fr.srt",
+	})
+
+	if !svc.FileExists("/complete/movies/Movie/Movie.en.srt") || svc.FileExists("/complete/movies/Movie/Subs/English.srt") {
+		t.Error("subtitle under the DIR entry was not renamed after the video")
+	}
+	// Extra.fr.srt is outside the video's folder but still paired with the
+	// release's only video; its explicit entry must follow the rename.
+	if written[1] != "/complete/movies/Movie/Movie.fr.srt" || !svc.FileExists(written[1]) {
+		t.Errorf("written paths = %v", written)
+	}
+}
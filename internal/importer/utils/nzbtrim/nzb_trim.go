@@ -5,11 +5,14 @@ import (
 	"strings"
 )
 
-// TrimNzbExtension removes .nzb or .nzb.gz from a filename (case-insensitive)
+// TrimNzbExtension removes .nzb or a compressed .nzb.gz/.nzb.bz2/.nzb.xz suffix
+// from a filename (case-insensitive)
 func TrimNzbExtension(filename string) string {
 	lower := strings.ToLower(filename)
-	if strings.HasSuffix(lower, ".nzb.gz") {
-		return filename[:len(filename)-7]
+	for _, ext := range []string{".nzb.gz", ".nzb.bz2", ".nzb.xz"} {
+		if strings.HasSuffix(lower, ext) {
+			return filename[:len(filename)-len(ext)]
+		}
 	}
 	if strings.HasSuffix(lower, ".nzb") {
 		return filename[:len(filename)-4]
@@ -0,0 +1,290 @@
+package utils
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/javi11/altmount/internal/importer/parser/fileinfo"
+)
+
+// subtitleExtensions are the subtitle formats kept as sidecars. VobSub comes
+// as an .idx/.sub pair that must keep a common base name.
+var subtitleExtensions = map[string]bool{
+	".srt": true, ".ass": true, ".ssa": true, ".sub": true, ".idx": true, ".vtt": true, ".sup": true,
+}
+
+// subtitleLanguages maps ISO 639-1/639-2 codes and English language names to
+// the ISO 639-1 code media servers expect in "Movie.<lang>.srt".
+var subtitleLanguages = func() map[string]string {
+	langs := map[string][]string{
+		"ar": {"ara", "arabic"},
+		"bg": {"bul", "bulgarian"},
+		"cs": {"cze", "ces", "czech"},
+		"da": {"dan", "danish"},
+		"de": {"ger", "deu", "german", "deutsch"},
+		"el": {"gre", "ell", "greek"},
+		"en": {"eng", "english"},
+		"es": {"spa", "spanish", "espanol", "castellano"},
+		"et": {"est", "estonian"},
+		"fa": {"per", "fas", "persian", "farsi"},
+		"fi": {"fin", "finnish"},
+		"fr": {"fre", "fra", "french", "francais"},
+		"he": {"heb", "hebrew"},
+		"hi": {"hin", "hindi"},
+		"hr": {"hrv", "croatian"},
+		"hu": {"hun", "hungarian"},
+		"id": {"ind", "indonesian"},
+		"is": {"ice", "isl", "icelandic"},
+		"it": {"ita", "italian"},
+		"ja": {"jpn", "japanese"},
+		"ko": {"kor", "korean"},
+		"lt": {"lit", "lithuanian"},
+		"lv": {"lav", "latvian"},
+		"ms": {"may", "msa", "malay"},
+		"nl": {"dut", "nld", "dutch"},
+		"no": {"nor", "nob", "norwegian"},
+		"pl": {"pol", "polish"},
+		"pt": {"por", "portuguese"},
+		"ro": {"rum", "ron", "romanian"},
+		"ru": {"rus", "russian"},
+		"sk": {"slo", "slk", "slovak"},
+		"sl": {"slv", "slovenian"},
+		"sr": {"srp", "serbian"},
+		"sv": {"swe", "swedish"},
+		"th": {"tha", "thai"},
+		"tr": {"tur", "turkish"},
+		"uk": {"ukr", "ukrainian"},
+		"vi": {"vie", "vietnamese"},
+		"zh": {"chi", "zho", "chinese"},
+	}
+	out := make(map[string]string)
+	for code, aliases := range langs {
+		out[code] = code
+		for _, a := range aliases {
+			out[a] = code
+		}
+	}
+	return out
+}()
+
+// subtitleFlags are name tokens that qualify a subtitle track, mapped to the
+// spelling Plex/Jellyfin/Emby recognise.
+var subtitleFlags = map[string]string{
+	"forced": "forced",
+	"sdh":    "sdh",
+	"hi":     "sdh",
+	"cc":     "sdh",
+}
+
+var (
+	subtitleTokenSplit = regexp.MustCompile(`[._\-\s()\[\]]+`)
+	episodeTokenRe     = regexp.MustCompile(`(?i)s(\d{1,2})[ ._-]?e(\d{1,3})`)
+)
+
+// SubtitleExtensions returns the subtitle file extensions kept as sidecars.
+func SubtitleExtensions() []string {
+	exts := make([]string, 0, len(subtitleExtensions))
+	for ext := range subtitleExtensions {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// IsSubtitleFile reports whether filename has a subtitle extension.
+func IsSubtitleFile(filename string) bool {
+	return subtitleExtensions[strings.ToLower(path.Ext(filename))]
+}
+
+// WithSubtitleExtensions returns allowed with the subtitle extensions added.
+// An empty list already allows everything and is returned unchanged.
+func WithSubtitleExtensions(allowed []string) []string {
+	if len(allowed) == 0 {
+		return allowed
+	}
+	out := append([]string(nil), allowed...)
+	have := createExtensionMap(allowed)
+	for _, ext := range SubtitleExtensions() {
+		if !have[strings.TrimPrefix(ext, ".")] {
+			out = append(out, ext)
+		}
+	}
+	return out
+}
+
+// ParseSubtitleName reads the language and track flags from a subtitle
+// filename, scanning its tokens from the end: "Movie.2020.eng.forced.srt"
+// yields ("en", ["forced"]), "2_English.srt" yields ("en", nil). The scan
+// stops at the first token that is neither, so words of the title are not
+// mistaken for languages. lang is "" when none is found.
+func ParseSubtitleName(filename string) (lang string, flags []string) {
+	base := strings.TrimSuffix(path.Base(filename), path.Ext(filename))
+	tokens := subtitleTokenSplit.Split(strings.ToLower(base), -1)
+	for i := len(tokens) - 1; i >= 0; i-- {
+		tok := tokens[i]
+		if tok == "" {
+			continue
+		}
+		if f, ok := subtitleFlags[tok]; ok {
+			if !containsString(flags, f) {
+				flags = append([]string{f}, flags...)
+			}
+			continue
+		}
+		if code, ok := subtitleLanguages[tok]; ok && lang == "" {
+			lang = code
+			continue
+		}
+		if _, err := strconv.Atoi(tok); err == nil {
+			// Track numbers ("2_English.srt") carry no meaning.
+			continue
+		}
+		break
+	}
+	return lang, flags
+}
+
+// SidecarFile is a virtual file considered by PlanSubtitleSidecars.
+type SidecarFile struct {
+	Path string
+	Size int64
+}
+
+// PlanSubtitleSidecars pairs each subtitle in files with a video and returns
+// the renames (old path to new path) that put it next to that video as
+// "<video stem>[.<lang>][.<flags>][.<n>].<ext>". A subtitle is paired with,
+// in order: the video whose stem prefixes its own name, the only video with
+// the same SxxEyy token, or the largest video when the release has no
+// episode numbering (a movie, possibly with CD parts and a stitched file).
+// Subtitles that cannot be paired, or that already have their target name,
+// are left out. VobSub .idx/.sub pairs keep a common base name.
+func PlanSubtitleSidecars(files []SidecarFile) map[string]string {
+	var videos, subs []SidecarFile
+	for _, f := range files {
+		switch {
+		case IsSubtitleFile(f.Path):
+			subs = append(subs, f)
+		case fileinfo.IsVideoFile(f.Path) && !strings.EqualFold(path.Ext(f.Path), ".strm"):
+			videos = append(videos, f)
+		}
+	}
+	if len(videos) == 0 || len(subs) == 0 {
+		return nil
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Path < subs[j].Path })
+
+	largest := videos[0]
+	episodic := false
+	for _, v := range videos {
+		if v.Size > largest.Size {
+			largest = v
+		}
+		if episodeTokenRe.MatchString(path.Base(v.Path)) {
+			episodic = true
+		}
+	}
+
+	var (
+		renames = make(map[string]string)
+		taken   = make(map[string]bool)
+		// bases maps a subtitle's source base (path without extension) to
+		// the target base chosen for it, so .idx/.sub pairs stay together.
+		bases = make(map[string]string)
+	)
+	for _, f := range files {
+		taken[strings.ToLower(f.Path)] = true
+	}
+
+	for _, sub := range subs {
+		video, ok := pairSubtitle(sub.Path, videos, largest, episodic)
+		if !ok {
+			continue
+		}
+		ext := strings.ToLower(path.Ext(sub.Path))
+		srcBase := strings.TrimSuffix(sub.Path, path.Ext(sub.Path))
+
+		target, seen := bases[srcBase]
+		if !seen {
+			stem := strings.TrimSuffix(video.Path, path.Ext(video.Path))
+			lang, flags := ParseSubtitleName(sub.Path)
+			base := stem
+			if lang != "" {
+				base += "." + lang
+			}
+			for _, fl := range flags {
+				base += "." + fl
+			}
+			target = base
+			for n := 2; taken[strings.ToLower(target+ext)] && !strings.EqualFold(target+ext, sub.Path); n++ {
+				target = base + "." + strconv.Itoa(n)
+			}
+			bases[srcBase] = target
+		}
+
+		newPath := target + ext
+		if strings.EqualFold(newPath, sub.Path) {
+			continue
+		}
+		if taken[strings.ToLower(newPath)] {
+			continue
+		}
+		taken[strings.ToLower(newPath)] = true
+		delete(taken, strings.ToLower(sub.Path))
+		renames[sub.Path] = newPath
+	}
+	if len(renames) == 0 {
+		return nil
+	}
+	return renames
+}
+
+// pairSubtitle picks the video a subtitle belongs to.
+func pairSubtitle(sub string, videos []SidecarFile, largest SidecarFile, episodic bool) (SidecarFile, bool) {
+	subBase := strings.ToLower(path.Base(sub))
+	for _, v := range videos {
+		stem := strings.ToLower(strings.TrimSuffix(path.Base(v.Path), path.Ext(v.Path)))
+		if strings.HasPrefix(subBase, stem+".") {
+			return v, true
+		}
+	}
+
+	if m := episodeTokenRe.FindStringSubmatch(sub); m != nil {
+		var match SidecarFile
+		n := 0
+		for _, v := range videos {
+			if vm := episodeTokenRe.FindStringSubmatch(path.Base(v.Path)); vm != nil && sameEpisode(m, vm) {
+				match = v
+				n++
+			}
+		}
+		if n == 1 {
+			return match, true
+		}
+		return SidecarFile{}, false
+	}
+
+	if episodic {
+		return SidecarFile{}, false
+	}
+	return largest, true
+}
+
+func sameEpisode(a, b []string) bool {
+	as, _ := strconv.Atoi(a[1])
+	ae, _ := strconv.Atoi(a[2])
+	bs, _ := strconv.Atoi(b[1])
+	be, _ := strconv.Atoi(b[2])
+	return as == bs && ae == be
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSubtitleName(t *testing.T) {
+	tests := []struct {
+		name  string
+		lang  string
+		flags []string
+	}{
+		{"Movie.2020.1080p.BluRay-GRP.eng.srt", "en", nil},
+		{"Subs/2_English.srt", "en", nil},
+		{"Movie.2020.German.forced.srt", "de", []string{"forced"}},
+		{"Movie.fr.sdh.forced.ass", "fr", []string{"sdh", "forced"}},
+		{"Movie.2020.1080p.BluRay-GRP.srt", "", nil},
+		{"Movie.English.HI.srt", "en", []string{"sdh"}},
+	}
+	for _, tt := range tests {
+		lang, flags := ParseSubtitleName(tt.name)
+		if lang != tt.lang || !reflect.DeepEqual(flags, tt.flags) {
+			t.Errorf("ParseSubtitleName(%q) = (%q, %v); want (%q, %v)", tt.name, lang, flags, tt.lang, tt.flags)
+		}
+	}
+}
+
+func TestPlanSubtitleSidecars_Movie(t *testing.T) {
+	const dir = "/complete/movies/Movie.2020"
+	got := PlanSubtitleSidecars([]SidecarFile{
+		{Path: dir + "/Movie.2020.CD1.mkv", Size: 700},
+		{Path: dir + "/Movie.2020.CD2.mkv", Size: 700},
+		{Path: dir + "/Movie.2020.mkv", Size: 1400}, // stitched
+		{Path: dir + "/Subs/2_English.srt"},
+		{Path: dir + "/Subs/3_English.srt"},
+		{Path: dir + "/Subs/vobsub.eng.idx"},
+		{Path: dir + "/Subs/vobsub.eng.sub"},
+		{Path: dir + "/Movie.2020.nl.srt"}, // already matches
+	})
+	want := map[string]string{
+		dir + "/Subs/2_English.srt":  dir + "/Movie.2020.en.srt",
+		dir + "/Subs/3_English.srt":  dir + "/Movie.2020.en.2.srt",
+		dir + "/Subs/vobsub.eng.idx": dir + "/Movie.2020.en.idx",
+		dir + "/Subs/vobsub.eng.sub": dir + "/Movie.2020.en.sub",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PlanSubtitleSidecars = %v\nwant %v", got, want)
+	}
+}
+
+func TestPlanSubtitleSidecars_Episodes(t *testing.T) {
+	const dir = "/complete/tv/Show.S01"
+	got := PlanSubtitleSidecars([]SidecarFile{
+		{Path: dir + "/Show.S01E01.mkv", Size: 100},
+		{Path: dir + "/Show.S01E02.mkv", Size: 100},
+		{Path: dir + "/Subs/Show.S01E02/English.srt"},
+		{Path: dir + "/Show.S01E01.spa.srt"},
+		{Path: dir + "/English.srt"}, // no episode: ambiguous
+	})
+	want := map[string]string{
+		dir + "/Subs/Show.S01E02/English.srt": dir + "/Show.S01E02.en.srt",
+		dir + "/Show.S01E01.spa.srt":          dir + "/Show.S01E01.es.srt",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PlanSubtitleSidecars = %v\nwant %v", got, want)
+	}
+
+	if got := PlanSubtitleSidecars([]SidecarFile{{Path: "/a/English.srt"}}); got != nil {
+		t.Errorf("no video: got %v", got)
+	}
+}
+
+func TestWithSubtitleExtensions(t *testing.T) {
+	if got := WithSubtitleExtensions(nil); got != nil {
+		t.Errorf("empty list must stay empty (allows everything), got %v", got)
+	}
+	got := WithSubtitleExtensions([]string{".mkv", "srt"})
+	if !IsAllowedFile("Movie.en.ass", 10, got, true) || !IsAllowedFile("Movie.mkv", 10, got, true) {
+		t.Errorf("WithSubtitleExtensions = %v", got)
+	}
+	for _, ext := range got {
+		if ext == ".srt" {
+			t.Errorf("srt already allowed must not be added twice: %v", got)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sync"
 	"time"
 
 	metapb "github.com/javi11/altmount/internal/metadata/proto"
@@ -147,6 +148,9 @@ func FastFailReleaseProbe(
 type FastFailFileResult struct {
 	Broken            bool
 	MissingSegmentIDs []string // segment IDs whose Stat failed
+	// MissingErrors holds the provider response for each MissingSegmentIDs
+	// entry (index-aligned), surfaced in the import report.
+	MissingErrors []string
 	// SampledCount is how many of the file's segments were Stat-checked (the
 	// sample size), needed to project the release-wide miss rate for the
 	// tolerant damage policy.
@@ -290,6 +294,7 @@ func FastFailCheckFiles(
 			if statErr := errByID[job.segID]; statErr != nil {
 				results[job.fileIdx].Broken = true
 				results[job.fileIdx].MissingSegmentIDs = append(results[job.fileIdx].MissingSegmentIDs, job.segID)
+				results[job.fileIdx].MissingErrors = append(results[job.fileIdx].MissingErrors, statErr.Error())
 				if job.groupKey != "" {
 					brokenGroups[job.groupKey] = struct{}{}
 				}
@@ -315,3 +320,94 @@ func FastFailCheckFiles(
 
 	return results, nil
 }
+
+// ProviderSample is one provider's result in FastFailProviderSample.
+type ProviderSample struct {
+	Provider string
+	// Sampled counts the segments the provider answered for definitively
+	// (found or 430 not found); Missing counts the 430s among them.
+	Sampled int
+	Missing int
+	// Err is the last non-430 failure. It is only set when the provider gave
+	// no definitive answer at all (unreachable, quota exhausted, ...).
+	Err error
+}
+
+// FastFailProviderSample Stats one release-wide sample (the same selection
+// FastFailReleaseProbe uses) on every provider of the pool separately, so each
+// provider's share of the release can be estimated on its own. Unlike the
+// probe it never stops early: each provider's whole sample is checked.
+// Providers are sampled concurrently, sharing maxConnections in proportion to
+// their connection counts (see pool.ProviderBudget). A release with no
+// segments or a pool with no providers yields nil.
+func FastFailProviderSample(
+	ctx context.Context,
+	files []FastFailFile,
+	poolManager pool.Manager,
+	segmentSamplePercentage int,
+	maxConnections int,
+	timeout time.Duration,
+) ([]ProviderSample, error) {
+	var segments []*metapb.SegmentData
+	for _, file := range files {
+		for _, segment := range file.Segments {
+			if segment != nil && segment.Id != "" {
+				segments = append(segments, segment)
+			}
+		}
+	}
+	if len(segments) == 0 {
+		return nil, nil
+	}
+
+	selected := usenet.SelectSegmentsForValidation(segments, segmentSamplePercentage)
+	if len(selected) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(selected))
+	for i, seg := range selected {
+		ids[i] = seg.Id
+	}
+
+	if !poolManager.HasPool() {
+		return nil, fmt.Errorf("cannot sample providers: usenet connection pool is nil")
+	}
+	usenetPool, err := poolManager.GetPool()
+	if err != nil {
+		return nil, fmt.Errorf("cannot sample providers: usenet connection pool unavailable: %w", err)
+	}
+	if usenetPool == nil {
+		return nil, fmt.Errorf("cannot sample providers: usenet connection pool is nil")
+	}
+
+	providers := usenetPool.Stats().Providers
+	if len(providers) == 0 {
+		return nil, nil
+	}
+	budget := pool.ProviderBudget(providers, maxConnections)
+
+	results := make([]ProviderSample, len(providers))
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = sampleProvider(ctx, usenetPool, p.Name, ids, budget[i], timeout)
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// sampleProvider Stats ids on a single provider and summarizes its answers.
+func sampleProvider(ctx context.Context, usenetPool pool.NntpClient, provider string, ids []string, concurrency int, timeout time.Duration) ProviderSample {
+	held, err := pool.StatOnProvider(ctx, usenetPool, provider, ids, concurrency, timeout)
+	result := ProviderSample{Provider: provider, Sampled: len(held), Err: err}
+	for _, has := range held {
+		if !has {
+			result.Missing++
+		}
+	}
+	return result
+}
@@ -520,3 +520,66 @@ func TestFastFailCheckFilesIndexAligned(t *testing.T) {
 		t.Error("results[2].Broken = true, want false")
 	}
 }
+
+func TestFastFailProviderSampleEstimatesEachProvider(t *testing.T) {
+	client := fakepool.New()
+	client.SetStats(nntppool.ClientStats{Providers: []nntppool.ProviderStats{
+		{Name: "primary", MaxConnections: 4},
+		{Name: "backup", MaxConnections: 2},
+	}})
+	// backup lost two of the ten articles; primary holds all of them.
+	client.SetProviderBehavior("backup", "video-3", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	client.SetProviderBehavior("backup", "video-7", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+
+	files := []FastFailFile{{Filename: "movie.mkv", Segments: makeTestSegments("video", 10)}}
+
+	samples, err := FastFailProviderSample(
+		context.Background(),
+		files,
+		fastFailPoolManager{client: client},
+		100,
+		8,
+		100*time.Millisecond,
+	)
+	if err != nil {
+		t.Fatalf("FastFailProviderSample returned error: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("len(samples) = %d, want 2", len(samples))
+	}
+
+	byName := make(map[string]ProviderSample, len(samples))
+	for _, s := range samples {
+		byName[s.Provider] = s
+	}
+	if got := byName["primary"]; got.Sampled != 10 || got.Missing != 0 || got.Err != nil {
+		t.Fatalf("primary = %+v, want 10 sampled, 0 missing", got)
+	}
+	if got := byName["backup"]; got.Sampled != 10 || got.Missing != 2 || got.Err != nil {
+		t.Fatalf("backup = %+v, want 10 sampled, 2 missing", got)
+	}
+	// Every provider sees the whole sample, independently.
+	if got := client.StatCalls(); got != 20 {
+		t.Fatalf("StatCalls = %d, want 20 (10 per provider)", got)
+	}
+}
+
+func TestFastFailProviderSampleNoProviders(t *testing.T) {
+	client := fakepool.New()
+	files := []FastFailFile{{Filename: "movie.mkv", Segments: makeTestSegments("video", 10)}}
+
+	samples, err := FastFailProviderSample(
+		context.Background(),
+		files,
+		fastFailPoolManager{client: client},
+		100,
+		8,
+		100*time.Millisecond,
+	)
+	if err != nil {
+		t.Fatalf("FastFailProviderSample returned error: %v", err)
+	}
+	if len(samples) != 0 {
+		t.Fatalf("samples = %+v, want none without provider stats", samples)
+	}
+}
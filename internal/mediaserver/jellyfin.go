@@ -0,0 +1,164 @@
+package mediaserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jellyfinPageSize is the number of items fetched per /Items request.
+const jellyfinPageSize = 5000
+
+// jellyfinClient lists and refreshes Jellyfin libraries. Emby serves the same
+// API under the /emby prefix.
+type jellyfinClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func newJellyfinClient(baseURL, prefix, token string, httpClient *http.Client) *jellyfinClient {
+	return &jellyfinClient{baseURL: strings.TrimRight(baseURL, "/") + prefix, token: token, http: httpClient}
+}
+
+// jellyfinItemTypes are the item types holding media files.
+const jellyfinItemTypes = "Movie,Episode,Video,Audio"
+
+// jellyfinItem is a library item with its file and, when listed for a user,
+// that user's watch state.
+type jellyfinItem struct {
+	Path     string `json:"Path"`
+	UserData struct {
+		LastPlayedDate string `json:"LastPlayedDate"`
+	} `json:"UserData"`
+}
+
+// ListFiles returns the files of every movie, episode, video and audio item.
+func (c *jellyfinClient) ListFiles(ctx context.Context) ([]string, error) {
+	var files []string
+	err := c.eachItem(ctx, "/Items", nil, func(item jellyfinItem) {
+		if item.Path != "" {
+			files = append(files, item.Path)
+		}
+	})
+	return files, err
+}
+
+// WatchState returns the files every user played or is part way through.
+func (c *jellyfinClient) WatchState(ctx context.Context) ([]Watched, error) {
+	var users []struct {
+		ID string `json:"Id"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/Users", nil, &users); err != nil {
+		return nil, err
+	}
+
+	var watched []Watched
+	for _, user := range users {
+		// Filters combine with AND, so partially watched and played items are
+		// listed separately.
+		for _, filter := range []string{"IsResumable", "IsPlayed"} {
+			extra := url.Values{"Filters": {filter}, "EnableUserData": {"true"}}
+			err := c.eachItem(ctx, "/Users/"+url.PathEscape(user.ID)+"/Items", extra, func(item jellyfinItem) {
+				lastPlayed, err := time.Parse(time.RFC3339Nano, item.UserData.LastPlayedDate)
+				if item.Path == "" || err != nil {
+					return
+				}
+				watched = append(watched, Watched{
+					Path:       item.Path,
+					LastPlayed: lastPlayed,
+					InProgress: filter == "IsResumable",
+				})
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return watched, nil
+}
+
+// eachItem pages through the media items of an items endpoint, with extra
+// query parameters, calling fn for each.
+func (c *jellyfinClient) eachItem(ctx context.Context, endpoint string, extra url.Values, fn func(jellyfinItem)) error {
+	for start := 0; ; start += jellyfinPageSize {
+		var page struct {
+			Items            []jellyfinItem `json:"Items"`
+			TotalRecordCount int            `json:"TotalRecordCount"`
+		}
+		query := url.Values{
+			"Recursive":        {"true"},
+			"IncludeItemTypes": {jellyfinItemTypes},
+			"Fields":           {"Path"},
+			"StartIndex":       {strconv.Itoa(start)},
+			"Limit":            {strconv.Itoa(jellyfinPageSize)},
+		}
+		for k, v := range extra {
+			query[k] = v
+		}
+		if err := c.do(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil, &page); err != nil {
+			return err
+		}
+		for _, item := range page.Items {
+			fn(item)
+		}
+		if len(page.Items) < jellyfinPageSize || start+len(page.Items) >= page.TotalRecordCount {
+			return nil
+		}
+	}
+}
+
+// RefreshDir reports dir as modified so the server rescans it.
+func (c *jellyfinClient) RefreshDir(ctx context.Context, dir string) error {
+	body := map[string]any{
+		"Updates": []map[string]string{{"Path": dir, "UpdateType": "Modified"}},
+	}
+	return c.do(ctx, http.MethodPost, "/Library/Media/Updated", body, nil)
+}
+
+// do calls an endpoint with an optional JSON body and decodes its JSON
+// response into out (when not nil).
+func (c *jellyfinClient) do(ctx context.Context, method, endpoint string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Emby-Token", c.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned status %d", method, strings.SplitN(endpoint, "?", 2)[0], resp.StatusCode)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", endpoint, err)
+	}
+	return nil
+}
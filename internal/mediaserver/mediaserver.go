@@ -0,0 +1,170 @@
+// Package mediaserver connects to Plex, Jellyfin and Emby servers to list the
+// files their libraries reference, read what their users watch and refresh
+// them after changes.
+package mediaserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/javi11/altmount/internal/httpclient"
+)
+
+// requestTimeout bounds every media server request; library listings of large
+// servers are the slowest.
+const requestTimeout = 60 * time.Second
+
+// Client is a media server connector.
+type Client interface {
+	// ListFiles returns the paths of every file in the server's libraries, as
+	// the server sees them.
+	ListFiles(ctx context.Context) ([]string, error)
+	// RefreshDir asks the server to rescan a directory, as the server sees it.
+	RefreshDir(ctx context.Context, dir string) error
+	// WatchState returns the files the server's users played or are part way
+	// through, with the paths as the server sees them.
+	WatchState(ctx context.Context) ([]Watched, error)
+}
+
+// Watched is a file a media server user played.
+type Watched struct {
+	Path       string
+	LastPlayed time.Time
+	// InProgress marks a partially watched file, one the server lists under
+	// "continue watching".
+	InProgress bool
+}
+
+// Server is a configured media server and the path it sees the mount at.
+type Server struct {
+	Name      string
+	Type      string
+	mountPath string
+	windows   bool // The server reports Windows paths
+	client    Client
+}
+
+// New creates the connector of a media server.
+func New(cfg config.MediaServerConfig, mountPath string, httpClient *http.Client) (*Server, error) {
+	var client Client
+	switch cfg.Type {
+	case config.MediaServerTypePlex:
+		client = newPlexClient(cfg.URL, cfg.Token, httpClient)
+	case config.MediaServerTypeJellyfin:
+		client = newJellyfinClient(cfg.URL, "", cfg.Token, httpClient)
+	case config.MediaServerTypeEmby:
+		client = newJellyfinClient(cfg.URL, "/emby", cfg.Token, httpClient)
+	default:
+		return nil, fmt.Errorf("unsupported media server type %q", cfg.Type)
+	}
+	return &Server{
+		Name:      cfg.Name,
+		Type:      cfg.Type,
+		mountPath: cleanServerPath(mountPath),
+		windows:   strings.Contains(mountPath, `\`),
+		client:    client,
+	}, nil
+}
+
+// FromConfig creates the connectors of every enabled media server.
+func FromConfig(cfg *config.Config) []*Server {
+	httpClient := httpclient.NewForExternal(cfg.Network, requestTimeout)
+	var servers []*Server
+	for _, ms := range cfg.GetMediaServers() {
+		s, err := New(ms, ms.MountPath, httpClient)
+		if err != nil {
+			slog.Warn("Skipping media server", "name", ms.Name, "error", err)
+			continue
+		}
+		servers = append(servers, s)
+	}
+	return servers
+}
+
+// MountFiles returns the mount-relative paths of the files the server
+// references inside the mount. Files outside it (local media, library
+// symlinks) are left out.
+func (s *Server) MountFiles(ctx context.Context) (map[string]bool, error) {
+	files, err := s.client.ListFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", s.Type, s.Name, err)
+	}
+	inMount := make(map[string]bool)
+	for _, f := range files {
+		if rel, ok := s.relativePath(f); ok {
+			inMount[rel] = true
+		}
+	}
+	return inMount, nil
+}
+
+// MountWatchState returns the watch state of the files inside the mount, with
+// mount-relative paths.
+func (s *Server) MountWatchState(ctx context.Context) ([]Watched, error) {
+	watched, err := s.client.WatchState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", s.Type, s.Name, err)
+	}
+	inMount := watched[:0]
+	for _, w := range watched {
+		if rel, ok := s.relativePath(w.Path); ok {
+			w.Path = rel
+			inMount = append(inMount, w)
+		}
+	}
+	return inMount, nil
+}
+
+// Refresh asks the server to rescan the directory of a mount-relative file.
+func (s *Server) Refresh(ctx context.Context, mountRelPath string) error {
+	dir := path.Dir(path.Join(s.mountPath, strings.TrimPrefix(mountRelPath, "/")))
+	if s.windows {
+		dir = strings.ReplaceAll(strings.TrimPrefix(dir, "/"), "/", `\`)
+	}
+	if err := s.client.RefreshDir(ctx, dir); err != nil {
+		return fmt.Errorf("%s %q: %w", s.Type, s.Name, err)
+	}
+	return nil
+}
+
+// relativePath maps a path the server sees to a mount-relative path.
+func (s *Server) relativePath(serverPath string) (string, bool) {
+	p := cleanServerPath(serverPath)
+	if s.mountPath == "/" {
+		return strings.TrimPrefix(p, "/"), p != "/"
+	}
+	rel, ok := strings.CutPrefix(p, s.mountPath+"/")
+	return rel, ok && rel != ""
+}
+
+// cleanServerPath normalizes a server path to a clean forward-slash path;
+// servers on Windows report backslashes.
+func cleanServerPath(p string) string {
+	return path.Clean("/" + strings.ReplaceAll(p, `\`, "/"))
+}
+
+// RefreshAll refreshes the directories of the given mount-relative files on
+// every server, logging failures. Each directory is refreshed once.
+func RefreshAll(ctx context.Context, servers []*Server, mountRelPaths []string) {
+	for _, s := range servers {
+		seen := make(map[string]bool)
+		for _, p := range mountRelPaths {
+			dir := path.Dir(p)
+			if seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			if err := s.Refresh(ctx, p); err != nil {
+				slog.WarnContext(ctx, "Failed to refresh media server library", "path", p, "error", err)
+				continue
+			}
+			slog.DebugContext(ctx, "Refreshed media server library", "server", s.Name, "path", p)
+		}
+	}
+}
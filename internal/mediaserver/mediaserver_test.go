@@ -0,0 +1,155 @@
+package mediaserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlexMountFilesAndRefresh(t *testing.T) {
+	var refreshed []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Plex-Token"))
+		switch r.URL.Path {
+		case "/library/sections":
+			_ = json.NewEncoder(w).Encode(map[string]any{"MediaContainer": map[string]any{"Directory": []any{
+				map[string]any{"key": "1", "type": "movie", "Location": []any{map[string]any{"path": "/data/altmount/movies"}}},
+				map[string]any{"key": "2", "type": "photo", "Location": []any{map[string]any{"path": "/photos"}}},
+			}}})
+		case "/library/sections/1/all":
+			assert.Equal(t, "1", r.URL.Query().Get("type"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"MediaContainer": map[string]any{"Metadata": []any{
+				map[string]any{"Media": []any{map[string]any{"Part": []any{map[string]any{"file": "/data/altmount/movies/A (2020)/A.mkv"}}}}},
+				map[string]any{"Media": []any{map[string]any{"Part": []any{map[string]any{"file": "/local/B.mkv"}}}}},
+			}}})
+		case "/library/sections/1/refresh":
+			refreshed = append(refreshed, r.URL.Query().Get("path"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	s, err := New(config.MediaServerConfig{Name: "plex", Type: config.MediaServerTypePlex, URL: srv.URL, Token: "secret"}, "/data/altmount/", srv.Client())
+	require.NoError(t, err)
+
+	files, err := s.MountFiles(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"movies/A (2020)/A.mkv": true}, files)
+
+	require.NoError(t, s.Refresh(context.Background(), "movies/C (2021)/C.mkv"))
+	require.NoError(t, s.Refresh(context.Background(), "tv/Show/S01E01.mkv"), "a path outside every section is skipped")
+	assert.Equal(t, []string{"/data/altmount/movies/C (2021)"}, refreshed)
+}
+
+func TestJellyfinMountFilesAndRefresh(t *testing.T) {
+	var updated []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("X-Emby-Token"))
+		switch r.URL.Path {
+		case "/emby/Items":
+			_ = json.NewEncoder(w).Encode(map[string]any{"TotalRecordCount": 2, "Items": []any{
+				map[string]any{"Path": `M:\altmount\tv\Show\S01E01.mkv`},
+				map[string]any{"Path": `M:\altmount`},
+			}})
+		case "/emby/Library/Media/Updated":
+			var body struct {
+				Updates []struct{ Path string } `json:"Updates"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			for _, u := range body.Updates {
+				updated = append(updated, u.Path)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	s, err := New(config.MediaServerConfig{Name: "emby", Type: config.MediaServerTypeEmby, URL: srv.URL + "/", Token: "key"}, `M:\altmount`, srv.Client())
+	require.NoError(t, err)
+
+	files, err := s.MountFiles(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"tv/Show/S01E01.mkv": true}, files)
+
+	RefreshAll(context.Background(), []*Server{s}, []string{"tv/Show/S01E01.mkv", "tv/Show/S01E02.mkv"})
+	assert.Equal(t, []string{`M:\altmount\tv\Show`}, updated, "each directory is refreshed once")
+}
+
+func TestPlexMountWatchState(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/library/sections":
+			_ = json.NewEncoder(w).Encode(map[string]any{"MediaContainer": map[string]any{"Directory": []any{
+				map[string]any{"key": "1", "type": "movie"},
+			}}})
+		case "/library/sections/1/all":
+			part := func(file string) []any {
+				return []any{map[string]any{"Part": []any{map[string]any{"file": file}}}}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"MediaContainer": map[string]any{"Metadata": []any{
+				map[string]any{"lastViewedAt": 1700000000, "Media": part("/data/altmount/movies/A.mkv")},
+				map[string]any{"lastViewedAt": 1700000100, "viewOffset": 60000, "Media": part("/data/altmount/movies/B.mkv")},
+				map[string]any{"Media": part("/data/altmount/movies/Unwatched.mkv")},
+				map[string]any{"lastViewedAt": 1700000200, "Media": part("/local/C.mkv")},
+			}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	s, err := New(config.MediaServerConfig{Name: "plex", Type: config.MediaServerTypePlex, URL: srv.URL}, "/data/altmount", srv.Client())
+	require.NoError(t, err)
+
+	watched, err := s.MountWatchState(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Watched{
+		{Path: "movies/A.mkv", LastPlayed: time.Unix(1700000000, 0)},
+		{Path: "movies/B.mkv", LastPlayed: time.Unix(1700000100, 0), InProgress: true},
+	}, watched)
+}
+
+func TestJellyfinMountWatchState(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Users":
+			_ = json.NewEncoder(w).Encode([]any{map[string]any{"Id": "u1"}})
+		case "/Users/u1/Items":
+			assert.Equal(t, "true", r.URL.Query().Get("EnableUserData"))
+			var items []any
+			switch r.URL.Query().Get("Filters") {
+			case "IsResumable":
+				items = []any{map[string]any{"Path": "/mnt/altmount/tv/S01E02.mkv", "UserData": map[string]any{"LastPlayedDate": "2024-05-02T20:00:00.0000000Z"}}}
+			case "IsPlayed":
+				items = []any{
+					map[string]any{"Path": "/mnt/altmount/tv/S01E01.mkv", "UserData": map[string]any{"LastPlayedDate": "2024-05-01T20:00:00.0000000Z"}},
+					map[string]any{"Path": "/mnt/altmount/tv/NeverDated.mkv"},
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"TotalRecordCount": len(items), "Items": items})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	s, err := New(config.MediaServerConfig{Name: "jellyfin", Type: config.MediaServerTypeJellyfin, URL: srv.URL}, "/mnt/altmount", srv.Client())
+	require.NoError(t, err)
+
+	watched, err := s.MountWatchState(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Watched{
+		{Path: "tv/S01E02.mkv", LastPlayed: time.Date(2024, 5, 2, 20, 0, 0, 0, time.UTC), InProgress: true},
+		{Path: "tv/S01E01.mkv", LastPlayed: time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)},
+	}, watched)
+}
@@ -0,0 +1,194 @@
+package mediaserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// plexClient lists and refreshes Plex library sections.
+type plexClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func newPlexClient(baseURL, token string, httpClient *http.Client) *plexClient {
+	return &plexClient{baseURL: strings.TrimRight(baseURL, "/"), token: token, http: httpClient}
+}
+
+type plexSection struct {
+	Key      string `json:"key"`
+	Type     string `json:"type"`
+	Location []struct {
+		Path string `json:"path"`
+	} `json:"Location"`
+}
+
+type plexMetadata struct {
+	LastViewedAt int64 `json:"lastViewedAt"` // Unix seconds
+	ViewOffset   int64 `json:"viewOffset"`   // Milliseconds into a partially watched item
+	Media        []struct {
+		Part []struct {
+			File string `json:"file"`
+		} `json:"Part"`
+	} `json:"Media"`
+}
+
+// plexItemTypes maps a section type to the Plex item type holding its files.
+var plexItemTypes = map[string]string{
+	"movie":  "1",
+	"show":   "4",  // episodes
+	"artist": "10", // tracks
+}
+
+// ListFiles returns the files of every movie, show and music section.
+func (c *plexClient) ListFiles(ctx context.Context) ([]string, error) {
+	items, err := c.items(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, item := range items {
+		files = append(files, item.files()...)
+	}
+	return files, nil
+}
+
+// WatchState returns the played and partially watched files of the token's
+// user.
+func (c *plexClient) WatchState(ctx context.Context) ([]Watched, error) {
+	items, err := c.items(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var watched []Watched
+	for _, item := range items {
+		if item.LastViewedAt == 0 {
+			continue
+		}
+		for _, f := range item.files() {
+			watched = append(watched, Watched{
+				Path:       f,
+				LastPlayed: time.Unix(item.LastViewedAt, 0),
+				InProgress: item.ViewOffset > 0,
+			})
+		}
+	}
+	return watched, nil
+}
+
+// items lists the items of every movie, show and music section.
+func (c *plexClient) items(ctx context.Context) ([]plexMetadata, error) {
+	sections, err := c.sections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []plexMetadata
+	for _, section := range sections {
+		itemType, ok := plexItemTypes[section.Type]
+		if !ok {
+			continue
+		}
+		var resp struct {
+			MediaContainer struct {
+				Metadata []plexMetadata `json:"Metadata"`
+			} `json:"MediaContainer"`
+		}
+		query := url.Values{"type": {itemType}}
+		if err := c.get(ctx, "/library/sections/"+url.PathEscape(section.Key)+"/all", query, &resp); err != nil {
+			return nil, err
+		}
+		items = append(items, resp.MediaContainer.Metadata...)
+	}
+	return items, nil
+}
+
+// files returns the paths of every part of an item.
+func (m plexMetadata) files() []string {
+	var files []string
+	for _, media := range m.Media {
+		for _, part := range media.Part {
+			if part.File != "" {
+				files = append(files, part.File)
+			}
+		}
+	}
+	return files
+}
+
+// RefreshDir runs a partial scan of dir in every section that contains it.
+func (c *plexClient) RefreshDir(ctx context.Context, dir string) error {
+	sections, err := c.sections(ctx)
+	if err != nil {
+		return err
+	}
+	target := cleanServerPath(dir)
+	for _, section := range sections {
+		for _, loc := range section.Location {
+			root := cleanServerPath(loc.Path)
+			if target != root && !strings.HasPrefix(target, strings.TrimSuffix(root, "/")+"/") {
+				continue
+			}
+			query := url.Values{"path": {dir}}
+			if err := c.get(ctx, "/library/sections/"+url.PathEscape(section.Key)+"/refresh", query, nil); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (c *plexClient) sections(ctx context.Context) ([]plexSection, error) {
+	var resp struct {
+		MediaContainer struct {
+			Directory []plexSection `json:"Directory"`
+		} `json:"MediaContainer"`
+	}
+	if err := c.get(ctx, "/library/sections", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.MediaContainer.Directory, nil
+}
+
+// get calls a Plex endpoint and decodes its JSON response into out (when not
+// nil).
+func (c *plexClient) get(ctx context.Context, endpoint string, query url.Values, out any) error {
+	u := c.baseURL + endpoint
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Plex-Token", c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("GET %s returned status %d", endpoint, resp.StatusCode)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", endpoint, err)
+	}
+	return nil
+}
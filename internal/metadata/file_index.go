@@ -0,0 +1,47 @@
+package metadata
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strings"
+)
+
+// FileIndex keeps a persisted index of the .meta files under the metadata root
+// so library sync can diff against it instead of walking the tree.
+// Implementations are provided by the database layer; the nil value is always safe to use.
+type FileIndex interface {
+	IndexMetadata(ctx context.Context, path string) error
+	RemoveMetadata(ctx context.Context, path string) error
+	RemoveMetadataDir(ctx context.Context, dir string) error
+	RenameMetadata(ctx context.Context, oldPath, newPath string) error
+	RenameMetadataDir(ctx context.Context, oldDir, newDir string) error
+}
+
+// SetFileIndex wires in a FileIndex that is kept up to date on every metadata
+// write, rename and delete.
+func (ms *MetadataService) SetFileIndex(idx FileIndex) {
+	ms.fileIndex = idx
+}
+
+// indexKey returns the mount-relative path a .meta file is indexed under: the
+// virtual path with the (possibly truncated) on-disk filename, forward slashes
+// and no leading slash, matching what a walk of the metadata root yields.
+func (ms *MetadataService) indexKey(virtualPath string) string {
+	dir := filepath.Dir(virtualPath)
+	name := ms.truncateFilename(filepath.Base(virtualPath))
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Join(dir, name)), "/")
+}
+
+// updateFileIndex applies op to the file index, if one is configured.
+// Indexing is best-effort: a DB failure is logged but does not fail the
+// caller, since the periodic full library sync rebuilds the index.
+func (ms *MetadataService) updateFileIndex(ctx context.Context, action, virtualPath string, op func(FileIndex) error) {
+	if ms.fileIndex == nil {
+		return
+	}
+	if err := op(ms.fileIndex); err != nil {
+		slog.WarnContext(ctx, "failed to update metadata file index",
+			"action", action, "path", virtualPath, "error", err)
+	}
+}
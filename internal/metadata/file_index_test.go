@@ -0,0 +1,88 @@
+package metadata
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFileIndex is an in-memory FileIndex.
+type recordingFileIndex struct {
+	paths map[string]bool
+}
+
+func (r *recordingFileIndex) IndexMetadata(_ context.Context, path string) error {
+	r.paths[path] = true
+	return nil
+}
+
+func (r *recordingFileIndex) RemoveMetadata(_ context.Context, path string) error {
+	delete(r.paths, path)
+	return nil
+}
+
+func (r *recordingFileIndex) RemoveMetadataDir(_ context.Context, dir string) error {
+	for p := range r.paths {
+		if strings.HasPrefix(p, dir+"/") {
+			delete(r.paths, p)
+		}
+	}
+	return nil
+}
+
+func (r *recordingFileIndex) RenameMetadata(_ context.Context, oldPath, newPath string) error {
+	delete(r.paths, oldPath)
+	r.paths[newPath] = true
+	return nil
+}
+
+func (r *recordingFileIndex) RenameMetadataDir(_ context.Context, oldDir, newDir string) error {
+	for p := range r.paths {
+		if strings.HasPrefix(p, oldDir+"/") {
+			delete(r.paths, p)
+			r.paths[newDir+strings.TrimPrefix(p, oldDir)] = true
+		}
+	}
+	return nil
+}
+
+func TestMetadataService_MaintainsFileIndex(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMetadataService(t.TempDir())
+	idx := &recordingFileIndex{paths: map[string]bool{}}
+	ms.SetFileIndex(idx)
+
+	write := func(virtualPath string) {
+		meta := ms.CreateFileMetadata(
+			1024, "test.nzb", metapb.FileStatus_FILE_STATUS_HEALTHY,
+			nil, metapb.Encryption_NONE, "", "", nil, nil, 0, nil, "",
+		)
+		require.NoError(t, ms.WriteFileMetadata(virtualPath, meta))
+	}
+
+	write("/movies/a.mkv")
+	write(filepath.Join("movies", "b.mkv"))
+	write(filepath.Join("tv", "show", "e01.mkv"))
+	write(filepath.Join("tv", "show", "e02.mkv"))
+	write(filepath.Join("other", "c.mkv"))
+
+	require.NoError(t, ms.RenameFileMetadata(filepath.Join("movies", "b.mkv"), filepath.Join("movies", "b2.mkv")))
+	require.NoError(t, ms.RenameDirectory(filepath.Join("tv", "show"), filepath.Join("tv", "renamed")))
+	require.NoError(t, ms.DeleteFileMetadata("/movies/a.mkv"))
+	require.NoError(t, ms.MoveToCorrupted(ctx, filepath.Join("other", "c.mkv")))
+	require.True(t, ms.FileExists(filepath.Join("tv", "renamed", "e01.mkv")))
+
+	assert.Equal(t, map[string]bool{
+		"movies/b2.mkv":      true,
+		"tv/renamed/e01.mkv": true,
+		"tv/renamed/e02.mkv": true,
+	}, idx.paths)
+
+	require.NoError(t, ms.DeleteDirectory("tv"))
+	assert.Equal(t, map[string]bool{"movies/b2.mkv": true}, idx.paths)
+}
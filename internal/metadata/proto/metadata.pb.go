@@ -386,9 +386,13 @@ func (x *NestedSegmentSource) GetSegmentRefs() []*SegmentRef {
 // (and delta_90k to the 90 kHz-equivalent of PCR base) for packets inside this
 // clip's byte range, lifting the clip onto the unified continuous timeline.
 type ClipBoundary struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ByteLen       int64                  `protobuf:"varint,1,opt,name=byte_len,json=byteLen,proto3" json:"byte_len,omitempty"`
-	Delta_90K     int64                  `protobuf:"varint,2,opt,name=delta_90k,json=delta90k,proto3" json:"delta_90k,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ByteLen   int64                  `protobuf:"varint,1,opt,name=byte_len,json=byteLen,proto3" json:"byte_len,omitempty"`
+	Delta_90K int64                  `protobuf:"varint,2,opt,name=delta_90k,json=delta90k,proto3" json:"delta_90k,omitempty"`
+	// packet_size is the source packet size of the clip: 0 or 192 for BDAV
+	// (Blu-ray M2TS), 188 for plain MPEG-TS parts stitched from multi-part
+	// releases.
+	PacketSize    int32 `protobuf:"varint,3,opt,name=packet_size,json=packetSize,proto3" json:"packet_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -437,6 +441,13 @@ func (x *ClipBoundary) GetDelta_90K() int64 {
 	return 0
 }
 
+func (x *ClipBoundary) GetPacketSize() int32 {
+	if x != nil {
+		return x.PacketSize
+	}
+	return 0
+}
+
 // HoleRun is a run of consecutive segments confirmed missing on every
 // provider (a "hole"). Persisted so streaming can zero-fill known holes
 // without a wasted network round-trip (replay pre-pad) and so health checks
@@ -533,8 +544,11 @@ type FileMetadata struct {
 	SegmentRefs        []*SegmentRef          `protobuf:"bytes,19,rep,name=segment_refs,json=segmentRefs,proto3" json:"segment_refs,omitempty"` // v3 replacement for segment_data
 	SegmentRuns        []*SegmentRun          `protobuf:"bytes,20,rep,name=segment_runs,json=segmentRuns,proto3" json:"segment_runs,omitempty"` // compact run encoding; preferred over segment_refs when present
 	KnownHoles         []*HoleRun             `protobuf:"bytes,21,rep,name=known_holes,json=knownHoles,proto3" json:"known_holes,omitempty"`    // segments confirmed missing on all providers (zero-filled during playback)
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Contents of a small generated file (e.g. a Blu-ray chapter sidecar)
+	// served from memory. Files with inline data have no segments.
+	InlineData    []byte `protobuf:"bytes,22,opt,name=inline_data,json=inlineData,proto3" json:"inline_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FileMetadata) Reset() {
@@ -714,6 +728,13 @@ func (x *FileMetadata) GetKnownHoles() []*HoleRun {
 	return nil
 }
 
+func (x *FileMetadata) GetInlineData() []byte {
+	if x != nil {
+		return x.InlineData
+	}
+	return nil
+}
+
 // NzbStore is the complete original NZB for a release, stored zstd-compressed at
 // the (renamed) source_nzb_path. Single source of truth for streaming + NZB regen.
 type NzbStore struct {
@@ -1054,13 +1075,15 @@ const file_internal_metadata_proto_metadata_proto_rawDesc = "" +
 	"\finner_length\x18\x05 \x01(\x03R\vinnerLength\x12*\n" +
 	"\x11inner_volume_size\x18\x06 \x01(\x03R\x0finnerVolumeSize\x129\n" +
 	"\x19shared_outer_source_index\x18\a \x01(\x05R\x16sharedOuterSourceIndex\x127\n" +
-	"\fsegment_refs\x18\b \x03(\v2\x14.metadata.SegmentRefR\vsegmentRefs\"F\n" +
+	"\fsegment_refs\x18\b \x03(\v2\x14.metadata.SegmentRefR\vsegmentRefs\"g\n" +
 	"\fClipBoundary\x12\x19\n" +
 	"\bbyte_len\x18\x01 \x01(\x03R\abyteLen\x12\x1b\n" +
-	"\tdelta_90k\x18\x02 \x01(\x03R\bdelta90k\"D\n" +
+	"\tdelta_90k\x18\x02 \x01(\x03R\bdelta90k\x12\x1f\n" +
+	"\vpacket_size\x18\x03 \x01(\x05R\n" +
+	"packetSize\"D\n" +
 	"\aHoleRun\x12#\n" +
 	"\rstart_segment\x18\x01 \x01(\x03R\fstartSegment\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x03R\x05count\"\xa8\a\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xc9\a\n" +
 	"\fFileMetadata\x12\x1b\n" +
 	"\tfile_size\x18\x01 \x01(\x03R\bfileSize\x12&\n" +
 	"\x0fsource_nzb_path\x18\x02 \x01(\tR\rsourceNzbPath\x12,\n" +
@@ -1089,7 +1112,9 @@ const file_internal_metadata_proto_metadata_proto_rawDesc = "" +
 	"\fsegment_refs\x18\x13 \x03(\v2\x14.metadata.SegmentRefR\vsegmentRefs\x127\n" +
 	"\fsegment_runs\x18\x14 \x03(\v2\x14.metadata.SegmentRunR\vsegmentRuns\x122\n" +
 	"\vknown_holes\x18\x15 \x03(\v2\x11.metadata.HoleRunR\n" +
-	"knownHoles\"8\n" +
+	"knownHoles\x12\x1f\n" +
+	"\vinline_data\x18\x16 \x01(\fR\n" +
+	"inlineData\"8\n" +
 	"\bNzbStore\x12,\n" +
 	"\x05files\x18\x01 \x03(\v2\x16.metadata.NzbFileEntryR\x05files\"\x9a\x01\n" +
 	"\fNzbFileEntry\x12\x18\n" +
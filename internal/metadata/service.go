@@ -67,6 +67,9 @@ type MetadataService struct {
 	// storeRefCounter tracks reference counts for shared NzbStore files.
 	// nil means reference counting is disabled.
 	storeRefCounter StoreRefCounter
+	// fileIndex is kept in step with every .meta write, rename and delete.
+	// nil means indexing is disabled.
+	fileIndex FileIndex
 }
 
 // NewMetadataService creates a new metadata service
@@ -218,6 +221,10 @@ func (ms *MetadataService) WriteFileMetadata(virtualPath string, metadata *metap
 
 	metadata.NzbdavId = nzbdavId // Restore for in-memory use
 
+	ms.updateFileIndex(context.Background(), "write", virtualPath, func(idx FileIndex) error {
+		return idx.IndexMetadata(context.Background(), ms.indexKey(virtualPath))
+	})
+
 	// Update only the lightweight cache; the full proto (with SegmentData) is
 	// never cached to avoid long-term retention of segment strings.
 	ms.liteCache.Add(virtualPath, &FileMetadataLite{
@@ -696,6 +703,9 @@ func (ms *MetadataService) DeleteFileMetadataWithSourceNzb(ctx context.Context,
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to delete metadata file: %w", err)
 	}
+	ms.updateFileIndex(ctx, "delete", virtualPath, func(idx FileIndex) error {
+		return idx.RemoveMetadata(ctx, ms.indexKey(virtualPath))
+	})
 
 	// Clean up .id sidecar file
 	idPath := metadataPath + ".id"
@@ -798,6 +808,9 @@ func (ms *MetadataService) DeleteDirectory(virtualPath string) error {
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to delete metadata directory: %w", err)
 	}
+	ms.updateFileIndex(ctx, "delete_dir", virtualPath, func(idx FileIndex) error {
+		return idx.RemoveMetadataDir(ctx, virtualPath)
+	})
 
 	// Post-pass: decrement ref counts and delete orphaned store files.
 	if ms.storeRefCounter != nil {
@@ -848,6 +861,9 @@ func (ms *MetadataService) RenameFileMetadata(oldVirtualPath, newVirtualPath str
 	if err := utils.MoveFile(oldMetaPath, newMetaPath); err != nil {
 		return fmt.Errorf("failed to rename metadata file: %w", err)
 	}
+	ms.updateFileIndex(context.Background(), "rename", oldVirtualPath, func(idx FileIndex) error {
+		return idx.RenameMetadata(context.Background(), ms.indexKey(oldVirtualPath), ms.indexKey(newVirtualPath))
+	})
 
 	// Also rename the .id sidecar file if it exists
 	oldIDPath := oldMetaPath + ".id"
@@ -861,6 +877,25 @@ func (ms *MetadataService) RenameFileMetadata(oldVirtualPath, newVirtualPath str
 	return nil
 }
 
+// RenameDirectory renames a metadata directory and everything under it.
+func (ms *MetadataService) RenameDirectory(oldVirtualPath, newVirtualPath string) error {
+	oldPrefix := oldVirtualPath + string(filepath.Separator)
+	for _, key := range ms.liteCache.Keys() {
+		if key == oldVirtualPath || strings.HasPrefix(key, oldPrefix) {
+			ms.liteCache.Remove(key)
+		}
+	}
+
+	if err := os.Rename(ms.GetMetadataDirectoryPath(oldVirtualPath), ms.GetMetadataDirectoryPath(newVirtualPath)); err != nil {
+		return err
+	}
+	ms.updateFileIndex(context.Background(), "rename_dir", oldVirtualPath, func(idx FileIndex) error {
+		return idx.RenameMetadataDir(context.Background(), oldVirtualPath, newVirtualPath)
+	})
+
+	return nil
+}
+
 // GetMetadataFilePath returns the filesystem path for a metadata file
 func (ms *MetadataService) GetMetadataFilePath(virtualPath string) string {
 	filename := filepath.Base(virtualPath)
@@ -972,6 +1007,9 @@ func (ms *MetadataService) MoveToCorrupted(ctx context.Context, virtualPath stri
 		// For simplicity, we return the error here as it's unexpected for metadata.
 		return err
 	}
+	ms.updateFileIndex(ctx, "move_to_corrupted", virtualPath, func(idx FileIndex) error {
+		return idx.RemoveMetadata(ctx, ms.indexKey(cleanPath))
+	})
 
 	// Also try to move the .id file if it exists
 	idPath := metadataPath + ".id"
@@ -98,6 +98,29 @@ func FlatSegments(store *metapb.NzbStore) []*metapb.NzbSeg {
 	return out
 }
 
+// ReplaceSegmentIDs rewrites the message-ids of the store at ref, keyed by flat
+// segment index, and writes the store back. Every file meta resolving through
+// the store picks the new ids up on its next read. The cached store is never
+// mutated in place; a modified copy replaces it.
+func (ss *StoreService) ReplaceSegmentIDs(ref string, ids map[int64]string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	store, err := ss.ReadStore(ref)
+	if err != nil {
+		return err
+	}
+	updated := proto.Clone(store).(*metapb.NzbStore)
+	flat := FlatSegments(updated)
+	for idx, id := range ids {
+		if idx < 0 || int(idx) >= len(flat) {
+			return fmt.Errorf("segment index %d out of range (%d segments)", idx, len(flat))
+		}
+		flat[idx].Id = id
+	}
+	return ss.WriteStore(ref, updated)
+}
+
 // RegenerateNZB reads the store at storePath and returns NZB XML bytes.
 // Returns (nil, nil) if the store does not exist.
 func (ss *StoreService) RegenerateNZB(storePath string) ([]byte, error) {
@@ -38,6 +38,27 @@ func TestStoreService_WriteRead(t *testing.T) {
 	assert.Equal(t, "p1@x", flat[2].Id)
 }
 
+func TestStoreService_ReplaceSegmentIDs(t *testing.T) {
+	ss := NewStoreService(t.TempDir())
+	ref := filepath.Join(t.TempDir(), "rel.nzbz")
+	require.NoError(t, ss.WriteStore(ref, sampleStore()))
+	cached, err := ss.ReadStore(ref)
+	require.NoError(t, err)
+
+	require.NoError(t, ss.ReplaceSegmentIDs(ref, map[int64]string{1: "alt2@y"}))
+	assert.Equal(t, "m2@x", FlatSegments(cached)[1].Id, "the cached store must not be mutated in place")
+
+	// Both the cache and a fresh read see the new id.
+	got, err := ss.ReadStore(ref)
+	require.NoError(t, err)
+	assert.Equal(t, "alt2@y", FlatSegments(got)[1].Id)
+	fresh, err := NewStoreService(t.TempDir()).ReadStore(ref)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m1@x", "alt2@y", "p1@x"}, []string{FlatSegments(fresh)[0].Id, FlatSegments(fresh)[1].Id, FlatSegments(fresh)[2].Id})
+
+	assert.Error(t, ss.ReplaceSegmentIDs(ref, map[int64]string{7: "x@y"}), "out-of-range index must error")
+}
+
 func TestResolveRefs(t *testing.T) {
 	store := sampleStore()
 	flat := FlatSegments(store)
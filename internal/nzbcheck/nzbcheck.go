@@ -0,0 +1,364 @@
+// Package nzbcheck vets an NZB's completeness without importing it: it Stats
+// every segment (or a sample) on each provider separately and reports per-file
+// and per-provider availability, an estimate of whether the release's PAR2
+// recovery volumes can repair the damage, and a verdict under the holes
+// policy.
+package nzbcheck
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/javi11/altmount/internal/holes"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/javi11/altmount/internal/pool"
+	"github.com/javi11/altmount/internal/usenet"
+	"github.com/javi11/nzbparser"
+)
+
+// par2VolumePattern matches PAR2 recovery volumes ("name.vol012+008.par2")
+// and captures their recovery block count.
+var par2VolumePattern = regexp.MustCompile(`(?i)\.vol\d+\+(\d+)\.par2$`)
+
+// Par2RecoveryBlocks returns the recovery block count a PAR2 volume's name
+// declares ("name.vol012+008.par2" carries 8), or 0 for the index file and
+// anything that is not a recovery volume.
+func Par2RecoveryBlocks(filename string) int {
+	m := par2VolumePattern.FindStringSubmatch(filename)
+	if m == nil {
+		return 0
+	}
+	blocks, _ := strconv.Atoi(m[1])
+	return blocks
+}
+
+// par2Pattern matches every PAR2 file, index and recovery volumes alike.
+var par2Pattern = regexp.MustCompile(`(?i)\.par2$`)
+
+// Options tunes a check.
+type Options struct {
+	// SamplePercentage is the share of each file's segments to Stat (1-100).
+	// 100, the default, checks every segment.
+	SamplePercentage int
+	// MaxConnections caps the Stats in flight across all providers.
+	MaxConnections int
+	// Timeout is the per-Stat timeout, scaled to the batch size.
+	Timeout time.Duration
+}
+
+// Result is the outcome of a check.
+type Result struct {
+	Files     []File       `json:"files"`
+	Providers []Provider   `json:"providers,omitempty"`
+	Repair    holes.Repair `json:"repair"`
+	// Verdict is the worst holes verdict among the release's data files
+	// (PAR2 files excluded).
+	Verdict holes.Verdict `json:"verdict"`
+	// Full is true when every segment was checked; verdicts are then
+	// measured rather than projected.
+	Full       bool  `json:"full"`
+	Segments   int   `json:"segments"`
+	Sampled    int   `json:"sampled"`
+	Missing    int   `json:"missing"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+// File is the availability of one NZB file.
+type File struct {
+	Filename string `json:"filename"`
+	Par2     bool   `json:"par2,omitempty"`
+	Bytes    int64  `json:"bytes"`
+	Segments int    `json:"segments"`
+	Sampled  int    `json:"sampled"`
+	// Missing counts sampled segments no provider holds; Unknown counts
+	// sampled segments no provider gave a definitive answer for.
+	Missing    int           `json:"missing"`
+	Unknown    int           `json:"unknown,omitempty"`
+	LongestRun int           `json:"longest_run"`
+	Verdict    holes.Verdict `json:"verdict"`
+	// Providers is the per-provider availability of this file's sample.
+	Providers []ProviderFile `json:"providers,omitempty"`
+}
+
+// ProviderFile is one provider's share of a file's sample.
+type ProviderFile struct {
+	Provider string `json:"provider"`
+	Sampled  int    `json:"sampled"`
+	Missing  int    `json:"missing"`
+}
+
+// Provider is one provider's share of the whole sample.
+type Provider struct {
+	Provider string `json:"provider"`
+	Sampled  int    `json:"sampled"`
+	Missing  int    `json:"missing"`
+	// Completeness is the fraction of definitively answered segments the
+	// provider holds (0..1).
+	Completeness float64 `json:"completeness"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// segmentState is the aggregated availability of one sampled segment.
+type segmentState uint8
+
+const (
+	segmentUnknown segmentState = iota
+	segmentMissing
+	segmentAvailable
+)
+
+// sampledFile is the check input for one NZB file.
+type sampledFile struct {
+	file    nzbparser.NzbFile
+	par2    bool
+	sampled []int // indexes into file.Segments
+}
+
+// Check Stats the sampled segments of n on every provider of the pool.
+// Providers run concurrently and share opts.MaxConnections in proportion to
+// their connection counts. A pool that reports no providers is checked as a
+// whole and the result carries no per-provider breakdown.
+func Check(ctx context.Context, n *nzbparser.Nzb, poolManager pool.Manager, opts Options) (*Result, error) {
+	start := time.Now()
+	if n == nil || len(n.Files) == 0 {
+		return nil, fmt.Errorf("NZB contains no files")
+	}
+	if poolManager == nil || !poolManager.HasPool() {
+		return nil, fmt.Errorf("usenet connection pool is not available")
+	}
+	usenetPool, err := poolManager.GetPool()
+	if err != nil {
+		return nil, fmt.Errorf("usenet connection pool unavailable: %w", err)
+	}
+	if usenetPool == nil {
+		return nil, fmt.Errorf("usenet connection pool is nil")
+	}
+
+	pct := opts.SamplePercentage
+	if pct < 1 || pct > 100 {
+		pct = 100
+	}
+
+	files := make([]sampledFile, len(n.Files))
+	var ids []string
+	seen := make(map[string]struct{})
+	for i, f := range n.Files {
+		files[i] = sampledFile{file: f, par2: par2Pattern.MatchString(f.Filename)}
+		segs := make([]*metapb.SegmentData, 0, len(f.Segments))
+		index := make(map[*metapb.SegmentData]int, len(f.Segments))
+		for j, s := range f.Segments {
+			if s.ID == "" {
+				continue
+			}
+			sd := &metapb.SegmentData{Id: s.ID}
+			index[sd] = j
+			segs = append(segs, sd)
+		}
+		if len(segs) == 0 {
+			continue
+		}
+		for _, sd := range usenet.SelectSegmentsForValidation(segs, pct) {
+			files[i].sampled = append(files[i].sampled, index[sd])
+			if _, ok := seen[sd.Id]; !ok {
+				seen[sd.Id] = struct{}{}
+				ids = append(ids, sd.Id)
+			}
+		}
+	}
+
+	providers := usenetPool.Stats().Providers
+	names := make([]string, 0, len(providers))
+	for _, p := range providers {
+		names = append(names, p.Name)
+	}
+	if len(names) == 0 {
+		names = []string{""} // the pool as a whole
+	}
+	budget := pool.ProviderBudget(providers, opts.MaxConnections)
+
+	// answers[p][id] is provider p's answer for id: true when it holds it.
+	answers := make([]map[string]bool, len(names))
+	providerErrs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			answers[i], providerErrs[i] = pool.StatOnProvider(ctx, usenetPool, name, ids, budget[i], opts.Timeout)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// A segment is available when any provider holds it and missing when every
+	// provider that answered reported it gone.
+	states := make(map[string]segmentState, len(ids))
+	for _, perProvider := range answers {
+		for id, has := range perProvider {
+			s := segmentMissing
+			if has {
+				s = segmentAvailable
+			}
+			if s > states[id] {
+				states[id] = s
+			}
+		}
+	}
+
+	res := &Result{Full: pct == 100}
+	perProvider := len(providers) > 0
+	if perProvider {
+		res.Providers = make([]Provider, len(names))
+		for i, name := range names {
+			res.Providers[i] = Provider{Provider: name}
+		}
+	}
+
+	for _, sf := range files {
+		out := checkFile(sf, states, answers, names, perProvider, res.Full)
+		res.Segments += out.Segments
+		res.Sampled += out.Sampled
+		res.Missing += out.Missing
+		for i, pf := range out.Providers {
+			res.Providers[i].Sampled += pf.Sampled
+			res.Providers[i].Missing += pf.Missing
+		}
+		res.Files = append(res.Files, out)
+	}
+	for i := range res.Providers {
+		p := &res.Providers[i]
+		if p.Sampled > 0 {
+			p.Completeness = float64(p.Sampled-p.Missing) / float64(p.Sampled)
+		} else if providerErrs[i] != nil {
+			p.Error = providerErrs[i].Error()
+		}
+	}
+
+	res.Verdict = releaseVerdict(res.Files)
+	res.Repair = estimateRepair(files, res.Files)
+	res.DurationMs = time.Since(start).Milliseconds()
+	return res, nil
+}
+
+// checkFile summarizes one file's sample and classifies it.
+func checkFile(sf sampledFile, states map[string]segmentState, answers []map[string]bool, names []string, perProvider, full bool) File {
+	f := sf.file
+	out := File{
+		Filename: f.Filename,
+		Par2:     sf.par2,
+		Bytes:    f.Bytes,
+		Segments: len(f.Segments),
+		Sampled:  len(sf.sampled),
+	}
+	if out.Bytes <= 0 {
+		for _, s := range f.Segments {
+			out.Bytes += int64(s.Bytes)
+		}
+	}
+	if perProvider {
+		out.Providers = make([]ProviderFile, len(names))
+		for i, name := range names {
+			out.Providers[i].Provider = name
+		}
+	}
+
+	var acc holes.Accumulator
+	for _, idx := range sf.sampled {
+		id := f.Segments[idx].ID
+		switch states[id] {
+		case segmentMissing:
+			out.Missing++
+			acc.Add(idx)
+		case segmentUnknown:
+			out.Unknown++
+		}
+		for i := range out.Providers {
+			if has, answered := answers[i][id]; answered {
+				out.Providers[i].Sampled++
+				if !has {
+					out.Providers[i].Missing++
+				}
+			}
+		}
+	}
+	out.LongestRun = acc.LongestRun()
+	out.Verdict = fileVerdict(out, acc.Runs(), full)
+	return out
+}
+
+// fileVerdict applies the holes policy to a file's sample. Files that may not
+// be zero-filled fail on any missing segment.
+func fileVerdict(f File, runs []holes.Run, full bool) holes.Verdict {
+	switch {
+	case f.Sampled == 0:
+		return holes.VerdictUnknown
+	case f.Missing > 0 && !holes.EligibleFile(f.Filename):
+		return holes.VerdictFailed
+	case full && f.Unknown == 0:
+		avgSeg := int64(0)
+		if f.Segments > 0 {
+			avgSeg = f.Bytes / int64(f.Segments)
+		}
+		return holes.Classify(runs, f.Bytes, avgSeg)
+	default:
+		return holes.ClassifyProjected(f.Missing, f.Sampled, f.Segments, f.LongestRun)
+	}
+}
+
+// releaseVerdict is the worst verdict among the data files.
+func releaseVerdict(files []File) holes.Verdict {
+	rank := map[holes.Verdict]int{
+		holes.VerdictClean:    0,
+		holes.VerdictUnknown:  1,
+		holes.VerdictDegraded: 2,
+		holes.VerdictFailed:   3,
+	}
+	verdict := holes.VerdictUnknown
+	worst := -1
+	for _, f := range files {
+		if f.Par2 {
+			continue
+		}
+		if r := rank[f.Verdict]; r > worst {
+			worst = r
+			verdict = f.Verdict
+		}
+	}
+	return verdict
+}
+
+// estimateRepair estimates whether the release's PAR2 recovery volumes
+// cover the missing data of its other files.
+func estimateRepair(files []sampledFile, checked []File) holes.Repair {
+	var volumes []holes.RecoveryVolume
+	var damage []holes.Damage
+	for i, sf := range files {
+		f := checked[i]
+		if sf.par2 {
+			volumes = append(volumes, holes.RecoveryVolume{
+				Blocks:  Par2RecoveryBlocks(f.Filename),
+				Bytes:   f.Bytes,
+				Checked: f.Sampled - f.Unknown,
+				Missing: f.Missing,
+			})
+			continue
+		}
+		damage = append(damage, holes.Damage{MissingRate: missingFraction(f), Bytes: f.Bytes, Segments: f.Segments})
+	}
+	return holes.EstimateRepair(volumes, damage)
+}
+
+// missingFraction is the share of a file's answered sample that is missing.
+func missingFraction(f File) float64 {
+	answered := f.Sampled - f.Unknown
+	if answered <= 0 {
+		return 0
+	}
+	return float64(f.Missing) / float64(answered)
+}
@@ -0,0 +1,168 @@
+package nzbcheck
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/holes"
+	"github.com/javi11/altmount/internal/pool"
+	"github.com/javi11/altmount/internal/testsupport/fakepool"
+	"github.com/javi11/altmount/internal/testsupport/nzbbuild"
+	"github.com/javi11/nntppool/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type checkPoolManager struct {
+	client *fakepool.Client
+}
+
+func (m checkPoolManager) GetPool() (pool.NntpClient, error) { return m.client, nil }
+func (m checkPoolManager) HasPool() bool                     { return m.client != nil }
+func (m checkPoolManager) IncArticlesDownloaded()            {}
+func (m checkPoolManager) IncArticlesPosted()                {}
+func (m checkPoolManager) UpdateDownloadProgress(string, int64) {
+}
+func (m checkPoolManager) GetMetrics() (pool.MetricsSnapshot, error) {
+	return pool.MetricsSnapshot{}, nil
+}
+func (m checkPoolManager) ResetMetrics(context.Context, bool, bool) error { return nil }
+func (m checkPoolManager) ResetProviderErrors(context.Context) error      { return nil }
+func (m checkPoolManager) SetProviders([]nntppool.Provider) error         { return nil }
+func (m checkPoolManager) ClearPool() error                               { return nil }
+func (m checkPoolManager) AddProvider(nntppool.Provider) error            { return nil }
+func (m checkPoolManager) RemoveProvider(string) error                    { return nil }
+func (m checkPoolManager) ResetProviderQuota(context.Context, string) error {
+	return nil
+}
+func (m checkPoolManager) SetProviderIDs(map[string]string) {}
+func (m checkPoolManager) AcquireImportSlot(context.Context) (func(), error) {
+	return func() {}, nil
+}
+func (m checkPoolManager) SetAdmissionCap(int) {}
+func (m checkPoolManager) AcquireImportConnection(context.Context) (func(), error) {
+	return func() {}, nil
+}
+func (m checkPoolManager) SetImportConnCapacity(int)                 {}
+func (m checkPoolManager) ImportConnCapacity() int                   { return 0 }
+func (m checkPoolManager) SetStreamSource(pool.StreamActivitySource) {}
+func (m checkPoolManager) NotifyStreamChange()                       {}
+
+func testFile(name string, segments, segBytes int) nzbbuild.File {
+	f := nzbbuild.File{Subject: name}
+	for i := range segments {
+		f.Segments = append(f.Segments, nzbbuild.Segment{ID: fmt.Sprintf("%s-%d", name, i), Bytes: segBytes})
+	}
+	return f
+}
+
+func twoProviderClient() *fakepool.Client {
+	client := fakepool.New()
+	client.SetStats(nntppool.ClientStats{Providers: []nntppool.ProviderStats{
+		{Name: "primary", MaxConnections: 10},
+		{Name: "backup", MaxConnections: 10},
+	}})
+	return client
+}
+
+func TestCheckReportsPerProviderAvailability(t *testing.T) {
+	client := twoProviderClient()
+	// backup lost two articles that primary still carries.
+	client.SetProviderBehavior("backup", "movie.mkv-3", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	client.SetProviderBehavior("backup", "movie.mkv-4", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+
+	n := nzbbuild.Build(testFile("movie.mkv", 20, 1000))
+	res, err := Check(context.Background(), n, checkPoolManager{client: client}, Options{MaxConnections: 8, Timeout: time.Second})
+	require.NoError(t, err)
+
+	assert.True(t, res.Full)
+	assert.Equal(t, 20, res.Sampled)
+	assert.Zero(t, res.Missing, "every segment is held by at least one provider")
+	assert.Equal(t, holes.VerdictClean, res.Verdict)
+	assert.Zero(t, res.Repair.NeededBlocks, "nothing to repair")
+
+	require.Len(t, res.Providers, 2)
+	assert.Equal(t, Provider{Provider: "primary", Sampled: 20, Completeness: 1}, res.Providers[0])
+	assert.Equal(t, "backup", res.Providers[1].Provider)
+	assert.Equal(t, 2, res.Providers[1].Missing)
+	assert.InDelta(t, 0.9, res.Providers[1].Completeness, 1e-9)
+
+	require.Len(t, res.Files, 1)
+	require.Len(t, res.Files[0].Providers, 2)
+	assert.Equal(t, 2, res.Files[0].Providers[1].Missing)
+}
+
+func TestCheckClassifiesHolesAndEstimatesRepair(t *testing.T) {
+	client := twoProviderClient()
+	for _, id := range []string{"movie.mkv-10", "movie.mkv-11"} {
+		client.SetBehavior(id, fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	}
+
+	n := nzbbuild.Build(
+		testFile("movie.mkv", 100, 700000),
+		testFile("movie.par2", 1, 1000),
+		testFile("movie.vol00+20.par2", 4, 700000),
+	)
+	res, err := Check(context.Background(), n, checkPoolManager{client: client}, Options{MaxConnections: 8, Timeout: time.Second})
+	require.NoError(t, err)
+
+	require.Len(t, res.Files, 3)
+	mkv := res.Files[0]
+	assert.Equal(t, 2, mkv.Missing)
+	assert.Equal(t, 2, mkv.LongestRun)
+	assert.Equal(t, holes.VerdictDegraded, mkv.Verdict, "a short run in a video file is paddable")
+	assert.True(t, res.Files[2].Par2)
+	assert.Equal(t, holes.VerdictDegraded, res.Verdict, "PAR2 files do not affect the release verdict")
+
+	// 2 missing 700 KB segments against 20 blocks of 140 KB.
+	assert.Equal(t, 2, res.Repair.Par2Files)
+	assert.Equal(t, 20, res.Repair.RecoveryBlocks)
+	assert.Equal(t, int64(140000), res.Repair.BlockSize)
+	assert.Equal(t, int64(1400000), res.Repair.MissingBytes)
+	assert.Equal(t, 12, res.Repair.NeededBlocks)
+	assert.True(t, res.Repair.Repairable())
+}
+
+func TestCheckFailsIneligibleFilesAndShortRecovery(t *testing.T) {
+	client := twoProviderClient()
+	client.SetBehavior("release.rar-1", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+
+	n := nzbbuild.Build(
+		testFile("release.rar", 10, 700000),
+		testFile("release.vol00+01.par2", 1, 700000),
+	)
+	res, err := Check(context.Background(), n, checkPoolManager{client: client}, Options{MaxConnections: 8, Timeout: time.Second})
+	require.NoError(t, err)
+
+	assert.Equal(t, holes.VerdictFailed, res.Verdict, "archives cannot be zero-filled")
+	assert.Equal(t, 2, res.Repair.NeededBlocks)
+	assert.False(t, res.Repair.Repairable())
+}
+
+func TestCheckSampleIsProjected(t *testing.T) {
+	client := twoProviderClient()
+	n := nzbbuild.Build(testFile("movie.mkv", 200, 1000))
+
+	res, err := Check(context.Background(), n, checkPoolManager{client: client}, Options{SamplePercentage: 10, MaxConnections: 8, Timeout: time.Second})
+	require.NoError(t, err)
+
+	assert.False(t, res.Full)
+	assert.Equal(t, 20, res.Sampled)
+	assert.Equal(t, holes.VerdictUnknown, res.Verdict, "a clean sample is absence of evidence")
+}
+
+func TestCheckWithoutProviderStatsUsesWholePool(t *testing.T) {
+	client := fakepool.New()
+	client.SetBehavior("movie.mkv-0", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	n := nzbbuild.Build(testFile("movie.mkv", 10, 1000))
+
+	res, err := Check(context.Background(), n, checkPoolManager{client: client}, Options{MaxConnections: 4, Timeout: time.Second})
+	require.NoError(t, err)
+
+	assert.Nil(t, res.Providers)
+	assert.Nil(t, res.Files[0].Providers)
+	assert.Equal(t, 1, res.Missing)
+	assert.Equal(t, int64(10), client.StatCalls())
+}
@@ -0,0 +1,298 @@
+package nzbfile
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ulikunitz/xz"
+)
+
+// Bz2Extension and XzExtension are the bzip2/xz-compressed NZB suffixes
+// accepted at intake. Unlike .nzb.gz they are never kept on disk: Expand
+// decompresses them to a plain .nzb before the file reaches the queue.
+const (
+	Bz2Extension = ".nzb.bz2"
+	XzExtension  = ".nzb.xz"
+)
+
+// MaxBundleEntries caps how many NZBs a single bundle may expand into.
+const MaxBundleEntries = 500
+
+// MaxEntrySize caps the decompressed size of a single NZB extracted from a
+// bundle or compressed input, guarding against decompression bombs. It matches
+// the upload size limit of the queue API.
+const MaxEntrySize = 100 * 1024 * 1024
+
+// ErrNotNzb is reported for bundle members that decode but are not NZB documents.
+var ErrNotNzb = errors.New("not an NZB document")
+
+// bundleExtensions are archive formats that may contain several NZBs.
+var bundleExtensions = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// Entry is a single NZB produced by Expand. Exactly one of Path or Err is set.
+type Entry struct {
+	// Name is the member name inside the bundle, or the input filename for a
+	// single compressed NZB.
+	Name string
+	// Path is the plain .nzb written to the destination directory.
+	Path string
+	// Err explains why this entry could not be extracted.
+	Err error
+}
+
+// IsBundle reports whether name is an archive of NZBs (.zip, .tar, .tar.gz, .tgz).
+func IsBundle(name string) bool {
+	lower := strings.ToLower(name)
+	for _, ext := range bundleExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpandable reports whether name must go through Expand before it can be
+// queued: NZB bundles and NZBs compressed with bzip2 or xz.
+func IsExpandable(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasSuffix(lower, Bz2Extension) || strings.HasSuffix(lower, XzExtension) || IsBundle(name)
+}
+
+// Expand unpacks srcPath into plain .nzb files inside destDir. Bundles yield
+// one Entry per contained NZB (other members such as .nfo files are ignored);
+// a bzip2/xz-compressed NZB yields a single Entry. Per-member failures are
+// reported on the Entry so the remaining NZBs can still be queued; the returned
+// error is reserved for inputs that cannot be read at all.
+func Expand(srcPath, destDir string) ([]Entry, error) {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create expansion directory: %w", err)
+	}
+
+	x := &expander{destDir: destDir, used: make(map[string]struct{})}
+	lower := strings.ToLower(srcPath)
+
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return x.expandZip(srcPath)
+	case IsBundle(srcPath):
+		return x.expandTar(srcPath, !strings.HasSuffix(lower, ".tar"))
+	case IsExpandable(srcPath):
+		f, err := os.Open(srcPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		name := filepath.Base(srcPath)
+		entry := x.extract(name, f)
+		if entry.Err != nil {
+			return nil, entry.Err
+		}
+		return []Entry{entry}, nil
+	default:
+		return nil, fmt.Errorf("unsupported NZB input: %s", filepath.Base(srcPath))
+	}
+}
+
+type expander struct {
+	destDir string
+	used    map[string]struct{}
+	entries []Entry
+}
+
+func (x *expander) expandZip(srcPath string) ([]Entry, error) {
+	zr, err := zip.OpenReader(srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip bundle: %w", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isNzbMember(f.Name) {
+			continue
+		}
+		if err := x.checkLimit(); err != nil {
+			return x.entries, err
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			x.entries = append(x.entries, Entry{Name: f.Name, Err: err})
+			continue
+		}
+		x.entries = append(x.entries, x.extract(f.Name, rc))
+		rc.Close()
+	}
+	return x.entries, nil
+}
+
+func (x *expander) expandTar(srcPath string, gzipped bool) ([]Entry, error) {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if gzipped {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open tar bundle: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return x.entries, nil
+		}
+		if err != nil {
+			// A truncated archive still yields the members read so far.
+			if len(x.entries) > 0 {
+				return x.entries, nil
+			}
+			return nil, fmt.Errorf("failed to read tar bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !isNzbMember(hdr.Name) {
+			continue
+		}
+		if err := x.checkLimit(); err != nil {
+			return x.entries, err
+		}
+		x.entries = append(x.entries, x.extract(hdr.Name, tr))
+	}
+}
+
+func (x *expander) checkLimit() error {
+	if len(x.entries) >= MaxBundleEntries {
+		return fmt.Errorf("bundle contains more than %d NZB files", MaxBundleEntries)
+	}
+	return nil
+}
+
+// extract decompresses a single NZB member into destDir.
+func (x *expander) extract(name string, r io.Reader) Entry {
+	entry := Entry{Name: name}
+
+	if IsBundle(name) {
+		entry.Err = errors.New("nested bundles are not supported")
+		return entry
+	}
+
+	dr, err := decompressor(name, r)
+	if err != nil {
+		entry.Err = err
+		return entry
+	}
+
+	br := bufio.NewReaderSize(io.LimitReader(dr, MaxEntrySize+1), 8192)
+	head, err := br.Peek(8192)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		entry.Err = err
+		return entry
+	}
+	if !bytes.Contains(bytes.ToLower(head), []byte("<nzb")) {
+		entry.Err = ErrNotNzb
+		return entry
+	}
+
+	dst := x.destPath(name)
+	out, err := os.Create(dst)
+	if err != nil {
+		entry.Err = err
+		return entry
+	}
+
+	n, err := io.Copy(out, br)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > MaxEntrySize {
+		err = fmt.Errorf("NZB exceeds %d MB when decompressed", MaxEntrySize/(1024*1024))
+	}
+	if err != nil {
+		_ = os.Remove(dst)
+		entry.Err = err
+		return entry
+	}
+
+	entry.Path = dst
+	return entry
+}
+
+// destPath returns a unique plain .nzb path in destDir for a member name.
+// Members in different bundle folders may share a base name, so collisions
+// get a numeric suffix.
+func (x *expander) destPath(name string) string {
+	base := trimNzbSuffix(path.Base(filepath.ToSlash(name)))
+	if base == "" || base == "." {
+		base = "bundle"
+	}
+
+	candidate := base
+	for i := 2; ; i++ {
+		if _, taken := x.used[candidate]; !taken {
+			break
+		}
+		candidate = base + "-" + strconv.Itoa(i)
+	}
+	x.used[candidate] = struct{}{}
+	return filepath.Join(x.destDir, candidate+PlainExtension)
+}
+
+// decompressor wraps r according to the compression suffix of name.
+func decompressor(name string, r io.Reader) (io.Reader, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".gz"):
+		return gzip.NewReader(r)
+	case strings.HasSuffix(lower, ".bz2"):
+		return bzip2.NewReader(r), nil
+	case strings.HasSuffix(lower, ".xz"):
+		return xz.NewReader(r)
+	default:
+		return r, nil
+	}
+}
+
+// isNzbMember reports whether a bundle member should be extracted. Hidden
+// files and resource-fork entries written by macOS archivers are skipped.
+func isNzbMember(name string) bool {
+	slashed := filepath.ToSlash(name)
+	if strings.HasPrefix(slashed, "__MACOSX/") || strings.HasPrefix(path.Base(slashed), ".") {
+		return false
+	}
+	lower := strings.ToLower(slashed)
+	for _, ext := range []string{PlainExtension, GzExtension, Bz2Extension, XzExtension} {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return IsBundle(lower)
+}
+
+// trimNzbSuffix strips a (possibly compressed) .nzb suffix, preserving case.
+func trimNzbSuffix(name string) string {
+	lower := strings.ToLower(name)
+	for _, ext := range []string{GzExtension, Bz2Extension, XzExtension, PlainExtension} {
+		if strings.HasSuffix(lower, ext) {
+			return name[:len(name)-len(ext)]
+		}
+	}
+	return name
+}
@@ -0,0 +1,149 @@
+package nzbfile
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ulikunitz/xz"
+)
+
+const testNzb = `<?xml version="1.0"?><nzb xmlns="http://www.newzbin.com/DTD/2003/nzb"></nzb>`
+
+func TestIsExpandable(t *testing.T) {
+	for name, want := range map[string]bool{
+		"movie.nzb":      false,
+		"movie.nzb.gz":   false,
+		"movie.nzb.bz2":  true,
+		"movie.NZB.XZ":   true,
+		"export.zip":     true,
+		"export.tar":     true,
+		"export.tar.gz":  true,
+		"export.tgz":     true,
+		"movie.mkv":      false,
+		"movie.part1.gz": false,
+	} {
+		if got := IsExpandable(name); got != want {
+			t.Errorf("IsExpandable(%q)=%v want %v", name, got, want)
+		}
+	}
+}
+
+func TestExpandZipReportsPerEntryErrors(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "export.zip")
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte(testNzb))
+	gw.Close()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string][]byte{
+		"one/Movie.nzb":        []byte(testNzb),
+		"two/Movie.nzb.gz":     gz.Bytes(),
+		"broken.nzb":           []byte("not xml at all"),
+		"nested.zip":           []byte("PK"),
+		"readme.nfo":           []byte("ignored"),
+		"__MACOSX/._Movie.nzb": []byte("ignored"),
+	} {
+		fw, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(body)
+	}
+	zw.Close()
+	if err := os.WriteFile(src, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Expand(src, filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4: %+v", len(entries), entries)
+	}
+
+	var ok []string
+	byName := map[string]Entry{}
+	for _, e := range entries {
+		byName[e.Name] = e
+		if e.Err == nil {
+			ok = append(ok, filepath.Base(e.Path))
+			got, err := os.ReadFile(e.Path)
+			if err != nil || string(got) != testNzb {
+				t.Errorf("entry %s content = %q, %v", e.Name, got, err)
+			}
+		}
+	}
+	if len(ok) != 2 || ok[0] == ok[1] {
+		t.Errorf("extracted paths = %v, want two distinct files", ok)
+	}
+	if !errors.Is(byName["broken.nzb"].Err, ErrNotNzb) {
+		t.Errorf("broken.nzb err = %v, want ErrNotNzb", byName["broken.nzb"].Err)
+	}
+	if byName["nested.zip"].Err == nil {
+		t.Error("nested bundle should be reported as an error")
+	}
+}
+
+func TestExpandTarGz(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "export.tar.gz")
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, name := range []string{"a.nzb", "b.nzb"} {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(testNzb)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(testNzb))
+	}
+	tw.Close()
+	gw.Close()
+	if err := os.WriteFile(src, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Expand(src, filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Err != nil || entries[1].Err != nil {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if filepath.Base(entries[0].Path) != "a.nzb" {
+		t.Errorf("path = %s, want a.nzb", entries[0].Path)
+	}
+}
+
+func TestExpandXz(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "Movie.2020.nzb.xz")
+
+	var buf bytes.Buffer
+	xw, err := xz.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xw.Write([]byte(testNzb))
+	xw.Close()
+	if err := os.WriteFile(src, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Expand(src, filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	if len(entries) != 1 || filepath.Base(entries[0].Path) != "Movie.2020.nzb" {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
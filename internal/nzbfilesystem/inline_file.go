@@ -0,0 +1,84 @@
+package nzbfilesystem
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+)
+
+// InlineFile implements afero.File for virtual files whose whole content is
+// stored in the metadata (FileMetadata.InlineData), such as chapter sidecars
+// generated at import time. Reads never touch Usenet.
+type InlineFile struct {
+	name    string
+	modTime time.Time
+	*bytes.Reader
+}
+
+// newInlineFile returns a read-only handle over meta's inline data.
+func newInlineFile(name string, meta *metapb.FileMetadata) *InlineFile {
+	return &InlineFile{
+		name:    name,
+		modTime: time.Unix(meta.ModifiedAt, 0),
+		Reader:  bytes.NewReader(meta.InlineData),
+	}
+}
+
+// Close implements afero.File.Close
+func (f *InlineFile) Close() error {
+	return nil
+}
+
+// Name implements afero.File.Name
+func (f *InlineFile) Name() string {
+	return f.name
+}
+
+// Readdir implements afero.File.Readdir
+func (f *InlineFile) Readdir(count int) ([]fs.FileInfo, error) {
+	return nil, ErrNotDirectory
+}
+
+// Readdirnames implements afero.File.Readdirnames
+func (f *InlineFile) Readdirnames(n int) ([]string, error) {
+	return nil, ErrNotDirectory
+}
+
+// Stat implements afero.File.Stat
+func (f *InlineFile) Stat() (fs.FileInfo, error) {
+	return &MetadataFileInfo{
+		name:    filepath.Base(f.name),
+		size:    f.Size(),
+		mode:    0644,
+		modTime: f.modTime,
+	}, nil
+}
+
+// Write implements afero.File.Write (not supported)
+func (f *InlineFile) Write(p []byte) (n int, err error) {
+	return 0, fmt.Errorf("write not supported")
+}
+
+// WriteAt implements afero.File.WriteAt (not supported)
+func (f *InlineFile) WriteAt(p []byte, off int64) (n int, err error) {
+	return 0, fmt.Errorf("write not supported")
+}
+
+// WriteString implements afero.File.WriteString (not supported)
+func (f *InlineFile) WriteString(s string) (ret int, err error) {
+	return 0, fmt.Errorf("write not supported")
+}
+
+// Sync implements afero.File.Sync
+func (f *InlineFile) Sync() error {
+	return nil
+}
+
+// Truncate implements afero.File.Truncate (not supported)
+func (f *InlineFile) Truncate(size int64) error {
+	return fmt.Errorf("truncate not supported")
+}
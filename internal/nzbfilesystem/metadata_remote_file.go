@@ -202,6 +202,12 @@ func (mrf *MetadataRemoteFile) OpenFile(ctx context.Context, name string) (bool,
 		}
 	}
 
+	// Generated sidecars carry their bytes in the metadata itself: no
+	// segments, no stream tracking.
+	if len(fileMeta.InlineData) > 0 {
+		return true, newInlineFile(name, fileMeta), nil
+	}
+
 	// Extract max prefetch from context if available (overrides global config)
 	maxPrefetch := mrf.getMaxPrefetch()
 
@@ -380,14 +386,12 @@ func (mrf *MetadataRemoteFile) RenameFile(ctx context.Context, oldName, newName
 
 	// Check if old path is a directory
 	if mrf.metadataService.DirectoryExists(normalizedOld) {
-		// Get the filesystem paths for the directories
-		oldDirPath := mrf.metadataService.GetMetadataDirectoryPath(normalizedOld)
-		newDirPath := mrf.metadataService.GetMetadataDirectoryPath(normalizedNew)
-
-		slog.InfoContext(ctx, "Moving metadata directory", "from", oldDirPath, "to", newDirPath)
+		slog.InfoContext(ctx, "Moving metadata directory",
+			"from", mrf.metadataService.GetMetadataDirectoryPath(normalizedOld),
+			"to", mrf.metadataService.GetMetadataDirectoryPath(normalizedNew))
 
 		// Rename the entire directory
-		if err := os.Rename(oldDirPath, newDirPath); err != nil {
+		if err := mrf.metadataService.RenameDirectory(normalizedOld, normalizedNew); err != nil {
 			return false, fmt.Errorf("failed to rename directory: %w", err)
 		}
 
@@ -2154,6 +2158,14 @@ func (mvf *MetadataVirtualFile) updateFileHealthOnError(dataCorruptionErr *usene
 		sourceNzbPath = nil
 	}
 
+	// Score the holes by the recoverability the last health check measured for
+	// the release's PAR2 volumes (see holes.Recoverable).
+	repairability := mvf.storedRepairability(ctx)
+	if classification != nil && repairability != nil && repairability.NeededBlocks > 0 {
+		score := repairability.Score
+		classification.RecoveryScore = &score
+	}
+
 	details := database.HealthErrorDetails{
 		ErrorType:       "ArticleNotFound",
 		MissingArticles: 1,
@@ -2176,6 +2188,14 @@ func (mvf *MetadataVirtualFile) updateFileHealthOnError(dataCorruptionErr *usene
 			"total_missing", classification.TotalMissing,
 			"longest_run", classification.LongestRun)
 		dbStatus = database.HealthStatusDegraded
+	} else if healthEnabled && cfg.GetHealthDeleteOnCorruption() && repairability.Repairable() {
+		// Delete-on-corruption is enabled but the release's PAR2 volumes cover the damage.
+		// AltMount streams without running PAR2, so the file stays unplayable, but its NZB
+		// still holds every byte: keep it as corrupted, without repair, instead of deleting.
+		slog.InfoContext(ctx, "Streaming failure on a PAR2-recoverable file, keeping it as corrupted instead of deleting",
+			"file", mvf.name,
+			"repairability_score", repairability.Score)
+		dbStatus = database.HealthStatusCorrupted
 	} else if healthEnabled && cfg.GetHealthDeleteOnCorruption() {
 		// Delete-on-corruption is enabled: remove the file instead of triggering a repair.
 		slog.WarnContext(ctx, "Streaming failure detected, deleting corrupted file instead of triggering repair", "file", mvf.name)
@@ -2249,6 +2269,16 @@ func (mvf *MetadataVirtualFile) updateFileHealthOnError(dataCorruptionErr *usene
 	}
 }
 
+// storedRepairability returns the PAR2 repairability the last health check
+// recorded for the file, or nil when none is known.
+func (mvf *MetadataVirtualFile) storedRepairability(ctx context.Context) *database.Repairability {
+	health, err := mvf.healthRepository.GetFileHealth(ctx, mvf.name)
+	if err != nil || health == nil {
+		return nil
+	}
+	return database.ParseRepairability(health.Repairability)
+}
+
 // readFullContext reads exactly len(buf) bytes from r, but returns early
 // if ctx is cancelled. This prevents io.ReadFull from blocking indefinitely
 // when the underlying reader is stuck (e.g., waiting for network data).
@@ -10,9 +10,19 @@ import (
 // clipSpan is one clip's absolute byte range in the virtual file plus the
 // 90 kHz timeline delta to add to every timestamp inside it.
 type clipSpan struct {
-	start int64 // inclusive absolute byte offset
-	end   int64 // inclusive absolute byte offset (start + byteLen - 1)
-	delta int64 // 90 kHz offset added to PTS/DTS/PCR-base of packets in this clip
+	start      int64 // inclusive absolute byte offset
+	end        int64 // inclusive absolute byte offset (start + byteLen - 1)
+	delta      int64 // 90 kHz offset added to PTS/DTS/PCR-base of packets in this clip
+	packetSize int   // 188 for plain TS stitched from multi-part releases; 0 means BDAV (192)
+}
+
+// sourcePacketLen returns the clip's source packet size: 192 for BD main
+// features (BDAV), 188 for plain MPEG-TS parts.
+func (c *clipSpan) sourcePacketLen() int {
+	if c.packetSize == tsPacketLen {
+		return tsPacketLen
+	}
+	return bdavPacketLen
 }
 
 // buildClipSpans turns the proto ClipBoundary table (byte_len + delta per clip,
@@ -28,7 +38,7 @@ func buildClipSpans(boundaries []*metapb.ClipBoundary) []clipSpan {
 		if b.ByteLen <= 0 {
 			continue
 		}
-		spans = append(spans, clipSpan{start: off, end: off + b.ByteLen - 1, delta: b.Delta_90K})
+		spans = append(spans, clipSpan{start: off, end: off + b.ByteLen - 1, delta: b.Delta_90K, packetSize: int(b.PacketSize)})
 		off += b.ByteLen
 	}
 	if len(spans) == 0 {
@@ -58,19 +68,19 @@ func spanContaining(spans []clipSpan, off int64) *clipSpan {
 	return &spans[idx]
 }
 
-// alignStartDown rounds off DOWN to the start of the BDAV packet that contains
-// it, using the containing clip's byte start as the grid origin (each clip's
-// bytes begin a fresh 192-byte packet grid). Offsets past the last clip are
+// alignStartDown rounds off DOWN to the start of the source packet that
+// contains it, using the containing clip's byte start as the grid origin (each
+// clip's bytes begin a fresh packet grid of the clip's packet size). Offsets past the last clip are
 // returned unchanged — that region, if any, is pure passthrough.
 func alignStartDown(spans []clipSpan, off int64) int64 {
 	sp := spanContaining(spans, off)
 	if sp == nil {
 		return off
 	}
-	return off - ((off - sp.start) % bdavPacketLen)
+	return off - ((off - sp.start) % int64(sp.sourcePacketLen()))
 }
 
-// alignEndUp rounds end UP to the last byte of the BDAV packet that contains it
+// alignEndUp rounds end UP to the last byte of the source packet that contains it
 // (grid origin = containing clip start), clamped to that clip's end and to
 // fileSize-1. Offsets past the last clip are returned unchanged.
 func alignEndUp(spans []clipSpan, end, fileSize int64) int64 {
@@ -79,7 +89,8 @@ func alignEndUp(spans []clipSpan, end, fileSize int64) int64 {
 		return end
 	}
 	into := end - sp.start
-	aligned := sp.start + ((into/bdavPacketLen)+1)*bdavPacketLen - 1
+	ps := int64(sp.sourcePacketLen())
+	aligned := sp.start + ((into/ps)+1)*ps - 1
 	if aligned > sp.end {
 		aligned = sp.end
 	}
@@ -158,7 +169,6 @@ type tsRemuxReader struct {
 	inner       io.ReadCloser
 	spans       []clipSpan
 	absPos      int64        // absolute offset of the next byte to pull from inner
-	packetSize  int          // 192 (BDAV); fixed for BD main features
 	disabled    bool         // true if the stream isn't recognisable TS → pure passthrough
 	syncChecked bool         // whether the first aligned packet's sync byte was validated
 	out         bytes.Buffer // rewritten bytes ready to deliver
@@ -166,7 +176,7 @@ type tsRemuxReader struct {
 }
 
 // readBuf returns a reusable scratch slice of length n. n is always small
-// (≤ packetSize for fill, 64 KiB for passthrough), so a single lazily-allocated
+// (≤ one source packet for fill, 64 KiB for passthrough), so a single lazily-allocated
 // 64 KiB buffer backs every read and no allocation happens on the streaming path.
 func (r *tsRemuxReader) readBuf(n int) []byte {
 	if cap(r.scratch) < n {
@@ -239,22 +249,19 @@ func (r *tsRemuxReader) fill() error {
 	if r.disabled {
 		return r.passthrough()
 	}
-	if r.packetSize == 0 {
-		// BD main features (the only files with a clip table) are BDAV-192.
-		r.packetSize = bdavPacketLen
-	}
-
 	clip := r.clipFor(r.absPos)
 	if clip == nil {
 		// Past the last clip (shouldn't happen for a well-formed table) —
 		// stream the remainder unmodified.
 		return r.passthrough()
 	}
+	// BD main features are BDAV-192; stitched multi-part TS files are 188.
+	packetSize := clip.sourcePacketLen()
 
 	// Bytes remaining to the next packet boundary within this clip.
 	intoClip := r.absPos - clip.start
-	rem := r.packetSize - int(intoClip%int64(r.packetSize))
-	aligned := rem == r.packetSize
+	rem := packetSize - int(intoClip%int64(packetSize))
+	aligned := rem == packetSize
 	want := rem
 	// Never read across a clip boundary in one chunk.
 	if r.absPos+int64(want) > clip.end+1 {
@@ -266,18 +273,18 @@ func (r *tsRemuxReader) fill() error {
 	nr, err := io.ReadFull(r.inner, chunk)
 	chunk = chunk[:nr]
 	if nr > 0 {
-		if aligned && nr == r.packetSize {
+		if aligned && nr == packetSize {
 			// Validate the first aligned packet looks like BDAV TS; if not,
 			// the stream isn't what we expect (wrong decryption, plain TS,
 			// non-media) so disable rewriting rather than corrupt bytes.
 			if !r.syncChecked {
 				r.syncChecked = true
-				if chunk[4] != tsSync {
+				if chunk[packetSize-tsPacketLen] != tsSync {
 					r.disabled = true
 				}
 			}
 			if !r.disabled {
-				rewritePacket(chunk, r.packetSize, clip.delta)
+				rewritePacket(chunk, packetSize, clip.delta)
 			}
 		}
 		r.out.Write(chunk)
@@ -195,3 +195,41 @@ func TestBuildClipSpans(t *testing.T) {
 		}
 	}
 }
+
+// TestTSRemuxReader_PlainTSClips: clips stitched from multi-part MPEG-TS
+// releases carry 188-byte packets; framing, sync validation and alignment
+// follow the clip's packet size.
+func TestTSRemuxReader_PlainTSClips(t *testing.T) {
+	const hz = 90000
+	var buf bytes.Buffer
+	for i := range 3 {
+		buf.Write(setPTS(newBDAVPacket(0x100, true, 0x01), int64(i)*hz)[4:])
+	}
+	part0Len := int64(buf.Len())
+	for i := range 2 {
+		buf.Write(setPTS(newBDAVPacket(0x100, true, 0x01), int64(i)*hz)[4:])
+	}
+	raw := buf.Bytes()
+
+	spans := buildClipSpans([]*metapb.ClipBoundary{
+		{ByteLen: part0Len, PacketSize: tsPacketLen},
+		{ByteLen: int64(len(raw)) - part0Len, Delta_90K: 3 * hz, PacketSize: tsPacketLen},
+	})
+	out, err := io.ReadAll(newTSRemuxReader(newMem(raw), spans, 0))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	for i := range 5 {
+		pkt := out[i*tsPacketLen : (i+1)*tsPacketLen]
+		if got, want := readTS(pkt[4:][9:14]), int64(i)*hz; got != want {
+			t.Errorf("packet %d PTS = %d, want %d", i, got, want)
+		}
+	}
+
+	if got := alignStartDown(spans, part0Len+200); got != part0Len+tsPacketLen {
+		t.Errorf("alignStartDown = %d, want %d", got, part0Len+tsPacketLen)
+	}
+	if got := alignEndUp(spans, 10, int64(len(raw))); got != tsPacketLen-1 {
+		t.Errorf("alignEndUp = %d, want %d", got, tsPacketLen-1)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/javi11/altmount/internal/arrs/model"
 )
 
 // ActiveStream represents a file currently being streamed
@@ -32,7 +34,7 @@ type ActiveStream struct {
 
 // ARRsRepairService abstracts the ARR repair operations needed by the filesystem.
 type ARRsRepairService interface {
-	TriggerFileRescan(ctx context.Context, pathForRescan string, relativePath string, metadataStr *string) error
+	TriggerFileRescan(ctx context.Context, pathForRescan string, relativePath string, metadataStr *string) (*model.RescanResult, error)
 }
 
 // StreamTracker interface for tracking active streams
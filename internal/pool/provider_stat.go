@@ -0,0 +1,73 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/javi11/nntppool/v4"
+)
+
+// defaultStatTimeout bounds a single Stat when the caller sets no timeout.
+const defaultStatTimeout = 30 * time.Second
+
+// ProviderBudget splits maxConnections across providers in proportion to
+// their connection counts, giving each at least one and at most its own
+// connection count, so Stat sweeps run on every provider at once stay within
+// one overall budget. With no providers the whole budget goes to the pool.
+func ProviderBudget(providers []nntppool.ProviderStats, maxConnections int) []int {
+	if maxConnections <= 0 {
+		maxConnections = 1
+	}
+	if len(providers) == 0 {
+		return []int{maxConnections}
+	}
+	total := 0
+	for _, p := range providers {
+		total += max(p.MaxConnections, 1)
+	}
+	out := make([]int, len(providers))
+	for i, p := range providers {
+		conns := max(p.MaxConnections, 1)
+		share := maxConnections * conns / total
+		out[i] = max(min(share, conns), 1)
+	}
+	return out
+}
+
+// StatOnProvider Stats ids on one provider (the whole pool when provider is
+// empty), concurrency at a time, with timeout per Stat (see StatManyTimeout).
+// The returned map holds true for each article the provider has and false for
+// each it reported not found. Any other failure says nothing about what the
+// provider holds, so that article is left out; when no article was answered
+// at all the last failure is returned.
+func StatOnProvider(ctx context.Context, client NntpClient, provider string, ids []string, concurrency int, timeout time.Duration) (map[string]bool, error) {
+	held := make(map[string]bool, len(ids))
+	if len(ids) == 0 {
+		return held, nil
+	}
+	if timeout <= 0 {
+		timeout = defaultStatTimeout
+	}
+	statCtx, cancel := context.WithTimeout(ctx, StatManyTimeout(len(ids), concurrency, timeout))
+	defer cancel()
+
+	var lastErr error
+	for r := range client.StatMany(statCtx, ids, nntppool.StatManyOptions{Concurrency: concurrency, Provider: provider}) {
+		switch {
+		case r.Err == nil:
+			held[r.MessageID] = true
+		case errors.Is(r.Err, nntppool.ErrArticleNotFound):
+			held[r.MessageID] = false
+		default:
+			lastErr = r.Err
+		}
+	}
+	if len(held) == 0 {
+		if lastErr == nil {
+			lastErr = statCtx.Err()
+		}
+		return held, lastErr
+	}
+	return held, nil
+}
@@ -0,0 +1,45 @@
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javi11/altmount/internal/pool"
+	"github.com/javi11/altmount/internal/testsupport/fakepool"
+	"github.com/javi11/nntppool/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderBudget(t *testing.T) {
+	providers := []nntppool.ProviderStats{
+		{Name: "big", MaxConnections: 30},
+		{Name: "small", MaxConnections: 10},
+		{Name: "tiny", MaxConnections: 1},
+	}
+	assert.Equal(t, []int{29, 9, 1}, pool.ProviderBudget(providers, 40))
+	assert.Equal(t, []int{30, 10, 1}, pool.ProviderBudget(providers, 1000), "capped at each provider's connections")
+	assert.Equal(t, []int{4}, pool.ProviderBudget(nil, 4))
+}
+
+func TestStatOnProvider(t *testing.T) {
+	ctx := context.Background()
+	client := fakepool.New()
+	client.SetProviderBehavior("p1", "gone@x", fakepool.SegmentBehavior{Err: nntppool.ErrArticleNotFound})
+	client.SetProviderBehavior("p1", "flaky@x", fakepool.SegmentBehavior{Err: errors.New("connection reset")})
+
+	held, err := pool.StatOnProvider(ctx, client, "p1", []string{"ok@x", "gone@x", "flaky@x"}, 2, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"ok@x": true, "gone@x": false}, held, "other failures leave the article unanswered")
+
+	// A provider that answered nothing reports why.
+	held, err = pool.StatOnProvider(ctx, client, "p1", []string{"flaky@x"}, 1, time.Second)
+	assert.Empty(t, held)
+	assert.ErrorContains(t, err, "connection reset")
+
+	held, err = pool.StatOnProvider(ctx, client, "p1", nil, 1, time.Second)
+	require.NoError(t, err)
+	assert.Empty(t, held)
+}
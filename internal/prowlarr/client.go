@@ -163,6 +163,8 @@ type ReleaseMeta struct {
 	Audio        string // from PTN: "AAC", "DTS", etc.
 	ParsedTitle  string // PTN-parsed title (e.g. "La película")
 	Year         int    // PTN-parsed year
+	Season       int    // PTN-parsed season (0 when absent)
+	Episode      int    // PTN-parsed episode (0 when absent)
 }
 
 var langFlags = map[string]string{
@@ -226,6 +228,8 @@ func InferReleaseMeta(title string) ReleaseMeta {
 		meta.Audio = info.Audio
 		meta.ParsedTitle = info.Title
 		meta.Year = info.Year
+		meta.Season = info.Season
+		meta.Episode = info.Episode
 		if meta.Language == "" && info.Language != "" {
 			meta.Language = info.Language
 		}
@@ -255,11 +259,24 @@ func (c *Client) SearchByTVDB(ctx context.Context, tvdbID, searchType string, ca
 	return c.searchWithID(ctx, "TvdbId", tvdbID, searchType, categories, indexers, season, episode)
 }
 
+// SearchTerm queries Prowlarr for NZB releases matching a free-text term, for
+// content without a known IMDB or TVDB ID (manual imports, repairs of files no
+// ARR manages). Arguments and ordering are as for Search.
+func (c *Client) SearchTerm(ctx context.Context, term, searchType string, categories, indexers []int, season, episode int) ([]NZBResult, error) {
+	return c.search(ctx, term, searchType, categories, indexers, season, episode)
+}
+
 func (c *Client) searchWithID(ctx context.Context, idField, idValue, searchType string, categories, indexers []int, season, episode int) ([]NZBResult, error) {
-	var query strings.Builder
+	query := ""
 	if idValue != "" {
-		query.WriteString("{" + idField + ":" + idValue + "}")
+		query = "{" + idField + ":" + idValue + "}"
 	}
+	return c.search(ctx, query, searchType, categories, indexers, season, episode)
+}
+
+func (c *Client) search(ctx context.Context, term, searchType string, categories, indexers []int, season, episode int) ([]NZBResult, error) {
+	var query strings.Builder
+	query.WriteString(term)
 	if season > 0 {
 		query.WriteString("{Season:" + strconv.Itoa(season) + "}")
 	}
@@ -40,6 +40,7 @@ package fakepool
 import (
 	"context"
 	"errors"
+	"hash/crc32"
 	"io"
 	"sync"
 	"sync/atomic"
@@ -79,6 +80,11 @@ type SegmentBehavior struct {
 	// PartSize, FileSize, etc.) without needing a real NNTP server.
 	YEnc nntppool.YEncMeta
 
+	// ExpectedCRC, if non-zero, is reported as the yEnc part CRC; CRCValid is
+	// set when it matches the CRC32 of Bytes. Use it to simulate damaged or
+	// mismatched articles.
+	ExpectedCRC uint32
+
 	// FailFirst makes the first N calls to this message-ID return FailErr (a
 	// transient error) before subsequent calls succeed normally. Use it to
 	// exercise retry logic that must distinguish transient failures from a
@@ -95,7 +101,8 @@ type Client struct {
 	mu              sync.RWMutex
 	defaultBehavior SegmentBehavior
 	perSegment      map[string]SegmentBehavior
-	releaseGate     <-chan struct{} // nil = no gate; closed = always permit
+	perProvider     map[string]map[string]SegmentBehavior // provider → message-ID → behavior
+	releaseGate     <-chan struct{}                       // nil = no gate; closed = always permit
 	stats           nntppool.ClientStats
 	hasFixedStats   bool
 
@@ -137,6 +144,21 @@ func (c *Client) SetBehavior(messageID string, b SegmentBehavior) {
 	c.mu.Unlock()
 }
 
+// SetProviderBehavior sets a message-ID behavior for STATs restricted to one
+// provider (StatManyOptions.Provider). It overrides SetBehavior for that
+// provider only, so tests can model retention that differs per provider.
+func (c *Client) SetProviderBehavior(provider, messageID string, b SegmentBehavior) {
+	c.mu.Lock()
+	if c.perProvider == nil {
+		c.perProvider = make(map[string]map[string]SegmentBehavior)
+	}
+	if c.perProvider[provider] == nil {
+		c.perProvider[provider] = make(map[string]SegmentBehavior)
+	}
+	c.perProvider[provider][messageID] = b
+	c.mu.Unlock()
+}
+
 // BlockUntil installs a gate that pins every subsequent call inside the
 // fake (after counter increment, before doing any work) until release is
 // closed. Useful for asserting "exactly N calls are concurrently in flight
@@ -259,6 +281,18 @@ func (c *Client) behaviorFor(messageID string) SegmentBehavior {
 	return c.defaultBehavior
 }
 
+// providerBehaviorFor resolves the behavior of a message-ID on one provider,
+// falling back to behaviorFor.
+func (c *Client) providerBehaviorFor(provider, messageID string) SegmentBehavior {
+	c.mu.RLock()
+	b, ok := c.perProvider[provider][messageID]
+	c.mu.RUnlock()
+	if ok {
+		return b
+	}
+	return c.behaviorFor(messageID)
+}
+
 // waitOrCancel waits for d to elapse or ctx to fire, whichever first.
 // Returns ctx.Err() on cancellation, nil otherwise.
 func waitOrCancel(ctx context.Context, d time.Duration) error {
@@ -314,10 +348,18 @@ func (c *Client) BodyAsync(ctx context.Context, messageID string, w io.Writer, o
 // Stat returns a StatResult with the message-ID echoed, after the
 // configured latency. If the behavior has Err set, it is returned.
 func (c *Client) Stat(ctx context.Context, messageID string) (*nntppool.StatResult, error) {
+	return c.stat(ctx, "", messageID)
+}
+
+// stat serves a STAT, on a single provider when provider is non-empty.
+func (c *Client) stat(ctx context.Context, provider, messageID string) (*nntppool.StatResult, error) {
 	c.statCalls.Add(1)
 	c.countMessage(messageID)
 	defer c.enter()()
 	b := c.behaviorFor(messageID)
+	if provider != "" {
+		b = c.providerBehaviorFor(provider, messageID)
+	}
 	if err := waitOrCancel(ctx, b.Latency); err != nil {
 		return nil, err
 	}
@@ -331,6 +373,7 @@ func (c *Client) Stat(ctx context.Context, messageID string) (*nntppool.StatResu
 // opts.Concurrency goroutines, mirroring nntppool's StatMany semantics: results
 // stream out of order, the channel closes once every dispatched check reports,
 // and ctx cancellation stops dispatch and lets in-flight sends bail out.
+// opts.Provider selects the SetProviderBehavior overrides of that provider.
 func (c *Client) StatMany(ctx context.Context, messageIDs []string, opts nntppool.StatManyOptions) <-chan nntppool.StatManyResult {
 	conc := opts.Concurrency
 	if conc <= 0 {
@@ -357,7 +400,7 @@ func (c *Client) StatMany(ctx context.Context, messageIDs []string, opts nntppoo
 			go func(id string) {
 				defer wg.Done()
 				defer func() { <-sem }()
-				res, err := c.Stat(ctx, id)
+				res, err := c.stat(ctx, opts.Provider, id)
 				select {
 				case out <- nntppool.StatManyResult{MessageID: id, Result: res, Err: err}:
 				case <-ctx.Done():
@@ -420,7 +463,10 @@ func (c *Client) serveBody(ctx context.Context, messageID string, w io.Writer, o
 		MessageID:    messageID,
 		BytesDecoded: len(payload),
 		YEnc:         b.YEnc,
+		CRC:          crc32.ChecksumIEEE(payload),
+		ExpectedCRC:  b.ExpectedCRC,
 	}
+	body.CRCValid = body.ExpectedCRC != 0 && body.CRC == body.ExpectedCRC
 	if w == nil {
 		body.Bytes = payload
 	}
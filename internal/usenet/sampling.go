@@ -0,0 +1,186 @@
+package usenet
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+
+	"github.com/javi11/altmount/internal/holes"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+)
+
+const (
+	// sampleHeadSegments and sampleTailSegments are always checked: takedowns
+	// hit the start of a post first, incomplete uploads lose the end.
+	sampleHeadSegments = 3
+	sampleTailSegments = 2
+	// sampleIndexFraction is the share of the file at each end treated as the
+	// container index region (MP4 moov, MKV SeekHead/Cues, AVI idx1): a file
+	// that is intact but for its index does not play.
+	sampleIndexFraction = 0.01
+	// sampleIndexPerEnd is how many segments of each index region are checked.
+	sampleIndexPerEnd = 2
+	// maxHoleEdgeProbes caps the known-hole neighbours probed per check.
+	maxHoleEdgeProbes = 32
+	// maxSampleSegments caps the head, tail, index and body strata together,
+	// matching the flat sampler's network I/O ceiling.
+	maxSampleSegments = 55
+	// sampleConfidenceZ is the normal quantile of the reported 95% interval.
+	sampleConfidenceZ = 1.959964
+	// SampleConfidenceLevel is the coverage of MissingRateInterval.
+	SampleConfidenceLevel = 0.95
+)
+
+var randIntn = rand.Intn
+
+// SamplePlan is a stratified segment sample of one file. Fixed (head, tail
+// and container index strata) and HoleEdges are checked in the first round;
+// Body holds one segment per uniform body stratum, ordered so that every
+// prefix spreads evenly over the file, letting a check stop after any prefix
+// once it is confident enough. Only message IDs are kept, so the segment
+// slice is collectible while the plan is checked.
+type SamplePlan struct {
+	Fixed []string
+	Body  []string
+	// HoleEdges are the segments adjacent to the file's known holes: a hole
+	// that grows is the first sign of spreading damage. They are targeted,
+	// so they never count towards the missing-rate estimate.
+	HoleEdges []string
+	// Full marks a plan that checks every segment of the file.
+	Full bool
+}
+
+// PlanSegmentSample builds the stratified sample of segments. The head, tail
+// and index strata plus up to samplePercentage of the file (min 5, max 55 in
+// total) are planned; known-hole segments are skipped since they are already
+// known missing. At 100% (or when the budget covers the file) every segment
+// is planned.
+func PlanSegmentSample(segments []*metapb.SegmentData, samplePercentage int, knownHoles []holes.Run) SamplePlan {
+	total := len(segments)
+	budget := min(max(total*samplePercentage/100, 5), maxSampleSegments)
+	if samplePercentage >= 100 || budget >= total {
+		ids := make([]string, total)
+		for i, seg := range segments {
+			ids[i] = seg.Id
+		}
+		return SamplePlan{Fixed: ids, Full: true}
+	}
+
+	var known holes.Accumulator
+	known.Load(knownHoles)
+	used := make(map[int]bool, budget)
+	var plan SamplePlan
+	take := func(dst *[]string, i int) bool {
+		if i < 0 || i >= total || used[i] || known.Has(i) {
+			return false
+		}
+		used[i] = true
+		*dst = append(*dst, segments[i].Id)
+		return true
+	}
+
+	// Head and tail strata.
+	for i := range sampleHeadSegments {
+		take(&plan.Fixed, i)
+	}
+	for i := total - sampleTailSegments; i < total; i++ {
+		take(&plan.Fixed, i)
+	}
+
+	// Container index strata, just inside the head and tail.
+	width := max(1, int(float64(total)*sampleIndexFraction))
+	bodyStart := min(sampleHeadSegments+width, total)
+	bodyEnd := max(total-sampleTailSegments-width, bodyStart)
+	for _, region := range [][2]int{
+		{sampleHeadSegments, bodyStart},
+		{bodyEnd, total - sampleTailSegments},
+	} {
+		sampleRange(region[0], region[1], sampleIndexPerEnd, func(i int) { take(&plan.Fixed, i) })
+	}
+
+	// Known-hole neighbours, reserved before the body so a stratum skipping
+	// over a hole does not land on its edge.
+	for _, run := range known.Runs() {
+		if len(plan.HoleEdges) >= maxHoleEdgeProbes {
+			break
+		}
+		take(&plan.HoleEdges, run.Start-1)
+		take(&plan.HoleEdges, run.Start+run.Count)
+	}
+
+	// Uniform body strata: one random segment each, in van der Corput order.
+	strata := max(budget-len(plan.Fixed), 0)
+	span := bodyEnd - bodyStart
+	if strata > span {
+		strata = span
+	}
+	for _, s := range spreadOrder(strata) {
+		lo := bodyStart + s*span/strata
+		hi := bodyStart + (s+1)*span/strata
+		i := lo + randIntn(hi-lo)
+		// A known hole, hole edge or index pick inside the stratum: take the next
+		// free segment of the stratum instead.
+		for i < hi && !take(&plan.Body, i) {
+			i++
+		}
+	}
+
+	return plan
+}
+
+// sampleRange calls pick for n random indexes of [lo, hi), or for every index
+// when the range is smaller.
+func sampleRange(lo, hi, n int, pick func(int)) {
+	if hi <= lo {
+		return
+	}
+	perm := randPerm(hi - lo)
+	for _, off := range perm[:min(n, len(perm))] {
+		pick(lo + off)
+	}
+}
+
+// spreadOrder returns 0..n-1 in bit-reversed (van der Corput) order, so the
+// first k entries of it are spread evenly over [0, n) for every k.
+func spreadOrder(n int) []int {
+	if n <= 0 {
+		return nil
+	}
+	width := bits.Len(uint(n - 1))
+	order := make([]int, 0, n)
+	for i := range 1 << width {
+		if r := int(bits.Reverse(uint(i)) >> (bits.UintSize - width)); r < n {
+			order = append(order, r)
+		}
+	}
+	return order
+}
+
+// MissingRateInterval is the estimate of a file's missing-segment rate from a
+// sample, with its Wilson score interval at SampleConfidenceLevel.
+type MissingRateInterval struct {
+	Checked  int
+	Missing  int
+	Estimate float64
+	Lower    float64
+	Upper    float64
+}
+
+// WilsonInterval estimates the missing rate from missing of checked sampled
+// segments. The Wilson interval stays meaningful at zero misses, where a
+// clean sample of n segments bounds the rate by about 3.84/(n+3.84).
+func WilsonInterval(missing, checked int) MissingRateInterval {
+	iv := MissingRateInterval{Checked: checked, Missing: missing, Upper: 1}
+	if checked <= 0 {
+		return iv
+	}
+	n := float64(checked)
+	p := float64(missing) / n
+	z2 := sampleConfidenceZ * sampleConfidenceZ
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	half := sampleConfidenceZ / (1 + z2/n) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	iv.Estimate = p
+	iv.Lower = max(0, center-half)
+	iv.Upper = min(1, center+half)
+	return iv
+}
@@ -0,0 +1,94 @@
+package usenet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/javi11/altmount/internal/holes"
+	metapb "github.com/javi11/altmount/internal/metadata/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleSegments(n int) []*metapb.SegmentData {
+	segments := make([]*metapb.SegmentData, n)
+	for i := range n {
+		segments[i] = &metapb.SegmentData{Id: fmt.Sprintf("seg%d", i)}
+	}
+	return segments
+}
+
+func TestPlanSegmentSample(t *testing.T) {
+	segments := sampleSegments(2000)
+
+	t.Run("small budget covers the file", func(t *testing.T) {
+		plan := PlanSegmentSample(sampleSegments(4), 10, nil)
+		assert.True(t, plan.Full)
+		assert.Equal(t, []string{"seg0", "seg1", "seg2", "seg3"}, plan.Fixed)
+		assert.Empty(t, plan.Body)
+	})
+
+	t.Run("strata", func(t *testing.T) {
+		plan := PlanSegmentSample(segments, 10, nil)
+		assert.False(t, plan.Full)
+		assert.Equal(t, maxSampleSegments, len(plan.Fixed)+len(plan.Body))
+		assert.Equal(t, []string{"seg0", "seg1", "seg2", "seg1998", "seg1999"}, plan.Fixed[:5])
+
+		// Two picks in each 1% index region, just inside the head and tail.
+		var head, tail int
+		for _, id := range plan.Fixed[5:] {
+			var i int
+			_, err := fmt.Sscanf(id, "seg%d", &i)
+			require.NoError(t, err)
+			switch {
+			case i >= 3 && i < 23:
+				head++
+			case i >= 1978 && i < 1998:
+				tail++
+			}
+		}
+		assert.Equal(t, sampleIndexPerEnd, head)
+		assert.Equal(t, sampleIndexPerEnd, tail)
+
+		seen := map[string]bool{}
+		for _, id := range append(append([]string{}, plan.Fixed...), plan.Body...) {
+			assert.False(t, seen[id], "%s planned twice", id)
+			seen[id] = true
+		}
+	})
+
+	t.Run("known holes are skipped and their edges probed", func(t *testing.T) {
+		known := []holes.Run{{Start: 0, Count: 1}, {Start: 1000, Count: 100}}
+		plan := PlanSegmentSample(segments, 10, known)
+
+		for _, id := range append(append([]string{}, plan.Fixed...), plan.Body...) {
+			var i int
+			_, err := fmt.Sscanf(id, "seg%d", &i)
+			require.NoError(t, err)
+			assert.False(t, i == 0 || (i >= 1000 && i < 1100), "%s is in a known hole", id)
+		}
+		// seg1, the first hole's only edge, is already in the head stratum.
+		assert.Equal(t, []string{"seg999", "seg1100"}, plan.HoleEdges)
+	})
+}
+
+func TestSpreadOrder(t *testing.T) {
+	assert.Nil(t, spreadOrder(0))
+	assert.Equal(t, []int{0, 4, 2, 1, 3}, spreadOrder(5))
+	assert.Equal(t, []int{0, 4, 2, 6, 1, 5, 3, 7}, spreadOrder(8))
+}
+
+func TestWilsonInterval(t *testing.T) {
+	iv := WilsonInterval(0, 0)
+	assert.Equal(t, 1.0, iv.Upper, "nothing checked bounds nothing")
+
+	iv = WilsonInterval(0, 35)
+	assert.Zero(t, iv.Estimate)
+	assert.Zero(t, iv.Lower)
+	assert.InDelta(t, 3.84/(35+3.84), iv.Upper, 0.001)
+
+	iv = WilsonInterval(5, 50)
+	assert.InDelta(t, 0.10, iv.Estimate, 1e-9)
+	assert.Less(t, iv.Lower, iv.Estimate)
+	assert.Greater(t, iv.Upper, iv.Estimate)
+}